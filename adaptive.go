@@ -0,0 +1,146 @@
+package vk
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	defaultAdaptiveMinRPS   = 0.5
+	defaultAdaptiveBackoff  = 0.5
+	defaultAdaptiveStep     = 0.25
+	defaultAdaptiveProbe    = 10 * time.Second
+	adaptiveBackoffCooldown = time.Second
+)
+
+// AdaptiveLimiter is Limiter that lowers rate when rate limit errors
+// (ErrTooManyRequests, ErrRateLimit) are observed and gradually
+// probes it back up, keeping throughput near real limit of token.
+//
+// Errors are observed by Done, use WithAdaptiveLimiter to set both.
+type AdaptiveLimiter struct {
+	// MaxRPS is initial and maximum rate, vk limit for user tokens if zero
+	MaxRPS float64
+	// MinRPS is minimum rate, 0.5 if zero
+	MinRPS float64
+	// Backoff multiplies rate on rate limit error, 0.5 if zero
+	Backoff float64
+	// Step is added to rate after every Probe without rate limit errors,
+	// 0.25 if zero
+	Step float64
+	// Probe is period after which rate is increased, 10s if zero
+	Probe time.Duration
+	// Clock is SystemClock if nil
+	Clock Clock
+
+	mux       sync.Mutex
+	rps       float64
+	next      time.Time
+	changed   time.Time
+	decreased time.Time
+}
+
+// WithAdaptiveLimiter sets l as rate limiter of client and
+// feeds it with results of requests
+func WithAdaptiveLimiter(l *AdaptiveLimiter) Option {
+	return func(c *Client) {
+		c.SetLimiter(l)
+		OnRequestDone(l.Done)(c)
+	}
+}
+
+func (l *AdaptiveLimiter) maxRPS() float64 {
+	if l.MaxRPS <= 0 {
+		return maxRequestsPerSecond
+	}
+	return l.MaxRPS
+}
+
+func (l *AdaptiveLimiter) minRPS() float64 {
+	if l.MinRPS <= 0 {
+		return defaultAdaptiveMinRPS
+	}
+	return l.MinRPS
+}
+
+// init sets initial rate, must be called under lock
+func (l *AdaptiveLimiter) init(now time.Time) {
+	if l.rps == 0 {
+		l.rps = l.maxRPS()
+		l.changed = now
+	}
+}
+
+// RPS returns current rate
+func (l *AdaptiveLimiter) RPS() float64 {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	l.init(clockOrSystem(l.Clock).Now())
+	return l.rps
+}
+
+func (l *AdaptiveLimiter) Wait(ctx context.Context) error {
+	clock := clockOrSystem(l.Clock)
+	l.mux.Lock()
+	now := clock.Now()
+	l.init(now)
+	l.probe(now)
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(time.Duration(float64(time.Second) / l.rps))
+	l.mux.Unlock()
+	return clock.Sleep(ctx, wait)
+}
+
+// probe increases rate if there were no rate limit errors
+// for Probe, must be called under lock
+func (l *AdaptiveLimiter) probe(now time.Time) {
+	probe := l.Probe
+	if probe <= 0 {
+		probe = defaultAdaptiveProbe
+	}
+	if l.rps >= l.maxRPS() || now.Sub(l.changed) < probe {
+		return
+	}
+	step := l.Step
+	if step <= 0 {
+		step = defaultAdaptiveStep
+	}
+	l.rps += step
+	if l.rps > l.maxRPS() {
+		l.rps = l.maxRPS()
+	}
+	l.changed = now
+}
+
+// Done observes result of request and lowers rate on rate limit
+// errors, it is RequestDoneFunc
+func (l *AdaptiveLimiter) Done(method string, d time.Duration, err error) {
+	if !IsServerError(err) {
+		return
+	}
+	if code := GetServerError(err).Code; code != ErrTooManyRequests && code != ErrRateLimit {
+		return
+	}
+	now := clockOrSystem(l.Clock).Now()
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	l.init(now)
+	if !l.decreased.IsZero() && now.Sub(l.decreased) < adaptiveBackoffCooldown {
+		// requests in flight were made with previous rate
+		return
+	}
+	backoff := l.Backoff
+	if backoff <= 0 || backoff >= 1 {
+		backoff = defaultAdaptiveBackoff
+	}
+	l.rps *= backoff
+	if l.rps < l.minRPS() {
+		l.rps = l.minRPS()
+	}
+	l.changed = now
+	l.decreased = now
+}
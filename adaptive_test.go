@@ -0,0 +1,61 @@
+package vk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAdaptiveLimiter(t *testing.T) {
+	Convey("Adaptive limiter", t, func() {
+		clock := NewFakeClock(time.Unix(1000, 0))
+		l := &AdaptiveLimiter{MaxRPS: 4, MinRPS: 1, Step: 1, Probe: 10 * time.Second, Clock: clock}
+		ctx := context.Background()
+		So(l.RPS(), ShouldEqual, 4)
+		So(l.Wait(ctx), ShouldBeNil)
+		So(l.Wait(ctx), ShouldBeNil)
+		So(clock.Slept(), ShouldResemble, []time.Duration{0, 250 * time.Millisecond})
+
+		l.Done(methodUsersGet, 0, Error{Code: ErrTooManyRequests})
+		So(l.RPS(), ShouldEqual, 2)
+		// errors of requests in flight are ignored
+		l.Done(methodUsersGet, 0, Error{Code: ErrRateLimit})
+		So(l.RPS(), ShouldEqual, 2)
+		l.Done(methodUsersGet, 0, Error{Code: ErrAuthFailed})
+		l.Done(methodUsersGet, 0, context.Canceled)
+		l.Done(methodUsersGet, 0, nil)
+		clock.Advance(time.Second)
+		l.Done(methodUsersGet, 0, Error{Code: ErrRateLimit})
+		So(l.RPS(), ShouldEqual, 1)
+		clock.Advance(time.Second)
+		l.Done(methodUsersGet, 0, Error{Code: ErrRateLimit})
+		So(l.RPS(), ShouldEqual, 1)
+
+		Convey("Probe", func() {
+			clock.Advance(5 * time.Second)
+			So(l.Wait(ctx), ShouldBeNil)
+			So(l.RPS(), ShouldEqual, 1)
+			clock.Advance(5 * time.Second)
+			So(l.Wait(ctx), ShouldBeNil)
+			So(l.RPS(), ShouldEqual, 2)
+			for i := 0; i < 5; i++ {
+				clock.Advance(10 * time.Second)
+				So(l.Wait(ctx), ShouldBeNil)
+			}
+			So(l.RPS(), ShouldEqual, 4)
+		})
+		Convey("Client", func() {
+			l := &AdaptiveLimiter{Clock: clock}
+			client := New(WithAdaptiveLimiter(l))
+			client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+				return jsonResponse(http.StatusOK, `{"error": {"error_code": 6}}`), nil
+			}))
+			_, err := client.Do(Request{Method: methodUsersGet})
+			So(err, ShouldNotBeNil)
+			So(l.RPS(), ShouldEqual, maxRequestsPerSecond*defaultAdaptiveBackoff)
+		})
+	})
+}
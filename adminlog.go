@@ -0,0 +1,175 @@
+package vk
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// AdminEventCallbackSettings is AdminRecord.Event of change
+	// of callback server settings found by AdminLog.Poll
+	AdminEventCallbackSettings = "callback_settings"
+
+	defaultAdminLogInterval = 5 * time.Minute
+)
+
+// adminEvents are callback events that are caused by community admins
+var adminEvents = map[string]bool{
+	"wall_post_new":         true,
+	"wall_reply_delete":     true,
+	"photo_comment_delete":  true,
+	"video_comment_delete":  true,
+	"market_comment_delete": true,
+	"board_post_delete":     true,
+	"group_officers_edit":   true,
+	"group_change_settings": true,
+	"group_change_photo":    true,
+	"user_block":            true,
+	"user_unblock":          true,
+}
+
+// AdminRecord is entry of community admin action log
+type AdminRecord struct {
+	Time    time.Time `json:"time"`
+	GroupID ID        `json:"group_id"`
+	Event   string    `json:"event"`
+	// AdminID is id of admin that made change, zero if unknown
+	AdminID ID `json:"admin_id,omitempty"`
+	// UserID is id of affected user, like blocked user or new officer
+	UserID ID `json:"user_id,omitempty"`
+	// Setting, Old and New describe changed callback setting
+	Setting string `json:"setting,omitempty"`
+	Old     string `json:"old,omitempty"`
+	New     string `json:"new,omitempty"`
+	// Object is event object
+	Object Raw `json:"object,omitempty"`
+}
+
+// adminObject has fields of admin events objects that identify
+// who made change
+type adminObject struct {
+	CreatedBy ID `json:"created_by"`
+	DeleterID ID `json:"deleter_id"`
+	AdminID   ID `json:"admin_id"`
+	UserID    ID `json:"user_id"`
+}
+
+// AdminLog emits structured stream of admin actions in community
+// from callback events and changes of callback server settings
+type AdminLog struct {
+	Groups   Groups
+	GroupID  int
+	ServerID int
+	// OnRecord receives records
+	OnRecord func(record AdminRecord)
+	// Interval of settings polling
+	Interval time.Duration
+	// Clock is SystemClock if nil
+	Clock Clock
+
+	mux      sync.Mutex
+	settings *CallbackSettings
+}
+
+func (l *AdminLog) emit(r AdminRecord) {
+	r.Time = clockOrSystem(l.Clock).Now()
+	if r.GroupID == 0 {
+		r.GroupID = ID(l.GroupID)
+	}
+	l.OnRecord(r)
+}
+
+// HandleEvent records admin events, it is EventHandler
+func (l *AdminLog) HandleEvent(event Event) error {
+	if !adminEvents[event.Type] {
+		return nil
+	}
+	var obj adminObject
+	if err := json.Unmarshal(event.Object, &obj); err != nil {
+		return err
+	}
+	r := AdminRecord{GroupID: event.GroupID, Event: event.Type, Object: event.Object}
+	switch {
+	case obj.CreatedBy != 0:
+		r.AdminID = obj.CreatedBy
+	case obj.DeleterID != 0:
+		r.AdminID = obj.DeleterID
+	case obj.AdminID != 0:
+		r.AdminID = obj.AdminID
+		r.UserID = obj.UserID
+	default:
+		r.AdminID = obj.UserID
+	}
+	if event.Type == "wall_post_new" && r.AdminID == 0 {
+		// post of user, not admin action
+		return nil
+	}
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	l.emit(r)
+	return nil
+}
+
+// Poll fetches callback server settings and records changed
+// events subscriptions and api version since previous poll
+func (l *AdminLog) Poll() error {
+	settings, err := l.Groups.GetCallbackSettings(l.GroupID, l.ServerID)
+	if err != nil {
+		return err
+	}
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	prev := l.settings
+	l.settings = &settings
+	if prev == nil {
+		return nil
+	}
+	if prev.APIVersion != settings.APIVersion {
+		l.emit(AdminRecord{Event: AdminEventCallbackSettings, Setting: "api_version", Old: prev.APIVersion, New: settings.APIVersion})
+	}
+	var names []string
+	for name := range settings.Events {
+		names = append(names, name)
+	}
+	for name := range prev.Events {
+		if _, ok := settings.Events[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		old, current := prev.Events[name], settings.Events[name]
+		if old == current {
+			continue
+		}
+		l.emit(AdminRecord{Event: AdminEventCallbackSettings, Setting: name, Old: boolString(old), New: boolString(current)})
+	}
+	return nil
+}
+
+func boolString(b Bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+// Run polls settings until ctx is done. AdminLog is Component.
+func (l *AdminLog) Run(ctx context.Context) error {
+	clock := clockOrSystem(l.Clock)
+	interval := l.Interval
+	if interval == 0 {
+		interval = defaultAdminLogInterval
+	}
+	for {
+		if err := l.Poll(); err != nil {
+			return err
+		}
+		if err := clock.Sleep(ctx, interval); err != nil {
+			return nil
+		}
+	}
+}
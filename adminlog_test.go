@@ -0,0 +1,63 @@
+package vk
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAdminLog(t *testing.T) {
+	Convey("Admin log", t, func() {
+		client := New()
+		settings := `{"api_version": "5.103", "events": {"message_new": 1, "wall_post_new": 0}}`
+		client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			So(req.URL.Path, ShouldEqual, "/method/groups.getCallbackSettings")
+			So(req.URL.Query().Get("server_id"), ShouldEqual, "3")
+			return jsonResponse(http.StatusOK, `{"response": `+settings+`}`), nil
+		}))
+		var records []AdminRecord
+		l := &AdminLog{
+			Groups:   client.Groups,
+			GroupID:  10,
+			ServerID: 3,
+			OnRecord: func(r AdminRecord) {
+				records = append(records, r)
+			},
+			Clock: NewFakeClock(time.Unix(1000, 0)),
+		}
+		Convey("Events", func() {
+			So(l.HandleEvent(Event{Type: "message_new", Object: Raw(`{}`)}), ShouldBeNil)
+			So(l.HandleEvent(Event{Type: "wall_post_new", Object: Raw(`{"id": 1, "from_id": 5}`)}), ShouldBeNil)
+			So(records, ShouldBeEmpty)
+			So(l.HandleEvent(Event{Type: "wall_post_new", GroupID: 10, Object: Raw(`{"id": 2, "from_id": -10, "created_by": 7}`)}), ShouldBeNil)
+			So(l.HandleEvent(Event{Type: "wall_reply_delete", GroupID: 10, Object: Raw(`{"id": 3, "deleter_id": 8}`)}), ShouldBeNil)
+			So(l.HandleEvent(Event{Type: "user_block", GroupID: 10, Object: Raw(`{"admin_id": 9, "user_id": 100}`)}), ShouldBeNil)
+			So(l.HandleEvent(Event{Type: "group_change_photo", Object: Raw(`{"user_id": 7}`)}), ShouldBeNil)
+			So(records, ShouldHaveLength, 4)
+			So(records[0].AdminID, ShouldEqual, 7)
+			So(records[0].Time, ShouldResemble, time.Unix(1000, 0))
+			So(records[1].AdminID, ShouldEqual, 8)
+			So(records[2].AdminID, ShouldEqual, 9)
+			So(records[2].UserID, ShouldEqual, 100)
+			So(records[3].Event, ShouldEqual, "group_change_photo")
+			So(records[3].AdminID, ShouldEqual, 7)
+			So(records[3].GroupID, ShouldEqual, 10)
+		})
+		Convey("Settings", func() {
+			So(l.Poll(), ShouldBeNil)
+			So(records, ShouldBeEmpty)
+			settings = `{"api_version": "5.131", "events": {"message_new": 0, "wall_post_new": 0, "user_block": 1}}`
+			So(l.Poll(), ShouldBeNil)
+			So(records, ShouldHaveLength, 3)
+			So(records[0].Setting, ShouldEqual, "api_version")
+			So(records[0].New, ShouldEqual, "5.131")
+			So(records[1].Setting, ShouldEqual, "message_new")
+			So(records[1].Old, ShouldEqual, "1")
+			So(records[1].New, ShouldEqual, "0")
+			So(records[2].Setting, ShouldEqual, "user_block")
+			So(records[2].Event, ShouldEqual, AdminEventCallbackSettings)
+		})
+	})
+}
@@ -0,0 +1,280 @@
+package vk
+
+import (
+	"container/heap"
+	"context"
+	"sort"
+	"sync"
+)
+
+const (
+	methodAdsGetStatistics = "ads.getStatistics"
+
+	maxAdsStatisticsIDs = 2000
+)
+
+// Ads resource
+type Ads struct {
+	Resource
+}
+
+// AdsIDsType is type of objects in ads.getStatistics
+type AdsIDsType string
+
+const (
+	AdsIDsAd       AdsIDsType = "ad"
+	AdsIDsCampaign AdsIDsType = "campaign"
+	AdsIDsClient   AdsIDsType = "client"
+	AdsIDsOffice   AdsIDsType = "office"
+)
+
+// AdsPeriod is period of statistics rows
+type AdsPeriod string
+
+const (
+	AdsPeriodDay     AdsPeriod = "day"
+	AdsPeriodWeek    AdsPeriod = "week"
+	AdsPeriodMonth   AdsPeriod = "month"
+	AdsPeriodYear    AdsPeriod = "year"
+	AdsPeriodOverall AdsPeriod = "overall"
+)
+
+type AdsStatisticsFields struct {
+	AccountID int        `url:"account_id"`
+	IDsType   AdsIDsType `url:"ids_type"`
+	IDs       []int      `url:"ids,comma"`
+	Period    AdsPeriod  `url:"period"`
+	DateFrom  string     `url:"date_from"`
+	DateTo    string     `url:"date_to"`
+}
+
+// AdsStats is statistics of object for one period
+type AdsStats struct {
+	Day         string `json:"day,omitempty"`
+	Month       string `json:"month,omitempty"`
+	Overall     int    `json:"overall,omitempty"`
+	Spent       string `json:"spent"`
+	Impressions int    `json:"impressions"`
+	Clicks      int    `json:"clicks"`
+	Reach       int    `json:"reach"`
+	JoinRate    int    `json:"join_rate"`
+}
+
+// SpentAmount parses Spent, that is in major units of account currency
+func (s AdsStats) SpentAmount() (Amount, error) {
+	if len(s.Spent) == 0 {
+		return 0, nil
+	}
+	return ParseAmount(s.Spent)
+}
+
+// Date returns day or month of stats
+func (s AdsStats) Date() string {
+	if len(s.Day) != 0 {
+		return s.Day
+	}
+	return s.Month
+}
+
+// AdsObjectStats is statistics of ad, campaign, client or office
+type AdsObjectStats struct {
+	ID    int        `json:"id"`
+	Type  AdsIDsType `json:"type"`
+	Stats []AdsStats `json:"stats"`
+}
+
+func (a Ads) GetStatistics(fields AdsStatisticsFields) (result []AdsObjectStats, err error) {
+	return result, a.Decode(a.Request(methodAdsGetStatistics, fields), &result)
+}
+
+// AdsReportQuery selects objects of account for report
+type AdsReportQuery struct {
+	AccountID int
+	IDsType   AdsIDsType
+	IDs       []int
+}
+
+// AdsReportRow is statistics of one object for one date
+type AdsReportRow struct {
+	Date      string
+	AccountID int
+	Type      AdsIDsType
+	ID        int
+	Stats     AdsStats
+}
+
+// AdsReportSink receives rows of report
+type AdsReportSink interface {
+	Write(row AdsReportRow) error
+}
+
+// AdsReportSinkFunc is function AdsReportSink
+type AdsReportSinkFunc func(row AdsReportRow) error
+
+func (f AdsReportSinkFunc) Write(row AdsReportRow) error {
+	return f(row)
+}
+
+// AdsReport collects statistics of many accounts and objects
+type AdsReport struct {
+	Ads      Ads
+	Period   AdsPeriod
+	DateFrom string
+	DateTo   string
+	// Limiter limits ads requests, ads methods have own limits
+	Limiter Limiter
+	// Concurrency is maximum number of parallel requests, 1 if zero
+	Concurrency int
+}
+
+// Run requests statistics for queries, splitting them to allowed
+// number of ids, and writes rows to sink merged by date, then by
+// account and object id. Every page covers whole date range, so
+// pages are kept sorted until all are received and then merged.
+func (r AdsReport) Run(ctx context.Context, queries []AdsReportQuery, sink AdsReportSink) error {
+	var chunks []AdsStatisticsFields
+	for _, q := range queries {
+		for start := 0; start < len(q.IDs); start += maxAdsStatisticsIDs {
+			end := start + maxAdsStatisticsIDs
+			if end > len(q.IDs) {
+				end = len(q.IDs)
+			}
+			chunks = append(chunks, AdsStatisticsFields{
+				AccountID: q.AccountID,
+				IDsType:   q.IDsType,
+				IDs:       q.IDs[start:end],
+				Period:    r.Period,
+				DateFrom:  r.DateFrom,
+				DateTo:    r.DateTo,
+			})
+		}
+	}
+
+	concurrency := r.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	var (
+		wg       sync.WaitGroup
+		mux      sync.Mutex
+		firstErr error
+	)
+	pages := make(adsPages, len(chunks))
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	jobs := make(chan int)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result, err := r.statistics(ctx, chunks[i])
+				if err != nil {
+					mux.Lock()
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+					mux.Unlock()
+					continue
+				}
+				// every page is written by one worker, so no lock is needed
+				pages[i] = adsPageRows(chunks[i].AccountID, result)
+			}
+		}()
+	}
+	for i := range chunks {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return pages.merge(sink)
+}
+
+// adsRowLess orders rows by date, account and object id
+func adsRowLess(a, b AdsReportRow) bool {
+	if a.Date != b.Date {
+		return a.Date < b.Date
+	}
+	if a.AccountID != b.AccountID {
+		return a.AccountID < b.AccountID
+	}
+	return a.ID < b.ID
+}
+
+// adsPageRows returns sorted rows of statistics page
+func adsPageRows(account int, page []AdsObjectStats) []AdsReportRow {
+	var rows []AdsReportRow
+	for _, object := range page {
+		for _, stats := range object.Stats {
+			rows = append(rows, AdsReportRow{
+				Date:      stats.Date(),
+				AccountID: account,
+				Type:      object.Type,
+				ID:        object.ID,
+				Stats:     stats,
+			})
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return adsRowLess(rows[i], rows[j])
+	})
+	return rows
+}
+
+// adsPages is heap of sorted pages ordered by first row
+type adsPages [][]AdsReportRow
+
+func (h adsPages) Len() int            { return len(h) }
+func (h adsPages) Less(i, j int) bool  { return adsRowLess(h[i][0], h[j][0]) }
+func (h adsPages) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *adsPages) Push(x interface{}) { *h = append(*h, x.([]AdsReportRow)) }
+func (h *adsPages) Pop() interface{} {
+	old := *h
+	page := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return page
+}
+
+// merge writes rows of sorted pages to sink in k-way merge order
+func (h adsPages) merge(sink AdsReportSink) error {
+	pages := h[:0]
+	for _, page := range h {
+		if len(page) != 0 {
+			pages = append(pages, page)
+		}
+	}
+	heap.Init(&pages)
+	for len(pages) != 0 {
+		if err := sink.Write(pages[0][0]); err != nil {
+			return err
+		}
+		if pages[0] = pages[0][1:]; len(pages[0]) == 0 {
+			heap.Pop(&pages)
+		} else {
+			heap.Fix(&pages, 0)
+		}
+	}
+	return nil
+}
+
+func (r AdsReport) statistics(ctx context.Context, fields AdsStatisticsFields) ([]AdsObjectStats, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if r.Limiter != nil {
+		if err := r.Limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return r.Ads.GetStatistics(fields)
+}
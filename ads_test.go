@@ -0,0 +1,80 @@
+package vk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAdsReport(t *testing.T) {
+	Convey("Ads report", t, func() {
+		var calls int32
+		mock := apiFuncMock(func(req Request) (*Response, error) {
+			atomic.AddInt32(&calls, 1)
+			account := req.Values.Get("account_id")
+			if account == "0" {
+				return apiResponse(`{"error": {"error_code": 600, "error_msg": "Permission denied"}}`)
+			}
+			ids := strings.Split(req.Values.Get("ids"), ",")
+			var objects []string
+			for _, id := range ids[:1] {
+				objects = append(objects, fmt.Sprintf(`{"id": %s, "type": "campaign", "stats": [
+					{"day": "2020-01-02", "spent": "1.50", "impressions": 10},
+					{"day": "2020-01-01", "spent": "2.00", "impressions": %s}
+				]}`, id, account))
+			}
+			return apiResponse(`{"response": [` + strings.Join(objects, ",") + `]}`)
+		})
+		report := AdsReport{
+			Ads:         Ads{Resource{APIClient: mock, RequestFactory: DefaultFactory}},
+			Period:      AdsPeriodDay,
+			DateFrom:    "2020-01-01",
+			DateTo:      "2020-01-02",
+			Limiter:     NewLimiter(1000),
+			Concurrency: 2,
+		}
+		many := make([]int, 2001)
+		for i := range many {
+			many[i] = i + 1
+		}
+		queries := []AdsReportQuery{
+			{AccountID: 2, IDsType: AdsIDsCampaign, IDs: []int{5}},
+			{AccountID: 1, IDsType: AdsIDsCampaign, IDs: many},
+		}
+		var rows []AdsReportRow
+		sink := AdsReportSinkFunc(func(row AdsReportRow) error {
+			rows = append(rows, row)
+			return nil
+		})
+		Convey("Ok", func() {
+			So(report.Run(context.Background(), queries, sink), ShouldBeNil)
+			So(atomic.LoadInt32(&calls), ShouldEqual, 3)
+			So(len(rows), ShouldEqual, 6)
+			So(rows[0].Date, ShouldEqual, "2020-01-01")
+			So(rows[0].AccountID, ShouldEqual, 1)
+			So(rows[0].ID, ShouldEqual, 1)
+			So(rows[1].ID, ShouldEqual, 2001)
+			So(rows[2].AccountID, ShouldEqual, 2)
+			So(rows[2].Stats.Impressions, ShouldEqual, 2)
+			So(rows[3].Date, ShouldEqual, "2020-01-02")
+			So(rows[5].Stats.Spent, ShouldEqual, "1.50")
+		})
+		Convey("Error", func() {
+			report.Concurrency = 1
+			queries = append([]AdsReportQuery{{AccountID: 0, IDsType: AdsIDsCampaign, IDs: []int{1}}}, queries...)
+			err := report.Run(context.Background(), queries, sink)
+			So(ErrInsufficientPermissionsAd.Is(err), ShouldBeTrue)
+			So(rows, ShouldBeEmpty)
+		})
+		Convey("Sink error", func() {
+			err := report.Run(context.Background(), queries, AdsReportSinkFunc(func(row AdsReportRow) error {
+				return ErrUnknown
+			}))
+			So(err, ShouldEqual, ErrUnknown)
+		})
+	})
+}
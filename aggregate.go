@@ -0,0 +1,172 @@
+package vk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+const (
+	methodWallGetComments   = "wall.getComments"
+	maxAggregateComments    = 100
+	maxUsersWithGroupsChunk = maxExecuteCalls - 1
+)
+
+// ErrPostNotFound is returned if post does not exist or is not accessible
+var ErrPostNotFound = errors.New("post not found")
+
+// isFalse reports whether result of api call in execute is false,
+// that means that call failed
+func isFalse(r Raw) bool {
+	s := strings.TrimSpace(string(r))
+	return len(s) == 0 || s == "false" || s == "null"
+}
+
+// callFailed returns error of failed api call of method in execute
+func callFailed(failed Errors, method string) error {
+	for _, e := range failed {
+		if e.Method == method {
+			return e
+		}
+	}
+	return ExecuteError{Method: method, Message: "call failed"}
+}
+
+// UserWithGroups is user with communities, Groups is
+// nil if they are hidden by privacy settings
+type UserWithGroups struct {
+	User
+	Groups []Group `json:"groups"`
+}
+
+// GetUsersWithGroups returns users with their communities, making
+// one execute request per 24 users
+func (c *Client) GetUsersWithGroups(ctx context.Context, ids []ID, fields Fields) ([]UserWithGroups, error) {
+	var result []UserWithGroups
+	for start := 0; start < len(ids); start += maxUsersWithGroupsChunk {
+		end := start + maxUsersWithGroupsChunk
+		if end > len(ids) {
+			end = len(ids)
+		}
+		chunk, err := c.getUsersWithGroups(ctx, ids[start:end], fields)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, chunk...)
+	}
+	return result, nil
+}
+
+func (c *Client) getUsersWithGroups(ctx context.Context, ids []ID, fields Fields) ([]UserWithGroups, error) {
+	s := NewScript().
+		Var("users", c.Users.Request(methodUsersGet, UsersGetFields{UserIDs: ids, Fields: fields})).
+		Line("var groups = [];")
+	for _, id := range ids {
+		s.Push("groups", c.Groups.Request(methodGroupsGet, GroupGetFields{
+			UserID:   int(id),
+			Extended: true,
+			Count:    1000,
+		}))
+	}
+	s.Return(`{"users": users, "groups": groups}`)
+
+	var response struct {
+		Users  Raw   `json:"users"`
+		Groups []Raw `json:"groups"`
+	}
+	failed, err := c.ExecuteScript(ctx, s, &response)
+	if err != nil {
+		return nil, err
+	}
+	if isFalse(response.Users) {
+		return nil, callFailed(failed, methodUsersGet)
+	}
+	var users []User
+	if err = json.Unmarshal(response.Users, &users); err != nil {
+		return nil, err
+	}
+	// users.get skips deleted ids, so groups are matched by id
+	groups := make(map[ID][]Group, len(ids))
+	for i, raw := range response.Groups {
+		if i >= len(ids) || isFalse(raw) {
+			continue
+		}
+		var g GroupGetResult
+		if err = json.Unmarshal(raw, &g); err != nil {
+			return nil, err
+		}
+		groups[ids[i]] = g.Items
+	}
+	result := make([]UserWithGroups, len(users))
+	for i, u := range users {
+		result[i] = UserWithGroups{User: u, Groups: groups[u.ID]}
+	}
+	return result, nil
+}
+
+// PostWithComments is post with its first comments
+type PostWithComments struct {
+	Post     Post      `json:"post"`
+	Count    int       `json:"count"`
+	Comments []Comment `json:"comments"`
+}
+
+type wallGetCommentsFields struct {
+	OwnerID ID     `url:"owner_id"`
+	PostID  int    `url:"post_id"`
+	Count   int    `url:"count,omitempty"`
+	Sort    string `url:"sort,omitempty"`
+}
+
+// GetPostWithComments returns post with up to 100 first comments
+// in one execute request
+func (c *Client) GetPostWithComments(ctx context.Context, owner ID, post int) (PostWithComments, error) {
+	result := PostWithComments{}
+	s := NewScript().
+		Var("posts", c.Wall.Request(methodWallGetByID, struct {
+			Posts string `url:"posts"`
+		}{int64s(int64(owner)) + "_" + strconv.Itoa(post)})).
+		Var("comments", c.Wall.Request(methodWallGetComments, wallGetCommentsFields{
+			OwnerID: owner,
+			PostID:  post,
+			Count:   maxAggregateComments,
+			Sort:    "asc",
+		})).
+		Return(`{"posts": posts, "comments": comments}`)
+
+	var response struct {
+		Posts    Raw `json:"posts"`
+		Comments Raw `json:"comments"`
+	}
+	failed, err := c.ExecuteScript(ctx, s, &response)
+	if err != nil {
+		return result, err
+	}
+	if isFalse(response.Posts) {
+		return result, callFailed(failed, methodWallGetByID)
+	}
+	var posts []Post
+	if err = json.Unmarshal(response.Posts, &posts); err != nil {
+		return result, err
+	}
+	if len(posts) == 0 {
+		return result, ErrPostNotFound
+	}
+	result.Post = posts[0]
+	if isFalse(response.Comments) {
+		// comments can be disabled for post
+		return result, nil
+	}
+	comments := struct {
+		Count int       `json:"count"`
+		Items []Comment `json:"items"`
+	}{}
+	if err = json.Unmarshal(response.Comments, &comments); err != nil {
+		return result, err
+	}
+	result.Count = comments.Count
+	result.Comments = comments.Items
+	return result, nil
+}
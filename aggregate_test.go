@@ -0,0 +1,80 @@
+package vk
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAggregate(t *testing.T) {
+	Convey("Aggregate", t, func() {
+		var codes []string
+		response := ""
+		client := NewWithToken("t", WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			So(req.URL.Path, ShouldEqual, "/method/execute")
+			codes = append(codes, req.URL.Query().Get("code"))
+			return jsonResponse(http.StatusOK, response), nil
+		})))
+		ctx := context.Background()
+		Convey("Users with groups", func() {
+			response = `{"response": {"users": [{"id": 1}, {"id": 3}], "groups": [` +
+				`{"count": 1, "items": [{"id": 10, "name": "a"}]}, false, false]}, ` +
+				`"execute_errors": [{"method": "groups.get", "error_code": 30, "error_msg": "private"}, ` +
+				`{"method": "groups.get", "error_code": 18, "error_msg": "deleted"}]}`
+			users, err := client.GetUsersWithGroups(ctx, []ID{1, 2, 3}, nil)
+			So(err, ShouldBeNil)
+			So(users, ShouldHaveLength, 2)
+			So(users[0].ID, ShouldEqual, 1)
+			So(users[0].Groups[0].Name, ShouldEqual, "a")
+			So(users[1].ID, ShouldEqual, 3)
+			So(users[1].Groups, ShouldBeNil)
+			So(codes, ShouldHaveLength, 1)
+			So(strings.Count(codes[0], "groups.push(API.groups.get("), ShouldEqual, 3)
+			So(codes[0], ShouldStartWith, `var users = API.users.get({"user_ids":"1,2,3"});`)
+
+			Convey("Chunks", func() {
+				codes = nil
+				ids := make([]ID, maxUsersWithGroupsChunk+1)
+				for i := range ids {
+					ids[i] = ID(i + 1)
+				}
+				_, err := client.GetUsersWithGroups(ctx, ids, nil)
+				So(err, ShouldBeNil)
+				So(codes, ShouldHaveLength, 2)
+			})
+			Convey("Failed", func() {
+				response = `{"response": {"users": false, "groups": []}, ` +
+					`"execute_errors": [{"method": "users.get", "error_code": 5, "error_msg": "auth"}]}`
+				_, err := client.GetUsersWithGroups(ctx, []ID{1}, nil)
+				So(err, ShouldResemble, ExecuteError{Method: methodUsersGet, Code: 5, Message: "auth"})
+			})
+		})
+		Convey("Post with comments", func() {
+			response = `{"response": {"posts": [{"id": 2, "owner_id": -1, "text": "post"}], ` +
+				`"comments": {"count": 5, "items": [{"id": 3, "from_id": 1, "text": "first"}]}}}`
+			post, err := client.GetPostWithComments(ctx, -1, 2)
+			So(err, ShouldBeNil)
+			So(post.Post.Text, ShouldEqual, "post")
+			So(post.Count, ShouldEqual, 5)
+			So(post.Comments[0].Text, ShouldEqual, "first")
+			So(codes[0], ShouldContainSubstring, `API.wall.getById({"posts":"-1_2"})`)
+
+			Convey("Comments disabled", func() {
+				response = `{"response": {"posts": [{"id": 2}], "comments": false}, ` +
+					`"execute_errors": [{"method": "wall.getComments", "error_code": 212, "error_msg": "closed"}]}`
+				post, err := client.GetPostWithComments(ctx, -1, 2)
+				So(err, ShouldBeNil)
+				So(post.Post.ID, ShouldEqual, 2)
+				So(post.Comments, ShouldBeEmpty)
+			})
+			Convey("Not found", func() {
+				response = `{"response": {"posts": [], "comments": false}}`
+				_, err := client.GetPostWithComments(ctx, -1, 2)
+				So(err, ShouldEqual, ErrPostNotFound)
+			})
+		})
+	})
+}
@@ -0,0 +1,274 @@
+package vk
+
+import (
+	"errors"
+	"sort"
+)
+
+const (
+	methodPhotosGet           = "photos.get"
+	methodPhotosGetAlbums     = "photos.getAlbums"
+	methodPhotosCreateAlbum   = "photos.createAlbum"
+	methodPhotosEditAlbum     = "photos.editAlbum"
+	methodPhotosReorderAlbums = "photos.reorderAlbums"
+	methodPhotosReorderPhotos = "photos.reorderPhotos"
+
+	maxPhotosCount = 1000
+)
+
+// ErrUnknownReorderID is returned when desired order has
+// id that is not in current order
+var ErrUnknownReorderID = errors.New("reorder: unknown id in desired order")
+
+// Album is photo album
+type Album struct {
+	ID          int    `json:"id"`
+	OwnerID     ID     `json:"owner_id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Created     Time   `json:"created"`
+	Updated     Time   `json:"updated"`
+	Size        int    `json:"size"`
+	ThumbID     int    `json:"thumb_id"`
+	ThumbSrc    string `json:"thumb_src,omitempty"`
+}
+
+type PhotosGetAlbumsFields struct {
+	OwnerID    ID    `url:"owner_id,omitempty"`
+	AlbumIDs   []int `url:"album_ids,comma,omitempty"`
+	Offset     int   `url:"offset,omitempty"`
+	Count      int   `url:"count,omitempty"`
+	NeedSystem Bool  `url:"need_system,omitempty"`
+	NeedCovers Bool  `url:"need_covers,omitempty"`
+}
+
+type PhotosGetAlbumsResult struct {
+	Count int     `json:"count"`
+	Items []Album `json:"items"`
+}
+
+func (p Photos) GetAlbums(fields PhotosGetAlbumsFields) (result PhotosGetAlbumsResult, err error) {
+	return result, p.Decode(p.Request(methodPhotosGetAlbums, fields), &result)
+}
+
+type PhotosCreateAlbumFields struct {
+	Title              string   `url:"title"`
+	GroupID            ID       `url:"group_id,omitempty"`
+	Description        string   `url:"description,omitempty"`
+	PrivacyView        []string `url:"privacy_view,comma,omitempty"`
+	PrivacyComment     []string `url:"privacy_comment,comma,omitempty"`
+	UploadByAdminsOnly Bool     `url:"upload_by_admins_only,omitempty"`
+	CommentsDisabled   Bool     `url:"comments_disabled,omitempty"`
+}
+
+func (p Photos) CreateAlbum(fields PhotosCreateAlbumFields) (album Album, err error) {
+	return album, p.Decode(p.Request(methodPhotosCreateAlbum, fields), &album)
+}
+
+type PhotosEditAlbumFields struct {
+	AlbumID            int      `url:"album_id"`
+	OwnerID            ID       `url:"owner_id,omitempty"`
+	Title              string   `url:"title,omitempty"`
+	Description        string   `url:"description,omitempty"`
+	PrivacyView        []string `url:"privacy_view,comma,omitempty"`
+	PrivacyComment     []string `url:"privacy_comment,comma,omitempty"`
+	UploadByAdminsOnly Bool     `url:"upload_by_admins_only,omitempty"`
+	CommentsDisabled   Bool     `url:"comments_disabled,omitempty"`
+}
+
+func (p Photos) EditAlbum(fields PhotosEditAlbumFields) error {
+	var result int
+	return p.Decode(p.Request(methodPhotosEditAlbum, fields), &result)
+}
+
+type PhotosGetFields struct {
+	OwnerID ID     `url:"owner_id,omitempty"`
+	AlbumID string `url:"album_id"`
+	Rev     Bool   `url:"rev,omitempty"`
+	Offset  int    `url:"offset,omitempty"`
+	Count   int    `url:"count,omitempty"`
+}
+
+type PhotosGetResult struct {
+	Count int     `json:"count"`
+	Items []Photo `json:"items"`
+}
+
+// Get returns photos of album, AlbumID is id or
+// one of "wall", "profile" and "saved"
+func (p Photos) Get(fields PhotosGetFields) (result PhotosGetResult, err error) {
+	return result, p.Decode(p.Request(methodPhotosGet, fields), &result)
+}
+
+// ReorderMove is single reorder call that moves ID before
+// Before or after After, only one of them is set
+type ReorderMove struct {
+	ID     int
+	Before int
+	After  int
+}
+
+type reorderAlbumFields struct {
+	OwnerID ID  `url:"owner_id,omitempty"`
+	AlbumID int `url:"album_id"`
+	Before  int `url:"before,omitempty"`
+	After   int `url:"after,omitempty"`
+}
+
+type reorderPhotoFields struct {
+	OwnerID ID  `url:"owner_id,omitempty"`
+	PhotoID int `url:"photo_id"`
+	Before  int `url:"before,omitempty"`
+	After   int `url:"after,omitempty"`
+}
+
+// ReorderAlbum moves album of owner according to move
+func (p Photos) ReorderAlbum(owner ID, move ReorderMove) error {
+	var result int
+	return p.Decode(p.Request(methodPhotosReorderAlbums, reorderAlbumFields{owner, move.ID, move.Before, move.After}), &result)
+}
+
+// ReorderPhoto moves photo of owner in album according to move
+func (p Photos) ReorderPhoto(owner ID, move ReorderMove) error {
+	var result int
+	return p.Decode(p.Request(methodPhotosReorderPhotos, reorderPhotoFields{owner, move.ID, move.Before, move.After}), &result)
+}
+
+// ReorderMoves returns minimal list of moves that changes current
+// order to desired. Ids of current order that are not in desired
+// are not moved. Moves should be applied in order.
+func ReorderMoves(current, desired []int) ([]ReorderMove, error) {
+	position := make(map[int]int, len(current))
+	for i, id := range current {
+		position[id] = i
+	}
+	positions := make([]int, len(desired))
+	for i, id := range desired {
+		p, ok := position[id]
+		if !ok {
+			return nil, ErrUnknownReorderID
+		}
+		positions[i] = p
+	}
+	// elements of longest increasing subsequence of current
+	// positions keep their places, all others are moved
+	keep := longestIncreasing(positions)
+	list := append([]int(nil), current...)
+	var moves []ReorderMove
+	for i, id := range desired {
+		if keep[i] {
+			continue
+		}
+		move := ReorderMove{ID: id}
+		if i == 0 {
+			if list[0] == id {
+				continue
+			}
+			move.Before = list[0]
+		} else {
+			move.After = desired[i-1]
+		}
+		list = applyMove(list, move)
+		moves = append(moves, move)
+	}
+	return moves, nil
+}
+
+// longestIncreasing marks elements of longest strictly increasing
+// subsequence of values
+func longestIncreasing(values []int) []bool {
+	// tails[k] is index of smallest tail of subsequence of length k+1
+	var tails []int
+	prev := make([]int, len(values))
+	for i, v := range values {
+		k := sort.Search(len(tails), func(j int) bool {
+			return values[tails[j]] >= v
+		})
+		prev[i] = -1
+		if k > 0 {
+			prev[i] = tails[k-1]
+		}
+		if k == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[k] = i
+		}
+	}
+	keep := make([]bool, len(values))
+	if len(tails) == 0 {
+		return keep
+	}
+	for i := tails[len(tails)-1]; i >= 0; i = prev[i] {
+		keep[i] = true
+	}
+	return keep
+}
+
+// applyMove returns list with move applied
+func applyMove(list []int, move ReorderMove) []int {
+	result := make([]int, 0, len(list))
+	for _, id := range list {
+		if id == move.ID {
+			continue
+		}
+		if id == move.Before {
+			result = append(result, move.ID)
+		}
+		result = append(result, id)
+		if id == move.After {
+			result = append(result, move.ID)
+		}
+	}
+	return result
+}
+
+// ApplyAlbumOrder reorders albums of owner to desired order
+// with minimal count of photos.reorderAlbums calls
+func (p Photos) ApplyAlbumOrder(owner ID, desired []int) error {
+	albums, err := p.GetAlbums(PhotosGetAlbumsFields{OwnerID: owner})
+	if err != nil {
+		return err
+	}
+	current := make([]int, len(albums.Items))
+	for i, a := range albums.Items {
+		current[i] = a.ID
+	}
+	moves, err := ReorderMoves(current, desired)
+	if err != nil {
+		return err
+	}
+	for _, move := range moves {
+		if err = p.ReorderAlbum(owner, move); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApplyPhotoOrder reorders photos in album to desired order
+// with minimal count of photos.reorderPhotos calls
+func (p Photos) ApplyPhotoOrder(owner ID, albumID int, desired []int) error {
+	var current []int
+	for {
+		photos, err := p.Get(PhotosGetFields{OwnerID: owner, AlbumID: int64s(int64(albumID)), Offset: len(current), Count: maxPhotosCount})
+		if err != nil {
+			return err
+		}
+		for _, photo := range photos.Items {
+			current = append(current, photo.ID)
+		}
+		if len(photos.Items) == 0 || len(current) >= photos.Count {
+			break
+		}
+	}
+	moves, err := ReorderMoves(current, desired)
+	if err != nil {
+		return err
+	}
+	for _, move := range moves {
+		if err = p.ReorderPhoto(owner, move); err != nil {
+			return err
+		}
+	}
+	return nil
+}
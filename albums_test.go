@@ -0,0 +1,101 @@
+package vk
+
+import (
+	"math/rand"
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestReorderMoves(t *testing.T) {
+	Convey("Reorder moves", t, func() {
+		Convey("Same order", func() {
+			moves, err := ReorderMoves([]int{1, 2, 3}, []int{1, 2, 3})
+			So(err, ShouldBeNil)
+			So(moves, ShouldBeEmpty)
+		})
+		Convey("Single move", func() {
+			moves, err := ReorderMoves([]int{1, 2, 3, 4}, []int{4, 1, 2, 3})
+			So(err, ShouldBeNil)
+			So(moves, ShouldResemble, []ReorderMove{{ID: 4, Before: 1}})
+			moves, err = ReorderMoves([]int{1, 2, 3, 4}, []int{2, 3, 4, 1})
+			So(err, ShouldBeNil)
+			So(moves, ShouldResemble, []ReorderMove{{ID: 1, After: 4}})
+		})
+		Convey("Unknown", func() {
+			_, err := ReorderMoves([]int{1, 2}, []int{3, 1})
+			So(err, ShouldEqual, ErrUnknownReorderID)
+		})
+		Convey("Random", func() {
+			r := rand.New(rand.NewSource(1))
+			for n := 1; n < 30; n++ {
+				current := r.Perm(n)
+				for i := range current {
+					current[i]++
+				}
+				desired := r.Perm(n)
+				for i := range desired {
+					desired[i]++
+				}
+				moves, err := ReorderMoves(current, desired)
+				So(err, ShouldBeNil)
+				list := current
+				for _, m := range moves {
+					list = applyMove(list, m)
+				}
+				So(list, ShouldResemble, desired)
+				position := make(map[int]int)
+				for i, id := range current {
+					position[id] = i
+				}
+				positions := make([]int, n)
+				for i, id := range desired {
+					positions[i] = position[id]
+				}
+				kept := 0
+				for _, k := range longestIncreasing(positions) {
+					if k {
+						kept++
+					}
+				}
+				So(len(moves), ShouldEqual, n-kept)
+			}
+		})
+	})
+}
+
+func TestAlbums(t *testing.T) {
+	Convey("Albums", t, func() {
+		client := New()
+		var calls []string
+		client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			switch req.URL.Path {
+			case "/method/photos.getAlbums":
+				return jsonResponse(http.StatusOK, `{"response": {"count": 3, "items": [{"id": 1}, {"id": 2}, {"id": 3}]}}`), nil
+			case "/method/photos.get":
+				if q.Get("offset") == "" {
+					return jsonResponse(http.StatusOK, `{"response": {"count": 3, "items": [{"id": 10}, {"id": 11}]}}`), nil
+				}
+				return jsonResponse(http.StatusOK, `{"response": {"count": 3, "items": [{"id": 12}]}}`), nil
+			case "/method/photos.createAlbum":
+				return jsonResponse(http.StatusOK, `{"response": {"id": 5, "title": "`+q.Get("title")+`"}}`), nil
+			}
+			calls = append(calls, req.URL.Path[len("/method/"):]+" "+q.Get("album_id")+q.Get("photo_id")+" "+q.Get("before")+" "+q.Get("after"))
+			return jsonResponse(http.StatusOK, `{"response": 1}`), nil
+		}))
+		album, err := client.Photos.CreateAlbum(PhotosCreateAlbumFields{Title: "cats", PrivacyView: []string{"friends"}})
+		So(err, ShouldBeNil)
+		So(album.ID, ShouldEqual, 5)
+		So(album.Title, ShouldEqual, "cats")
+		So(client.Photos.EditAlbum(PhotosEditAlbumFields{AlbumID: 5, Title: "dogs"}), ShouldBeNil)
+		So(client.Photos.ApplyAlbumOrder(-1, []int{3, 1, 2}), ShouldBeNil)
+		So(client.Photos.ApplyPhotoOrder(-1, 5, []int{11, 12, 10}), ShouldBeNil)
+		So(calls, ShouldResemble, []string{
+			"photos.editAlbum 5  ",
+			"photos.reorderAlbums 3 1 ",
+			"photos.reorderPhotos 10  12",
+		})
+	})
+}
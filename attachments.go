@@ -0,0 +1,92 @@
+package vk
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Attachment types
+const (
+	AttachmentPhoto        = "photo"
+	AttachmentDoc          = "doc"
+	AttachmentAudioMessage = "audio_message"
+)
+
+// PhotoSize is one of copies of photo
+type PhotoSize struct {
+	Type   string `json:"type"`
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// Photo object
+type Photo struct {
+	ID        int         `json:"id"`
+	AlbumID   int         `json:"album_id"`
+	OwnerID   ID          `json:"owner_id"`
+	Text      string      `json:"text"`
+	Date      Time        `json:"date"`
+	AccessKey string      `json:"access_key,omitempty"`
+	Sizes     []PhotoSize `json:"sizes"`
+}
+
+// Largest returns copy of photo with largest area
+func (p Photo) Largest() (size PhotoSize, ok bool) {
+	for _, s := range p.Sizes {
+		if !ok || s.Width*s.Height > size.Width*size.Height {
+			size, ok = s, true
+		}
+	}
+	return size, ok
+}
+
+// Doc is document object
+type Doc struct {
+	ID        int    `json:"id"`
+	OwnerID   ID     `json:"owner_id"`
+	Title     string `json:"title"`
+	Size      int64  `json:"size"`
+	Ext       string `json:"ext"`
+	URL       string `json:"url"`
+	Date      Time   `json:"date"`
+	AccessKey string `json:"access_key,omitempty"`
+}
+
+// AudioMessage is voice message object
+type AudioMessage struct {
+	ID        int    `json:"id"`
+	OwnerID   ID     `json:"owner_id"`
+	Duration  int    `json:"duration"`
+	LinkOGG   string `json:"link_ogg"`
+	LinkMP3   string `json:"link_mp3"`
+	AccessKey string `json:"access_key,omitempty"`
+}
+
+// Attachment of message or post, field
+// according to Type is set for known types
+type Attachment struct {
+	Type         string        `json:"type"`
+	Photo        *Photo        `json:"photo,omitempty"`
+	Doc          *Doc          `json:"doc,omitempty"`
+	AudioMessage *AudioMessage `json:"audio_message,omitempty"`
+}
+
+// ParseAttachment decodes attachment from raw object
+func ParseAttachment(raw Raw) (a Attachment, err error) {
+	return a, json.Unmarshal(raw, &a)
+}
+
+// String returns attachment in type{owner_id}_{id} format,
+// that is used in attachment parameters
+func (a Attachment) String() string {
+	switch {
+	case a.Photo != nil:
+		return fmt.Sprintf("%s%d_%d", a.Type, a.Photo.OwnerID, a.Photo.ID)
+	case a.Doc != nil:
+		return fmt.Sprintf("%s%d_%d", a.Type, a.Doc.OwnerID, a.Doc.ID)
+	case a.AudioMessage != nil:
+		return fmt.Sprintf("%s%d_%d", a.Type, a.AudioMessage.OwnerID, a.AudioMessage.ID)
+	}
+	return a.Type
+}
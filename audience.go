@@ -0,0 +1,189 @@
+package vk
+
+import "context"
+
+const maxMembersCount = 1000
+
+type GroupsGetMembersFields struct {
+	GroupID ID     `url:"group_id"`
+	Sort    string `url:"sort,omitempty"`
+	Offset  int    `url:"offset,omitempty"`
+	Count   int    `url:"count,omitempty"`
+}
+
+type groupsGetMemberIDsResult struct {
+	Count int  `json:"count"`
+	Items []ID `json:"items"`
+}
+
+// Members iterates over ids of community members:
+//
+//	m := client.Groups.Members(GroupsGetMembersFields{GroupID: 1})
+//	for m.Next() {
+//		id := m.ID()
+//	}
+//	if err := m.Err(); err != nil {
+//		// handle error
+//	}
+type Members struct {
+	offsetPager
+	groups Groups
+	fields GroupsGetMembersFields
+	items  []ID
+}
+
+// Members returns iterator over members of community starting at fields.Offset
+func (g Groups) Members(fields GroupsGetMembersFields) *Members {
+	if fields.Count == 0 {
+		fields.Count = maxMembersCount
+	}
+	return &Members{offsetPager: offsetPager{offset: fields.Offset}, groups: g, fields: fields}
+}
+
+// Next advances to next member, fetching next page if needed,
+// and returns false when members are over or error occurred
+func (m *Members) Next() bool {
+	return m.next(func(offset int) (int, int, error) {
+		m.fields.Offset = offset
+		result := groupsGetMemberIDsResult{}
+		err := m.groups.Decode(m.groups.Request(methodGroupsGetMembers, m.fields), &result)
+		m.items = result.Items
+		return len(result.Items), result.Count, err
+	})
+}
+
+// ID returns current member
+func (m *Members) ID() ID {
+	return m.items[m.pos]
+}
+
+// Count returns total count of members reported by api
+func (m *Members) Count() int {
+	return m.total
+}
+
+// Err returns error occurred during iteration
+func (m *Members) Err() error {
+	return m.err
+}
+
+// Overlap is audience overlap of communities, indexes
+// of counts are indexes of Groups
+type Overlap struct {
+	Groups  []ID
+	Members []int
+	// Exclusive is count of members of only one community
+	Exclusive []int
+	// Shared[i][j] is count of members of both i and j
+	Shared       [][]int
+	Union        int
+	Intersection int
+}
+
+// Jaccard returns similarity of audiences of i and j
+func (o Overlap) Jaccard(i, j int) float64 {
+	union := o.Members[i] + o.Members[j] - o.Shared[i][j]
+	if union == 0 {
+		return 0
+	}
+	return float64(o.Shared[i][j]) / float64(union)
+}
+
+// Audience loads members of communities into sets
+type Audience struct {
+	Groups Groups
+	// SpillSize and Dir are used for member sets, see IDSet
+	SpillSize int
+	Dir       string
+}
+
+// Load returns member sets of communities, that
+// should be closed to remove spill files
+func (a Audience) Load(ctx context.Context, groups ...ID) ([]*IDSet, error) {
+	sets := make([]*IDSet, 0, len(groups))
+	closeAll := func() {
+		for _, s := range sets {
+			s.Close()
+		}
+	}
+	for _, g := range groups {
+		s := &IDSet{SpillSize: a.SpillSize, Dir: a.Dir}
+		sets = append(sets, s)
+		m := a.Groups.Members(GroupsGetMembersFields{GroupID: g})
+		for m.Next() {
+			if err := s.Add(m.ID()); err != nil {
+				closeAll()
+				return nil, err
+			}
+		}
+		if err := m.Err(); err != nil {
+			closeAll()
+			return nil, err
+		}
+		if err := ctx.Err(); err != nil {
+			closeAll()
+			return nil, err
+		}
+	}
+	return sets, nil
+}
+
+// Intersection calls fn for members of all communities
+func (a Audience) Intersection(ctx context.Context, fn func(id ID) error, groups ...ID) error {
+	return a.merge(ctx, groups, func(id ID, in []int) error {
+		if len(in) != len(groups) {
+			return nil
+		}
+		return fn(id)
+	})
+}
+
+// Union calls fn for members of any of communities
+func (a Audience) Union(ctx context.Context, fn func(id ID) error, groups ...ID) error {
+	return a.merge(ctx, groups, func(id ID, in []int) error {
+		return fn(id)
+	})
+}
+
+func (a Audience) merge(ctx context.Context, groups []ID, fn func(id ID, in []int) error) error {
+	sets, err := a.Load(ctx, groups...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for _, s := range sets {
+			s.Close()
+		}
+	}()
+	return MergeIDSets(sets, fn)
+}
+
+// Overlap returns audience overlap statistics of communities
+func (a Audience) Overlap(ctx context.Context, groups ...ID) (Overlap, error) {
+	o := Overlap{
+		Groups:    groups,
+		Members:   make([]int, len(groups)),
+		Exclusive: make([]int, len(groups)),
+		Shared:    make([][]int, len(groups)),
+	}
+	for i := range o.Shared {
+		o.Shared[i] = make([]int, len(groups))
+	}
+	err := a.merge(ctx, groups, func(id ID, in []int) error {
+		o.Union++
+		if len(in) == len(groups) {
+			o.Intersection++
+		}
+		if len(in) == 1 {
+			o.Exclusive[in[0]]++
+		}
+		for _, i := range in {
+			o.Members[i]++
+			for _, j := range in {
+				o.Shared[i][j]++
+			}
+		}
+		return nil
+	})
+	return o, err
+}
@@ -0,0 +1,63 @@
+package vk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAudience(t *testing.T) {
+	Convey("Audience", t, func() {
+		members := map[string][]string{
+			"1": {`{"count": 4, "items": [1, 2, 3]}`, `{"count": 4, "items": [4]}`},
+			"2": {`{"count": 3, "items": [3, 4, 5]}`},
+			"3": {`{"count": 2, "items": [4, 6]}`},
+		}
+		client := New(WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			So(req.URL.Path, ShouldEqual, "/method/groups.getMembers")
+			q := req.URL.Query()
+			page := 0
+			if q.Get("offset") != "" {
+				page = 1
+			}
+			return jsonResponse(http.StatusOK, `{"response": `+members[q.Get("group_id")][page]+`}`), nil
+		})))
+		ctx := context.Background()
+		Convey("Members", func() {
+			m := client.Groups.Members(GroupsGetMembersFields{GroupID: 1, Count: 3})
+			var ids []ID
+			for m.Next() {
+				ids = append(ids, m.ID())
+			}
+			So(m.Err(), ShouldBeNil)
+			So(m.Count(), ShouldEqual, 4)
+			So(ids, ShouldResemble, []ID{1, 2, 3, 4})
+		})
+		a := Audience{Groups: client.Groups, SpillSize: 2}
+		Convey("Overlap", func() {
+			o, err := a.Overlap(ctx, 1, 2, 3)
+			So(err, ShouldBeNil)
+			So(o.Members, ShouldResemble, []int{4, 3, 2})
+			So(o.Union, ShouldEqual, 6)
+			So(o.Intersection, ShouldEqual, 1)
+			So(o.Exclusive, ShouldResemble, []int{2, 1, 1})
+			So(o.Shared[0][1], ShouldEqual, 2)
+			So(o.Shared[1][0], ShouldEqual, 2)
+			So(o.Jaccard(0, 1), ShouldAlmostEqual, 0.4)
+		})
+		Convey("Intersection and union", func() {
+			var ids []ID
+			collect := func(id ID) error {
+				ids = append(ids, id)
+				return nil
+			}
+			So(a.Intersection(ctx, collect, 1, 2), ShouldBeNil)
+			So(ids, ShouldResemble, []ID{3, 4})
+			ids = nil
+			So(a.Union(ctx, collect, 2, 3), ShouldBeNil)
+			So(ids, ShouldResemble, []ID{3, 4, 5, 6})
+		})
+	})
+}
@@ -0,0 +1,111 @@
+package vk
+
+import (
+	"errors"
+	"io"
+)
+
+const (
+	methodAudioGet             = "audio.get"
+	methodAudioSearch          = "audio.search"
+	methodAudioGetByID         = "audio.getById"
+	methodAudioGetUploadServer = "audio.getUploadServer"
+	methodAudioSave            = "audio.save"
+)
+
+// ErrOfficialClientRequired is returned by methods that are available
+// only in official-client mode, see WithOfficialClient
+var ErrOfficialClientRequired = errors.New("method requires official client, see WithOfficialClient")
+
+// Audio resource, enabled only in official-client mode
+type Audio struct {
+	Resource
+	official bool
+}
+
+func (a Audio) decode(method string, fields interface{}, v interface{}) error {
+	if !a.official {
+		return ErrOfficialClientRequired
+	}
+	return a.Decode(a.Request(method, fields), v)
+}
+
+// AudioItem is audio record
+type AudioItem struct {
+	ID       int    `json:"id"`
+	OwnerID  ID     `json:"owner_id"`
+	Artist   string `json:"artist"`
+	Title    string `json:"title"`
+	Duration int    `json:"duration"`
+	URL      string `json:"url"`
+	AlbumID  int    `json:"album_id,omitempty"`
+	GenreID  int    `json:"genre_id,omitempty"`
+	Date     Time   `json:"date"`
+}
+
+type AudioGetFields struct {
+	OwnerID ID  `url:"owner_id,omitempty"`
+	AlbumID int `url:"album_id,omitempty"`
+	Offset  int `url:"offset,omitempty"`
+	Count   int `url:"count,omitempty"`
+}
+
+type AudioSearchFields struct {
+	Query         string `url:"q"`
+	AutoComplete  Bool   `url:"auto_complete,omitempty"`
+	PerformerOnly Bool   `url:"performer_only,omitempty"`
+	Sort          int    `url:"sort,omitempty"`
+	SearchOwn     Bool   `url:"search_own,omitempty"`
+	Offset        int    `url:"offset,omitempty"`
+	Count         int    `url:"count,omitempty"`
+}
+
+type AudioGetResult struct {
+	Count int         `json:"count"`
+	Items []AudioItem `json:"items"`
+}
+
+type audioGetByIDFields struct {
+	Audios []string `url:"audios,comma"`
+}
+
+// AudioSaveFields are optional metadata of uploaded audio
+type AudioSaveFields struct {
+	Artist string `url:"artist,omitempty" json:"-"`
+	Title  string `url:"title,omitempty" json:"-"`
+}
+
+type audioSaveFields struct {
+	Server int    `url:"server" json:"server"`
+	Audio  string `url:"audio" json:"audio"`
+	Hash   string `url:"hash" json:"hash"`
+	AudioSaveFields
+}
+
+// Get returns audio records of user or community
+func (a Audio) Get(fields AudioGetFields) (result AudioGetResult, err error) {
+	return result, a.decode(methodAudioGet, fields, &result)
+}
+
+// Search returns audio records found by query
+func (a Audio) Search(fields AudioSearchFields) (result AudioGetResult, err error) {
+	return result, a.decode(methodAudioSearch, fields, &result)
+}
+
+// GetByID returns audio records by "{owner_id}_{audio_id}" identifiers
+func (a Audio) GetByID(audios ...string) (result []AudioItem, err error) {
+	return result, a.decode(methodAudioGetByID, audioGetByIDFields{audios}, &result)
+}
+
+// Upload uploads audio file from r and saves it to current user audio records
+func (a Audio) Upload(fields AudioSaveFields, name string, r io.Reader) (result AudioItem, err error) {
+	server := uploadServer{}
+	if err = a.decode(methodAudioGetUploadServer, nil, &server); err != nil {
+		return result, err
+	}
+	saved := audioSaveFields{AudioSaveFields: fields}
+	if err = a.Uploader.Upload(server.UploadURL, "file", name, r, &saved); err != nil {
+		return result, err
+	}
+	return result, a.decode(methodAudioSave, saved, &result)
+}
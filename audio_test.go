@@ -0,0 +1,60 @@
+package vk
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAudio(t *testing.T) {
+	Convey("Audio", t, func() {
+		f := rf()
+		resource := record(newApiMock(`{"response": {"count": 1, "items": [
+			{"id": 2, "owner_id": 1, "artist": "a", "title": "t", "duration": 60}]}}`, nil), &f)
+		Convey("Disabled", func() {
+			a := Audio{Resource: resource}
+			_, err := a.Get(AudioGetFields{OwnerID: 1})
+			So(err, ShouldEqual, ErrOfficialClientRequired)
+			_, err = a.GetByID("1_2")
+			So(err, ShouldEqual, ErrOfficialClientRequired)
+			So(New().Audio.official, ShouldBeFalse)
+			So(New(WithOfficialClient(ClientAndroid)).Audio.official, ShouldBeTrue)
+		})
+		a := Audio{resource, true}
+		Convey("Get", func() {
+			result, err := a.Get(AudioGetFields{OwnerID: 1, Count: 10})
+			So(err, ShouldBeNil)
+			So(result.Items[0].Artist, ShouldEqual, "a")
+			So(f.request.Method, ShouldEqual, "audio.get")
+			So(f.request.Values.Get("owner_id"), ShouldEqual, "1")
+		})
+		Convey("Search", func() {
+			_, err := a.Search(AudioSearchFields{Query: "song", AutoComplete: true})
+			So(err, ShouldBeNil)
+			So(f.request.Method, ShouldEqual, "audio.search")
+			So(f.request.Values.Get("q"), ShouldEqual, "song")
+			So(f.request.Values.Get("auto_complete"), ShouldEqual, "1")
+		})
+		Convey("Get by id", func() {
+			a.APIClient = newApiMock(`{"response": [{"id": 2, "owner_id": 1}]}`, nil)
+			result, err := a.GetByID("1_2", "1_3")
+			So(err, ShouldBeNil)
+			So(result, ShouldHaveLength, 1)
+			So(f.request.Values.Get("audios"), ShouldEqual, "1_2,1_3")
+		})
+		Convey("Upload", func() {
+			server := newUploadServer("file", `{"server": 5, "audio": "data", "hash": "h"}`)
+			defer server.Close()
+			a.APIClient = newApiMock(`{"response": {"upload_url": "`+server.URL+`", "id": 2, "owner_id": 1, "title": "t"}}`, nil)
+			a.Uploader = Uploader{getDefaultHTTPClient()}
+			result, err := a.Upload(AudioSaveFields{Title: "t"}, "cover.png", bytes.NewBufferString("image"))
+			So(err, ShouldBeNil)
+			So(result.ID, ShouldEqual, 2)
+			So(f.request.Method, ShouldEqual, "audio.save")
+			So(f.request.Values.Get("server"), ShouldEqual, "5")
+			So(f.request.Values.Get("audio"), ShouldEqual, "data")
+			So(f.request.Values.Get("title"), ShouldEqual, "t")
+		})
+	})
+}
@@ -0,0 +1,64 @@
+package vk
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// AuditRecord describes single api call, secrets
+// are stripped from Params
+type AuditRecord struct {
+	Time     time.Time     `json:"time"`
+	Method   string        `json:"method"`
+	Params   url.Values    `json:"params,omitempty"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+	Err      error         `json:"-"`
+}
+
+// AuditFunc receives record of every api call
+type AuditFunc func(record AuditRecord)
+
+// WithAudit sets function that receives record of every api call
+func WithAudit(f AuditFunc) Option {
+	return func(c *Client) {
+		c.audit = f
+	}
+}
+
+// NewAuditWriter returns AuditFunc that writes records to w
+// as JSON lines, writes are serialized
+func NewAuditWriter(w io.Writer) AuditFunc {
+	var mux sync.Mutex
+	encoder := json.NewEncoder(w)
+	return func(record AuditRecord) {
+		mux.Lock()
+		defer mux.Unlock()
+		encoder.Encode(record)
+	}
+}
+
+// doAudited performs request and reports it to audit function
+func (c *Client) doAudited(ctx context.Context, request Request) (*Response, error) {
+	if c.audit == nil {
+		return c.doWithToken(ctx, request)
+	}
+	start := c.clock.Now()
+	res, err := c.doWithToken(ctx, request)
+	record := AuditRecord{
+		Time:     start,
+		Method:   request.Method,
+		Params:   redactValues(request.Values),
+		Duration: c.clock.Now().Sub(start),
+		Err:      err,
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	c.audit(record)
+	return res, err
+}
@@ -0,0 +1,64 @@
+package vk
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAudit(t *testing.T) {
+	Convey("Audit", t, func() {
+		var records []AuditRecord
+		clock := NewFakeClock(time.Unix(1000, 0))
+		client := New(WithClock(clock), WithAudit(func(record AuditRecord) {
+			records = append(records, record)
+		}))
+		status := http.StatusOK
+		client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			clock.Advance(time.Second)
+			return jsonResponse(status, `{"response": 1}`), nil
+		}))
+		request := Request{Method: "users.get", Token: "t", Values: url.Values{
+			"user_ids":      {"1"},
+			"client_secret": {"s"},
+		}}
+		_, err := client.Do(request)
+		So(err, ShouldBeNil)
+		So(records, ShouldHaveLength, 1)
+		r := records[0]
+		So(r.Method, ShouldEqual, "users.get")
+		So(r.Time, ShouldResemble, time.Unix(1000, 0))
+		So(r.Duration, ShouldEqual, time.Second)
+		So(r.Params.Get("user_ids"), ShouldEqual, "1")
+		So(r.Params.Get("client_secret"), ShouldEqual, "***")
+		So(r.Error, ShouldBeEmpty)
+		So(request.Values.Get("client_secret"), ShouldEqual, "s")
+
+		Convey("Error", func() {
+			status = http.StatusBadGateway
+			_, err := client.Do(request)
+			So(errors.Is(err, ErrBadResponseCode), ShouldBeTrue)
+			So(records[1].Err, ShouldResemble, err)
+			So(records[1].Error, ShouldEqual, "http 502 Bad Gateway: {\"response\": 1}")
+		})
+		Convey("Writer", func() {
+			buf := new(bytes.Buffer)
+			w := NewAuditWriter(buf)
+			w(r)
+			w(r)
+			decoder := json.NewDecoder(buf)
+			var decoded AuditRecord
+			So(decoder.Decode(&decoded), ShouldBeNil)
+			So(decoder.Decode(&decoded), ShouldBeNil)
+			So(decoded.Method, ShouldEqual, "users.get")
+			So(decoded.Params.Get("client_secret"), ShouldEqual, "***")
+			So(decoded.Duration, ShouldEqual, time.Second)
+		})
+	})
+}
@@ -0,0 +1,157 @@
+package vk
+
+const (
+	methodGroupsBan       = "groups.ban"
+	methodGroupsUnban     = "groups.unban"
+	methodGroupsGetBanned = "groups.getBanned"
+	methodAccountBan      = "account.ban"
+	methodAccountUnban    = "account.unban"
+
+	defaultBanListCount = 200
+)
+
+// GroupBanFields are arguments of groups.ban
+type GroupBanFields struct {
+	GroupID        ID     `url:"group_id"`
+	OwnerID        ID     `url:"owner_id"`
+	EndDate        Time   `url:"end_date,omitempty"`
+	Reason         int    `url:"reason,omitempty"`
+	Comment        string `url:"comment,omitempty"`
+	CommentVisible Bool   `url:"comment_visible,omitempty"`
+}
+
+// Ban adds user or group to black list of community
+func (g Groups) Ban(fields GroupBanFields) error {
+	var result int
+	return g.Decode(g.Request(methodGroupsBan, fields), &result)
+}
+
+type groupUnbanFields struct {
+	GroupID ID `url:"group_id"`
+	OwnerID ID `url:"owner_id"`
+}
+
+// Unban removes user or group from black list of community
+func (g Groups) Unban(groupID, ownerID ID) error {
+	var result int
+	return g.Decode(g.Request(methodGroupsUnban, groupUnbanFields{groupID, ownerID}), &result)
+}
+
+// BanInfo describes ban in community
+type BanInfo struct {
+	AdminID        ID     `json:"admin_id"`
+	Date           Time   `json:"date"`
+	Reason         int    `json:"reason"`
+	Comment        string `json:"comment"`
+	CommentVisible Bool   `json:"comment_visible"`
+	EndDate        Time   `json:"end_date"`
+}
+
+// Banned is entry of community black list,
+// Profile or Group is set according to Type
+type Banned struct {
+	Type    string  `json:"type"`
+	Profile *User   `json:"profile,omitempty"`
+	Group   *Group  `json:"group,omitempty"`
+	BanInfo BanInfo `json:"ban_info"`
+}
+
+// OwnerID returns id of banned user or negative id of banned group
+func (b Banned) OwnerID() ID {
+	if b.Profile != nil {
+		return b.Profile.ID
+	}
+	if b.Group != nil {
+		return -b.Group.ID
+	}
+	return 0
+}
+
+type GroupGetBannedFields struct {
+	GroupID ID     `url:"group_id"`
+	Offset  int    `url:"offset,omitempty"`
+	Count   int    `url:"count,omitempty"`
+	Fields  Fields `url:"fields,omitempty"`
+	OwnerID ID     `url:"owner_id,omitempty"`
+}
+
+type GroupGetBannedResult struct {
+	Count int      `json:"count"`
+	Items []Banned `json:"items"`
+}
+
+// GetBanned returns page of community black list
+func (g Groups) GetBanned(fields GroupGetBannedFields) (result GroupGetBannedResult, err error) {
+	return result, g.Decode(g.Request(methodGroupsGetBanned, fields), &result)
+}
+
+// BanList iterates over whole black list of community:
+//
+//	list := client.Groups.BanList(GroupGetBannedFields{GroupID: 1})
+//	for list.Next() {
+//		fmt.Println(list.Banned().OwnerID())
+//	}
+//	if err := list.Err(); err != nil {
+//		// handle error
+//	}
+type BanList struct {
+	offsetPager
+	groups Groups
+	fields GroupGetBannedFields
+	items  []Banned
+}
+
+// BanList returns iterator over black list of community
+func (g Groups) BanList(fields GroupGetBannedFields) *BanList {
+	if fields.Count == 0 {
+		fields.Count = defaultBanListCount
+	}
+	return &BanList{offsetPager: offsetPager{offset: fields.Offset}, groups: g, fields: fields}
+}
+
+// Next advances to next entry, fetching next page if needed,
+// and returns false when list is over or error occurred
+func (l *BanList) Next() bool {
+	return l.next(func(offset int) (int, int, error) {
+		l.fields.Offset = offset
+		result, err := l.groups.GetBanned(l.fields)
+		l.items = result.Items
+		return len(result.Items), result.Count, err
+	})
+}
+
+// Banned returns current entry
+func (l *BanList) Banned() Banned {
+	return l.items[l.pos]
+}
+
+// Count returns total count of list reported by api
+func (l *BanList) Count() int {
+	return l.total
+}
+
+// Err returns error occurred during iteration
+func (l *BanList) Err() error {
+	return l.err
+}
+
+// Account resource
+type Account struct {
+	Resource
+}
+
+type accountBanFields struct {
+	OwnerID ID `url:"owner_id"`
+}
+
+// Ban adds user or group to black list of current user
+func (a Account) Ban(ownerID ID) error {
+	var result int
+	return a.Decode(a.Request(methodAccountBan, accountBanFields{ownerID}), &result)
+}
+
+// Unban removes user or group from black list of current user
+func (a Account) Unban(ownerID ID) error {
+	var result int
+	return a.Decode(a.Request(methodAccountUnban, accountBanFields{ownerID}), &result)
+}
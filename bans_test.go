@@ -0,0 +1,68 @@
+package vk
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBans(t *testing.T) {
+	Convey("Bans", t, func() {
+		client := New()
+		var calls []string
+		pages := map[string]string{
+			"": `{"response": {"count": 3, "items": [
+				{"type": "profile", "profile": {"id": 1}, "ban_info": {"admin_id": 5, "comment": "spam", "end_date": 0}},
+				{"type": "group", "group": {"id": 2}, "ban_info": {"end_date": 2000}}
+			]}}`,
+			"2": `{"response": {"count": 3, "items": [{"type": "profile", "profile": {"id": 3}, "ban_info": {}}]}}`,
+		}
+		client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			switch req.URL.Path {
+			case "/method/groups.getBanned":
+				calls = append(calls, "groups.getBanned "+q.Get("offset")+" "+q.Get("count"))
+				if page, ok := pages[q.Get("offset")]; ok {
+					return jsonResponse(http.StatusOK, page), nil
+				}
+				return jsonResponse(http.StatusOK, `{"response": {"count": 3, "items": []}}`), nil
+			}
+			calls = append(calls, req.URL.Path[len("/method/"):]+" "+q.Encode())
+			return jsonResponse(http.StatusOK, `{"response": 1}`), nil
+		}))
+		Convey("Ban and unban", func() {
+			So(client.Groups.Ban(GroupBanFields{GroupID: 10, OwnerID: 1, EndDate: Time{time.Unix(2000, 0)}, Comment: "spam", CommentVisible: true}), ShouldBeNil)
+			So(client.Groups.Unban(10, 1), ShouldBeNil)
+			So(client.Account.Ban(-2), ShouldBeNil)
+			So(client.Account.Unban(-2), ShouldBeNil)
+			So(calls, ShouldResemble, []string{
+				"groups.ban comment=spam&comment_visible=1&end_date=2000&group_id=10&https=1&owner_id=1&v=" + defaultVersion,
+				"groups.unban group_id=10&https=1&owner_id=1&v=" + defaultVersion,
+				"account.ban https=1&owner_id=-2&v=" + defaultVersion,
+				"account.unban https=1&owner_id=-2&v=" + defaultVersion,
+			})
+		})
+		Convey("List", func() {
+			list := client.Groups.BanList(GroupGetBannedFields{GroupID: 10})
+			var ids []ID
+			for list.Next() {
+				ids = append(ids, list.Banned().OwnerID())
+			}
+			So(list.Err(), ShouldBeNil)
+			So(ids, ShouldResemble, []ID{1, -2, 3})
+			So(list.Count(), ShouldEqual, 3)
+			So(calls, ShouldResemble, []string{"groups.getBanned  200", "groups.getBanned 2 200"})
+			So(list.Next(), ShouldBeFalse)
+		})
+		Convey("List error", func() {
+			client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+				return jsonResponse(http.StatusOK, `{"error": {"error_code": 15}}`), nil
+			}))
+			list := client.Groups.BanList(GroupGetBannedFields{GroupID: 10, Count: 1})
+			So(list.Next(), ShouldBeFalse)
+			So(ErrNotAllowed.Is(list.Err()), ShouldBeTrue)
+		})
+	})
+}
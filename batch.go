@@ -0,0 +1,139 @@
+package vk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+// maxExecuteCalls is limit of api calls in one execute
+const maxExecuteCalls = 25
+
+type batchCall struct {
+	request Request
+	dest    interface{}
+}
+
+// Batch collects api calls that are made in one execute request,
+// results are unmarshaled into their own destinations
+type Batch struct {
+	client  *Client
+	factory RequestFactory
+	calls   []batchCall
+}
+
+// Batch returns empty batch of client
+func (c *Client) Batch() *Batch {
+	return &Batch{client: c, factory: c.Users.RequestFactory}
+}
+
+// Add enqueues call of method with arguments, result is
+// unmarshaled into dest on Commit, nil dest discards result
+func (b *Batch) Add(method string, arguments interface{}, dest interface{}) *Batch {
+	b.calls = append(b.calls, batchCall{b.factory.Request(method, arguments), dest})
+	return b
+}
+
+// Len returns count of enqueued calls
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+func (b *Batch) UsersGet(fields UsersGetFields, dest *[]User) *Batch {
+	return b.Add(methodUsersGet, fields, dest)
+}
+
+func (b *Batch) WallGet(fields WallGetFields, dest *WallGetResult) *Batch {
+	return b.Add(methodWallGet, fields, dest)
+}
+
+func (b *Batch) NewsfeedGet(fields NewsfeedGetFields, dest *NewsfeedGetResult) *Batch {
+	return b.Add(methodNewsfeedGet, fields, dest)
+}
+
+func (b *Batch) FriendsGetOnline(fields FriendsGetOnlineFields, dest *[]ID) *Batch {
+	return b.Add(methodFriendsGetOnline, fields, dest)
+}
+
+func (b *Batch) GroupsGet(fields GroupGetFields, dest *GroupGetResult) *Batch {
+	return b.Add(methodGroupsGet, fields, dest)
+}
+
+func (b *Batch) GroupsGetMembers(fields GroupSearchFields, dest *GroupSearchResult) *Batch {
+	return b.Add(methodGroupsGetMembers, fields, dest)
+}
+
+// executeCode returns code of execute that returns array of call results
+func executeCode(calls []batchCall) string {
+	code := new(bytes.Buffer)
+	code.WriteString("return [")
+	for i, call := range calls {
+		if i > 0 {
+			code.WriteString(",")
+		}
+		code.WriteString(call.request.JS())
+	}
+	code.WriteString("];")
+	return code.String()
+}
+
+type executeFields struct {
+	Code string `url:"code"`
+}
+
+// Commit makes enqueued calls in execute requests of up to 25 calls
+// and unmarshals results. Failed calls leave their destinations
+// untouched and are reported by returned Errors.
+func (b *Batch) Commit(ctx context.Context) error {
+	var failed Errors
+	for start := 0; start < len(b.calls); start += maxExecuteCalls {
+		end := start + maxExecuteCalls
+		if end > len(b.calls) {
+			end = len(b.calls)
+		}
+		errs, err := b.commit(ctx, b.calls[start:end])
+		if err != nil {
+			return err
+		}
+		failed = append(failed, errs...)
+	}
+	b.calls = nil
+	if len(failed) != 0 {
+		return failed
+	}
+	return nil
+}
+
+func (b *Batch) commit(ctx context.Context, calls []batchCall) (Errors, error) {
+	request := b.factory.Request(methodExecute, executeFields{executeCode(calls)})
+	res, err := b.client.DoContext(ctx, request)
+	failed, partial := err.(Errors)
+	if err != nil && !partial {
+		return nil, err
+	}
+	var results []Raw
+	if err = res.To(&results); err != nil {
+		return nil, err
+	}
+	for i, call := range calls {
+		if i >= len(results) || call.dest == nil || strings.TrimSpace(results[i].String()) == "false" {
+			continue
+		}
+		if err = json.Unmarshal(results[i], call.dest); err != nil {
+			return nil, err
+		}
+	}
+	return failed, nil
+}
+
+// DecodeExecute is Decode for execute requests, that decodes response
+// even if some api calls of code failed, returning their errors
+func (r Resource) DecodeExecute(request Request, v interface{}) (Errors, error) {
+	res, err := r.Do(request)
+	failed, partial := err.(Errors)
+	if err != nil && !partial {
+		return nil, err
+	}
+	return failed, res.To(v)
+}
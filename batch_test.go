@@ -0,0 +1,84 @@
+package vk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBatch(t *testing.T) {
+	Convey("Batch", t, func() {
+		var codes []string
+		response := `{"response": [[{"id": 1, "first_name": "Pavel"}], {"count": 1, "items": [{"id": 10, "text": "a"}]}, [2, 3]]}`
+		client := NewWithToken("t", WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			So(req.URL.Path, ShouldEqual, "/method/execute")
+			So(req.URL.Query().Get("access_token"), ShouldEqual, "t")
+			codes = append(codes, req.URL.Query().Get("code"))
+			return jsonResponse(http.StatusOK, response), nil
+		})))
+		ctx := context.Background()
+		var (
+			users  []User
+			wall   WallGetResult
+			online []ID
+		)
+		b := client.Batch().
+			UsersGet(UsersGetFields{UserIDs: []ID{1}}, &users).
+			WallGet(WallGetFields{OwnerID: -1, Count: 1}, &wall).
+			FriendsGetOnline(FriendsGetOnlineFields{}, &online)
+		So(b.Len(), ShouldEqual, 3)
+		So(b.Commit(ctx), ShouldBeNil)
+		So(codes, ShouldResemble, []string{`return [API.users.get({"user_ids":"1"}),` +
+			`API.wall.get({"count":"1","owner_id":"-1"}),API.friends.getOnline({})];`})
+		So(users[0].FirstName, ShouldEqual, "Pavel")
+		So(wall.Items[0].Text, ShouldEqual, "a")
+		So(online, ShouldResemble, []ID{2, 3})
+		So(b.Len(), ShouldEqual, 0)
+
+		Convey("Partial failure", func() {
+			response = `{"response": [false, [2]], "execute_errors": [
+				{"method": "users.get", "error_code": 18, "error_msg": "User was deleted or banned"}]}`
+			users = nil
+			err := client.Batch().
+				UsersGet(UsersGetFields{UserIDs: []ID{1}}, &users).
+				FriendsGetOnline(FriendsGetOnlineFields{}, &online).
+				Commit(ctx)
+			So(err, ShouldHaveSameTypeAs, Errors{})
+			So(err.(Errors)[0].Method, ShouldEqual, "users.get")
+			So(users, ShouldBeNil)
+			So(online, ShouldResemble, []ID{2})
+		})
+		Convey("Decode execute", func() {
+			response = `{"response": {"count": 2}, "execute_errors": [
+				{"method": "users.get", "error_code": 18, "error_msg": "User was deleted or banned"}]}`
+			var result struct {
+				Count int `json:"count"`
+			}
+			failed, err := client.Users.DecodeExecute(client.Users.Request(methodExecute, executeFields{"return 1;"}), &result)
+			So(err, ShouldBeNil)
+			So(failed, ShouldHaveLength, 1)
+			So(result.Count, ShouldEqual, 2)
+		})
+		Convey("Chunks", func() {
+			results := make([]string, 25)
+			for i := range results {
+				results[i] = "1"
+			}
+			response = fmt.Sprintf(`{"response": [%s]}`, strings.Join(results, ","))
+			b := client.Batch()
+			dest := make([]int, 30)
+			for i := range dest {
+				b.Add("utils.getServerTime", nil, &dest[i])
+			}
+			So(b.Commit(ctx), ShouldBeNil)
+			So(codes, ShouldHaveLength, 3)
+			So(strings.Count(codes[1], "API."), ShouldEqual, 25)
+			So(strings.Count(codes[2], "API."), ShouldEqual, 5)
+			So(dest[29], ShouldEqual, 1)
+		})
+	})
+}
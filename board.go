@@ -0,0 +1,20 @@
+package vk
+
+const methodBoardDeleteComment = "board.deleteComment"
+
+// Board resource for group discussions
+type Board struct {
+	Resource
+}
+
+type BoardCommentFields struct {
+	GroupID   ID  `url:"group_id"`
+	TopicID   int `url:"topic_id"`
+	CommentID int `url:"comment_id"`
+}
+
+// DeleteComment deletes comment in topic of group
+func (b Board) DeleteComment(fields BoardCommentFields) error {
+	var result int
+	return b.Decode(b.Request(methodBoardDeleteComment, fields), &result)
+}
@@ -0,0 +1,148 @@
+package vk
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// defaultBridgeTimeout is less than time vk waits
+// for callback response before retry
+const defaultBridgeTimeout = 3 * time.Second
+
+// ErrBridgeFull is returned by Bridge when buffer is full,
+// so vk redelivers event later
+var ErrBridgeFull = errors.New("bridge: buffer is full")
+
+// ErrBridgeStopped is returned by buffered Bridge after Run is
+// done, so vk redelivers event to another instance or later
+var ErrBridgeStopped = errors.New("bridge: stopped")
+
+// Publisher publishes events to queue, like message broker producer
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// PublisherFunc is function Publisher
+type PublisherFunc func(ctx context.Context, event Event) error
+
+func (f PublisherFunc) Publish(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
+
+// ChannelPublisher publishes events to channel, for in-process consumers
+type ChannelPublisher chan Event
+
+func (p ChannelPublisher) Publish(ctx context.Context, event Event) error {
+	select {
+	case p <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Bridge republishes callback events to Publisher, so callback
+// is answered fast and events are processed by queue consumers:
+//
+//	bridge := &Bridge{Publisher: producer}
+//	http.Handle("/callback", &Callback{Handler: bridge.HandleEvent})
+//
+// Events are published before response, failed publish is answered
+// with error and redelivered by vk. With Buffer events are accepted
+// into memory and published by Run, that is faster, but buffered
+// events are lost on crash.
+type Bridge struct {
+	Publisher Publisher
+	// Timeout of publish, 3 seconds if zero
+	Timeout time.Duration
+	// Buffer is size of in-memory buffer, events are
+	// published synchronously if zero
+	Buffer int
+	// OnError is called when buffered event is not published
+	OnError func(event Event, err error)
+
+	once    sync.Once
+	queue   chan Event
+	mux     sync.RWMutex
+	stopped bool
+}
+
+func (b *Bridge) timeout() time.Duration {
+	if b.Timeout == 0 {
+		return defaultBridgeTimeout
+	}
+	return b.Timeout
+}
+
+func (b *Bridge) init() {
+	b.once.Do(func() {
+		if b.Buffer > 0 {
+			b.queue = make(chan Event, b.Buffer)
+		}
+	})
+}
+
+// HandleEvent is EventHandler that publishes or buffers event
+func (b *Bridge) HandleEvent(event Event) error {
+	b.init()
+	if b.queue == nil {
+		return b.publish(context.Background(), event)
+	}
+	// read lock is held while event is queued, so Run
+	// drains every event accepted before it stopped
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+	if b.stopped {
+		return ErrBridgeStopped
+	}
+	select {
+	case b.queue <- event:
+		return nil
+	default:
+		return ErrBridgeFull
+	}
+}
+
+func (b *Bridge) publish(ctx context.Context, event Event) error {
+	ctx, cancel := context.WithTimeout(ctx, b.timeout())
+	defer cancel()
+	return b.Publisher.Publish(ctx, event)
+}
+
+// Run publishes buffered events until ctx is done, then publishes
+// remaining ones and rejects new ones. Bridge is Component.
+func (b *Bridge) Run(ctx context.Context) error {
+	b.init()
+	if b.queue == nil {
+		<-ctx.Done()
+		return nil
+	}
+	for {
+		select {
+		case event := <-b.queue:
+			b.publishBuffered(ctx, event)
+		case <-ctx.Done():
+			b.mux.Lock()
+			b.stopped = true
+			b.mux.Unlock()
+			// events are accepted already, so they are
+			// published regardless of ctx
+			for {
+				select {
+				case event := <-b.queue:
+					b.publishBuffered(context.Background(), event)
+				default:
+					return nil
+				}
+			}
+		}
+	}
+}
+
+func (b *Bridge) publishBuffered(ctx context.Context, event Event) {
+	if err := b.publish(ctx, event); err != nil && b.OnError != nil {
+		b.OnError(event, err)
+	}
+}
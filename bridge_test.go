@@ -0,0 +1,65 @@
+package vk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBridge(t *testing.T) {
+	Convey("Bridge", t, func() {
+		events := make(ChannelPublisher, 10)
+		body := `{"type": "message_new", "group_id": 1, "object": {"id": 1}}`
+		Convey("Synchronous", func() {
+			b := &Bridge{Publisher: events}
+			w := callbackRequest(&Callback{Handler: b.HandleEvent}, "127.0.0.1:1", body)
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Body.String(), ShouldEqual, "ok")
+			So(len(events), ShouldEqual, 1)
+			e := <-events
+			So(e.Type, ShouldEqual, "message_new")
+			So(e.GroupID, ShouldEqual, 1)
+
+			Convey("Publish error", func() {
+				b.Publisher = PublisherFunc(func(ctx context.Context, event Event) error {
+					_, ok := ctx.Deadline()
+					So(ok, ShouldBeTrue)
+					return errors.New("broker is down")
+				})
+				w := callbackRequest(&Callback{Handler: b.HandleEvent}, "127.0.0.1:1", body)
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+		Convey("Buffered", func() {
+			failed := errors.New("failed")
+			var published, errs []Event
+			b := &Bridge{
+				Buffer: 2,
+				Publisher: PublisherFunc(func(ctx context.Context, event Event) error {
+					if event.EventID == "bad" {
+						return failed
+					}
+					published = append(published, event)
+					return nil
+				}),
+				OnError: func(event Event, err error) {
+					So(err, ShouldEqual, failed)
+					errs = append(errs, event)
+				},
+			}
+			So(b.HandleEvent(Event{EventID: "1"}), ShouldBeNil)
+			So(b.HandleEvent(Event{EventID: "bad"}), ShouldBeNil)
+			So(b.HandleEvent(Event{EventID: "3"}), ShouldEqual, ErrBridgeFull)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			So(b.Run(ctx), ShouldBeNil)
+			So(published, ShouldResemble, []Event{{EventID: "1"}})
+			So(errs, ShouldResemble, []Event{{EventID: "bad"}})
+			So(b.HandleEvent(Event{EventID: "4"}), ShouldEqual, ErrBridgeStopped)
+		})
+	})
+}
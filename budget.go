@@ -0,0 +1,122 @@
+package vk
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRetryRatio      = 0.1
+	defaultRetryMinRetries = 10
+	defaultRetryWindow     = 10 * time.Second
+	retryBudgetBuckets     = 10
+)
+
+// RetryBudgetError is returned when request should be retried,
+// but retry budget of client is exhausted
+type RetryBudgetError struct {
+	Method string
+	// Err is error of last attempt
+	Err error
+}
+
+func (e RetryBudgetError) Error() string {
+	return fmt.Sprintf("retry budget exhausted for %s: %v", e.Method, e.Err)
+}
+
+func (e RetryBudgetError) Unwrap() error {
+	return e.Err
+}
+
+type retryBucket struct {
+	start    time.Time
+	requests int
+	retries  int
+}
+
+// RetryBudget limits retries to share of requests over sliding window,
+// so retries can not amplify outage of api, safe for concurrent use
+type RetryBudget struct {
+	// Ratio is maximum retries per request, 0.1 if zero
+	Ratio float64
+	// MinRetries are allowed in window regardless of ratio, 10 if zero
+	MinRetries int
+	// Window is 10s if zero
+	Window time.Duration
+	// Clock is SystemClock if nil
+	Clock Clock
+
+	mux     sync.Mutex
+	buckets [retryBudgetBuckets]retryBucket
+}
+
+// WithRetryBudget limits retries of client by budget
+func WithRetryBudget(b *RetryBudget) Option {
+	return func(c *Client) {
+		c.retryBudget = b
+	}
+}
+
+// bucket returns current bucket, must be called under lock
+func (b *RetryBudget) bucket(now time.Time) *retryBucket {
+	window := b.Window
+	if window <= 0 {
+		window = defaultRetryWindow
+	}
+	size := window / retryBudgetBuckets
+	start := now.Truncate(size)
+	bucket := &b.buckets[int(start.UnixNano()/int64(size))%retryBudgetBuckets]
+	if !bucket.start.Equal(start) {
+		*bucket = retryBucket{start: start}
+	}
+	return bucket
+}
+
+// totals returns requests and retries in window, must be called under lock
+func (b *RetryBudget) totals(now time.Time) (requests, retries int) {
+	window := b.Window
+	if window <= 0 {
+		window = defaultRetryWindow
+	}
+	for _, bucket := range b.buckets {
+		if now.Sub(bucket.start) < window {
+			requests += bucket.requests
+			retries += bucket.retries
+		}
+	}
+	return requests, retries
+}
+
+// Request records request
+func (b *RetryBudget) Request() {
+	b.mux.Lock()
+	b.bucket(clockOrSystem(b.Clock).Now()).requests++
+	b.mux.Unlock()
+}
+
+// Retry reports whether retry is allowed and records it if so
+func (b *RetryBudget) Retry() bool {
+	now := clockOrSystem(b.Clock).Now()
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	requests, retries := b.totals(now)
+	ratio := b.Ratio
+	if ratio <= 0 {
+		ratio = defaultRetryRatio
+	}
+	min := b.MinRetries
+	if min <= 0 {
+		min = defaultRetryMinRetries
+	}
+	if retries >= min && float64(retries) >= ratio*float64(requests) {
+		return false
+	}
+	b.bucket(now).retries++
+	return true
+}
+
+// retryAllowed reports whether client can retry request
+func (c *Client) retryAllowed() bool {
+	return c.retryBudget == nil || c.retryBudget.Retry()
+}
@@ -0,0 +1,45 @@
+package vk
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRetryBudget(t *testing.T) {
+	Convey("Retry budget", t, func() {
+		clock := NewFakeClock(time.Unix(1000, 0))
+		b := &RetryBudget{Ratio: 0.5, MinRetries: 2, Window: 10 * time.Second, Clock: clock}
+		So(b.Retry(), ShouldBeTrue)
+		So(b.Retry(), ShouldBeTrue)
+		So(b.Retry(), ShouldBeFalse)
+		for i := 0; i < 6; i++ {
+			b.Request()
+		}
+		So(b.Retry(), ShouldBeTrue)
+		So(b.Retry(), ShouldBeFalse)
+		clock.Advance(5 * time.Second)
+		So(b.Retry(), ShouldBeFalse)
+		clock.Advance(5 * time.Second)
+		// retries and requests left window
+		So(b.Retry(), ShouldBeTrue)
+
+		Convey("Client", func() {
+			clock := NewFakeClock(time.Unix(1000, 0))
+			b := &RetryBudget{MinRetries: 1, Clock: clock}
+			calls := 0
+			client := New(WithClock(clock), WithRetryBudget(b), WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+				calls++
+				return jsonResponse(http.StatusTooManyRequests, ""), nil
+			})))
+			_, err := client.Do(Request{Method: methodUsersGet})
+			So(err, ShouldResemble, RetryBudgetError{Method: methodUsersGet, Err: HTTPError{Status: http.StatusTooManyRequests}})
+			So(errors.Is(err, ErrBadResponseCode), ShouldBeTrue)
+			So(err.Error(), ShouldEqual, "retry budget exhausted for users.get: http 429 Too Many Requests")
+			So(calls, ShouldEqual, 2)
+		})
+	})
+}
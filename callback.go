@@ -0,0 +1,435 @@
+package vk
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	methodGroupsGetCallbackConfirmationCode = "groups.getCallbackConfirmationCode"
+	methodGroupsGetCallbackServers          = "groups.getCallbackServers"
+	methodGroupsAddCallbackServer           = "groups.addCallbackServer"
+	methodGroupsEditCallbackServer          = "groups.editCallbackServer"
+	methodGroupsSetCallbackSettings         = "groups.setCallbackSettings"
+	methodGroupsGetCallbackSettings         = "groups.getCallbackSettings"
+
+	callbackStatusOK     = "ok"
+	callbackStatusFailed = "failed"
+
+	defaultCallbackAttempts = 10
+	defaultCallbackInterval = time.Second
+)
+
+var (
+	// ErrCallbackNotConfirmed is returned when vk was unable
+	// to confirm callback server
+	ErrCallbackNotConfirmed = errors.New("callback server is not confirmed")
+)
+
+// CallbackServer is callback api server of community
+type CallbackServer struct {
+	ID        ID     `json:"id"`
+	Title     string `json:"title"`
+	CreatorID ID     `json:"creator_id"`
+	URL       string `json:"url"`
+	SecretKey string `json:"secret_key"`
+	Status    string `json:"status"`
+}
+
+// CallbackEvents is list of event types that are
+// encoded as event_type=1 parameters
+type CallbackEvents []string
+
+func (e CallbackEvents) EncodeValues(key string, v *url.Values) error {
+	for _, event := range e {
+		v.Set(event, "1")
+	}
+	return nil
+}
+
+type callbackGroupFields struct {
+	GroupID int `url:"group_id"`
+}
+
+type CallbackServerFields struct {
+	GroupID   int    `url:"group_id"`
+	ServerID  int    `url:"server_id,omitempty"`
+	URL       string `url:"url"`
+	Title     string `url:"title"`
+	SecretKey string `url:"secret_key,omitempty"`
+}
+
+type CallbackSettingsFields struct {
+	GroupID    int            `url:"group_id"`
+	ServerID   int            `url:"server_id"`
+	APIVersion string         `url:"api_version,omitempty"`
+	Events     CallbackEvents `url:"events,omitempty"`
+}
+
+type CallbackServersResult struct {
+	Count int              `json:"count"`
+	Items []CallbackServer `json:"items"`
+}
+
+func (g Groups) GetCallbackConfirmationCode(groupID int) (code string, err error) {
+	result := struct {
+		Code string `json:"code"`
+	}{}
+	return result.Code, g.Decode(g.Request(methodGroupsGetCallbackConfirmationCode, callbackGroupFields{groupID}), &result)
+}
+
+func (g Groups) GetCallbackServers(groupID int) (result CallbackServersResult, err error) {
+	return result, g.Decode(g.Request(methodGroupsGetCallbackServers, callbackGroupFields{groupID}), &result)
+}
+
+func (g Groups) AddCallbackServer(fields CallbackServerFields) (id int, err error) {
+	result := struct {
+		ServerID int `json:"server_id"`
+	}{}
+	return result.ServerID, g.Decode(g.Request(methodGroupsAddCallbackServer, fields), &result)
+}
+
+func (g Groups) EditCallbackServer(fields CallbackServerFields) error {
+	var ok int
+	return g.Decode(g.Request(methodGroupsEditCallbackServer, fields), &ok)
+}
+
+func (g Groups) SetCallbackSettings(fields CallbackSettingsFields) error {
+	var ok int
+	return g.Decode(g.Request(methodGroupsSetCallbackSettings, fields), &ok)
+}
+
+type callbackServerFields struct {
+	GroupID  int `url:"group_id"`
+	ServerID int `url:"server_id"`
+}
+
+// CallbackSettings are event subscriptions of callback server
+type CallbackSettings struct {
+	APIVersion string          `json:"api_version"`
+	Events     map[string]Bool `json:"events"`
+}
+
+func (g Groups) GetCallbackSettings(groupID, serverID int) (result CallbackSettings, err error) {
+	return result, g.Decode(g.Request(methodGroupsGetCallbackSettings, callbackServerFields{groupID, serverID}), &result)
+}
+
+// CallbackSetup describes desired callback server of community
+type CallbackSetup struct {
+	GroupID   int
+	URL       string
+	Title     string
+	SecretKey string
+	Events    []string
+	// Confirm is called with confirmation code before server
+	// is registered, so handler can respond to confirmation event
+	Confirm func(code string)
+	// Attempts and Interval of confirmation status checks
+	Attempts int
+	Interval time.Duration
+	// Clock is SystemClock if nil
+	Clock Clock
+}
+
+// SetupCallback registers or updates callback server with provided url,
+// subscribes it to events and waits until vk confirms it
+func (g Groups) SetupCallback(setup CallbackSetup) (server CallbackServer, err error) {
+	code, err := g.GetCallbackConfirmationCode(setup.GroupID)
+	if err != nil {
+		return server, err
+	}
+	if setup.Confirm != nil {
+		setup.Confirm(code)
+	}
+	fields := CallbackServerFields{
+		GroupID:   setup.GroupID,
+		URL:       setup.URL,
+		Title:     setup.Title,
+		SecretKey: setup.SecretKey,
+	}
+	servers, err := g.GetCallbackServers(setup.GroupID)
+	if err != nil {
+		return server, err
+	}
+	for _, s := range servers.Items {
+		if s.URL == setup.URL {
+			fields.ServerID = int(s.ID)
+		}
+	}
+	if fields.ServerID == 0 {
+		if fields.ServerID, err = g.AddCallbackServer(fields); err != nil {
+			return server, err
+		}
+	} else if err = g.EditCallbackServer(fields); err != nil {
+		return server, err
+	}
+	if err = g.SetCallbackSettings(CallbackSettingsFields{
+		GroupID:    setup.GroupID,
+		ServerID:   fields.ServerID,
+		APIVersion: defaultVersion,
+		Events:     setup.Events,
+	}); err != nil {
+		return server, err
+	}
+	return g.waitCallback(setup, fields.ServerID)
+}
+
+func (g Groups) waitCallback(setup CallbackSetup, id int) (server CallbackServer, err error) {
+	if setup.Attempts == 0 {
+		setup.Attempts = defaultCallbackAttempts
+	}
+	if setup.Interval == 0 {
+		setup.Interval = defaultCallbackInterval
+	}
+	for attempt := 0; attempt < setup.Attempts; attempt++ {
+		if attempt > 0 {
+			clockOrSystem(setup.Clock).Sleep(context.Background(), setup.Interval)
+		}
+		servers, err := g.GetCallbackServers(setup.GroupID)
+		if err != nil {
+			return server, err
+		}
+		for _, s := range servers.Items {
+			if s.ID != ID(id) {
+				continue
+			}
+			switch s.Status {
+			case callbackStatusOK:
+				return s, nil
+			case callbackStatusFailed:
+				return s, ErrCallbackNotConfirmed
+			}
+			server = s
+		}
+	}
+	return server, ErrCallbackNotConfirmed
+}
+
+const (
+	eventConfirmation  = "confirmation"
+	callbackResponseOK = "ok"
+)
+
+// Event is callback api or bots long poll event
+type Event struct {
+	Type    string `json:"type"`
+	Object  Raw    `json:"object"`
+	GroupID ID     `json:"group_id"`
+	EventID string `json:"event_id,omitempty"`
+	Secret  string `json:"secret,omitempty"`
+}
+
+// EventHandler handles events, event is redelivered by vk on error
+type EventHandler func(event Event) error
+
+// VKNetworks returns networks that vk callback requests originate from
+func VKNetworks() []*net.IPNet {
+	var networks []*net.IPNet
+	for _, cidr := range []string{
+		"95.142.192.0/21",
+		"87.240.128.0/18",
+		"93.186.224.0/20",
+		"2a00:bdc0::/32",
+	} {
+		_, network, err := net.ParseCIDR(cidr)
+		must(err)
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// Callback is http.Handler for callback api
+type Callback struct {
+	Handler EventHandler
+	// AllowedNetworks limits source addresses of requests, any if empty
+	AllowedNetworks []*net.IPNet
+	// RealIPHeader is header with source address when
+	// handler is behind proxy, e.g. X-Forwarded-For
+	RealIPHeader string
+	// TrustedProxies are networks of proxies, RealIPHeader
+	// is used only for requests from them
+	TrustedProxies []*net.IPNet
+	// Dedupe skips events that were already handled
+	Dedupe DedupeStore
+
+	mux          sync.RWMutex
+	confirmation string
+	secrets      []string
+	// groups is registered groups, nil if AddGroup was not called
+	groups map[ID]callbackGroup
+}
+
+// callbackGroup is confirmation and secrets of registered group
+type callbackGroup struct {
+	confirmation string
+	secrets      []string
+}
+
+// AddGroup registers group with its confirmation code and secret
+// keys, replacing previous ones. After first call events of groups
+// that are not registered are rejected, even if all are removed. Global confirmation and secrets are
+// used for group if not provided.
+func (c *Callback) AddGroup(groupID ID, confirmation string, secrets ...string) {
+	c.mux.Lock()
+	if c.groups == nil {
+		c.groups = make(map[ID]callbackGroup)
+	}
+	c.groups[groupID] = callbackGroup{confirmation: confirmation, secrets: secrets}
+	c.mux.Unlock()
+}
+
+// RemoveGroup unregisters group, so its events are rejected
+func (c *Callback) RemoveGroup(groupID ID) {
+	c.mux.Lock()
+	delete(c.groups, groupID)
+	c.mux.Unlock()
+}
+
+// group returns confirmation and secrets for group and
+// false if group is not registered
+func (c *Callback) group(groupID ID) (callbackGroup, bool) {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	g := callbackGroup{confirmation: c.confirmation, secrets: c.secrets}
+	// groups is not nil once per-group mode is enabled by AddGroup
+	if c.groups == nil {
+		return g, true
+	}
+	registered, ok := c.groups[groupID]
+	if !ok {
+		return g, false
+	}
+	if len(registered.confirmation) != 0 {
+		g.confirmation = registered.confirmation
+	}
+	if len(registered.secrets) != 0 {
+		g.secrets = registered.secrets
+	}
+	return g, true
+}
+
+// SetConfirmation sets response to confirmation event
+func (c *Callback) SetConfirmation(code string) {
+	c.mux.Lock()
+	c.confirmation = code
+	c.mux.Unlock()
+}
+
+// SetSecrets sets accepted secret keys, multiple secrets are
+// accepted during rotation, no check is made if none are set
+func (c *Callback) SetSecrets(secrets ...string) {
+	c.mux.Lock()
+	c.secrets = secrets
+	c.mux.Unlock()
+}
+
+func (g callbackGroup) validSecret(secret string) bool {
+	if len(g.secrets) == 0 {
+		return true
+	}
+	for _, s := range g.secrets {
+		if subtle.ConstantTimeCompare([]byte(s), []byte(secret)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// parseHost returns ip of address with optional port
+func parseHost(addr string) net.IP {
+	addr = strings.TrimSpace(addr)
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	return net.ParseIP(addr)
+}
+
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// sourceIP returns address of request source, RealIPHeader is
+// used only if request is made by trusted proxy, and rightmost
+// address in it that is not trusted proxy is taken, as
+// addresses on the left are set by client
+func (c *Callback) sourceIP(r *http.Request) net.IP {
+	ip := parseHost(r.RemoteAddr)
+	if ip == nil || len(c.RealIPHeader) == 0 || !containsIP(c.TrustedProxies, ip) {
+		return ip
+	}
+	hops := strings.Split(r.Header.Get(c.RealIPHeader), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		if ip = parseHost(hops[i]); ip == nil || !containsIP(c.TrustedProxies, ip) {
+			return ip
+		}
+	}
+	return ip
+}
+
+func (c *Callback) allowed(r *http.Request) bool {
+	if len(c.AllowedNetworks) == 0 {
+		return true
+	}
+	ip := c.sourceIP(r)
+	return ip != nil && containsIP(c.AllowedNetworks, ip)
+}
+
+func (c *Callback) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !c.allowed(r) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	event := Event{}
+	if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	group, ok := c.group(event.GroupID)
+	if !ok || !group.validSecret(event.Secret) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	if event.Type == eventConfirmation {
+		io.WriteString(w, group.confirmation)
+		return
+	}
+	// secret is not passed to handlers, so it is not
+	// leaked to queues, spill storage or dead letters
+	event.Secret = ""
+	if err := c.handle(event); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	io.WriteString(w, callbackResponseOK)
+}
+
+func (c *Callback) handle(event Event) error {
+	if c.Handler == nil {
+		return nil
+	}
+	if c.Dedupe == nil || len(event.EventID) == 0 {
+		return c.Handler(event)
+	}
+	seen, err := c.Dedupe.Seen(event.EventID)
+	if err != nil || seen {
+		return err
+	}
+	if err = c.Handler(event); err != nil {
+		c.Dedupe.Forget(event.EventID)
+	}
+	return err
+}
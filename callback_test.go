@@ -0,0 +1,186 @@
+package vk
+
+import (
+	"bytes"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCallbackSetup(t *testing.T) {
+	Convey("Callback setup", t, func() {
+		Convey("Events", func() {
+			v := &url.Values{}
+			So(CallbackEvents{"message_new", "wall_post_new"}.EncodeValues("events", v), ShouldBeNil)
+			So(v.Get("message_new"), ShouldEqual, "1")
+			So(v.Get("wall_post_new"), ShouldEqual, "1")
+		})
+		mock := &apiMethodMock{responses: map[string][]string{
+			methodGroupsGetCallbackConfirmationCode: {`{"response": {"code": "abc"}}`},
+			methodGroupsAddCallbackServer:           {`{"response": {"server_id": 7}}`},
+			methodGroupsEditCallbackServer:          {`{"response": 1}`},
+			methodGroupsSetCallbackSettings:         {`{"response": 1}`},
+		}}
+		g := Groups{Resource{APIClient: mock, RequestFactory: DefaultFactory}}
+		var code string
+		setup := CallbackSetup{
+			GroupID:  1,
+			URL:      "https://example.com/vk",
+			Title:    "bot",
+			Events:   []string{"message_new"},
+			Confirm:  func(c string) { code = c },
+			Interval: time.Millisecond,
+		}
+		Convey("New server", func() {
+			mock.responses[methodGroupsGetCallbackServers] = []string{
+				`{"response": {"count": 0, "items": []}}`,
+				`{"response": {"count": 1, "items": [{"id": 7, "url": "https://example.com/vk", "status": "wait"}]}}`,
+				`{"response": {"count": 1, "items": [{"id": 7, "url": "https://example.com/vk", "status": "ok"}]}}`,
+			}
+			server, err := g.SetupCallback(setup)
+			So(err, ShouldBeNil)
+			So(code, ShouldEqual, "abc")
+			So(server.ID, ShouldEqual, 7)
+			So(mock.methods(), ShouldResemble, []string{
+				methodGroupsGetCallbackConfirmationCode,
+				methodGroupsGetCallbackServers,
+				methodGroupsAddCallbackServer,
+				methodGroupsSetCallbackSettings,
+				methodGroupsGetCallbackServers,
+				methodGroupsGetCallbackServers,
+			})
+			settings := mock.requests[3].Values
+			So(settings.Get("server_id"), ShouldEqual, "7")
+			So(settings.Get("message_new"), ShouldEqual, "1")
+			So(settings.Get("api_version"), ShouldEqual, defaultVersion)
+		})
+		Convey("Existing server", func() {
+			mock.responses[methodGroupsGetCallbackServers] = []string{
+				`{"response": {"count": 1, "items": [{"id": 3, "url": "https://example.com/vk", "status": "ok"}]}}`,
+			}
+			server, err := g.SetupCallback(setup)
+			So(err, ShouldBeNil)
+			So(server.ID, ShouldEqual, 3)
+			So(mock.methods()[2], ShouldEqual, methodGroupsEditCallbackServer)
+		})
+		Convey("Failed", func() {
+			mock.responses[methodGroupsGetCallbackServers] = []string{
+				`{"response": {"count": 1, "items": [{"id": 3, "url": "https://example.com/vk", "status": "failed"}]}}`,
+			}
+			_, err := g.SetupCallback(setup)
+			So(err, ShouldEqual, ErrCallbackNotConfirmed)
+		})
+		Convey("Timeout", func() {
+			setup.Attempts = 2
+			mock.responses[methodGroupsGetCallbackServers] = []string{
+				`{"response": {"count": 1, "items": [{"id": 3, "url": "https://example.com/vk", "status": "wait"}]}}`,
+			}
+			server, err := g.SetupCallback(setup)
+			So(err, ShouldEqual, ErrCallbackNotConfirmed)
+			So(server.Status, ShouldEqual, "wait")
+		})
+	})
+}
+
+func callbackRequest(c *Callback, remote, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+	r.RemoteAddr = remote
+	c.ServeHTTP(w, r)
+	return w
+}
+
+func TestCallbackHandler(t *testing.T) {
+	Convey("Callback handler", t, func() {
+		var events []Event
+		c := &Callback{Handler: func(e Event) error {
+			events = append(events, e)
+			if e.Type == "fail" {
+				return ErrUnknown
+			}
+			return nil
+		}}
+		c.SetConfirmation("abc")
+		const remote = "95.142.192.10:4000"
+		Convey("Confirmation", func() {
+			w := callbackRequest(c, remote, `{"type": "confirmation", "group_id": 1}`)
+			So(w.Body.String(), ShouldEqual, "abc")
+			So(events, ShouldBeEmpty)
+		})
+		Convey("Event", func() {
+			w := callbackRequest(c, remote, `{"type": "message_new", "object": {"id": 1}, "group_id": 1, "event_id": "e1"}`)
+			So(w.Body.String(), ShouldEqual, "ok")
+			So(len(events), ShouldEqual, 1)
+			So(events[0].EventID, ShouldEqual, "e1")
+			So(events[0].Object.String(), ShouldEqual, `{"id": 1}`)
+		})
+		Convey("Handler error", func() {
+			w := callbackRequest(c, remote, `{"type": "fail"}`)
+			So(w.Code, ShouldEqual, http.StatusInternalServerError)
+		})
+		Convey("Bad body", func() {
+			w := callbackRequest(c, remote, `{`)
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+		})
+		Convey("Secret rotation", func() {
+			c.SetSecrets("old", "new")
+			So(callbackRequest(c, remote, `{"type": "a", "secret": "old"}`).Code, ShouldEqual, http.StatusOK)
+			So(callbackRequest(c, remote, `{"type": "a", "secret": "new"}`).Code, ShouldEqual, http.StatusOK)
+			So(callbackRequest(c, remote, `{"type": "a", "secret": "bad"}`).Code, ShouldEqual, http.StatusForbidden)
+			So(callbackRequest(c, remote, `{"type": "a"}`).Code, ShouldEqual, http.StatusForbidden)
+			c.SetSecrets("new")
+			So(callbackRequest(c, remote, `{"type": "a", "secret": "old"}`).Code, ShouldEqual, http.StatusForbidden)
+			So(len(events), ShouldEqual, 2)
+			So(events[0].Secret, ShouldBeEmpty)
+			So(events[1].Secret, ShouldBeEmpty)
+		})
+		Convey("Groups", func() {
+			c.SetSecrets("global")
+			c.AddGroup(1, "one", "s1")
+			c.AddGroup(2, "")
+			So(callbackRequest(c, remote, `{"type": "confirmation", "group_id": 1, "secret": "s1"}`).Body.String(), ShouldEqual, "one")
+			So(callbackRequest(c, remote, `{"type": "confirmation", "group_id": 2, "secret": "global"}`).Body.String(), ShouldEqual, "abc")
+			So(callbackRequest(c, remote, `{"type": "a", "group_id": 1, "secret": "global"}`).Code, ShouldEqual, http.StatusForbidden)
+			So(callbackRequest(c, remote, `{"type": "a", "group_id": 3, "secret": "global"}`).Code, ShouldEqual, http.StatusForbidden)
+			So(callbackRequest(c, remote, `{"type": "a", "group_id": 1, "secret": "s1"}`).Code, ShouldEqual, http.StatusOK)
+			c.RemoveGroup(1)
+			So(callbackRequest(c, remote, `{"type": "a", "group_id": 1, "secret": "s1"}`).Code, ShouldEqual, http.StatusForbidden)
+			c.RemoveGroup(2)
+			So(callbackRequest(c, remote, `{"type": "a", "group_id": 1, "secret": "global"}`).Code, ShouldEqual, http.StatusForbidden)
+			So(callbackRequest(c, remote, `{"type": "a", "group_id": 2, "secret": "global"}`).Code, ShouldEqual, http.StatusForbidden)
+			So(len(events), ShouldEqual, 1)
+		})
+		Convey("IP allowlist", func() {
+			c.AllowedNetworks = VKNetworks()
+			So(callbackRequest(c, remote, `{"type": "a"}`).Code, ShouldEqual, http.StatusOK)
+			So(callbackRequest(c, "[2a00:bdc0::1]:443", `{"type": "a"}`).Code, ShouldEqual, http.StatusOK)
+			So(callbackRequest(c, "10.0.0.1:4000", `{"type": "a"}`).Code, ShouldEqual, http.StatusForbidden)
+			So(callbackRequest(c, "bad", `{"type": "a"}`).Code, ShouldEqual, http.StatusForbidden)
+			Convey("Behind proxy", func() {
+				c.RealIPHeader = "X-Forwarded-For"
+				forwarded := func(remote, header string) int {
+					w := httptest.NewRecorder()
+					r := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"type": "a"}`))
+					r.RemoteAddr = remote
+					r.Header.Set("X-Forwarded-For", header)
+					c.ServeHTTP(w, r)
+					return w.Code
+				}
+				// header is ignored if proxy is not trusted
+				So(forwarded("10.0.0.1:4000", "95.142.192.10"), ShouldEqual, http.StatusForbidden)
+				_, proxies, _ := net.ParseCIDR("10.0.0.0/8")
+				c.TrustedProxies = []*net.IPNet{proxies}
+				So(forwarded("10.0.0.1:4000", "95.142.192.10"), ShouldEqual, http.StatusOK)
+				So(forwarded("10.0.0.1:4000", "95.142.192.10, 10.0.0.2"), ShouldEqual, http.StatusOK)
+				// leftmost address is set by client
+				So(forwarded("10.0.0.1:4000", "95.142.192.10, 1.1.1.1"), ShouldEqual, http.StatusForbidden)
+				So(forwarded("95.142.192.10:4000", "1.1.1.1"), ShouldEqual, http.StatusOK)
+			})
+		})
+	})
+}
@@ -0,0 +1,89 @@
+package vk
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock is source of time for rate limiting, retries and backoff,
+// that can be replaced by FakeClock in tests
+type Clock interface {
+	Now() time.Time
+	// Sleep pauses for d or until ctx is done
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Sleep(ctx context.Context, d time.Duration) error {
+	return sleep(ctx, d)
+}
+
+// SystemClock is Clock backed by time package
+var SystemClock Clock = systemClock{}
+
+// clockOrSystem returns c or SystemClock if c is nil
+func clockOrSystem(c Clock) Clock {
+	if c == nil {
+		return SystemClock
+	}
+	return c
+}
+
+// WithClock sets clock of client
+func WithClock(clock Clock) Option {
+	return func(c *Client) {
+		c.clock = clock
+	}
+}
+
+// FakeClock is Clock that does not wait, Sleep just
+// advances current time by provided duration
+type FakeClock struct {
+	mux   sync.Mutex
+	now   time.Time
+	slept []time.Duration
+}
+
+// NewFakeClock returns FakeClock that starts at now
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.mux.Lock()
+	c.slept = append(c.slept, d)
+	c.mux.Unlock()
+	if d > 0 {
+		c.Advance(d)
+	}
+	return nil
+}
+
+// Advance moves current time forward by d
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mux.Lock()
+	c.now = c.now.Add(d)
+	c.mux.Unlock()
+}
+
+// Slept returns durations passed to Sleep
+func (c *FakeClock) Slept() []time.Duration {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return append([]time.Duration(nil), c.slept...)
+}
@@ -0,0 +1,42 @@
+package vk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClock(t *testing.T) {
+	Convey("Clock", t, func() {
+		ctx := context.Background()
+		Convey("Fake", func() {
+			start := time.Unix(1000, 0)
+			c := NewFakeClock(start)
+			So(c.Now(), ShouldResemble, start)
+			So(c.Sleep(ctx, time.Hour), ShouldBeNil)
+			So(c.Now(), ShouldResemble, start.Add(time.Hour))
+			c.Advance(time.Minute)
+			So(c.Now(), ShouldResemble, start.Add(time.Hour+time.Minute))
+			So(c.Slept(), ShouldResemble, []time.Duration{time.Hour})
+			canceled, cancel := context.WithCancel(ctx)
+			cancel()
+			So(c.Sleep(canceled, time.Hour), ShouldEqual, context.Canceled)
+			So(c.Now(), ShouldResemble, start.Add(time.Hour+time.Minute))
+		})
+		Convey("System", func() {
+			So(clockOrSystem(nil), ShouldResemble, SystemClock)
+			So(SystemClock.Sleep(ctx, time.Millisecond), ShouldBeNil)
+			So(time.Since(SystemClock.Now()), ShouldBeLessThan, time.Second)
+		})
+		Convey("Client", func() {
+			c := NewFakeClock(time.Unix(1000, 0))
+			client := New(WithClock(c))
+			So(client.Now(), ShouldResemble, time.Unix(1000, 0))
+			token := Token{AccessToken: "a", ExpiresAt: Time{time.Unix(1030, 0)}}
+			So(token.expiresWithin(client.clock.Now(), tokenRefreshMargin), ShouldBeTrue)
+			So(token.expiresWithin(client.clock.Now(), time.Second), ShouldBeFalse)
+		})
+	})
+}
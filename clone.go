@@ -0,0 +1,52 @@
+package vk
+
+import "sync/atomic"
+
+// Clone returns copy of client, see WithOptions
+func (c *Client) Clone() *Client {
+	return c.WithOptions()
+}
+
+// WithOptions returns copy of client with options applied. Copy shares
+// http client (and so connection pool), limiter, quotas, retry budget,
+// hedging, clock and hooks with c, so it is cheap to derive per-user clients with
+// WithToken. Resources of copy always make requests with its own token.
+// Token renewal callback and client secret belong to token of c, so copy
+// with other token does not inherit them, use OnTokenExpired to renew it.
+func (c *Client) WithOptions(options ...Option) *Client {
+	httpClient, limiter := c.transport()
+	token := c.Token()
+	clone := &Client{
+		clockOffset:     atomic.LoadInt64(&c.clockOffset),
+		httpClient:      httpClient,
+		limiter:         limiter,
+		clock:           c.clock,
+		audit:           c.audit,
+		onRequestDone:   append([]RequestDoneFunc(nil), c.onRequestDone...),
+		validateMethods: c.validateMethods,
+		token:           token,
+		official:        c.official,
+		lang:            c.lang,
+		signing:         c.signing,
+		quotas:          c.quotas,
+		retryBudget:     c.retryBudget,
+		hedging:         c.hedging,
+	}
+	c.debug.mux.Lock()
+	clone.debug.w = c.debug.w
+	c.debug.mux.Unlock()
+	clone.debug.enabled = atomic.LoadInt32(&c.debug.enabled)
+	for _, option := range options {
+		option(clone)
+	}
+	if clone.Token().AccessToken == token.AccessToken {
+		if clone.onTokenExpired == nil {
+			clone.onTokenExpired = c.onTokenExpired
+		}
+		if len(clone.clientSecret) == 0 {
+			clone.clientSecret = c.clientSecret
+		}
+	}
+	clone.initResources(nil)
+	return clone
+}
@@ -0,0 +1,78 @@
+package vk
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClone(t *testing.T) {
+	Convey("Clone", t, func() {
+		var queries []string
+		httpClient := httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			queries = append(queries, req.URL.Query().Get("access_token")+":"+req.URL.Query().Get("lang"))
+			return jsonResponse(http.StatusOK, `{"response": 1}`), nil
+		})
+		var done int
+		limiter := NewLimiter(100)
+		base := NewWithToken("base", WithHTTPClient(httpClient), WithLang("ru"), WithLimiter(limiter),
+			OnRequestDone(func(method string, d time.Duration, err error) {
+				done++
+			}))
+		user := base.WithOptions(WithToken(NewToken("user")), WithLang("en"))
+		So(user.httpClient, ShouldEqual, base.httpClient)
+		So(user.limiter, ShouldEqual, limiter)
+		So(user.Token().AccessToken, ShouldEqual, "user")
+		So(base.Token().AccessToken, ShouldEqual, "base")
+
+		So(user.Users.Decode(user.Users.Request("users.get", nil), new(int)), ShouldBeNil)
+		So(base.Users.Decode(base.Users.Request("users.get", nil), new(int)), ShouldBeNil)
+		So(queries, ShouldResemble, []string{"user:en", "base:ru"})
+		So(done, ShouldEqual, 2)
+
+		Convey("Plain copy", func() {
+			clone := base.Clone()
+			clone.SetToken("other")
+			So(base.Token().AccessToken, ShouldEqual, "base")
+			So(clone.lang, ShouldEqual, "ru")
+			_, err := clone.Do(Request{Method: "users.get", Values: map[string][]string{"lang": {"de"}}})
+			So(err, ShouldBeNil)
+			So(queries[2], ShouldEqual, ":de")
+		})
+		Convey("Quotas", func() {
+			q := &Quotas{Limits: map[string]int{"users.get": 1}}
+			base := NewWithToken("base", WithHTTPClient(httpClient), WithQuotas(q))
+			clone := base.Clone()
+			So(clone.quotas, ShouldEqual, q)
+			_, err := base.Do(Request{Method: "users.get", Token: "base"})
+			So(err, ShouldBeNil)
+			_, err = clone.Do(Request{Method: "users.get", Token: "base"})
+			So(err, ShouldHaveSameTypeAs, QuotaError{})
+		})
+		Convey("Retry budget", func() {
+			b := &RetryBudget{}
+			So(NewWithToken("base", WithRetryBudget(b)).Clone().retryBudget, ShouldEqual, b)
+		})
+		Convey("Token renewal", func() {
+			renew := func() (Token, error) {
+				return NewToken("base"), nil
+			}
+			base := NewWithToken("base", OnTokenExpired(renew), WithClientSecret("secret"))
+			clone := base.Clone()
+			So(clone.onTokenExpired, ShouldNotBeNil)
+			So(clone.clientSecret, ShouldEqual, "secret")
+			// community token is not renewed with token of base
+			community := base.WithOptions(WithToken(NewToken("community")))
+			So(community.onTokenExpired, ShouldBeNil)
+			So(community.clientSecret, ShouldBeEmpty)
+			community = base.WithOptions(WithToken(NewToken("community")), OnTokenExpired(renew))
+			So(community.onTokenExpired, ShouldNotBeNil)
+		})
+		Convey("Hedging", func() {
+			h := &Hedging{}
+			So(NewWithToken("base", WithHedging(h)).Clone().hedging, ShouldEqual, h)
+		})
+	})
+}
@@ -0,0 +1,62 @@
+package vk
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	headerAcceptEncoding  = "Accept-Encoding"
+	headerContentEncoding = "Content-Encoding"
+
+	encodingGzip    = "gzip"
+	encodingDeflate = "deflate"
+
+	// defaultAcceptEncoding is sent with every api request, so
+	// responses are compressed with any HTTPClient implementation
+	defaultAcceptEncoding = encodingGzip + ", " + encodingDeflate
+)
+
+// decompressedBody closes both decompressing reader and underlying body
+type decompressedBody struct {
+	io.Reader
+	decoder io.Closer
+	body    io.Closer
+}
+
+func (b decompressedBody) Close() error {
+	b.decoder.Close()
+	return b.body.Close()
+}
+
+// decompress replaces body of res with decompressing reader
+// according to Content-Encoding header. Responses that were already
+// decompressed by http.Transport have no such header.
+func decompress(res *http.Response) error {
+	var (
+		decoder io.ReadCloser
+		err     error
+	)
+	switch strings.ToLower(strings.TrimSpace(res.Header.Get(headerContentEncoding))) {
+	case encodingGzip:
+		decoder, err = gzip.NewReader(res.Body)
+	case encodingDeflate:
+		// http deflate is zlib stream, not raw deflate
+		decoder, err = zlib.NewReader(res.Body)
+	default:
+		return nil
+	}
+	if err != nil {
+		res.Body.Close()
+		return err
+	}
+	res.Body = decompressedBody{Reader: decoder, decoder: decoder, body: res.Body}
+	res.Header.Del(headerContentEncoding)
+	res.Header.Del("Content-Length")
+	res.ContentLength = -1
+	res.Uncompressed = true
+	return nil
+}
@@ -0,0 +1,54 @@
+package vk
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDecompress(t *testing.T) {
+	Convey("Decompress", t, func() {
+		body := `{"response": [1, 2, 3]}`
+		encoded := map[string][]byte{}
+		buf := new(bytes.Buffer)
+		gz := gzip.NewWriter(buf)
+		gz.Write([]byte(body))
+		gz.Close()
+		encoded[encodingGzip] = buf.Bytes()
+		buf = new(bytes.Buffer)
+		zw := zlib.NewWriter(buf)
+		zw.Write([]byte(body))
+		zw.Close()
+		encoded[encodingDeflate] = buf.Bytes()
+
+		for _, encoding := range []string{encodingGzip, encodingDeflate, ""} {
+			encoding := encoding
+			Convey("Encoding "+encoding, func() {
+				client := New()
+				client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+					So(req.Header.Get(headerAcceptEncoding), ShouldEqual, defaultAcceptEncoding)
+					if encoding == "" {
+						return jsonResponse(http.StatusOK, body), nil
+					}
+					res := jsonResponse(http.StatusOK, "")
+					res.Body = ioutil.NopCloser(bytes.NewReader(encoded[encoding]))
+					res.Header.Set(headerContentEncoding, encoding)
+					return res, nil
+				}))
+				res, err := client.Do(Request{Method: "users.get"})
+				So(err, ShouldBeNil)
+				So(res.Response.String(), ShouldEqual, "[1, 2, 3]")
+			})
+		}
+		Convey("Corrupted", func() {
+			res := jsonResponse(http.StatusOK, "not gzip")
+			res.Header.Set(headerContentEncoding, encodingGzip)
+			So(decompress(res), ShouldNotBeNil)
+		})
+	})
+}
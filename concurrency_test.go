@@ -0,0 +1,50 @@
+package vk
+
+import (
+	"bytes"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// TestClientConcurrency should be run with -race
+func TestClientConcurrency(t *testing.T) {
+	Convey("Concurrent client mutation", t, func() {
+		httpClient := httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusOK, `{"response": 1}`), nil
+		})
+		client := NewWithToken("a", WithHTTPClient(httpClient), WithClock(NewFakeClock(time.Unix(0, 0))))
+		var wg sync.WaitGroup
+		errs := make(chan error, 100)
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 25; j++ {
+					var v int
+					errs <- client.Users.Decode(client.Users.Request("users.get", nil), &v)
+				}
+			}()
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 25; j++ {
+				client.SetHTTPClient(httpClient)
+				client.SetLimiter(NewLimiter(1000))
+				client.SetToken("b")
+				client.SetDebugWriter(new(bytes.Buffer))
+				client.SetDebug(false)
+				client.Clone()
+			}
+		}()
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			So(err, ShouldBeNil)
+		}
+	})
+}
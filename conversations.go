@@ -0,0 +1,208 @@
+package vk
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+const (
+	methodMessagesGetConversations            = "messages.getConversations"
+	methodMessagesPin                         = "messages.pin"
+	methodMessagesUnpin                       = "messages.unpin"
+	methodMessagesMarkAsImportantConversation = "messages.markAsImportantConversation"
+
+	// user long poll event of chat change, [52, type_id, peer_id, info]
+	longPollChatInfoChanged = 52
+	longPollChatPinned      = 5
+
+	actionChatPinMessage   = "chat_pin_message"
+	actionChatUnpinMessage = "chat_unpin_message"
+)
+
+// MessageAction is service action of chat message
+type MessageAction struct {
+	Type     string `json:"type"`
+	MemberID ID     `json:"member_id,omitempty"`
+	Text     string `json:"text,omitempty"`
+	// ConversationMessageID is set for pin actions
+	ConversationMessageID int    `json:"conversation_message_id,omitempty"`
+	Message               string `json:"message,omitempty"`
+}
+
+// ConversationPeer is peer of conversation
+type ConversationPeer struct {
+	ID      ID     `json:"id"`
+	Type    string `json:"type"`
+	LocalID ID     `json:"local_id"`
+}
+
+// SortID is position of conversation in list, Major is
+// non-zero for pinned conversations
+type SortID struct {
+	Major int `json:"major_id"`
+	Minor int `json:"minor_id"`
+}
+
+// Less reports whether s is placed below other in list
+func (s SortID) Less(other SortID) bool {
+	if s.Major != other.Major {
+		return s.Major < other.Major
+	}
+	return s.Minor < other.Minor
+}
+
+// ChatSettings are settings of multi-user chat
+type ChatSettings struct {
+	Title         string   `json:"title"`
+	MembersCount  int      `json:"members_count"`
+	State         string   `json:"state"`
+	PinnedMessage *Message `json:"pinned_message,omitempty"`
+}
+
+// Conversation is private conversation, chat or community conversation
+type Conversation struct {
+	Peer         ConversationPeer `json:"peer"`
+	InRead       int              `json:"in_read"`
+	OutRead      int              `json:"out_read"`
+	UnreadCount  int              `json:"unread_count"`
+	Important    Bool             `json:"important"`
+	Unanswered   Bool             `json:"unanswered"`
+	SortID       SortID           `json:"sort_id"`
+	ChatSettings *ChatSettings    `json:"chat_settings,omitempty"`
+}
+
+// Pinned reports whether conversation is pinned at top of list
+func (c Conversation) Pinned() bool {
+	return c.SortID.Major != 0
+}
+
+// PinnedMessage returns pinned message of chat
+func (c Conversation) PinnedMessage() (*Message, bool) {
+	if c.ChatSettings == nil || c.ChatSettings.PinnedMessage == nil {
+		return nil, false
+	}
+	return c.ChatSettings.PinnedMessage, true
+}
+
+// SortConversations sorts conversations like vk does, pinned
+// first, then by last message
+func SortConversations(conversations []Conversation) {
+	sort.SliceStable(conversations, func(i, j int) bool {
+		return conversations[j].SortID.Less(conversations[i].SortID)
+	})
+}
+
+type MessagesGetConversationsFields struct {
+	Offset   int    `url:"offset,omitempty"`
+	Count    int    `url:"count,omitempty"`
+	Filter   string `url:"filter,omitempty"`
+	Extended Bool   `url:"extended,omitempty"`
+	Fields   Fields `url:"fields,omitempty"`
+	GroupID  ID     `url:"group_id,omitempty"`
+}
+
+// ConversationItem is conversation with last message
+type ConversationItem struct {
+	Conversation Conversation `json:"conversation"`
+	LastMessage  Message      `json:"last_message"`
+}
+
+type MessagesGetConversationsResult struct {
+	Count       int                `json:"count"`
+	UnreadCount int                `json:"unread_count"`
+	Items       []ConversationItem `json:"items"`
+	Profiles    []User             `json:"profiles,omitempty"`
+	Groups      []Group            `json:"groups,omitempty"`
+}
+
+// GetConversations returns conversations, filter is "all",
+// "unread", "important" or "unanswered"
+func (m Messages) GetConversations(fields MessagesGetConversationsFields) (result MessagesGetConversationsResult, err error) {
+	return result, m.Decode(m.Request(methodMessagesGetConversations, fields), &result)
+}
+
+type MessagesPinFields struct {
+	PeerID                ID  `url:"peer_id"`
+	MessageID             int `url:"message_id,omitempty"`
+	ConversationMessageID int `url:"conversation_message_id,omitempty"`
+}
+
+// Pin pins message in conversation and returns pinned message
+func (m Messages) Pin(fields MessagesPinFields) (pinned Message, err error) {
+	return pinned, m.Decode(m.Request(methodMessagesPin, fields), &pinned)
+}
+
+type messagesUnpinFields struct {
+	PeerID  ID `url:"peer_id"`
+	GroupID ID `url:"group_id,omitempty"`
+}
+
+// Unpin unpins message of conversation, groupID is set for community conversations
+func (m Messages) Unpin(peerID, groupID ID) error {
+	var result int
+	return m.Decode(m.Request(methodMessagesUnpin, messagesUnpinFields{peerID, groupID}), &result)
+}
+
+type messagesMarkAsImportantConversationFields struct {
+	PeerID    ID   `url:"peer_id"`
+	Important Bool `url:"important"`
+	GroupID   ID   `url:"group_id,omitempty"`
+}
+
+// MarkAsImportantConversation sets important flag of conversation
+func (m Messages) MarkAsImportantConversation(peerID ID, important bool, groupID ID) error {
+	var result int
+	fields := messagesMarkAsImportantConversationFields{peerID, Bool(important), groupID}
+	return m.Decode(m.Request(methodMessagesMarkAsImportantConversation, fields), &result)
+}
+
+// PinEvent is change of pinned message of conversation
+type PinEvent struct {
+	PeerID ID
+	// ConversationMessageID is zero if it is unknown
+	ConversationMessageID int
+	Pinned                bool
+	// MemberID is user that changed pinned message, if known
+	MemberID ID
+}
+
+// PinHandler calls OnPin for pin changes from user long poll
+// updates and from service messages of callback or bots long poll
+type PinHandler struct {
+	OnPin func(e PinEvent) error
+}
+
+// HandleLongPollUpdate accepts user long poll update, event
+// [52, 5, peer_id, conversation_message_id] is pin of message,
+// other updates are ignored
+func (h PinHandler) HandleLongPollUpdate(update []int64) error {
+	if len(update) < 4 || update[0] != longPollChatInfoChanged || update[1] != longPollChatPinned {
+		return nil
+	}
+	return h.OnPin(PinEvent{PeerID: ID(update[2]), ConversationMessageID: int(update[3]), Pinned: true})
+}
+
+// HandleEvent is EventHandler that handles message_new
+// events with pin and unpin actions
+func (h PinHandler) HandleEvent(event Event) error {
+	if event.Type != eventMessageNew {
+		return nil
+	}
+	var object messageNew
+	if err := json.Unmarshal(event.Object, &object); err != nil {
+		return err
+	}
+	m := object.Message
+	if m.Action == nil {
+		return nil
+	}
+	e := PinEvent{PeerID: m.PeerID, ConversationMessageID: m.Action.ConversationMessageID, MemberID: m.Action.MemberID}
+	switch m.Action.Type {
+	case actionChatPinMessage:
+		e.Pinned = true
+	case actionChatUnpinMessage:
+	default:
+		return nil
+	}
+	return h.OnPin(e)
+}
@@ -0,0 +1,72 @@
+package vk
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestConversations(t *testing.T) {
+	Convey("Conversations", t, func() {
+		var calls []string
+		response := `{"response": 1}`
+		client := New(WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			q.Del(paramVersion)
+			q.Del(paramHTTPS)
+			calls = append(calls, req.URL.Path[len("/method/"):]+" "+q.Encode())
+			return jsonResponse(http.StatusOK, response), nil
+		})))
+		Convey("Get", func() {
+			response = `{"response": {"count": 2, "items": [` +
+				`{"conversation": {"peer": {"id": 1, "type": "user", "local_id": 1}, "sort_id": {"major_id": 0, "minor_id": 10}}, "last_message": {"id": 10}}, ` +
+				`{"conversation": {"peer": {"id": 2000000001, "type": "chat", "local_id": 1}, "important": true, "sort_id": {"major_id": 16, "minor_id": 5}, ` +
+				`"chat_settings": {"title": "chat", "pinned_message": {"id": 3, "text": "rules"}}}, "last_message": {"id": 5}}]}}`
+			result, err := client.Messages.GetConversations(MessagesGetConversationsFields{Filter: "all"})
+			So(err, ShouldBeNil)
+			So(result.Count, ShouldEqual, 2)
+			conversations := []Conversation{result.Items[0].Conversation, result.Items[1].Conversation}
+			SortConversations(conversations)
+			So(conversations[0].Peer.ID, ShouldEqual, 2000000001)
+			So(conversations[0].Pinned(), ShouldBeTrue)
+			So(bool(conversations[0].Important), ShouldBeTrue)
+			pinned, ok := conversations[0].PinnedMessage()
+			So(ok, ShouldBeTrue)
+			So(pinned.Text, ShouldEqual, "rules")
+			So(conversations[1].Pinned(), ShouldBeFalse)
+			_, ok = conversations[1].PinnedMessage()
+			So(ok, ShouldBeFalse)
+		})
+		Convey("Pin", func() {
+			response = `{"response": {"id": 3, "text": "rules"}}`
+			m, err := client.Messages.Pin(MessagesPinFields{PeerID: 2000000001, ConversationMessageID: 3})
+			So(err, ShouldBeNil)
+			So(m.Text, ShouldEqual, "rules")
+			response = `{"response": 1}`
+			So(client.Messages.Unpin(2000000001, 0), ShouldBeNil)
+			So(client.Messages.MarkAsImportantConversation(1, true, 0), ShouldBeNil)
+			So(calls, ShouldResemble, []string{
+				"messages.pin conversation_message_id=3&peer_id=2000000001",
+				"messages.unpin peer_id=2000000001",
+				"messages.markAsImportantConversation important=1&peer_id=1",
+			})
+		})
+		Convey("Events", func() {
+			var events []PinEvent
+			h := PinHandler{OnPin: func(e PinEvent) error {
+				events = append(events, e)
+				return nil
+			}}
+			So(h.HandleLongPollUpdate([]int64{52, 5, 2000000001, 7}), ShouldBeNil)
+			So(h.HandleLongPollUpdate([]int64{52, 1, 2000000001, 0}), ShouldBeNil)
+			So(h.HandleEvent(Event{Type: eventMessageNew, Object: Raw(`{"message": {"peer_id": 2000000001, ` +
+				`"action": {"type": "chat_unpin_message", "member_id": 1, "conversation_message_id": 7}}}`)}), ShouldBeNil)
+			So(h.HandleEvent(Event{Type: eventMessageNew, Object: Raw(`{"message": {"peer_id": 1, "text": "hi"}}`)}), ShouldBeNil)
+			So(events, ShouldResemble, []PinEvent{
+				{PeerID: 2000000001, ConversationMessageID: 7, Pinned: true},
+				{PeerID: 2000000001, ConversationMessageID: 7, MemberID: 1},
+			})
+		})
+	})
+}
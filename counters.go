@@ -0,0 +1,127 @@
+package vk
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+const methodAccountGetCounters = "account.getCounters"
+
+// UserCounters are counters of user profile, returned
+// by users.get with counters field for single user
+type UserCounters struct {
+	Albums        int `json:"albums"`
+	Videos        int `json:"videos"`
+	Audios        int `json:"audios"`
+	Photos        int `json:"photos"`
+	Notes         int `json:"notes"`
+	Friends       int `json:"friends"`
+	Groups        int `json:"groups"`
+	OnlineFriends int `json:"online_friends"`
+	MutualFriends int `json:"mutual_friends"`
+	UserVideos    int `json:"user_videos"`
+	UserPhotos    int `json:"user_photos"`
+	Followers     int `json:"followers"`
+	Pages         int `json:"pages"`
+	Subscriptions int `json:"subscriptions"`
+	Gifts         int `json:"gifts"`
+	Articles      int `json:"articles"`
+	Clips         int `json:"clips"`
+}
+
+// GroupCounters are counters of community, returned by
+// groups.getById with counters field
+type GroupCounters struct {
+	Photos   int `json:"photos"`
+	Albums   int `json:"albums"`
+	Audios   int `json:"audios"`
+	Videos   int `json:"videos"`
+	Topics   int `json:"topics"`
+	Docs     int `json:"docs"`
+	Market   int `json:"market"`
+	Articles int `json:"articles"`
+	Clips    int `json:"clips"`
+}
+
+// AccountCounters are unread counters of current user
+type AccountCounters struct {
+	Friends                int `json:"friends"`
+	FriendsSuggestions     int `json:"friends_suggestions"`
+	Messages               int `json:"messages"`
+	Photos                 int `json:"photos"`
+	Videos                 int `json:"videos"`
+	Notes                  int `json:"notes"`
+	Gifts                  int `json:"gifts"`
+	Events                 int `json:"events"`
+	Groups                 int `json:"groups"`
+	Notifications          int `json:"notifications"`
+	SDK                    int `json:"sdk"`
+	AppRequests            int `json:"app_requests"`
+	FriendsRecommendations int `json:"friends_recommendations"`
+}
+
+type accountGetCountersFields struct {
+	Filter string `url:"filter,omitempty"`
+}
+
+// GetCounters returns unread counters, filter is comma separated
+// list of counters like "friends,messages", all if empty
+func (a Account) GetCounters(filter string) (result AccountCounters, err error) {
+	return result, a.Decode(a.Request(methodAccountGetCounters, accountGetCountersFields{filter}), &result)
+}
+
+// CountersMap returns counters of UserCounters, GroupCounters
+// or AccountCounters by their api names
+func CountersMap(counters interface{}) (map[string]int, error) {
+	data, err := json.Marshal(counters)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]int)
+	return m, json.Unmarshal(data, &m)
+}
+
+// Badges computes "new since last check" badges of counters,
+// keeping last seen counters in Storage
+type Badges struct {
+	Storage Storage
+	Prefix  string
+	// TTL of last seen counters, zero is forever
+	TTL time.Duration
+}
+
+// Badge is increase of counter since last check
+type Badge struct {
+	Name  string
+	Count int
+}
+
+// Check returns badges of counters that increased since last check
+// of key and remembers counters. First check only remembers them.
+func (b Badges) Check(key string, counters map[string]int) ([]Badge, error) {
+	key = b.Prefix + key
+	previous := make(map[string]int)
+	data, err := b.Storage.Get(key)
+	switch err {
+	case nil:
+		if err = json.Unmarshal(data, &previous); err != nil {
+			return nil, err
+		}
+	case ErrKeyNotFound:
+		previous = counters
+	default:
+		return nil, err
+	}
+	var badges []Badge
+	for name, count := range counters {
+		if d := count - previous[name]; d > 0 {
+			badges = append(badges, Badge{Name: name, Count: d})
+		}
+	}
+	sort.Slice(badges, func(i, j int) bool { return badges[i].Name < badges[j].Name })
+	if data, err = json.Marshal(counters); err != nil {
+		return nil, err
+	}
+	return badges, b.Storage.Set(key, data, b.TTL)
+}
@@ -0,0 +1,57 @@
+package vk
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCounters(t *testing.T) {
+	Convey("Counters", t, func() {
+		var u User
+		So(json.Unmarshal([]byte(`{"id": 1, "counters": {"friends": 10, "followers": 5}}`), &u), ShouldBeNil)
+		So(u.Counters.Friends, ShouldEqual, 10)
+		So(u.Counters.Followers, ShouldEqual, 5)
+		var g Group
+		So(json.Unmarshal([]byte(`{"id": 1, "counters": {"topics": 3}}`), &g), ShouldBeNil)
+		So(g.Counters.Topics, ShouldEqual, 3)
+
+		var filters []string
+		response := `{"response": {"friends": 1, "messages": 2}}`
+		client := New(WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			So(req.URL.Path, ShouldEqual, "/method/account.getCounters")
+			filters = append(filters, req.URL.Query().Get("filter"))
+			return jsonResponse(http.StatusOK, response), nil
+		})))
+		counters, err := client.Account.GetCounters("friends,messages")
+		So(err, ShouldBeNil)
+		So(counters, ShouldResemble, AccountCounters{Friends: 1, Messages: 2})
+		So(filters, ShouldResemble, []string{"friends,messages"})
+
+		Convey("Badges", func() {
+			m, err := CountersMap(counters)
+			So(err, ShouldBeNil)
+			So(m["messages"], ShouldEqual, 2)
+			So(m["notifications"], ShouldEqual, 0)
+
+			b := Badges{Storage: &MemoryStorage{}, Prefix: "counters:"}
+			badges, err := b.Check("1", m)
+			So(err, ShouldBeNil)
+			So(badges, ShouldBeEmpty)
+
+			response = `{"response": {"friends": 3, "messages": 1, "gifts": 1}}`
+			counters, err = client.Account.GetCounters("")
+			So(err, ShouldBeNil)
+			m, err = CountersMap(counters)
+			So(err, ShouldBeNil)
+			badges, err = b.Check("1", m)
+			So(err, ShouldBeNil)
+			So(badges, ShouldResemble, []Badge{{Name: "friends", Count: 2}, {Name: "gifts", Count: 1}})
+			badges, err = b.Check("1", m)
+			So(err, ShouldBeNil)
+			So(badges, ShouldBeEmpty)
+		})
+	})
+}
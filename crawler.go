@@ -0,0 +1,189 @@
+package vk
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const defaultCrawlSaveEvery = 100
+
+// Edge is friendship found by Crawler, From is user that is
+// closer to seeds and Depth is distance from seeds to From
+type Edge struct {
+	From  ID  `json:"from"`
+	To    ID  `json:"to"`
+	Depth int `json:"depth"`
+}
+
+// CrawlNode is user queued for crawling
+type CrawlNode struct {
+	ID    ID  `json:"id"`
+	Depth int `json:"depth"`
+}
+
+// CrawlState is state of Crawler that can be saved to resume
+// crawling later
+type CrawlState struct {
+	Queue []CrawlNode `json:"queue"`
+	// Seen contains queued and crawled users
+	Seen map[ID]bool `json:"seen"`
+	// Done contains users which friends are fetched
+	Done map[ID]bool `json:"done"`
+	// Private contains users with hidden friends
+	Private []ID `json:"private,omitempty"`
+}
+
+// Crawler traverses friends of seeds breadth-first, every friendship
+// is reported once, as edge from user that is crawled first
+type Crawler struct {
+	Friends Friends
+	// MaxDepth is maximum distance from seeds of crawled users, so
+	// 1 crawls friends of seeds and friends of friends are only edges
+	MaxDepth int
+	// OnEdge is called for every found edge
+	OnEdge func(e Edge) error
+	// Storage keeps state under Key if set, it is loaded on Crawl
+	// and saved after every SaveEvery crawled users and when Crawl
+	// returns. SaveEvery is 100 if zero.
+	Storage   Storage
+	Key       string
+	SaveEvery int
+
+	State CrawlState
+}
+
+// Seed queues users with zero depth
+func (c *Crawler) Seed(ids ...ID) {
+	c.init()
+	for _, id := range ids {
+		c.push(CrawlNode{ID: id})
+	}
+}
+
+func (c *Crawler) init() {
+	if c.State.Seen == nil {
+		c.State.Seen = make(map[ID]bool)
+	}
+	if c.State.Done == nil {
+		c.State.Done = make(map[ID]bool)
+	}
+}
+
+func (c *Crawler) push(n CrawlNode) {
+	if c.State.Seen[n.ID] {
+		return
+	}
+	c.State.Seen[n.ID] = true
+	c.State.Queue = append(c.State.Queue, n)
+}
+
+// Load restores state from Storage, it is not an error
+// if state was not saved
+func (c *Crawler) Load() error {
+	data, err := c.Storage.Get(c.Key)
+	if err == ErrKeyNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var state CrawlState
+	if err = json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	c.State = state
+	return nil
+}
+
+// Save saves state to Storage
+func (c *Crawler) Save() error {
+	data, err := json.Marshal(c.State)
+	if err != nil {
+		return err
+	}
+	return c.Storage.Set(c.Key, data, 0)
+}
+
+// Crawl crawls queued users until queue is empty or ctx is done,
+// restoring state from Storage first if it is set. Users crawled
+// after last save are crawled again if process is killed, so their
+// edges can be reported twice.
+func (c *Crawler) Crawl(ctx context.Context) error {
+	if c.Storage != nil {
+		queued := c.State.Queue
+		if err := c.Load(); err != nil {
+			return err
+		}
+		if len(c.State.Queue) == 0 && len(c.State.Done) == 0 {
+			c.State.Queue = queued
+		}
+	}
+	c.init()
+	every := c.SaveEvery
+	if every <= 0 {
+		every = defaultCrawlSaveEvery
+	}
+	// state is saved periodically, as it grows with
+	// crawled users and saving it every time is quadratic
+	for crawled := 1; len(c.State.Queue) != 0; crawled++ {
+		if err := ctx.Err(); err != nil {
+			return c.checkpoint(err)
+		}
+		n := c.State.Queue[0]
+		if err := c.crawl(n); err != nil {
+			return c.checkpoint(err)
+		}
+		c.State.Queue = c.State.Queue[1:]
+		c.State.Done[n.ID] = true
+		if c.Storage != nil && crawled%every == 0 {
+			if err := c.Save(); err != nil {
+				return err
+			}
+		}
+	}
+	return c.checkpoint(nil)
+}
+
+// checkpoint saves state if Storage is set and returns err,
+// or save error if err is nil
+func (c *Crawler) checkpoint(err error) error {
+	if c.Storage == nil {
+		return err
+	}
+	if saveErr := c.Save(); err == nil {
+		return saveErr
+	}
+	return err
+}
+
+func (c *Crawler) crawl(n CrawlNode) error {
+	fields := FriendsGetFields{UserID: n.ID, Count: maxFriendsCount}
+	for {
+		friends, err := c.Friends.Get(fields)
+		if ErrPrivateProfile.Is(err) || ErrUserDeleted.Is(err) {
+			c.State.Private = append(c.State.Private, n.ID)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, id := range friends.Items {
+			if c.State.Done[id] {
+				// edge is already reported by friend
+				continue
+			}
+			if c.OnEdge != nil {
+				if err = c.OnEdge(Edge{From: n.ID, To: id, Depth: n.Depth}); err != nil {
+					return err
+				}
+			}
+			if n.Depth < c.MaxDepth {
+				c.push(CrawlNode{ID: id, Depth: n.Depth + 1})
+			}
+		}
+		fields.Offset += len(friends.Items)
+		if len(friends.Items) == 0 || fields.Offset >= friends.Count {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package vk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// countingStorage counts Set calls of Storage
+type countingStorage struct {
+	Storage
+	sets int
+}
+
+func (s *countingStorage) Set(key string, value []byte, ttl time.Duration) error {
+	s.sets++
+	return s.Storage.Set(key, value, ttl)
+}
+
+func TestCrawler(t *testing.T) {
+	Convey("Crawler", t, func() {
+		graph := map[string]string{
+			"1": `{"count": 2, "items": [2, 3]}`,
+			"2": `{"count": 3, "items": [1, 3, 4]}`,
+			"3": `{"count": 2, "items": [1, 2]}`,
+			"4": `{"count": 1, "items": [2]}`,
+		}
+		var calls []string
+		client := New(WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			So(req.URL.Path, ShouldEqual, "/method/friends.get")
+			id := req.URL.Query().Get("user_id")
+			calls = append(calls, id)
+			if id == "3" {
+				return jsonResponse(http.StatusOK, `{"error": {"error_code": 30, "error_msg": "private"}}`), nil
+			}
+			return jsonResponse(http.StatusOK, `{"response": `+graph[id]+`}`), nil
+		})))
+		var edges []Edge
+		c := &Crawler{Friends: client.Friends, MaxDepth: 1, OnEdge: func(e Edge) error {
+			edges = append(edges, e)
+			return nil
+		}}
+		c.Seed(1)
+		ctx := context.Background()
+		So(c.Crawl(ctx), ShouldBeNil)
+		So(calls, ShouldResemble, []string{"1", "2", "3"})
+		So(edges, ShouldResemble, []Edge{
+			{From: 1, To: 2}, {From: 1, To: 3},
+			{From: 2, To: 3, Depth: 1}, {From: 2, To: 4, Depth: 1},
+		})
+		So(c.State.Private, ShouldResemble, []ID{3})
+		So(c.State.Queue, ShouldBeEmpty)
+
+		Convey("Resume", func() {
+			storage := &MemoryStorage{Clock: NewFakeClock(time.Unix(0, 0))}
+			calls, edges = nil, nil
+			failed := errors.New("failed")
+			c := &Crawler{Friends: client.Friends, MaxDepth: 1, Storage: storage, Key: "crawl", OnEdge: func(e Edge) error {
+				if e.From == 2 {
+					return failed
+				}
+				edges = append(edges, e)
+				return nil
+			}}
+			c.Seed(1)
+			So(c.Crawl(ctx), ShouldEqual, failed)
+			So(calls, ShouldResemble, []string{"1", "2"})
+
+			calls = nil
+			resumed := &Crawler{Friends: client.Friends, MaxDepth: 1, Storage: storage, Key: "crawl", OnEdge: func(e Edge) error {
+				edges = append(edges, e)
+				return nil
+			}}
+			resumed.Seed(10)
+			So(resumed.Crawl(ctx), ShouldBeNil)
+			So(calls, ShouldResemble, []string{"2", "3"})
+			So(edges, ShouldHaveLength, 4)
+			So(resumed.State.Done, ShouldResemble, map[ID]bool{1: true, 2: true, 3: true})
+		})
+		Convey("Save every", func() {
+			storage := &countingStorage{Storage: &MemoryStorage{}}
+			c := &Crawler{Friends: client.Friends, MaxDepth: 1, Storage: storage, Key: "crawl", SaveEvery: 2}
+			c.Seed(1)
+			So(c.Crawl(ctx), ShouldBeNil)
+			// after second user and on return
+			So(storage.sets, ShouldEqual, 2)
+		})
+		Convey("Canceled", func() {
+			ctx, cancel := context.WithCancel(ctx)
+			cancel()
+			c := &Crawler{Friends: client.Friends}
+			c.Seed(1)
+			So(c.Crawl(ctx), ShouldEqual, context.Canceled)
+		})
+	})
+}
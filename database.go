@@ -0,0 +1,112 @@
+package vk
+
+import (
+	"errors"
+	"sync"
+)
+
+const (
+	methodDatabaseGetCities     = "database.getCities"
+	methodDatabaseGetCitiesByID = "database.getCitiesById"
+)
+
+// ErrCityNotFound is returned when city of coordinates is not resolved
+var ErrCityNotFound = errors.New("city not found")
+
+// Database resource
+type Database struct {
+	Resource
+}
+
+// DatabaseCity is city from database methods
+type DatabaseCity struct {
+	ID     int    `json:"id"`
+	Title  string `json:"title"`
+	Area   string `json:"area,omitempty"`
+	Region string `json:"region,omitempty"`
+}
+
+type DatabaseGetCitiesFields struct {
+	CountryID CountryID `url:"country_id"`
+	RegionID  int       `url:"region_id,omitempty"`
+	Query     string    `url:"q,omitempty"`
+	NeedAll   Bool      `url:"need_all,omitempty"`
+	Offset    int       `url:"offset,omitempty"`
+	Count     int       `url:"count,omitempty"`
+}
+
+type DatabaseGetCitiesResult struct {
+	Count int            `json:"count"`
+	Items []DatabaseCity `json:"items"`
+}
+
+func (d Database) GetCities(fields DatabaseGetCitiesFields) (result DatabaseGetCitiesResult, err error) {
+	return result, d.Decode(d.Request(methodDatabaseGetCities, fields), &result)
+}
+
+type databaseCityIDsFields struct {
+	CityIDs []int `url:"city_ids,comma"`
+}
+
+func (d Database) GetCitiesByID(ids ...int) (result []DatabaseCity, err error) {
+	return result, d.Decode(d.Request(methodDatabaseGetCitiesByID, databaseCityIDsFields{ids}), &result)
+}
+
+// CityResolver resolves coordinates of geo attachments to cities:
+// city id is taken from place of geo or from nearest place found
+// by places.search and title is fetched by database.getCitiesById
+type CityResolver struct {
+	Places   Places
+	Database Database
+
+	mux    sync.Mutex
+	cities map[int]DatabaseCity
+}
+
+// City returns city by id, cities are cached
+func (r *CityResolver) City(id int) (DatabaseCity, error) {
+	r.mux.Lock()
+	city, ok := r.cities[id]
+	r.mux.Unlock()
+	if ok {
+		return city, nil
+	}
+	cities, err := r.Database.GetCitiesByID(id)
+	if err != nil {
+		return city, err
+	}
+	if len(cities) == 0 {
+		return city, ErrCityNotFound
+	}
+	r.mux.Lock()
+	if r.cities == nil {
+		r.cities = make(map[int]DatabaseCity)
+	}
+	r.cities[id] = cities[0]
+	r.mux.Unlock()
+	return cities[0], nil
+}
+
+// At returns city of coordinates by places near them
+func (r *CityResolver) At(c Coordinates) (DatabaseCity, error) {
+	for _, radius := range []PlaceRadius{PlaceRadius2km, PlaceRadius7km, PlaceRadius50km} {
+		places, err := r.Places.Near(c, radius)
+		if err != nil {
+			return DatabaseCity{}, err
+		}
+		for _, p := range places.Items {
+			if p.City != 0 {
+				return r.City(p.City)
+			}
+		}
+	}
+	return DatabaseCity{}, ErrCityNotFound
+}
+
+// Geo returns city of geo object of message or post
+func (r *CityResolver) Geo(g Geo) (DatabaseCity, error) {
+	if g.Place != nil && g.Place.City != 0 {
+		return r.City(g.Place.City)
+	}
+	return r.At(g.Coordinates)
+}
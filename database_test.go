@@ -0,0 +1,50 @@
+package vk
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCityResolver(t *testing.T) {
+	Convey("City resolver", t, func() {
+		client := New()
+		var calls []string
+		client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			calls = append(calls, req.URL.Path[len("/method/"):]+" "+q.Get("radius")+q.Get("city_ids"))
+			switch req.URL.Path {
+			case "/method/places.search":
+				if q.Get("radius") == "2" {
+					return jsonResponse(http.StatusOK, `{"response": {"count": 1, "items": [{"id": 1, "city": 0}]}}`), nil
+				}
+				return jsonResponse(http.StatusOK, `{"response": {"count": 1, "items": [{"id": 2, "city": 1}]}}`), nil
+			case "/method/database.getCitiesById":
+				return jsonResponse(http.StatusOK, `{"response": [{"id": `+q.Get("city_ids")+`, "title": "Moscow"}]}`), nil
+			case "/method/places.getCheckins":
+				return jsonResponse(http.StatusOK, `{"response": {"count": 1, "items": [{"id": 1, "user_id": 5, "latitude": 55.75, "longitude": 37.62}]}}`), nil
+			}
+			return jsonResponse(http.StatusOK, `{"response": {"count": 1, "items": [{"id": 1, "title": "Moscow", "region": "Moscow"}]}}`), nil
+		}))
+		r := &CityResolver{Places: client.Places, Database: client.Database}
+		city, err := r.At(Coordinates{55.75, 37.62})
+		So(err, ShouldBeNil)
+		So(city, ShouldResemble, DatabaseCity{ID: 1, Title: "Moscow"})
+		So(calls, ShouldResemble, []string{"places.search 2", "places.search 3", "database.getCitiesById 1"})
+
+		calls = nil
+		city, err = r.Geo(Geo{Place: &Place{City: 1}})
+		So(err, ShouldBeNil)
+		So(city.Title, ShouldEqual, "Moscow")
+		So(calls, ShouldBeEmpty)
+
+		cities, err := client.Database.GetCities(DatabaseGetCitiesFields{CountryID: Russia, Query: "Mos"})
+		So(err, ShouldBeNil)
+		So(cities.Items[0].Region, ShouldEqual, "Moscow")
+
+		checkins, err := client.Places.GetCheckins(PlaceGetCheckinsFields{Latitude: 55.75, Longitude: 37.62})
+		So(err, ShouldBeNil)
+		So(checkins.Items[0].Coordinates(), ShouldResemble, Coordinates{55.75, 37.62})
+	})
+}
@@ -0,0 +1,194 @@
+package vk
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	defaultDeadLetterAttempts = 3
+	defaultDeadLetterPath     = "dead_letters.jsonl"
+)
+
+// DefaultDeadLetters is sink of DeadLetterHandler without Sink,
+// that appends to dead_letters.jsonl in working directory
+var DefaultDeadLetters DeadLetterSink = &FileDeadLetters{Path: defaultDeadLetterPath}
+
+// DeadLetter is event that handler failed to handle
+type DeadLetter struct {
+	Event Event  `json:"event"`
+	Error string `json:"error"`
+	// Panic is true if last attempt panicked
+	Panic    bool      `json:"panic,omitempty"`
+	Attempts int       `json:"attempts"`
+	Time     time.Time `json:"time"`
+}
+
+// DeadLetterSink stores dead letters
+type DeadLetterSink interface {
+	Put(letter DeadLetter) error
+}
+
+// DeadLetterHandler calls Handler up to Attempts times and puts
+// event to Sink if all attempts failed or panicked, so event is
+// not lost and not redelivered
+type DeadLetterHandler struct {
+	Handler EventHandler
+	// Sink is DefaultDeadLetters if nil
+	Sink DeadLetterSink
+	// Attempts is 3 if zero
+	Attempts int
+	// Clock is SystemClock if nil
+	Clock Clock
+}
+
+// HandleEvent handles event, error is returned only if
+// event can not be put to Sink
+func (h DeadLetterHandler) HandleEvent(event Event) error {
+	attempts := h.Attempts
+	if attempts == 0 {
+		attempts = defaultDeadLetterAttempts
+	}
+	var (
+		err      error
+		panicked bool
+	)
+	for i := 0; i < attempts; i++ {
+		if panicked, err = h.handle(event); err == nil {
+			return nil
+		}
+	}
+	sink := h.Sink
+	if sink == nil {
+		sink = DefaultDeadLetters
+	}
+	return sink.Put(DeadLetter{
+		Event:    event,
+		Error:    err.Error(),
+		Panic:    panicked,
+		Attempts: attempts,
+		Time:     clockOrSystem(h.Clock).Now(),
+	})
+}
+
+// handle calls Handler, converting panic to error
+func (h DeadLetterHandler) handle(event Event) (panicked bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicked, err = true, fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return false, h.Handler(event)
+}
+
+// ReadDeadLetters calls fn for every dead letter in JSONL from r
+func ReadDeadLetters(r io.Reader, fn func(letter DeadLetter) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var letter DeadLetter
+		if err := json.Unmarshal(line, &letter); err != nil {
+			return err
+		}
+		if err := fn(letter); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// FileDeadLetters is DeadLetterSink that appends dead letters
+// to JSONL file, it should not be shared by concurrent processes
+type FileDeadLetters struct {
+	Path string
+
+	mux sync.Mutex
+}
+
+func (f *FileDeadLetters) Put(letter DeadLetter) error {
+	data, err := json.Marshal(letter)
+	if err != nil {
+		return err
+	}
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	file, err := os.OpenFile(f.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err = file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Replay re-injects dead letters to handler, letters that fail
+// again are kept in file with updated error, as are lines that can
+// not be decoded. Returns count of replayed letters.
+func (f *FileDeadLetters) Replay(handler EventHandler) (int, error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	data, err := ioutil.ReadFile(f.Path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	var (
+		failed   bytes.Buffer
+		replayed int
+		h        = DeadLetterHandler{Handler: handler}
+	)
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		var letter DeadLetter
+		if json.Unmarshal(line, &letter) != nil {
+			failed.Write(append(line, '\n'))
+			continue
+		}
+		panicked, err := h.handle(letter.Event)
+		if err == nil {
+			replayed++
+			continue
+		}
+		letter.Error, letter.Panic = err.Error(), panicked
+		letter.Attempts++
+		// original line is kept if letter can not be encoded
+		if encoded, err := json.Marshal(letter); err == nil {
+			line = encoded
+		}
+		failed.Write(append(line, '\n'))
+	}
+	// file is replaced atomically, so letters are not lost on crash
+	tmp, err := ioutil.TempFile(filepath.Dir(f.Path), ".tmp-")
+	if err != nil {
+		return replayed, err
+	}
+	if _, err = tmp.Write(failed.Bytes()); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return replayed, err
+	}
+	if err = tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return replayed, err
+	}
+	return replayed, os.Rename(tmp.Name(), f.Path)
+}
@@ -0,0 +1,120 @@
+package vk
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDeadLetters(t *testing.T) {
+	Convey("Dead letters", t, func() {
+		dir, err := ioutil.TempDir("", "vk-deadletter")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		sink := &FileDeadLetters{Path: filepath.Join(dir, "dead.jsonl")}
+		clock := NewFakeClock(time.Unix(100, 0))
+		calls := map[string]int{}
+		h := DeadLetterHandler{Sink: sink, Clock: clock, Handler: func(e Event) error {
+			calls[e.EventID]++
+			switch e.Type {
+			case "fail":
+				return ErrUnknown
+			case "panic":
+				panic("boom")
+			case "flaky":
+				if calls[e.EventID] < 2 {
+					return ErrUnknown
+				}
+			}
+			return nil
+		}}
+		So(h.HandleEvent(Event{Type: "ok", EventID: "1", Object: Raw("{}")}), ShouldBeNil)
+		So(h.HandleEvent(Event{Type: "flaky", EventID: "2", Object: Raw("{}")}), ShouldBeNil)
+		So(h.HandleEvent(Event{Type: "fail", EventID: "3", Object: Raw("{}")}), ShouldBeNil)
+		So(h.HandleEvent(Event{Type: "panic", EventID: "4", Object: Raw("{}")}), ShouldBeNil)
+		So(calls, ShouldResemble, map[string]int{"1": 1, "2": 2, "3": 3, "4": 3})
+
+		read := func() []DeadLetter {
+			f, err := os.Open(sink.Path)
+			So(err, ShouldBeNil)
+			defer f.Close()
+			var letters []DeadLetter
+			So(ReadDeadLetters(f, func(l DeadLetter) error {
+				letters = append(letters, l)
+				return nil
+			}), ShouldBeNil)
+			return letters
+		}
+		letters := read()
+		So(letters, ShouldHaveLength, 2)
+		So(letters[0].Event.EventID, ShouldEqual, "3")
+		So(letters[0].Error, ShouldEqual, ErrUnknown.Error())
+		So(letters[0].Attempts, ShouldEqual, 3)
+		So(letters[0].Time.Unix(), ShouldEqual, 100)
+		So(letters[1].Error, ShouldEqual, "panic: boom")
+		So(letters[1].Panic, ShouldBeTrue)
+
+		Convey("Replay", func() {
+			n, err := sink.Replay(func(e Event) error {
+				if e.Type == "panic" {
+					return ErrUnknown
+				}
+				return nil
+			})
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 1)
+			letters := read()
+			So(letters, ShouldHaveLength, 1)
+			So(letters[0].Event.EventID, ShouldEqual, "4")
+			So(letters[0].Panic, ShouldBeFalse)
+			So(letters[0].Attempts, ShouldEqual, 4)
+
+			n, err = sink.Replay(func(e Event) error { return nil })
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 1)
+			So(read(), ShouldBeEmpty)
+		})
+		Convey("Default sink", func() {
+			So(DefaultDeadLetters.(*FileDeadLetters).Path, ShouldEqual, "dead_letters.jsonl")
+			defaultSink := DefaultDeadLetters
+			defer func() { DefaultDeadLetters = defaultSink }()
+			sink := &FileDeadLetters{Path: filepath.Join(dir, "default.jsonl")}
+			DefaultDeadLetters = sink
+			h.Sink = nil
+			So(h.HandleEvent(Event{Type: "fail", EventID: "5", Object: Raw("{}")}), ShouldBeNil)
+			data, err := ioutil.ReadFile(sink.Path)
+			So(err, ShouldBeNil)
+			So(string(data), ShouldContainSubstring, `"event_id":"5"`)
+		})
+		Convey("Replay keeps corrupt lines", func() {
+			f, err := os.OpenFile(sink.Path, os.O_WRONLY|os.O_APPEND, 0644)
+			So(err, ShouldBeNil)
+			f.WriteString("{corrupt\n")
+			f.Close()
+			So(sink.Put(DeadLetter{Event: Event{Type: "fail", EventID: "6", Object: Raw("{}")}}), ShouldBeNil)
+			var replayed []string
+			n, err := sink.Replay(func(e Event) error {
+				replayed = append(replayed, e.EventID)
+				return nil
+			})
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 3)
+			So(replayed, ShouldResemble, []string{"3", "4", "6"})
+			data, err := ioutil.ReadFile(sink.Path)
+			So(err, ShouldBeNil)
+			So(string(data), ShouldEqual, "{corrupt\n")
+			n, err = sink.Replay(func(e Event) error { return nil })
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 0)
+		})
+		Convey("Bad line", func() {
+			err := ReadDeadLetters(strings.NewReader("{\n"), func(DeadLetter) error { return nil })
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
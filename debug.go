@@ -0,0 +1,125 @@
+package vk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+)
+
+const redacted = "***"
+
+// secretParams are request parameters that are never logged
+var secretParams = []string{paramToken, paramCheckedToken, paramClientSecret, paramPassword, paramCode}
+
+// redactValues returns copy of values with secrets replaced
+func redactValues(values url.Values) url.Values {
+	result := make(url.Values, len(values))
+	for k, v := range values {
+		result[k] = append([]string(nil), v...)
+	}
+	for _, k := range secretParams {
+		if _, ok := result[k]; ok {
+			result[k] = []string{redacted}
+		}
+	}
+	return result
+}
+
+// redactURL returns u as string with secrets replaced
+func redactURL(u *url.URL) string {
+	redactedURL := *u
+	redactedURL.RawQuery = redactValues(u.Query()).Encode()
+	return redactedURL.String()
+}
+
+// debugLog writes dumps of requests and responses,
+// it is safe to change it during requests
+type debugLog struct {
+	mux     sync.Mutex
+	w       io.Writer
+	enabled int32
+}
+
+// WithDebugWriter enables dumping of requests and responses to w
+func WithDebugWriter(w io.Writer) Option {
+	return func(c *Client) {
+		c.SetDebugWriter(w)
+	}
+}
+
+// SetDebugWriter sets writer for dumps of requests and responses,
+// nil disables dumping
+func (c *Client) SetDebugWriter(w io.Writer) {
+	c.debug.mux.Lock()
+	c.debug.w = w
+	c.debug.mux.Unlock()
+	c.SetDebug(w != nil)
+}
+
+// SetDebug toggles dumping to writer set by SetDebugWriter
+func (c *Client) SetDebug(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&c.debug.enabled, v)
+}
+
+func (d *debugLog) writer() io.Writer {
+	if atomic.LoadInt32(&d.enabled) == 0 {
+		return nil
+	}
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	return d.w
+}
+
+func (d *debugLog) printf(format string, args ...interface{}) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	if d.w != nil {
+		fmt.Fprintf(d.w, format, args...)
+	}
+}
+
+// dumpRequest writes request url and body
+func (d *debugLog) dumpRequest(req *http.Request) error {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(req.Body); err != nil {
+			return err
+		}
+		req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	d.printf("> %s %s\n", req.Method, redactURL(req.URL))
+	if len(body) != 0 {
+		d.printf("%s\n", body)
+	}
+	return nil
+}
+
+// dumpResponse writes status and indented body of response,
+// replacing body with buffered copy
+func (d *debugLog) dumpResponse(res *http.Response) error {
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+	pretty := new(bytes.Buffer)
+	if json.Indent(pretty, body, "", "  ") != nil {
+		pretty.Reset()
+		pretty.Write(body)
+	}
+	d.printf("< %d %s\n%s\n", res.StatusCode, http.StatusText(res.StatusCode), pretty)
+	return nil
+}
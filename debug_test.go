@@ -0,0 +1,61 @@
+package vk
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDebugWriter(t *testing.T) {
+	Convey("Debug writer", t, func() {
+		buf := new(bytes.Buffer)
+		client := NewWithToken("secret-token", WithDebugWriter(buf))
+		client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusOK, `{"response": {"id": 1}}`), nil
+		}))
+		res, err := client.Do(client.Users.Request("users.get", nil))
+		So(err, ShouldBeNil)
+		So(string(res.Response), ShouldEqual, `{"id": 1}`)
+		out := buf.String()
+		So(out, ShouldContainSubstring, "> GET https://api.vk.com/method/users.get?")
+		So(out, ShouldContainSubstring, "access_token=%2A%2A%2A")
+		So(out, ShouldNotContainSubstring, "secret-token")
+		So(out, ShouldContainSubstring, "< 200 OK\n{\n  \"response\": {\n    \"id\": 1\n  }\n}")
+
+		Convey("Toggle", func() {
+			buf.Reset()
+			client.SetDebug(false)
+			_, err := client.Do(Request{Method: "users.get"})
+			So(err, ShouldBeNil)
+			So(buf.Len(), ShouldEqual, 0)
+			client.SetDebug(true)
+			_, err = client.Do(Request{Method: "users.get"})
+			So(err, ShouldBeNil)
+			So(buf.Len(), ShouldNotEqual, 0)
+		})
+		Convey("Redact", func() {
+			values := url.Values{"client_secret": {"s"}, "password": {"p"}, "token": {"t"}, "code": {"c"}, "q": {"x"}}
+			r := redactValues(values)
+			So(r.Get("token"), ShouldEqual, "***")
+			So(r.Get("code"), ShouldEqual, "***")
+			So(r.Get("client_secret"), ShouldEqual, "***")
+			So(r.Get("password"), ShouldEqual, "***")
+			So(r.Get("q"), ShouldEqual, "x")
+			So(values.Get("password"), ShouldEqual, "p")
+		})
+		Convey("Not JSON", func() {
+			buf.Reset()
+			client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+				return jsonResponse(http.StatusBadGateway, `bad gateway`), nil
+			}))
+			_, err := client.Do(Request{Method: "users.get"})
+			So(errors.Is(err, ErrBadResponseCode), ShouldBeTrue)
+			So(strings.TrimSpace(buf.String()), ShouldEndWith, "bad gateway")
+		})
+	})
+}
@@ -0,0 +1,56 @@
+package vk
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// maxDecodeErrorBody limits malformed data kept in DecodeError
+const maxDecodeErrorBody = 256
+
+// DecodeError is returned when response of server is malformed,
+// Body is prefix of malformed data if it is known
+type DecodeError struct {
+	Err  error
+	Body []byte
+}
+
+func (e DecodeError) Error() string {
+	if len(e.Body) == 0 {
+		return fmt.Sprintf("decode: %v", e.Err)
+	}
+	return fmt.Sprintf("decode: %v: %s", e.Err, e.Body)
+}
+
+// Unwrap returns underlying error
+func (e DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// newDecodeError wraps err into DecodeError with truncated copy of body
+func newDecodeError(err error, body []byte) error {
+	if _, ok := err.(DecodeError); ok {
+		return err
+	}
+	if len(body) > maxDecodeErrorBody {
+		body = body[:maxDecodeErrorBody]
+	}
+	return DecodeError{Err: err, Body: append([]byte(nil), body...)}
+}
+
+// recoverDecode sets *err to DecodeError if decoding panicked
+func recoverDecode(err *error, body []byte) {
+	if r := recover(); r != nil {
+		*err = newDecodeError(fmt.Errorf("panic: %v", r), body)
+	}
+}
+
+// decodeJSON is json.Unmarshal that returns DecodeError
+// on malformed data and on panics of custom unmarshalers
+func decodeJSON(data []byte, v interface{}) (err error) {
+	defer recoverDecode(&err, data)
+	if err = json.Unmarshal(data, v); err != nil {
+		return newDecodeError(err, data)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package vk
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type panicUnmarshaler struct{}
+
+func (panicUnmarshaler) UnmarshalJSON([]byte) error {
+	panic("boom")
+}
+
+func TestDecodeError(t *testing.T) {
+	Convey("Decode error", t, func() {
+		Convey("Malformed body", func() {
+			_, err := Process(strings.NewReader(`{"response": [1,`))
+			So(err, ShouldHaveSameTypeAs, DecodeError{})
+			So(errors.Is(err, io.ErrUnexpectedEOF), ShouldBeTrue)
+			So(err.Error(), ShouldEqual, "decode: unexpected EOF")
+			_, err = Process(strings.NewReader(`{"error": {"error_code": "x"}}`))
+			So(err, ShouldHaveSameTypeAs, DecodeError{})
+		})
+		Convey("Wrong types", func() {
+			res := Response{Response: Raw(`[{"id": 1, "online": {}}]`)}
+			var users []User
+			err := res.To(&users)
+			So(err, ShouldHaveSameTypeAs, DecodeError{})
+			So(string(err.(DecodeError).Body), ShouldEqual, string(res.Response))
+			So(Object(`{"id": "x"}`).To(&User{}), ShouldHaveSameTypeAs, DecodeError{})
+		})
+		Convey("Truncated body", func() {
+			body := bytes.Repeat([]byte("x"), 1024)
+			err := newDecodeError(io.EOF, body).(DecodeError)
+			So(err.Body, ShouldHaveLength, maxDecodeErrorBody)
+			So(newDecodeError(err, nil), ShouldResemble, err)
+		})
+		Convey("Panic", func() {
+			var v panicUnmarshaler
+			err := Response{Response: Raw(`1`)}.To(&v)
+			So(err, ShouldHaveSameTypeAs, DecodeError{})
+			So(err.Error(), ShouldEqual, "decode: panic: boom: 1")
+		})
+	})
+}
@@ -0,0 +1,73 @@
+package vk
+
+import (
+	"sync"
+	"time"
+)
+
+const defaultDedupeTTL = time.Hour
+
+// DedupeStore remembers ids of processed events, so
+// redelivered events are handled only once
+type DedupeStore interface {
+	// Seen marks id as seen and reports whether it was already marked
+	Seen(id string) (bool, error)
+	// Forget removes mark, so event can be processed again
+	Forget(id string) error
+}
+
+// MemoryDedupe is in-memory DedupeStore that keeps ids for TTL
+type MemoryDedupe struct {
+	TTL time.Duration
+
+	mux     sync.Mutex
+	seen    map[string]time.Time
+	cleaned time.Time
+}
+
+// NewMemoryDedupe returns in-memory store with provided ttl
+func NewMemoryDedupe(ttl time.Duration) *MemoryDedupe {
+	return &MemoryDedupe{TTL: ttl}
+}
+
+func (d *MemoryDedupe) ttl() time.Duration {
+	if d.TTL == 0 {
+		return defaultDedupeTTL
+	}
+	return d.TTL
+}
+
+// cleanup removes expired ids, at most once per ttl
+func (d *MemoryDedupe) cleanup(now time.Time) {
+	if now.Sub(d.cleaned) < d.ttl() {
+		return
+	}
+	for id, expires := range d.seen {
+		if now.After(expires) {
+			delete(d.seen, id)
+		}
+	}
+	d.cleaned = now
+}
+
+func (d *MemoryDedupe) Seen(id string) (bool, error) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	now := time.Now()
+	if d.seen == nil {
+		d.seen = make(map[string]time.Time)
+	}
+	d.cleanup(now)
+	if expires, ok := d.seen[id]; ok && now.Before(expires) {
+		return true, nil
+	}
+	d.seen[id] = now.Add(d.ttl())
+	return false, nil
+}
+
+func (d *MemoryDedupe) Forget(id string) error {
+	d.mux.Lock()
+	delete(d.seen, id)
+	d.mux.Unlock()
+	return nil
+}
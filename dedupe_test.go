@@ -0,0 +1,61 @@
+package vk
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMemoryDedupe(t *testing.T) {
+	Convey("Memory dedupe", t, func() {
+		d := NewMemoryDedupe(time.Hour)
+		seen, err := d.Seen("a")
+		So(err, ShouldBeNil)
+		So(seen, ShouldBeFalse)
+		seen, _ = d.Seen("a")
+		So(seen, ShouldBeTrue)
+		Convey("Forget", func() {
+			So(d.Forget("a"), ShouldBeNil)
+			seen, _ = d.Seen("a")
+			So(seen, ShouldBeFalse)
+		})
+		Convey("Expiration", func() {
+			d := NewMemoryDedupe(time.Millisecond)
+			seen, _ = d.Seen("b")
+			So(seen, ShouldBeFalse)
+			time.Sleep(time.Millisecond * 2)
+			seen, _ = d.Seen("b")
+			So(seen, ShouldBeFalse)
+			So(len(d.seen), ShouldEqual, 1)
+		})
+		Convey("Zero value", func() {
+			d := &MemoryDedupe{}
+			So(d.Forget("a"), ShouldBeNil)
+			seen, _ := d.Seen("a")
+			So(seen, ShouldBeFalse)
+		})
+	})
+}
+
+func TestCallbackDedupe(t *testing.T) {
+	Convey("Callback dedupe", t, func() {
+		calls := 0
+		fail := true
+		c := &Callback{Dedupe: NewMemoryDedupe(0), Handler: func(e Event) error {
+			calls++
+			if fail {
+				return ErrUnknown
+			}
+			return nil
+		}}
+		body := `{"type": "message_new", "event_id": "e1"}`
+		So(callbackRequest(c, "", body).Code, ShouldEqual, 500)
+		fail = false
+		So(callbackRequest(c, "", body).Body.String(), ShouldEqual, "ok")
+		So(callbackRequest(c, "", body).Body.String(), ShouldEqual, "ok")
+		So(calls, ShouldEqual, 2)
+		So(callbackRequest(c, "", `{"type": "message_new"}`).Body.String(), ShouldEqual, "ok")
+		So(calls, ShouldEqual, 3)
+	})
+}
@@ -0,0 +1,251 @@
+package vk
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFallbackDelay = 300 * time.Millisecond
+	defaultDNSCacheTTL   = time.Minute
+)
+
+// IPFamily is preference of ip address family for connections
+type IPFamily int
+
+const (
+	// IPAny uses addresses in resolver order
+	IPAny IPFamily = iota
+	// PreferIPv4 tries ipv4 addresses first, falling back to ipv6
+	PreferIPv4
+	// PreferIPv6 tries ipv6 addresses first, falling back to ipv4
+	PreferIPv6
+	// OnlyIPv4 uses only ipv4 addresses
+	OnlyIPv4
+	// OnlyIPv6 uses only ipv6 addresses
+	OnlyIPv6
+)
+
+// errNoAddress is returned when host has no addresses of allowed family
+var errNoAddress = errors.New("no suitable address")
+
+// DialConfig configures connections of http client
+type DialConfig struct {
+	// LocalAddr is address to dial from, any if nil
+	LocalAddr net.Addr
+	Family    IPFamily
+	// FallbackDelay is delay before addresses of other family are
+	// tried in parallel (happy eyeballs), 300ms if zero, fallback
+	// is sequential if negative
+	FallbackDelay time.Duration
+	// DNSCache caches resolved addresses, not cached if nil
+	DNSCache *DNSCache
+}
+
+// WithDial sets http client with default timeouts, that
+// makes connections according to config
+func WithDial(config DialConfig) Option {
+	return WithHTTPClient(newHTTPClient(config))
+}
+
+// dialer dials addresses resolved according to DialConfig
+type dialer struct {
+	config DialConfig
+	net    *net.Dialer
+}
+
+func (d dialer) lookup(ctx context.Context, host string) ([]net.IP, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return []net.IP{ip}, nil
+	}
+	if d.config.DNSCache != nil {
+		return d.config.DNSCache.LookupIP(ctx, host)
+	}
+	return lookupIP(ctx, net.DefaultResolver, host)
+}
+
+func lookupIP(ctx context.Context, r *net.Resolver, host string) ([]net.IP, error) {
+	addrs, err := r.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	ips := make([]net.IP, len(addrs))
+	for i, addr := range addrs {
+		ips[i] = addr.IP
+	}
+	return ips, nil
+}
+
+// splitFamily returns addresses of first family to try and fallback ones
+func splitFamily(ips []net.IP, family IPFamily) (primary, fallback []net.IP) {
+	isPrimary := func(ip net.IP) bool {
+		v4 := ip.To4() != nil
+		switch family {
+		case PreferIPv4, OnlyIPv4:
+			return v4
+		case PreferIPv6, OnlyIPv6:
+			return !v4
+		}
+		// resolver order, family of first address is primary
+		return v4 == (ips[0].To4() != nil)
+	}
+	for _, ip := range ips {
+		if isPrimary(ip) {
+			primary = append(primary, ip)
+		} else if family != OnlyIPv4 && family != OnlyIPv6 {
+			fallback = append(fallback, ip)
+		}
+	}
+	if len(primary) == 0 {
+		return fallback, nil
+	}
+	return primary, fallback
+}
+
+// dialSerial dials ips one by one, returning first connection
+func (d dialer) dialSerial(ctx context.Context, network, port string, ips []net.IP) (net.Conn, error) {
+	err := errNoAddress
+	for _, ip := range ips {
+		var conn net.Conn
+		conn, err = d.net.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+	return nil, err
+}
+
+func (d dialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	ips, err := d.lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	primary, fallback := splitFamily(ips, d.config.Family)
+	delay := d.config.FallbackDelay
+	if delay == 0 {
+		delay = defaultFallbackDelay
+	}
+	if len(fallback) == 0 || delay < 0 {
+		return d.dialSerial(ctx, network, port, append(primary, fallback...))
+	}
+	return d.dialParallel(ctx, network, port, primary, fallback, delay)
+}
+
+// dialParallel dials primary addresses, starting fallback ones after
+// delay or primary failure, and returns first connection
+func (d dialer) dialParallel(ctx context.Context, network, port string, primary, fallback []net.IP, delay time.Duration) (net.Conn, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type result struct {
+		conn net.Conn
+		err  error
+	}
+	results := make(chan result, 2)
+	race := func(ips []net.IP) {
+		conn, err := d.dialSerial(ctx, network, port, ips)
+		results <- result{conn, err}
+	}
+	go race(primary)
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	started, failed := false, 0
+	var firstErr error
+	for {
+		select {
+		case <-timer.C:
+			if !started {
+				started = true
+				go race(fallback)
+			}
+		case r := <-results:
+			if r.err == nil {
+				if started && failed == 0 {
+					// close connection of loser
+					go func() {
+						if r := <-results; r.conn != nil {
+							r.conn.Close()
+						}
+					}()
+				}
+				return r.conn, nil
+			}
+			failed++
+			if firstErr == nil {
+				firstErr = r.err
+			}
+			if !started {
+				started = true
+				go race(fallback)
+			} else if failed == 2 {
+				return nil, firstErr
+			}
+		}
+	}
+}
+
+type dnsEntry struct {
+	ips     []net.IP
+	expires time.Time
+}
+
+// DNSCache is in-process cache of resolved addresses
+type DNSCache struct {
+	// TTL of entries, one minute if zero
+	TTL time.Duration
+	// Resolver is net.DefaultResolver if nil
+	Resolver *net.Resolver
+	// Clock is SystemClock if nil
+	Clock Clock
+
+	mux     sync.Mutex
+	entries map[string]dnsEntry
+	// lookup resolves host, it is replaced in tests
+	lookup func(ctx context.Context, host string) ([]net.IP, error)
+}
+
+// LookupIP returns cached addresses of host, resolving
+// it if there is no entry or it is expired
+func (c *DNSCache) LookupIP(ctx context.Context, host string) ([]net.IP, error) {
+	now := clockOrSystem(c.Clock).Now()
+	c.mux.Lock()
+	e, ok := c.entries[host]
+	c.mux.Unlock()
+	if ok && now.Before(e.expires) {
+		return e.ips, nil
+	}
+	lookup := c.lookup
+	if lookup == nil {
+		r := c.Resolver
+		if r == nil {
+			r = net.DefaultResolver
+		}
+		lookup = func(ctx context.Context, host string) ([]net.IP, error) {
+			return lookupIP(ctx, r, host)
+		}
+	}
+	ips, err := lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	ttl := c.TTL
+	if ttl <= 0 {
+		ttl = defaultDNSCacheTTL
+	}
+	c.mux.Lock()
+	if c.entries == nil {
+		c.entries = make(map[string]dnsEntry)
+	}
+	c.entries[host] = dnsEntry{ips: ips, expires: now.Add(ttl)}
+	c.mux.Unlock()
+	return ips, nil
+}
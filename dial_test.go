@@ -0,0 +1,75 @@
+package vk
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDial(t *testing.T) {
+	Convey("Dial", t, func() {
+		v4, v6 := net.ParseIP("127.0.0.1"), net.ParseIP("::1")
+		Convey("Family", func() {
+			ips := []net.IP{v6, v4}
+			primary, fallback := splitFamily(ips, IPAny)
+			So(primary, ShouldResemble, []net.IP{v6})
+			So(fallback, ShouldResemble, []net.IP{v4})
+			primary, fallback = splitFamily(ips, PreferIPv4)
+			So(primary, ShouldResemble, []net.IP{v4})
+			So(fallback, ShouldResemble, []net.IP{v6})
+			primary, fallback = splitFamily(ips, OnlyIPv6)
+			So(primary, ShouldResemble, []net.IP{v6})
+			So(fallback, ShouldBeEmpty)
+			primary, fallback = splitFamily([]net.IP{v4}, PreferIPv6)
+			So(primary, ShouldResemble, []net.IP{v4})
+			So(fallback, ShouldBeEmpty)
+			primary, _ = splitFamily([]net.IP{v4}, OnlyIPv6)
+			So(primary, ShouldBeEmpty)
+		})
+		Convey("DNS cache", func() {
+			clock := NewFakeClock(time.Unix(1000, 0))
+			lookups := 0
+			cache := &DNSCache{TTL: time.Second, Clock: clock}
+			cache.lookup = func(ctx context.Context, host string) ([]net.IP, error) {
+				lookups++
+				return []net.IP{v6, v4}, nil
+			}
+			ctx := context.Background()
+			for i := 0; i < 3; i++ {
+				ips, err := cache.LookupIP(ctx, "api.vk.com")
+				So(err, ShouldBeNil)
+				So(ips, ShouldHaveLength, 2)
+			}
+			So(lookups, ShouldEqual, 1)
+			clock.Advance(time.Second)
+			_, err := cache.LookupIP(ctx, "api.vk.com")
+			So(err, ShouldBeNil)
+			So(lookups, ShouldEqual, 2)
+
+			Convey("Client", func() {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+				defer server.Close()
+				_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+				for _, config := range []DialConfig{
+					// ipv6 is tried first and fails, as server listens only ipv4
+					{Family: PreferIPv6, DNSCache: cache, FallbackDelay: time.Second},
+					{Family: PreferIPv6, DNSCache: cache, FallbackDelay: time.Nanosecond},
+					{Family: PreferIPv6, DNSCache: cache, FallbackDelay: -1},
+				} {
+					httpClient := newHTTPClient(config)
+					res, err := httpClient.Get("http://api.test:" + port)
+					So(err, ShouldBeNil)
+					res.Body.Close()
+				}
+				httpClient := newHTTPClient(DialConfig{Family: OnlyIPv6, DNSCache: cache})
+				_, err = httpClient.Get("http://api.test:" + port)
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
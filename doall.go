@@ -0,0 +1,46 @@
+package vk
+
+import (
+	"context"
+	"sync"
+)
+
+// maxDoAllConcurrency is count of requests of DoAll in flight,
+// throughput is still bounded by limiter of client
+const maxDoAllConcurrency = maxRequestsPerSecond
+
+// DoAll performs requests concurrently with ctx, storing response of
+// every request to responses with same index, and returns errors of
+// requests in order, nil for succeeded ones. Responses must be of
+// same length as requests.
+func (c *Client) DoAll(ctx context.Context, requests []Request, responses []Response) []error {
+	if len(responses) != len(requests) {
+		panic("vk: DoAll responses and requests length mismatch")
+	}
+	errs := make([]error, len(requests))
+	n := maxDoAllConcurrency
+	if n > len(requests) {
+		n = len(requests)
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				res, err := c.DoContext(ctx, requests[i])
+				if res != nil {
+					responses[i] = *res
+				}
+				errs[i] = err
+			}
+		}()
+	}
+	for i := range requests {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return errs
+}
@@ -0,0 +1,60 @@
+package vk
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDoAll(t *testing.T) {
+	Convey("DoAll", t, func() {
+		client := New()
+		var inFlight, maxInFlight int32
+		client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			n := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				m := atomic.LoadInt32(&maxInFlight)
+				if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+					break
+				}
+			}
+			id := req.URL.Query().Get("id")
+			if id == "3" {
+				return jsonResponse(http.StatusOK, `{"error": {"error_code": 15}}`), nil
+			}
+			return jsonResponse(http.StatusOK, `{"response": `+id+`}`), nil
+		}))
+		var requests []Request
+		for i := 0; i < 10; i++ {
+			requests = append(requests, Request{Method: "users.get", Values: map[string][]string{"id": {int64s(int64(i))}}})
+		}
+		responses := make([]Response, len(requests))
+		errs := client.DoAll(context.Background(), requests, responses)
+		So(errs, ShouldHaveLength, len(requests))
+		for i, err := range errs {
+			if i == 3 {
+				So(IsServerError(err), ShouldBeTrue)
+				So(GetServerError(err).Code, ShouldEqual, ErrNotAllowed)
+				continue
+			}
+			So(err, ShouldBeNil)
+			So(responses[i].Response.String(), ShouldEqual, int64s(int64(i)))
+		}
+		So(atomic.LoadInt32(&maxInFlight), ShouldBeLessThanOrEqualTo, maxDoAllConcurrency)
+
+		Convey("Canceled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			client.SetLimiter(NewLimiter(1))
+			errs := client.DoAll(ctx, requests[:2], make([]Response, 2))
+			So(errs, ShouldResemble, []error{context.Canceled, context.Canceled})
+		})
+		Convey("Mismatch", func() {
+			So(func() { client.DoAll(context.Background(), requests, nil) }, ShouldPanic)
+		})
+	})
+}
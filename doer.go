@@ -0,0 +1,12 @@
+package vk
+
+import "context"
+
+// Doer performs api requests, it is implemented by *Client and by
+// vktest.Fake, so code can depend on it instead of Client
+type Doer interface {
+	APIClient
+	DoContext(ctx context.Context, request Request) (*Response, error)
+}
+
+var _ Doer = (*Client)(nil)
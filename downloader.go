@@ -0,0 +1,260 @@
+package vk
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	// DownloadManifest is name of manifest file in download directory
+	DownloadManifest = "manifest.jsonl"
+
+	defaultDownloadConcurrency = 4
+)
+
+// DownloadFile is file of attachment to download
+type DownloadFile struct {
+	// Name is deterministic file name, like photo1_2.jpg
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	Size int64  `json:"size,omitempty"`
+}
+
+// AttachmentFile returns file of photo, doc or audio message
+// attachment, ok is false for other types
+func AttachmentFile(a Attachment) (f DownloadFile, ok bool) {
+	switch {
+	case a.Photo != nil:
+		size, ok := a.Photo.Largest()
+		if !ok {
+			return f, false
+		}
+		return DownloadFile{Name: a.String() + extension(size.URL, "jpg"), URL: size.URL}, true
+	case a.Doc != nil:
+		ext := "." + a.Doc.Ext
+		if len(a.Doc.Ext) == 0 {
+			ext = ""
+		}
+		return DownloadFile{Name: a.String() + ext, URL: a.Doc.URL}, true
+	case a.AudioMessage != nil:
+		if len(a.AudioMessage.LinkOGG) != 0 {
+			return DownloadFile{Name: a.String() + ".ogg", URL: a.AudioMessage.LinkOGG}, true
+		}
+		return DownloadFile{Name: a.String() + ".mp3", URL: a.AudioMessage.LinkMP3}, true
+	}
+	return f, false
+}
+
+// extension returns extension of file from url path or def
+func extension(rawURL, def string) string {
+	p := rawURL
+	if i := strings.IndexAny(p, "?#"); i >= 0 {
+		p = p[:i]
+	}
+	if ext := path.Ext(p); len(ext) > 1 && len(ext) <= 5 {
+		return ext
+	}
+	return "." + def
+}
+
+// MessageFiles returns files of attachments of messages,
+// including forwarded and replied messages
+func MessageFiles(messages ...Message) ([]DownloadFile, error) {
+	var files []DownloadFile
+	for _, m := range messages {
+		f, err := attachmentFiles(m.Attachments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f...)
+		nested := m.FwdMessages
+		if m.ReplyMessage != nil {
+			nested = append(nested, *m.ReplyMessage)
+		}
+		if f, err = MessageFiles(nested...); err != nil {
+			return nil, err
+		}
+		files = append(files, f...)
+	}
+	return files, nil
+}
+
+// PostFiles returns files of attachments of posts, including reposted
+func PostFiles(posts ...Post) ([]DownloadFile, error) {
+	var files []DownloadFile
+	for _, p := range posts {
+		f, err := attachmentFiles(p.Attachments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f...)
+		if f, err = PostFiles(p.CopyHistory...); err != nil {
+			return nil, err
+		}
+		files = append(files, f...)
+	}
+	return files, nil
+}
+
+func attachmentFiles(attachments []Raw) ([]DownloadFile, error) {
+	var files []DownloadFile
+	for _, raw := range attachments {
+		a, err := ParseAttachment(raw)
+		if err != nil {
+			return nil, err
+		}
+		if f, ok := AttachmentFile(a); ok {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// Downloader downloads files concurrently to directory. Downloaded
+// files are recorded in manifest, so interrupted download is resumed.
+type Downloader struct {
+	Dir string
+	// HTTPClient is default client if nil
+	HTTPClient HTTPClient
+	// Limiter limits rate of downloads if set
+	Limiter     Limiter
+	Concurrency int
+}
+
+// readManifest returns names of downloaded files
+func (d Downloader) readManifest() (map[string]bool, error) {
+	done := make(map[string]bool)
+	f, err := os.Open(filepath.Join(d.Dir, DownloadManifest))
+	if os.IsNotExist(err) {
+		return done, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var file DownloadFile
+		if json.Unmarshal(scanner.Bytes(), &file) != nil {
+			// incomplete line of interrupted write
+			continue
+		}
+		done[file.Name] = true
+	}
+	return done, scanner.Err()
+}
+
+// Download downloads files that are not in manifest and
+// returns count of downloaded files
+func (d Downloader) Download(ctx context.Context, files []DownloadFile) (int, error) {
+	if err := os.MkdirAll(d.Dir, 0755); err != nil {
+		return 0, err
+	}
+	done, err := d.readManifest()
+	if err != nil {
+		return 0, err
+	}
+	manifest, err := os.OpenFile(filepath.Join(d.Dir, DownloadManifest), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return 0, err
+	}
+	defer manifest.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	queue := make(chan DownloadFile)
+	go func() {
+		defer close(queue)
+		for _, f := range files {
+			if done[f.Name] {
+				continue
+			}
+			done[f.Name] = true
+			select {
+			case queue <- f:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	n := d.Concurrency
+	if n <= 0 {
+		n = defaultDownloadConcurrency
+	}
+	var (
+		wg         sync.WaitGroup
+		mux        sync.Mutex
+		downloaded int
+		first      error
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for f := range queue {
+				err := d.download(ctx, &f)
+				mux.Lock()
+				if err == nil {
+					err = json.NewEncoder(manifest).Encode(f)
+				}
+				if err == nil {
+					downloaded++
+				} else if first == nil {
+					first = err
+					cancel()
+				}
+				mux.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return downloaded, first
+}
+
+// download writes file to temporary file and renames it on success
+func (d Downloader) download(ctx context.Context, f *DownloadFile) error {
+	if d.Limiter != nil {
+		if err := d.Limiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	httpClient := d.HTTPClient
+	if httpClient == nil {
+		httpClient = defaultHTTPClient
+	}
+	req, err := http.NewRequest(http.MethodGet, f.URL, nil)
+	if err != nil {
+		return err
+	}
+	res, err := httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return newHTTPError(res)
+	}
+	tmp, err := ioutil.TempFile(d.Dir, "."+f.Name+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	f.Size, err = io.Copy(tmp, res.Body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(d.Dir, f.Name))
+}
@@ -0,0 +1,91 @@
+package vk
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDownloader(t *testing.T) {
+	Convey("Downloader", t, func() {
+		messages := []Message{{
+			Attachments: []Raw{
+				Raw(`{"type": "photo", "photo": {"id": 2, "owner_id": 1, "sizes": [
+					{"type": "s", "url": "https://img/s.jpg", "width": 75, "height": 50},
+					{"type": "w", "url": "https://img/w.png?size=big", "width": 1280, "height": 960}
+				]}}`),
+				Raw(`{"type": "link", "link": {"url": "https://example.com"}}`),
+			},
+			FwdMessages: []Message{{Attachments: []Raw{
+				Raw(`{"type": "audio_message", "audio_message": {"id": 3, "owner_id": 1, "link_ogg": "https://audio/3.ogg"}}`),
+			}}},
+			ReplyMessage: &Message{Attachments: []Raw{
+				Raw(`{"type": "doc", "doc": {"id": 4, "owner_id": -5, "ext": "pdf", "url": "https://doc/4"}}`),
+			}},
+		}}
+		files, err := MessageFiles(messages...)
+		So(err, ShouldBeNil)
+		So(files, ShouldResemble, []DownloadFile{
+			{Name: "photo1_2.png", URL: "https://img/w.png?size=big"},
+			{Name: "audio_message1_3.ogg", URL: "https://audio/3.ogg"},
+			{Name: "doc-5_4.pdf", URL: "https://doc/4"},
+		})
+		posts, err := PostFiles(Post{CopyHistory: []Post{{Attachments: messages[0].ReplyMessage.Attachments}}})
+		So(err, ShouldBeNil)
+		So(posts, ShouldHaveLength, 1)
+
+		dir, err := ioutil.TempDir("", "vk-download")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		var (
+			mux       sync.Mutex
+			requested []string
+		)
+		d := Downloader{Dir: dir, HTTPClient: httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			mux.Lock()
+			requested = append(requested, req.URL.String())
+			mux.Unlock()
+			if req.URL.Host == "doc" {
+				return jsonResponse(http.StatusNotFound, "not found"), nil
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewBufferString("data " + req.URL.Path))}, nil
+		})}
+		ctx := context.Background()
+		n, err := d.Download(ctx, files)
+		So(err, ShouldNotBeNil)
+		So(err.(HTTPError).Status, ShouldEqual, http.StatusNotFound)
+		data, err := ioutil.ReadFile(filepath.Join(dir, "photo1_2.png"))
+		So(err, ShouldBeNil)
+		So(string(data), ShouldEqual, "data /w.png")
+		So(n, ShouldBeBetweenOrEqual, 0, 2)
+
+		Convey("Resume", func() {
+			requested = nil
+			files[2].URL = "https://img/4.pdf"
+			_, err := d.Download(ctx, files)
+			So(err, ShouldBeNil)
+			So(requested, ShouldContain, "https://img/4.pdf")
+			manifest, err := ioutil.ReadFile(filepath.Join(dir, DownloadManifest))
+			So(err, ShouldBeNil)
+			So(strings.Count(string(manifest), "\n"), ShouldEqual, 3)
+
+			Convey("Nothing left", func() {
+				requested = nil
+				n, err := d.Download(ctx, files)
+				So(err, ShouldBeNil)
+				So(n, ShouldEqual, 0)
+				So(requested, ShouldBeEmpty)
+				entries, _ := ioutil.ReadDir(dir)
+				So(entries, ShouldHaveLength, 4)
+			})
+		})
+	})
+}
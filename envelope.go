@@ -0,0 +1,193 @@
+package vk
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+)
+
+// bufferPool holds buffers for reading response bodies
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+// DecodeResponse decodes response envelope from data into response.
+//
+// Responses without errors are decoded by scanning data without
+// intermediate allocations, reusing buffer of response.Response, so
+// no allocations are made when response is reused and its buffer
+// is large enough. Raw value is validated only by Response.To.
+// Responses with errors or extra fields are decoded by encoding/json.
+func DecodeResponse(data []byte, response *Response) error {
+	raw, ok := scanEnvelope(data)
+	if !ok {
+		return decodeEnvelope(data, response)
+	}
+	response.Errors = nil
+	response.Error = Error{}
+	if raw == nil {
+		response.Response = nil
+	} else {
+		response.Response = append(response.Response[:0], raw...)
+	}
+	return nil
+}
+
+// decodeEnvelope is slow path of DecodeResponse
+func decodeEnvelope(data []byte, response *Response) (err error) {
+	defer recoverDecode(&err, data)
+	*response = Response{}
+	var fields map[string]Raw
+	if err = json.NewDecoder(bytes.NewReader(data)).Decode(&fields); err != nil {
+		return newDecodeError(err, nil)
+	}
+	for k, v := range fields {
+		switch k {
+		case "response":
+			response.Response = v
+		case "error":
+			err = json.Unmarshal(v, &response.Error)
+		case "execute_errors":
+			err = json.Unmarshal(v, &response.Errors)
+		default:
+			if response.Extra == nil {
+				response.Extra = make(map[string]Raw)
+			}
+			response.Extra[k] = v
+		}
+		if err != nil {
+			return newDecodeError(err, v)
+		}
+	}
+	return response.ServerError()
+}
+
+// scanEnvelope returns value of response key from json object in
+// data, ok is false if data has keys other than response or is not
+// valid object
+func scanEnvelope(data []byte) (raw []byte, ok bool) {
+	i := skipSpace(data, 0)
+	if i >= len(data) || data[i] != '{' {
+		return nil, false
+	}
+	i = skipSpace(data, i+1)
+	if i < len(data) && data[i] == '}' {
+		return nil, true
+	}
+	for {
+		if i >= len(data) || data[i] != '"' {
+			return nil, false
+		}
+		end := skipString(data, i)
+		if end < 0 {
+			return nil, false
+		}
+		key := data[i+1 : end-1]
+		i = skipSpace(data, end)
+		if i >= len(data) || data[i] != ':' {
+			return nil, false
+		}
+		i = skipSpace(data, i+1)
+		end = skipValue(data, i)
+		if end < 0 {
+			return nil, false
+		}
+		if string(key) != "response" {
+			// errors and extra fields are left to encoding/json
+			return nil, false
+		}
+		raw = data[i:end]
+		i = skipSpace(data, end)
+		if i >= len(data) {
+			return nil, false
+		}
+		switch data[i] {
+		case ',':
+			i = skipSpace(data, i+1)
+		case '}':
+			return raw, true
+		default:
+			return nil, false
+		}
+	}
+}
+
+func skipSpace(data []byte, i int) int {
+	for i < len(data) {
+		switch data[i] {
+		case ' ', '\t', '\r', '\n':
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// skipString returns index after string that starts at i or -1
+func skipString(data []byte, i int) int {
+	for i++; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// skipValue returns index after json value that starts at i or -1,
+// nested values of objects and arrays are not validated
+func skipValue(data []byte, i int) int {
+	if i >= len(data) {
+		return -1
+	}
+	switch c := data[i]; {
+	case c == '"':
+		return skipString(data, i)
+	case c == '{' || c == '[':
+		depth := 0
+		for ; i < len(data); i++ {
+			switch data[i] {
+			case '"':
+				end := skipString(data, i)
+				if end < 0 {
+					return -1
+				}
+				i = end - 1
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return i + 1
+				}
+			}
+		}
+		return -1
+	case c == 't':
+		return skipLiteral(data, i, "true")
+	case c == 'f':
+		return skipLiteral(data, i, "false")
+	case c == 'n':
+		return skipLiteral(data, i, "null")
+	case c == '-' || (c >= '0' && c <= '9'):
+		for i++; i < len(data); i++ {
+			switch c := data[i]; {
+			case c >= '0' && c <= '9', c == '.', c == 'e', c == 'E', c == '+', c == '-':
+			default:
+				return i
+			}
+		}
+		return i
+	}
+	return -1
+}
+
+func skipLiteral(data []byte, i int, literal string) int {
+	if len(data)-i < len(literal) || string(data[i:i+len(literal)]) != literal {
+		return -1
+	}
+	return i + len(literal)
+}
@@ -0,0 +1,102 @@
+package vk
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const benchEnvelope = `{"response": {"ts": "1", "updates": [{"type": "message_new", "object": {"text": "}\"]"}}]}}`
+
+func TestDecodeResponse(t *testing.T) {
+	Convey("Decode response", t, func() {
+		Convey("Fast path", func() {
+			for body, raw := range map[string]string{
+				benchEnvelope:           `{"ts": "1", "updates": [{"type": "message_new", "object": {"text": "}\"]"}}]}`,
+				` { "response":1 } `:    `1`,
+				`{"response": -1.5e+3}`: `-1.5e+3`,
+				`{"response": "a\"b"}`:  `"a\"b"`,
+				`{"response": null}`:    `null`,
+				`{"response": true}`:    `true`,
+				`{}`:                    ``,
+			} {
+				_, ok := scanEnvelope([]byte(body))
+				So(ok, ShouldBeTrue)
+				res := new(Response)
+				So(DecodeResponse([]byte(body), res), ShouldBeNil)
+				So(res.Response.String(), ShouldEqual, raw)
+			}
+		})
+		Convey("Slow path", func() {
+			for _, body := range []string{
+				`{"error": {"error_code": 5}}`,
+				`{"response": 1, "execute_errors": [{"method": "a", "error_code": 5}]}`,
+				`{"response": tru}`,
+				`{"response": [1}`,
+				`{"response" 1}`,
+				`[1]`,
+				``,
+			} {
+				_, ok := scanEnvelope([]byte(body))
+				So(ok, ShouldBeFalse)
+				So(DecodeResponse([]byte(body), new(Response)), ShouldNotBeNil)
+			}
+			res := &Response{Response: Raw("1")}
+			So(DecodeResponse([]byte(`{"error": {"error_code": 5}}`), res), ShouldResemble, res.Error)
+			So(res.Response, ShouldBeNil)
+			So(res.Error.Code, ShouldEqual, ErrAuthFailed)
+		})
+		Convey("Extra fields", func() {
+			res := new(Response)
+			So(DecodeResponse([]byte(` { "other" : [1, {}], "response":1 } `), res), ShouldBeNil)
+			So(res.Response.String(), ShouldEqual, "1")
+			So(res.Extra["other"].String(), ShouldEqual, "[1, {}]")
+			err := DecodeResponse([]byte(`{"response": [1, false], "execute_errors": [{"method": "wall.get", "error_code": 15}]}`), res)
+			So(err, ShouldHaveSameTypeAs, Errors{})
+			So(res.Extra, ShouldBeNil)
+			So(res.Errors[0].Code, ShouldEqual, ErrNotAllowed)
+			So(res.Response.String(), ShouldEqual, "[1, false]")
+		})
+		Convey("Reuse", func() {
+			res := new(Response)
+			So(DecodeResponse([]byte(`{"error": {"error_code": 5}}`), res), ShouldNotBeNil)
+			So(DecodeResponse([]byte(`{"response": 2}`), res), ShouldBeNil)
+			So(res.ServerError(), ShouldBeNil)
+			So(res.Response.String(), ShouldEqual, "2")
+		})
+		Convey("Allocations", func() {
+			data := []byte(benchEnvelope)
+			res := new(Response)
+			allocs := testing.AllocsPerRun(100, func() {
+				if err := DecodeResponse(data, res); err != nil {
+					panic(err)
+				}
+			})
+			So(allocs, ShouldEqual, 0)
+		})
+	})
+}
+
+func BenchmarkDecodeResponse(b *testing.B) {
+	data := []byte(benchEnvelope)
+	res := new(Response)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		if err := DecodeResponse(data, res); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeResponseJSON(b *testing.B) {
+	data := []byte(benchEnvelope)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		res := new(Response)
+		if err := decodeEnvelope(data, res); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
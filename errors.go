@@ -1,9 +1,16 @@
 package vk
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
 )
 
+// maxHTTPErrorBody is limit of response body kept in HTTPError
+const maxHTTPErrorBody = 512
+
 //go:generate stringer -type=ServerError
 type ServerError int
 
@@ -48,9 +55,38 @@ func (e ServerError) Is(err error) bool {
 	if another, ok := err.(Error); ok {
 		return another.Code == e
 	}
+	if _, ok := err.(HTTPError); ok {
+		return e == ErrBadResponseCode
+	}
 	return false
 }
 
+// HTTPError is returned on unexpected http status of response,
+// it matches ErrBadResponseCode with errors.Is
+type HTTPError struct {
+	Status int
+	// Body is beginning of response body, like html error page
+	Body []byte
+}
+
+func (e HTTPError) Error() string {
+	if len(e.Body) == 0 {
+		return fmt.Sprintf("http %d %s", e.Status, http.StatusText(e.Status))
+	}
+	return fmt.Sprintf("http %d %s: %s", e.Status, http.StatusText(e.Status), e.Body)
+}
+
+// Is returns true for ErrBadResponseCode
+func (e HTTPError) Is(err error) bool {
+	return err == ErrBadResponseCode
+}
+
+// newHTTPError returns HTTPError with status and beginning of body of res
+func newHTTPError(res *http.Response) HTTPError {
+	body, _ := ioutil.ReadAll(io.LimitReader(res.Body, maxHTTPErrorBody))
+	return HTTPError{Status: res.StatusCode, Body: body}
+}
+
 func IsServerError(err error) bool {
 	if _, ok := err.(Error); ok {
 		return true
@@ -76,32 +112,36 @@ func (s ServerError) Error() string {
 const (
 	// Possible error codes
 	// https://vk.com/dev/errors
-	ErrZero ServerError = iota
-	ErrUnknown
-	ErrApplicationDisabled
-	ErrUnknownMethod
-	ErrInvalidSignature
-	ErrAuthFailed
-	ErrTooManyRequests
-	ErrInsufficientPermissions
-	ErrInvalidRequest
-	ErrTooManyOneTypeRequests
-	ErrInternalServerError
-	ErrAppInTestMode
-	ErrCaptchaNeeded
-	ErrNotAllowed
-	ErrHttpsOnly
-	ErrNeedValidation
-	ErrStandaloneOnly
-	ErrStandaloneOpenAPIOnly
-	ErrMethodDisabled
-	ErrNeedConfirmation
+	ErrZero                      ServerError = 0
+	ErrUnknown                   ServerError = 1
+	ErrApplicationDisabled       ServerError = 2
+	ErrUnknownMethod             ServerError = 3
+	ErrInvalidSignature          ServerError = 4
+	ErrAuthFailed                ServerError = 5
+	ErrTooManyRequests           ServerError = 6
+	ErrInsufficientPermissions   ServerError = 7
+	ErrInvalidRequest            ServerError = 8
+	ErrTooManyOneTypeRequests    ServerError = 9
+	ErrInternalServerError       ServerError = 10
+	ErrAppInTestMode             ServerError = 11
+	ErrCaptchaNeeded             ServerError = 14
+	ErrNotAllowed                ServerError = 15
+	ErrHttpsOnly                 ServerError = 16
+	ErrNeedValidation            ServerError = 17
+	ErrUserDeleted               ServerError = 18
+	ErrStandaloneOnly            ServerError = 20
+	ErrStandaloneOpenAPIOnly     ServerError = 21
+	ErrMethodDisabled            ServerError = 23
+	ErrNeedConfirmation          ServerError = 24
+	ErrRateLimit                 ServerError = 29
+	ErrPrivateProfile            ServerError = 30
 	ErrOneOfParametersInvalid    ServerError = 100
 	ErrInvalidAPIID              ServerError = 101
 	ErrInvalidAUserID            ServerError = 113
 	ErrInvalidTimestamp          ServerError = 150
 	ErrAlbumAccessProhibited     ServerError = 200
 	ErrGroupAccessProhibited     ServerError = 203
+	ErrPostAddDenied             ServerError = 214
 	ErrAlbumOverflow             ServerError = 300
 	ErrMoneyTransferNotAllowed   ServerError = 500
 	ErrInsufficientPermissionsAd ServerError = 600
@@ -109,3 +149,139 @@ const (
 
 	ErrBadResponseCode ServerError = -1
 )
+
+// ErrorCategory is class of api error that defines how it should be handled
+type ErrorCategory int
+
+const (
+	// CategoryUnknown is category of errors that are not classified
+	CategoryUnknown ErrorCategory = iota
+	// CategoryTemporary errors can be retried later
+	CategoryTemporary
+	// CategoryPermanent errors will be returned for same request again
+	CategoryPermanent
+	// CategoryAuth errors require new or wider token
+	CategoryAuth
+	// CategoryCaptcha errors require captcha to be solved
+	CategoryCaptcha
+	// CategoryValidation errors require user validation or confirmation
+	CategoryValidation
+)
+
+var errorCategoryNames = [...]string{"unknown", "temporary", "permanent", "auth", "captcha", "validation"}
+
+func (c ErrorCategory) String() string {
+	if c < 0 || int(c) >= len(errorCategoryNames) {
+		return errorCategoryNames[CategoryUnknown]
+	}
+	return errorCategoryNames[c]
+}
+
+var errorCategories = map[ServerError]ErrorCategory{
+	ErrBadResponseCode:           CategoryTemporary,
+	ErrUnknown:                   CategoryTemporary,
+	ErrTooManyRequests:           CategoryTemporary,
+	ErrTooManyOneTypeRequests:    CategoryTemporary,
+	ErrInternalServerError:       CategoryTemporary,
+	ErrRateLimit:                 CategoryTemporary,
+	ErrInternalServerErrorAd:     CategoryTemporary,
+	ErrApplicationDisabled:       CategoryPermanent,
+	ErrUnknownMethod:             CategoryPermanent,
+	ErrInvalidSignature:          CategoryPermanent,
+	ErrInvalidRequest:            CategoryPermanent,
+	ErrAppInTestMode:             CategoryPermanent,
+	ErrNotAllowed:                CategoryPermanent,
+	ErrHttpsOnly:                 CategoryPermanent,
+	ErrUserDeleted:               CategoryPermanent,
+	ErrStandaloneOnly:            CategoryPermanent,
+	ErrStandaloneOpenAPIOnly:     CategoryPermanent,
+	ErrMethodDisabled:            CategoryPermanent,
+	ErrPrivateProfile:            CategoryPermanent,
+	ErrOneOfParametersInvalid:    CategoryPermanent,
+	ErrInvalidAPIID:              CategoryPermanent,
+	ErrInvalidAUserID:            CategoryPermanent,
+	ErrInvalidTimestamp:          CategoryPermanent,
+	ErrAlbumAccessProhibited:     CategoryPermanent,
+	ErrGroupAccessProhibited:     CategoryPermanent,
+	ErrPostAddDenied:             CategoryPermanent,
+	ErrAlbumOverflow:             CategoryPermanent,
+	ErrMoneyTransferNotAllowed:   CategoryPermanent,
+	ErrAuthFailed:                CategoryAuth,
+	ErrInsufficientPermissions:   CategoryAuth,
+	ErrInsufficientPermissionsAd: CategoryAuth,
+	ErrCaptchaNeeded:             CategoryCaptcha,
+	ErrNeedValidation:            CategoryValidation,
+	ErrNeedConfirmation:          CategoryValidation,
+}
+
+// Category returns category of error code
+func (e ServerError) Category() ErrorCategory {
+	return errorCategories[e]
+}
+
+// Temporary reports whether request can succeed if retried later
+func (e ServerError) Temporary() bool {
+	return e.Category() == CategoryTemporary
+}
+
+// Permanent reports whether request will fail again if retried
+func (e ServerError) Permanent() bool {
+	return e.Category() == CategoryPermanent
+}
+
+// Auth reports whether token is invalid or has not enough permissions
+func (e ServerError) Auth() bool {
+	return e.Category() == CategoryAuth
+}
+
+// Captcha reports whether captcha must be solved to continue
+func (e ServerError) Captcha() bool {
+	return e.Category() == CategoryCaptcha
+}
+
+// Validation reports whether user must validate or confirm action
+func (e ServerError) Validation() bool {
+	return e.Category() == CategoryValidation
+}
+
+// Category returns category of error code
+func (e Error) Category() ErrorCategory {
+	return e.Code.Category()
+}
+
+// Temporary reports whether request can succeed if retried later
+func (e Error) Temporary() bool {
+	return e.Code.Temporary()
+}
+
+// Category returns CategoryTemporary for server errors and too
+// many requests and CategoryPermanent for other statuses
+func (e HTTPError) Category() ErrorCategory {
+	if e.Status >= http.StatusInternalServerError || e.Status == http.StatusTooManyRequests {
+		return CategoryTemporary
+	}
+	return CategoryPermanent
+}
+
+// CategoryOf returns category of err, that is or wraps ServerError,
+// Error or HTTPError. DecodeError is temporary, as malformed response
+// is usually returned by overloaded server or proxy.
+func CategoryOf(err error) ErrorCategory {
+	var apiErr Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Category()
+	}
+	var code ServerError
+	if errors.As(err, &code) {
+		return code.Category()
+	}
+	var httpErr HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Category()
+	}
+	var decodeErr DecodeError
+	if errors.As(err, &decodeErr) {
+		return CategoryTemporary
+	}
+	return CategoryUnknown
+}
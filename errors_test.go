@@ -2,7 +2,12 @@ package vk
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -24,6 +29,45 @@ func TestErrors(t *testing.T) {
 			So(ErrZero.Is(Error{Code: ErrAlbumOverflow}), ShouldBeFalse)
 			So(ErrZero.Is(error(Error{Code: ErrAlbumOverflow})), ShouldBeFalse)
 		})
+		Convey("Codes", func() {
+			So(int(ErrCaptchaNeeded), ShouldEqual, 14)
+			So(int(ErrNotAllowed), ShouldEqual, 15)
+			So(int(ErrNeedValidation), ShouldEqual, 17)
+			So(ErrNeedConfirmation.String(), ShouldEqual, "ErrNeedConfirmation")
+			So(ServerError(12).String(), ShouldEqual, "ServerError(12)")
+		})
+		Convey("Categories", func() {
+			So(ErrTooManyRequests.Temporary(), ShouldBeTrue)
+			So(ErrInternalServerError.Temporary(), ShouldBeTrue)
+			So(ErrAuthFailed.Temporary(), ShouldBeFalse)
+			So(ErrAuthFailed.Auth(), ShouldBeTrue)
+			So(ErrNotAllowed.Permanent(), ShouldBeTrue)
+			So(ErrCaptchaNeeded.Captcha(), ShouldBeTrue)
+			So(ErrNeedValidation.Validation(), ShouldBeTrue)
+			So(ServerError(12).Category(), ShouldEqual, CategoryUnknown)
+			So(Error{Code: ErrRateLimit}.Temporary(), ShouldBeTrue)
+			So(CategoryOf(Error{Code: ErrNeedConfirmation}), ShouldEqual, CategoryValidation)
+			So(CategoryOf(ErrAlbumOverflow), ShouldEqual, CategoryPermanent)
+			So(CategoryOf(io.EOF), ShouldEqual, CategoryUnknown)
+			So(CategoryOf(fmt.Errorf("call: %w", Error{Code: ErrAuthFailed})), ShouldEqual, CategoryAuth)
+			So(CategoryOf(fmt.Errorf("call: %w", ErrRateLimit)), ShouldEqual, CategoryTemporary)
+			So(CategoryOf(HTTPError{Status: http.StatusBadGateway}), ShouldEqual, CategoryTemporary)
+			So(CategoryOf(fmt.Errorf("call: %w", HTTPError{Status: http.StatusNotFound})), ShouldEqual, CategoryPermanent)
+			So(CategoryOf(DecodeError{Err: io.ErrUnexpectedEOF}), ShouldEqual, CategoryTemporary)
+			So(CategoryAuth.String(), ShouldEqual, "auth")
+			So(ErrorCategory(42).String(), ShouldEqual, "unknown")
+		})
+		Convey("HTTP", func() {
+			body := strings.NewReader("<html>" + strings.Repeat("x", 1024))
+			err := newHTTPError(&http.Response{StatusCode: http.StatusBadGateway, Body: ioutil.NopCloser(body)})
+			So(err.Status, ShouldEqual, http.StatusBadGateway)
+			So(len(err.Body), ShouldEqual, maxHTTPErrorBody)
+			So(string(err.Body[:6]), ShouldEqual, "<html>")
+			So(errors.Is(err, ErrBadResponseCode), ShouldBeTrue)
+			So(ErrBadResponseCode.Is(err), ShouldBeTrue)
+			So(errors.Is(err, ErrAuthFailed), ShouldBeFalse)
+			So(HTTPError{Status: http.StatusNotFound}.Error(), ShouldEqual, "http 404 Not Found")
+		})
 		Convey("Set and get request", func() {
 			e := Error{}
 			e.setRequest(Request{Method: "test"})
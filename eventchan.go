@@ -0,0 +1,249 @@
+package vk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+const defaultEventChannelSize = 100
+
+// ErrNoSpillStorage is returned by EventChannel with OverflowSpill and nil Storage
+var ErrNoSpillStorage = errors.New("event channel: spill policy requires storage")
+
+// OverflowPolicy is behaviour of EventChannel when buffer is full
+type OverflowPolicy int
+
+const (
+	// OverflowBlock blocks producer until consumer receives event
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest drops oldest buffered event
+	OverflowDropOldest
+	// OverflowSpill writes events to Storage until consumer catches up
+	OverflowSpill
+)
+
+// EventChannel is buffered channel of events between producer, like
+// long poll or callback handler, and slow consumer. It is Publisher
+// and its HandleEvent is EventHandler, so it can be used with Bridge
+// or Callback. Order of events is kept with every policy.
+type EventChannel struct {
+	// Size of in-memory buffer, 100 if zero
+	Size   int
+	Policy OverflowPolicy
+	// Storage and Prefix keep spilled events with OverflowSpill,
+	// events spilled before restart are received first
+	Storage Storage
+	Prefix  string
+	// OnDrop is called for every dropped event with OverflowDropOldest
+	OnDrop func(event Event)
+
+	dropped int64
+
+	mux        sync.Mutex
+	queue      []Event
+	spillHead  int64
+	spillTail  int64
+	notEmpty   chan struct{}
+	notFull    chan struct{}
+	initialize sync.Once
+	initErr    error
+}
+
+// init creates channels and loads spill indices, that are
+// persisted so spilled events survive restart
+func (c *EventChannel) init() error {
+	c.initialize.Do(func() {
+		c.notEmpty = make(chan struct{}, 1)
+		c.notFull = make(chan struct{}, 1)
+		if c.Policy != OverflowSpill {
+			return
+		}
+		if c.Storage == nil {
+			c.initErr = ErrNoSpillStorage
+			return
+		}
+		if c.spillHead, c.initErr = c.loadIndex(spillHeadKey); c.initErr != nil {
+			return
+		}
+		c.spillTail, c.initErr = c.loadIndex(spillTailKey)
+	})
+	return c.initErr
+}
+
+func (c *EventChannel) size() int {
+	if c.Size <= 0 {
+		return defaultEventChannelSize
+	}
+	return c.Size
+}
+
+func notify(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// Dropped returns count of dropped events
+func (c *EventChannel) Dropped() int64 {
+	return atomic.LoadInt64(&c.dropped)
+}
+
+// Len returns count of buffered and spilled events
+func (c *EventChannel) Len() int {
+	c.init()
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return len(c.queue) + int(c.spillTail-c.spillHead)
+}
+
+const (
+	spillHeadKey = "head"
+	spillTailKey = "tail"
+)
+
+func (c *EventChannel) spillKey(n int64) string {
+	return c.Prefix + strconv.FormatInt(n, 10)
+}
+
+func (c *EventChannel) loadIndex(name string) (int64, error) {
+	data, err := c.Storage.Get(c.Prefix + name)
+	if err == ErrKeyNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(string(data), 10, 64)
+}
+
+func (c *EventChannel) saveIndex(name string, n int64) error {
+	return c.Storage.Set(c.Prefix+name, []byte(strconv.FormatInt(n, 10)), 0)
+}
+
+// HandleEvent is EventHandler that sends event
+func (c *EventChannel) HandleEvent(event Event) error {
+	return c.Publish(context.Background(), event)
+}
+
+// Publish sends event to channel according to Policy
+func (c *EventChannel) Publish(ctx context.Context, event Event) error {
+	if err := c.init(); err != nil {
+		return err
+	}
+	for {
+		c.mux.Lock()
+		spilled := c.spillTail != c.spillHead
+		if !spilled && len(c.queue) < c.size() {
+			c.queue = append(c.queue, event)
+			c.mux.Unlock()
+			notify(c.notEmpty)
+			return nil
+		}
+		switch c.Policy {
+		case OverflowDropOldest:
+			dropped := c.queue[0]
+			c.queue = append(c.queue[1:], event)
+			c.mux.Unlock()
+			atomic.AddInt64(&c.dropped, 1)
+			if c.OnDrop != nil {
+				c.OnDrop(dropped)
+			}
+			notify(c.notEmpty)
+			return nil
+		case OverflowSpill:
+			err := c.spill(event)
+			c.mux.Unlock()
+			if err == nil {
+				notify(c.notEmpty)
+			}
+			return err
+		}
+		c.mux.Unlock()
+		select {
+		case <-c.notFull:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// spill writes event to storage, mux is held
+func (c *EventChannel) spill(event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if err = c.Storage.Set(c.spillKey(c.spillTail), data, 0); err != nil {
+		return err
+	}
+	if err = c.saveIndex(spillTailKey, c.spillTail+1); err != nil {
+		return err
+	}
+	c.spillTail++
+	return nil
+}
+
+// refill moves spilled events to buffer, mux is held
+func (c *EventChannel) refill() error {
+	for c.spillHead != c.spillTail && len(c.queue) < c.size() {
+		key := c.spillKey(c.spillHead)
+		data, err := c.Storage.Get(key)
+		if err != nil {
+			return err
+		}
+		var event Event
+		if err = json.Unmarshal(data, &event); err != nil {
+			return err
+		}
+		// head is saved before delete, so it never points to deleted event
+		if err = c.saveIndex(spillHeadKey, c.spillHead+1); err != nil {
+			return err
+		}
+		c.queue = append(c.queue, event)
+		c.spillHead++
+		if err = c.Storage.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Receive returns next event, blocking until it is available or ctx is done
+func (c *EventChannel) Receive(ctx context.Context) (Event, error) {
+	if err := c.init(); err != nil {
+		return Event{}, err
+	}
+	for {
+		c.mux.Lock()
+		if len(c.queue) == 0 {
+			if err := c.refill(); err != nil {
+				c.mux.Unlock()
+				return Event{}, err
+			}
+		}
+		if len(c.queue) != 0 {
+			event := c.queue[0]
+			c.queue = c.queue[1:]
+			// failed refill is retried when buffer is empty
+			c.refill()
+			more := len(c.queue) != 0
+			c.mux.Unlock()
+			notify(c.notFull)
+			if more {
+				notify(c.notEmpty)
+			}
+			return event, nil
+		}
+		c.mux.Unlock()
+		select {
+		case <-c.notEmpty:
+		case <-ctx.Done():
+			return Event{}, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package vk
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func receiveIDs(c *EventChannel, n int) []string {
+	ctx := context.Background()
+	var ids []string
+	for i := 0; i < n; i++ {
+		e, err := c.Receive(ctx)
+		So(err, ShouldBeNil)
+		ids = append(ids, e.EventID)
+	}
+	return ids
+}
+
+func TestEventChannel(t *testing.T) {
+	Convey("Event channel", t, func() {
+		ctx := context.Background()
+		Convey("Block", func() {
+			c := &EventChannel{Size: 1}
+			So(c.HandleEvent(Event{EventID: "1"}), ShouldBeNil)
+			timeout, cancel := context.WithTimeout(ctx, time.Millisecond)
+			defer cancel()
+			So(errors.Is(c.Publish(timeout, Event{EventID: "2"}), context.DeadlineExceeded), ShouldBeTrue)
+
+			done := make(chan error)
+			go func() { done <- c.Publish(ctx, Event{EventID: "2"}) }()
+			So(receiveIDs(c, 1), ShouldResemble, []string{"1"})
+			So(<-done, ShouldBeNil)
+			So(receiveIDs(c, 1), ShouldResemble, []string{"2"})
+
+			timeout, cancel = context.WithTimeout(ctx, time.Millisecond)
+			defer cancel()
+			_, err := c.Receive(timeout)
+			So(errors.Is(err, context.DeadlineExceeded), ShouldBeTrue)
+		})
+		Convey("Drop oldest", func() {
+			var dropped []string
+			c := &EventChannel{Size: 2, Policy: OverflowDropOldest, OnDrop: func(e Event) {
+				dropped = append(dropped, e.EventID)
+			}}
+			for _, id := range []string{"1", "2", "3", "4"} {
+				So(c.HandleEvent(Event{EventID: id}), ShouldBeNil)
+			}
+			So(c.Dropped(), ShouldEqual, 2)
+			So(dropped, ShouldResemble, []string{"1", "2"})
+			So(receiveIDs(c, 2), ShouldResemble, []string{"3", "4"})
+		})
+		Convey("Spill", func() {
+			storage := &MemoryStorage{}
+			c := &EventChannel{Size: 2, Policy: OverflowSpill, Storage: storage, Prefix: "events:"}
+			for _, id := range []string{"1", "2", "3", "4", "5"} {
+				So(c.HandleEvent(Event{EventID: id, Object: Raw(`{}`)}), ShouldBeNil)
+			}
+			So(c.Len(), ShouldEqual, 5)
+			_, err := storage.Get("events:0")
+			So(err, ShouldBeNil)
+			So(receiveIDs(c, 2), ShouldResemble, []string{"1", "2"})
+			So(c.HandleEvent(Event{EventID: "6", Object: Raw(`{}`)}), ShouldBeNil)
+			So(receiveIDs(c, 4), ShouldResemble, []string{"3", "4", "5", "6"})
+			So(c.Len(), ShouldEqual, 0)
+			_, err = storage.Get("events:0")
+			So(err, ShouldEqual, ErrKeyNotFound)
+
+			Convey("Restart", func() {
+				for _, id := range []string{"7", "8", "9", "10"} {
+					So(c.HandleEvent(Event{EventID: id, Object: Raw(`{}`)}), ShouldBeNil)
+				}
+				So(receiveIDs(c, 1), ShouldResemble, []string{"7"})
+				// buffered events are lost, spilled ones are kept
+				restarted := &EventChannel{Size: 2, Policy: OverflowSpill, Storage: storage, Prefix: "events:"}
+				So(restarted.HandleEvent(Event{EventID: "11", Object: Raw(`{}`)}), ShouldBeNil)
+				So(restarted.Len(), ShouldEqual, 2)
+				So(receiveIDs(restarted, 2), ShouldResemble, []string{"10", "11"})
+			})
+		})
+		Convey("Spill without storage", func() {
+			c := &EventChannel{Policy: OverflowSpill}
+			So(c.HandleEvent(Event{EventID: "1"}), ShouldEqual, ErrNoSpillStorage)
+			_, err := c.Receive(ctx)
+			So(err, ShouldEqual, ErrNoSpillStorage)
+		})
+		Convey("Bridge", func() {
+			c := &EventChannel{}
+			b := &Bridge{Publisher: c}
+			So(b.HandleEvent(Event{EventID: "1"}), ShouldBeNil)
+			So(receiveIDs(c, 1), ShouldResemble, []string{"1"})
+		})
+	})
+}
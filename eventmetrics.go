@@ -0,0 +1,135 @@
+package vk
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// EventMetric is measurement of handled event
+type EventMetric struct {
+	Type    string
+	GroupID ID
+	// Lag is time from event date to start of handling,
+	// zero if HasLag is false
+	Lag    time.Duration
+	HasLag bool
+	// Duration of handler call
+	Duration time.Duration
+	Err      error
+}
+
+// EventObserver receives metrics of handled events
+type EventObserver interface {
+	ObserveEvent(m EventMetric)
+}
+
+// EventObserverFunc is function that implements EventObserver
+type EventObserverFunc func(m EventMetric)
+
+// ObserveEvent calls f
+func (f EventObserverFunc) ObserveEvent(m EventMetric) {
+	f(m)
+}
+
+// eventDate is date of event object or of message in it
+type eventDate struct {
+	Date    Time `json:"date"`
+	Message *struct {
+		Date Time `json:"date"`
+	} `json:"message"`
+}
+
+// EventTime returns date of event object, like message or post
+// date, and false if object has no date
+func EventTime(e Event) (time.Time, bool) {
+	var d eventDate
+	if len(e.Object) == 0 || json.Unmarshal(e.Object, &d) != nil {
+		return time.Time{}, false
+	}
+	if d.Message != nil && !d.Message.Date.IsZero() {
+		return d.Message.Date.Time, true
+	}
+	return d.Date.Time, !d.Date.IsZero()
+}
+
+// EventMetrics measures lag and duration of Handler calls
+// and reports them to Observer
+type EventMetrics struct {
+	Handler  EventHandler
+	Observer EventObserver
+	// Clock is SystemClock if nil
+	Clock Clock
+}
+
+// HandleEvent calls Handler and observes result
+func (e EventMetrics) HandleEvent(event Event) error {
+	clock := clockOrSystem(e.Clock)
+	start := clock.Now()
+	m := EventMetric{Type: event.Type, GroupID: event.GroupID}
+	if date, ok := EventTime(event); ok {
+		m.Lag, m.HasLag = start.Sub(date), true
+	}
+	m.Err = e.Handler(event)
+	m.Duration = clock.Now().Sub(start)
+	e.Observer.ObserveEvent(m)
+	return m.Err
+}
+
+// EventTypeStats is counters and lag gauge of event type
+type EventTypeStats struct {
+	Count  int64 `json:"count"`
+	Errors int64 `json:"errors"`
+	// Lag is lag of last event with date
+	Lag    time.Duration `json:"lag"`
+	MaxLag time.Duration `json:"max_lag"`
+	// Duration is total duration of handler calls
+	Duration time.Duration `json:"duration"`
+}
+
+// EventStats is EventObserver that keeps counters per event type,
+// it can be published with expvar as it implements expvar.Var
+type EventStats struct {
+	mux   sync.Mutex
+	types map[string]EventTypeStats
+}
+
+// ObserveEvent updates counters of event type
+func (s *EventStats) ObserveEvent(m EventMetric) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.types == nil {
+		s.types = make(map[string]EventTypeStats)
+	}
+	t := s.types[m.Type]
+	t.Count++
+	if m.Err != nil {
+		t.Errors++
+	}
+	if m.HasLag {
+		t.Lag = m.Lag
+		if m.Lag > t.MaxLag {
+			t.MaxLag = m.Lag
+		}
+	}
+	t.Duration += m.Duration
+	s.types[m.Type] = t
+}
+
+// Snapshot returns copy of counters by event type
+func (s *EventStats) Snapshot() map[string]EventTypeStats {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	snapshot := make(map[string]EventTypeStats, len(s.types))
+	for k, v := range s.types {
+		snapshot[k] = v
+	}
+	return snapshot
+}
+
+// String returns counters as JSON
+func (s *EventStats) String() string {
+	data, err := json.Marshal(s.Snapshot())
+	must(err)
+	return string(data)
+}
@@ -0,0 +1,43 @@
+package vk
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestEventMetrics(t *testing.T) {
+	Convey("Event metrics", t, func() {
+		clock := NewFakeClock(time.Unix(1000, 0))
+		stats := &EventStats{}
+		m := EventMetrics{Clock: clock, Observer: stats, Handler: func(e Event) error {
+			clock.Advance(time.Second)
+			if e.Type == "fail" {
+				return ErrUnknown
+			}
+			return nil
+		}}
+		So(m.HandleEvent(Event{Type: "message_new", Object: Raw(`{"message": {"date": 990}}`)}), ShouldBeNil)
+		So(m.HandleEvent(Event{Type: "wall_post_new", Object: Raw(`{"date": 1000}`)}), ShouldBeNil)
+		So(m.HandleEvent(Event{Type: "message_new", Object: Raw(`{"message": {"date": 1001}}`)}), ShouldBeNil)
+		So(m.HandleEvent(Event{Type: "fail", Object: Raw(`{}`)}), ShouldEqual, ErrUnknown)
+
+		So(stats.Snapshot(), ShouldResemble, map[string]EventTypeStats{
+			"message_new":   {Count: 2, Lag: time.Second, MaxLag: 10 * time.Second, Duration: 2 * time.Second},
+			"wall_post_new": {Count: 1, Lag: time.Second, MaxLag: time.Second, Duration: time.Second},
+			"fail":          {Count: 1, Errors: 1, Duration: time.Second},
+		})
+		So(stats.String(), ShouldContainSubstring, `"fail":{"count":1,"errors":1,"lag":0,"max_lag":0,"duration":1000000000}`)
+
+		Convey("Event time", func() {
+			_, ok := EventTime(Event{Object: Raw(`{"id": 1}`)})
+			So(ok, ShouldBeFalse)
+			_, ok = EventTime(Event{})
+			So(ok, ShouldBeFalse)
+			date, ok := EventTime(Event{Object: Raw(`{"date": 5}`)})
+			So(ok, ShouldBeTrue)
+			So(date.Unix(), ShouldEqual, 5)
+		})
+	})
+}
@@ -0,0 +1,98 @@
+package vk
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+)
+
+// Exporter dumps whole conversation history into JSON Lines, one
+// message as returned by api per line, from oldest to newest
+type Exporter struct {
+	Messages Messages
+	PeerID   ID
+	// GroupID is set for community conversations
+	GroupID ID
+	// OnMessage is called for every exported message after
+	// it is written, e.g. to download attachments
+	OnMessage func(m Message) error
+}
+
+// Export writes messages starting from offset in chronological
+// order to w and returns count of written messages
+func (e Exporter) Export(w io.Writer, offset int) (written int, err error) {
+	h := e.Messages.History(MessagesGetHistoryFields{
+		PeerID:  e.PeerID,
+		GroupID: e.GroupID,
+		Offset:  offset,
+		Rev:     true,
+	})
+	line := new(bytes.Buffer)
+	for h.Next() {
+		line.Reset()
+		if err = json.Compact(line, h.Raw()); err != nil {
+			return written, err
+		}
+		line.WriteByte('\n')
+		if _, err = w.Write(line.Bytes()); err != nil {
+			return written, err
+		}
+		written++
+		if e.OnMessage == nil {
+			continue
+		}
+		m, err := h.Message()
+		if err != nil {
+			return written, err
+		}
+		if err = e.OnMessage(m); err != nil {
+			return written, err
+		}
+	}
+	return written, h.Err()
+}
+
+// ExportFile exports history to file, resuming previous export:
+// messages already in file are skipped and incomplete last line
+// left by interrupted export is removed
+func (e Exporter) ExportFile(name string) (written int, err error) {
+	f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	lines, size, err := completeLines(f)
+	if err != nil {
+		return 0, err
+	}
+	if err = f.Truncate(size); err != nil {
+		return 0, err
+	}
+	if _, err = f.Seek(size, io.SeekStart); err != nil {
+		return 0, err
+	}
+	w := bufio.NewWriter(f)
+	written, err = e.Export(w, lines)
+	if flushErr := w.Flush(); err == nil {
+		err = flushErr
+	}
+	return written, err
+}
+
+// completeLines returns count and total size of newline-terminated lines of r
+func completeLines(r io.Reader) (lines int, size int64, err error) {
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if err == io.EOF {
+			return lines, size, nil
+		}
+		if err != nil {
+			return lines, size, err
+		}
+		lines++
+		size += int64(len(line))
+	}
+}
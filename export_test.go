@@ -0,0 +1,77 @@
+package vk
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestExporter(t *testing.T) {
+	Convey("Exporter", t, func() {
+		client := New()
+		const total = 5
+		var offsets []string
+		client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			So(req.URL.Path, ShouldEqual, "/method/messages.getHistory")
+			So(q.Get("rev"), ShouldEqual, "1")
+			So(q.Get("peer_id"), ShouldEqual, "2000000001")
+			offsets = append(offsets, q.Get("offset"))
+			offset, _ := strconv.Atoi(q.Get("offset"))
+			var items []string
+			for i := offset; i < total && i < offset+2; i++ {
+				id := strconv.Itoa(i + 1)
+				items = append(items, `{"id": `+id+`, "text": "m`+id+`", "reactions": [{"reaction_id": 1}]}`)
+			}
+			return jsonResponse(http.StatusOK, `{"response": {"count": 5, "items": [`+strings.Join(items, ",")+`]}}`), nil
+		}))
+		e := Exporter{Messages: client.Messages, PeerID: 2000000001}
+		Convey("Writer", func() {
+			var ids []ID
+			e.OnMessage = func(m Message) error {
+				ids = append(ids, m.ID)
+				return nil
+			}
+			buf := new(bytes.Buffer)
+			n, err := e.Export(buf, 0)
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 5)
+			So(ids, ShouldResemble, []ID{1, 2, 3, 4, 5})
+			So(offsets, ShouldResemble, []string{"", "2", "4"})
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			So(lines, ShouldHaveLength, 5)
+			So(lines[0], ShouldEqual, `{"id":1,"text":"m1","reactions":[{"reaction_id":1}]}`)
+		})
+		Convey("Resume", func() {
+			dir, err := ioutil.TempDir("", "vk-export")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(dir)
+			name := filepath.Join(dir, "peer.jsonl")
+			So(ioutil.WriteFile(name, []byte("{\"id\":1}\n{\"id\":2}\n{\"id\":3,\"te"), 0644), ShouldBeNil)
+			n, err := e.ExportFile(name)
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 3)
+			So(offsets, ShouldResemble, []string{"2", "4"})
+			data, err := ioutil.ReadFile(name)
+			So(err, ShouldBeNil)
+			lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+			So(lines, ShouldHaveLength, 5)
+			So(lines[2], ShouldStartWith, `{"id":3,"text":"m3"`)
+
+			Convey("Complete", func() {
+				offsets = nil
+				n, err := e.ExportFile(name)
+				So(err, ShouldBeNil)
+				So(n, ShouldEqual, 0)
+				So(offsets, ShouldResemble, []string{"5"})
+			})
+		})
+	})
+}
@@ -0,0 +1,139 @@
+package vk
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Field is value of fields parameter of users and groups methods
+type Field string
+
+// User and community fields
+const (
+	FieldID                     Field = "id"
+	FieldFirstName              Field = "first_name"
+	FieldLastName               Field = "last_name"
+	FieldPhotoID                Field = "photo_id"
+	FieldVerified               Field = "verified"
+	FieldSex                    Field = "sex"
+	FieldBirthday               Field = "bdate"
+	FieldCity                   Field = "city"
+	FieldCountry                Field = "country"
+	FieldHomeTown               Field = "home_town"
+	FieldHasPhoto               Field = "has_photo"
+	FieldPhoto50                Field = "photo_50"
+	FieldPhoto100               Field = "photo_100"
+	FieldPhoto200               Field = "photo_200"
+	FieldPhoto200Orig           Field = "photo_200_orig"
+	FieldPhoto400Orig           Field = "photo_400_orig"
+	FieldPhotoMax               Field = "photo_max"
+	FieldPhotoMaxOrig           Field = "photo_max_orig"
+	FieldOnline                 Field = "online"
+	FieldDomain                 Field = "domain"
+	FieldHasMobile              Field = "has_mobile"
+	FieldContacts               Field = "contacts"
+	FieldSite                   Field = "site"
+	FieldEducation              Field = "education"
+	FieldUniversities           Field = "universities"
+	FieldSchools                Field = "schools"
+	FieldStatus                 Field = "status"
+	FieldLastSeen               Field = "last_seen"
+	FieldFollowersCount         Field = "followers_count"
+	FieldCommonCount            Field = "common_count"
+	FieldOccupation             Field = "occupation"
+	FieldNickname               Field = "nickname"
+	FieldRelatives              Field = "relatives"
+	FieldRelation               Field = "relation"
+	FieldPersonal               Field = "personal"
+	FieldConnections            Field = "connections"
+	FieldExports                Field = "exports"
+	FieldActivities             Field = "activities"
+	FieldInterests              Field = "interests"
+	FieldMusic                  Field = "music"
+	FieldMovies                 Field = "movies"
+	FieldTV                     Field = "tv"
+	FieldBooks                  Field = "books"
+	FieldGames                  Field = "games"
+	FieldAbout                  Field = "about"
+	FieldQuotes                 Field = "quotes"
+	FieldCanPost                Field = "can_post"
+	FieldCanSeeAllPosts         Field = "can_see_all_posts"
+	FieldCanWritePrivateMessage Field = "can_write_private_message"
+	FieldCanSendFriendRequest   Field = "can_send_friend_request"
+	FieldIsFavorite             Field = "is_favorite"
+	FieldIsHiddenFromFeed       Field = "is_hidden_from_feed"
+	FieldTimezone               Field = "timezone"
+	FieldScreenName             Field = "screen_name"
+	FieldMaidenName             Field = "maiden_name"
+	FieldCropPhoto              Field = "crop_photo"
+	FieldIsFriend               Field = "is_friend"
+	FieldFriendStatus           Field = "friend_status"
+	FieldCareer                 Field = "career"
+	FieldMilitary               Field = "military"
+	FieldBlacklisted            Field = "blacklisted"
+	FieldBlacklistedByMe        Field = "blacklisted_by_me"
+	FieldCounters               Field = "counters"
+
+	FieldActivity          Field = "activity"
+	FieldBanInfo           Field = "ban_info"
+	FieldCover             Field = "cover"
+	FieldDescription       Field = "description"
+	FieldFixedPost         Field = "fixed_post"
+	FieldIsMessagesBlocked Field = "is_messages_blocked"
+	FieldLinks             Field = "links"
+	FieldMainAlbumID       Field = "main_album_id"
+	FieldMainSection       Field = "main_section"
+	FieldMarket            Field = "market"
+	FieldMemberStatus      Field = "member_status"
+	FieldMembersCount      Field = "members_count"
+	FieldPlace             Field = "place"
+	FieldPublicDateLabel   Field = "public_date_label"
+	FieldStartDate         Field = "start_date"
+	FieldFinishDate        Field = "finish_date"
+	FieldTrending          Field = "trending"
+	FieldWall              Field = "wall"
+	FieldWikiPage          Field = "wiki_page"
+)
+
+// Fields is set of fields that is encoded as comma-separated list
+type Fields []Field
+
+// NewFields returns Fields from provided fields
+func NewFields(fields ...Field) Fields {
+	return Fields(nil).Add(fields...)
+}
+
+// Has returns true if f contains field
+func (f Fields) Has(field Field) bool {
+	for _, v := range f {
+		if v == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Add returns copy of fields with provided ones appended, skipping
+// duplicates, so f is never modified and can be shared
+func (f Fields) Add(fields ...Field) Fields {
+	f = append(Fields(nil), f...)
+	for _, field := range fields {
+		if !f.Has(field) {
+			f = append(f, field)
+		}
+	}
+	return f
+}
+
+func (f Fields) String() string {
+	s := make([]string, len(f))
+	for i, field := range f {
+		s[i] = string(field)
+	}
+	return strings.Join(s, ",")
+}
+
+func (f Fields) EncodeValues(key string, v *url.Values) error {
+	v.Add(key, f.String())
+	return nil
+}
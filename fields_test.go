@@ -0,0 +1,40 @@
+package vk
+
+import (
+	"net/url"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFields(t *testing.T) {
+	Convey("Fields", t, func() {
+		f := NewFields(FieldPhoto200, FieldLastSeen, FieldPhoto200)
+		So(f.String(), ShouldEqual, "photo_200,last_seen")
+		So(f.Has(FieldLastSeen), ShouldBeTrue)
+		So(f.Has(FieldCity), ShouldBeFalse)
+		f = f.Add(FieldCity, FieldCounters, FieldLastSeen)
+		So(f.String(), ShouldEqual, "photo_200,last_seen,city,counters")
+		So(Fields{}.String(), ShouldBeBlank)
+		Convey("Shared base", func() {
+			base := make(Fields, 0, 4).Add(FieldSex)
+			a := base.Add(FieldCity)
+			b := base.Add(FieldCounters)
+			So(a.String(), ShouldEqual, "sex,city")
+			So(b.String(), ShouldEqual, "sex,counters")
+			So(base.String(), ShouldEqual, "sex")
+		})
+		Convey("Values", func() {
+			type args struct {
+				Fields Fields `url:"fields,omitempty"`
+			}
+			r := DefaultFactory.Request("users.get", args{f})
+			So(r.Values.Get("fields"), ShouldEqual, "photo_200,last_seen,city,counters")
+			r = DefaultFactory.Request("users.get", args{})
+			So(r.Values, ShouldNotContainKey, "fields")
+			v := &url.Values{}
+			So(NewFields(FieldSex).EncodeValues("fields", v), ShouldBeNil)
+			So(v.Get("fields"), ShouldEqual, "sex")
+		})
+	})
+}
@@ -0,0 +1,43 @@
+package vk
+
+const (
+	methodFriendsGetOnline = "friends.getOnline"
+	methodFriendsGet       = "friends.get"
+
+	maxFriendsCount = 5000
+)
+
+// Friends resource
+type Friends struct {
+	Resource
+}
+
+type FriendsGetOnlineFields struct {
+	UserID ID     `url:"user_id,omitempty"`
+	ListID int    `url:"list_id,omitempty"`
+	Order  string `url:"order,omitempty"`
+	Count  int    `url:"count,omitempty"`
+	Offset int    `url:"offset,omitempty"`
+}
+
+// GetOnline returns ids of friends that are online
+func (f Friends) GetOnline(fields FriendsGetOnlineFields) (result []ID, err error) {
+	return result, f.Decode(f.Request(methodFriendsGetOnline, fields), &result)
+}
+
+type FriendsGetFields struct {
+	UserID ID     `url:"user_id,omitempty"`
+	Order  string `url:"order,omitempty"`
+	Count  int    `url:"count,omitempty"`
+	Offset int    `url:"offset,omitempty"`
+}
+
+type FriendsGetResult struct {
+	Count int  `json:"count"`
+	Items []ID `json:"items"`
+}
+
+// Get returns ids of friends of user, current user if id is not set
+func (f Friends) Get(fields FriendsGetFields) (result FriendsGetResult, err error) {
+	return result, f.Decode(f.Request(methodFriendsGet, fields), &result)
+}
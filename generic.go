@@ -0,0 +1,23 @@
+package vk
+
+import "context"
+
+// Do performs request and returns decoded response payload:
+//
+//	users, err := vk.Do[[]vk.User](client, request)
+func Do[T any](client APIClient, request Request) (result T, err error) {
+	res, err := client.Do(request)
+	if err != nil {
+		return result, err
+	}
+	return result, res.To(&result)
+}
+
+// DoContext is Do with ctx
+func DoContext[T any](ctx context.Context, client *Client, request Request) (result T, err error) {
+	res, err := client.DoContext(ctx, request)
+	if err != nil {
+		return result, err
+	}
+	return result, res.To(&result)
+}
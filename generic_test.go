@@ -0,0 +1,28 @@
+package vk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGenericDo(t *testing.T) {
+	Convey("Generic Do", t, func() {
+		client := New(WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/method/users.get" {
+				return jsonResponse(http.StatusOK, `{"response": [{"id": 1, "first_name": "Pavel"}]}`), nil
+			}
+			return jsonResponse(http.StatusOK, `{"error": {"error_code": 5, "error_msg": "auth"}}`), nil
+		})))
+		users, err := Do[[]User](client, Request{Method: MethodUsersGet})
+		So(err, ShouldBeNil)
+		So(users[0].FirstName, ShouldEqual, "Pavel")
+		n, err := DoContext[int](context.Background(), client, Request{Method: MethodMessagesSend})
+		So(ErrAuthFailed.Is(err), ShouldBeTrue)
+		So(n, ShouldEqual, 0)
+		_, err = Do[int](client, Request{Method: MethodUsersGet})
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -1,9 +1,14 @@
 module github.com/ernado-legacy/vk
 
-go 1.13
+go 1.18
 
 require (
 	github.com/google/go-querystring v1.0.0
 	github.com/smartystreets/goconvey v1.6.4
 	github.com/spf13/viper v1.6.3
 )
+
+require (
+	github.com/jtolds/gls v4.20.0+incompatible // indirect
+	github.com/smartystreets/assertions v0.0.0-20180927180507-b2de0cb4f26d // indirect
+)
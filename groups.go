@@ -7,6 +7,7 @@ import "text/template"
 type Resource struct {
 	APIClient
 	RequestFactory
+	Uploader Uploader
 }
 
 func (r Resource) Decode(request Request, v interface{}) error {
@@ -51,8 +52,8 @@ const (
 )
 
 type Group struct {
-	ID           int                    `json:"id"`
-	Name         string                 `json:"name`
+	ID           ID                     `json:"id"`
+	Name         string                 `json:"name"`
 	Slug         string                 `json:"screen_name"`
 	Deactivated  GroupDeactivatedStatus `json:"deactivated"`
 	IsClosed     GroupType              `json:"is_closed"`
@@ -66,6 +67,7 @@ type Group struct {
 	Description  string                 `json:"description"`
 	MembersCount int                    `json:"members_count"`
 	Status       string                 `json:"status"`
+	Counters     *GroupCounters         `json:"counters,omitempty"`
 }
 
 func (g Group) GetStatus() string {
@@ -90,7 +92,7 @@ type GroupSearchResult struct {
 
 type groupSearchResponse struct {
 	Error    `json:"error"`
-	Response GroupSearchResult `json:"response`
+	Response GroupSearchResult `json:"response"`
 }
 
 func (g Groups) GetMembers(q GroupSearchFields) (result GroupSearchResult, err error) {
@@ -117,7 +119,7 @@ func (g Groups) GetForUser(id int) ([]Group, error) {
 	request := g.Request(methodGroupsGet, GroupGetFields{UserID: id,
 		Count:    1000,
 		Extended: true,
-		Fields:   "description,members_count",
+		Fields:   NewFields(FieldDescription, FieldMembersCount).String(),
 	})
 	return result.Items, g.Decode(request, &result)
 }
@@ -24,6 +24,48 @@ func (api apiJSONMock) Do(req Request) (res *Response, err error) {
 	return res, json.NewDecoder(bytes.NewBufferString(api.response)).Decode(res)
 }
 
+// apiMethodMock responds with queued responses for each method,
+// the last response of method is repeated
+type apiMethodMock struct {
+	responses map[string][]string
+	requests  []Request
+}
+
+func (api *apiMethodMock) Do(req Request) (*Response, error) {
+	api.requests = append(api.requests, req)
+	queue := api.responses[req.Method]
+	if len(queue) == 0 {
+		return nil, ErrUnknownMethod
+	}
+	if len(queue) > 1 {
+		api.responses[req.Method] = queue[1:]
+	}
+	res := new(Response)
+	if err := json.NewDecoder(bytes.NewBufferString(queue[0])).Decode(res); err != nil {
+		return nil, err
+	}
+	return res, res.ServerError()
+}
+
+func (api *apiMethodMock) methods() (methods []string) {
+	for _, r := range api.requests {
+		methods = append(methods, r.Method)
+	}
+	return methods
+}
+
+// apiFuncMock is APIClient mock from function
+type apiFuncMock func(req Request) (*Response, error)
+
+func (f apiFuncMock) Do(req Request) (*Response, error) {
+	return f(req)
+}
+
+// apiResponse decodes response envelope as real client does
+func apiResponse(data string) (*Response, error) {
+	return Process(bytes.NewBufferString(data))
+}
+
 type recordFactory struct {
 	request Request
 }
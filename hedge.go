@@ -0,0 +1,151 @@
+package vk
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultHedgePercentile = 0.95
+	defaultHedgeMinDelay   = 500 * time.Millisecond
+	hedgeSamples           = 128
+	hedgeMinSamples        = 16
+)
+
+// readPrefixes are prefixes of names of read-only methods
+var readPrefixes = []string{"get", "search", "is", "check", "resolve"}
+
+// ReadMethod reports whether method only reads data, like users.get,
+// so it can be safely repeated
+func ReadMethod(method string) bool {
+	i := strings.LastIndexByte(method, '.')
+	if i < 0 {
+		return false
+	}
+	name := method[i+1:]
+	for _, p := range readPrefixes {
+		if strings.HasPrefix(name, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Hedging makes second attempt of slow requests to read methods if
+// first one did not answer within percentile of observed latencies,
+// taking whichever finishes first and canceling the other one
+type Hedging struct {
+	// Percentile of latency that is threshold of slow call, 0.95 if zero
+	Percentile float64
+	// MinDelay is minimum threshold, that is used until enough
+	// latencies are observed, 500ms if zero
+	MinDelay time.Duration
+	// Methods reports whether method can be hedged, ReadMethod if nil
+	Methods func(method string) bool
+
+	mux     sync.Mutex
+	samples []time.Duration
+	next    int
+	hedged  int64
+}
+
+// WithHedging enables hedging of requests
+func WithHedging(h *Hedging) Option {
+	return func(c *Client) {
+		c.hedging = h
+	}
+}
+
+func (h *Hedging) allowed(method string) bool {
+	if h.Methods == nil {
+		return ReadMethod(method)
+	}
+	return h.Methods(method)
+}
+
+// Threshold returns duration after which call is slow and is hedged
+func (h *Hedging) Threshold() time.Duration {
+	min := h.MinDelay
+	if min <= 0 {
+		min = defaultHedgeMinDelay
+	}
+	h.mux.Lock()
+	if len(h.samples) < hedgeMinSamples {
+		h.mux.Unlock()
+		return min
+	}
+	sorted := append([]time.Duration(nil), h.samples...)
+	h.mux.Unlock()
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	p := h.Percentile
+	if p <= 0 || p > 1 {
+		p = defaultHedgePercentile
+	}
+	d := sorted[int(p*float64(len(sorted)-1))]
+	if d < min {
+		return min
+	}
+	return d
+}
+
+// Hedged returns count of second attempts made
+func (h *Hedging) Hedged() int64 {
+	return atomic.LoadInt64(&h.hedged)
+}
+
+// observe records latency of call
+func (h *Hedging) observe(d time.Duration) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	if len(h.samples) < hedgeSamples {
+		h.samples = append(h.samples, d)
+		return
+	}
+	h.samples[h.next] = d
+	h.next = (h.next + 1) % hedgeSamples
+}
+
+// doHedged performs request, making second attempt if first is slow
+func (c *Client) doHedged(ctx context.Context, request Request) (*Response, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type result struct {
+		res *Response
+		err error
+	}
+	results := make(chan result, 2)
+	attempt := func() {
+		res, err := c.doObserved(ctx, request)
+		results <- result{res, err}
+	}
+	start := c.clock.Now()
+	go attempt()
+	hedge := make(chan struct{})
+	go func() {
+		if c.clock.Sleep(ctx, c.hedging.Threshold()) == nil {
+			close(hedge)
+		}
+	}()
+	pending := 1
+	for {
+		select {
+		case <-hedge:
+			hedge = nil
+			atomic.AddInt64(&c.hedging.hedged, 1)
+			pending++
+			go attempt()
+		case r := <-results:
+			pending--
+			if r.err != nil && pending > 0 && ctx.Err() == nil {
+				// other attempt can still succeed
+				continue
+			}
+			c.hedging.observe(c.clock.Now().Sub(start))
+			return r.res, r.err
+		}
+	}
+}
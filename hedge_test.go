@@ -0,0 +1,71 @@
+package vk
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestHedging(t *testing.T) {
+	Convey("Hedging", t, func() {
+		So(ReadMethod(methodUsersGet), ShouldBeTrue)
+		So(ReadMethod("groups.isMember"), ShouldBeTrue)
+		So(ReadMethod(methodMessagesSend), ShouldBeFalse)
+		So(ReadMethod(methodExecute), ShouldBeFalse)
+
+		h := &Hedging{MinDelay: 10 * time.Millisecond}
+		So(h.Threshold(), ShouldEqual, 10*time.Millisecond)
+		for i := 1; i <= hedgeSamples+10; i++ {
+			h.observe(time.Duration(i) * time.Millisecond)
+		}
+		So(h.samples, ShouldHaveLength, hedgeSamples)
+		So(h.Threshold(), ShouldEqual, 131*time.Millisecond)
+
+		Convey("Client", func() {
+			h := &Hedging{MinDelay: 10 * time.Millisecond}
+			var calls, canceled int32
+			client := New(WithHedging(h), WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+				if atomic.AddInt32(&calls, 1) == 1 {
+					<-req.Context().Done()
+					atomic.AddInt32(&canceled, 1)
+					return nil, req.Context().Err()
+				}
+				return jsonResponse(http.StatusOK, `{"response": 2}`), nil
+			})))
+			res, err := client.Do(Request{Method: methodUsersGet})
+			So(err, ShouldBeNil)
+			So(res.Response.String(), ShouldEqual, "2")
+			So(h.Hedged(), ShouldEqual, 1)
+			for atomic.LoadInt32(&canceled) == 0 {
+				time.Sleep(time.Millisecond)
+			}
+
+			_, err = client.Do(Request{Method: methodMessagesSend})
+			So(err, ShouldBeNil)
+			So(atomic.LoadInt32(&calls), ShouldEqual, 3)
+			So(h.Hedged(), ShouldEqual, 1)
+		})
+		Convey("Stream is not hedged", func() {
+			h := &Hedging{MinDelay: time.Millisecond}
+			var calls int32
+			client := New(WithHedging(h), WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(10 * time.Millisecond)
+				return jsonResponse(http.StatusOK, `{"response": {"count": 2, "items": [1, 2]}}`), nil
+			})))
+			var items []string
+			_, err := client.DoStream(context.Background(), Request{Method: "groups.getMembers"}, func(item Raw) error {
+				items = append(items, item.String())
+				return nil
+			})
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []string{"1", "2"})
+			So(atomic.LoadInt32(&calls), ShouldEqual, 1)
+			So(h.Hedged(), ShouldEqual, 0)
+		})
+	})
+}
@@ -0,0 +1,88 @@
+package vk
+
+import "encoding/json"
+
+const (
+	methodMessagesGetHistory = "messages.getHistory"
+
+	defaultHistoryCount = 200
+)
+
+type MessagesGetHistoryFields struct {
+	PeerID         ID     `url:"peer_id"`
+	Offset         int    `url:"offset,omitempty"`
+	Count          int    `url:"count,omitempty"`
+	StartMessageID int    `url:"start_message_id,omitempty"`
+	Rev            Bool   `url:"rev,omitempty"`
+	Extended       Bool   `url:"extended,omitempty"`
+	Fields         Fields `url:"fields,omitempty"`
+	GroupID        ID     `url:"group_id,omitempty"`
+}
+
+// MessagesGetHistoryResult has raw items, so fields that are not
+// in Message, like reactions, are kept
+type MessagesGetHistoryResult struct {
+	Count int   `json:"count"`
+	Items []Raw `json:"items"`
+}
+
+// GetHistory returns page of conversation messages
+func (m Messages) GetHistory(fields MessagesGetHistoryFields) (result MessagesGetHistoryResult, err error) {
+	return result, m.Decode(m.Request(methodMessagesGetHistory, fields), &result)
+}
+
+// History iterates over conversation messages, from newest to
+// oldest or from oldest to newest with Rev:
+//
+//	h := client.Messages.History(MessagesGetHistoryFields{PeerID: peer, Rev: true})
+//	for h.Next() {
+//		m, err := h.Message()
+//	}
+//	if err := h.Err(); err != nil {
+//		// handle error
+//	}
+type History struct {
+	offsetPager
+	messages Messages
+	fields   MessagesGetHistoryFields
+	items    []Raw
+}
+
+// History returns iterator over conversation messages starting at fields.Offset
+func (m Messages) History(fields MessagesGetHistoryFields) *History {
+	if fields.Count == 0 {
+		fields.Count = defaultHistoryCount
+	}
+	return &History{offsetPager: offsetPager{offset: fields.Offset}, messages: m, fields: fields}
+}
+
+// Next advances to next message, fetching next page if needed,
+// and returns false when history is over or error occurred
+func (h *History) Next() bool {
+	return h.next(func(offset int) (int, int, error) {
+		h.fields.Offset = offset
+		result, err := h.messages.GetHistory(h.fields)
+		h.items = result.Items
+		return len(result.Items), result.Count, err
+	})
+}
+
+// Raw returns current message as returned by api
+func (h *History) Raw() Raw {
+	return h.items[h.pos]
+}
+
+// Message decodes current message
+func (h *History) Message() (m Message, err error) {
+	return m, json.Unmarshal(h.items[h.pos], &m)
+}
+
+// Count returns total count of messages reported by api
+func (h *History) Count() int {
+	return h.total
+}
+
+// Err returns error occurred during iteration
+func (h *History) Err() error {
+	return h.err
+}
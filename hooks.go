@@ -0,0 +1,31 @@
+package vk
+
+import (
+	"context"
+	"time"
+)
+
+// RequestDoneFunc is called after api call with its method, duration and error
+type RequestDoneFunc func(method string, d time.Duration, err error)
+
+// OnRequestDone adds function that is called after every api call,
+// it is lightweight alternative to audit for metrics and tracing
+func OnRequestDone(f RequestDoneFunc) Option {
+	return func(c *Client) {
+		c.onRequestDone = append(c.onRequestDone, f)
+	}
+}
+
+// doObserved performs request and calls OnRequestDone functions
+func (c *Client) doObserved(ctx context.Context, request Request) (*Response, error) {
+	if len(c.onRequestDone) == 0 {
+		return c.doAudited(ctx, request)
+	}
+	start := c.clock.Now()
+	res, err := c.doAudited(ctx, request)
+	d := c.clock.Now().Sub(start)
+	for _, f := range c.onRequestDone {
+		f(request.Method, d, err)
+	}
+	return res, err
+}
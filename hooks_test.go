@@ -0,0 +1,42 @@
+package vk
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestOnRequestDone(t *testing.T) {
+	Convey("On request done", t, func() {
+		type call struct {
+			method string
+			d      time.Duration
+			err    error
+		}
+		var calls []call
+		var second int
+		clock := NewFakeClock(time.Unix(1000, 0))
+		client := New(WithClock(clock), OnRequestDone(func(method string, d time.Duration, err error) {
+			calls = append(calls, call{method, d, err})
+		}), OnRequestDone(func(method string, d time.Duration, err error) {
+			second++
+		}))
+		status := http.StatusOK
+		client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			clock.Advance(50 * time.Millisecond)
+			return jsonResponse(status, `{"response": 1}`), nil
+		}))
+		_, err := client.Do(Request{Method: "users.get"})
+		So(err, ShouldBeNil)
+		status = http.StatusInternalServerError
+		_, err = client.Do(Request{Method: "groups.get"})
+		So(err, ShouldResemble, HTTPError{Status: http.StatusInternalServerError, Body: []byte(`{"response": 1}`)})
+		So(calls, ShouldResemble, []call{
+			{"users.get", 50 * time.Millisecond, nil},
+			{"groups.get", 50 * time.Millisecond, err},
+		})
+		So(second, ShouldEqual, 2)
+	})
+}
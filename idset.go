@@ -0,0 +1,296 @@
+package vk
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+)
+
+// IDSet is set of ids that keeps up to SpillSize ids in memory,
+// spilling sorted runs to temporary files, so sets of millions
+// of community members can be merged with bounded memory
+type IDSet struct {
+	// SpillSize is maximum count of ids in memory, zero is unlimited
+	SpillSize int
+	// Dir is directory of spill files, os.TempDir if empty
+	Dir string
+
+	mem  []ID
+	runs []string
+}
+
+// Add adds id to set
+func (s *IDSet) Add(id ID) error {
+	s.mem = append(s.mem, id)
+	if s.SpillSize > 0 && len(s.mem) >= s.SpillSize {
+		return s.spill()
+	}
+	return nil
+}
+
+func (s *IDSet) sort() {
+	sort.Slice(s.mem, func(i, j int) bool { return s.mem[i] < s.mem[j] })
+}
+
+func (s *IDSet) spill() error {
+	s.sort()
+	f, err := ioutil.TempFile(s.Dir, "vk-idset-")
+	if err != nil {
+		return err
+	}
+	s.runs = append(s.runs, f.Name())
+	w := bufio.NewWriter(f)
+	var buf [8]byte
+	for _, id := range s.mem {
+		binary.BigEndian.PutUint64(buf[:], uint64(id))
+		if _, err = w.Write(buf[:]); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err = w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	s.mem = s.mem[:0]
+	return f.Close()
+}
+
+// Close removes spill files
+func (s *IDSet) Close() error {
+	var err error
+	for _, name := range s.runs {
+		if rErr := os.Remove(name); rErr != nil && err == nil {
+			err = rErr
+		}
+	}
+	s.runs, s.mem = nil, nil
+	return err
+}
+
+// Iter returns iterator over unique ids of set in ascending order,
+// set should not be changed while iterator is used
+func (s *IDSet) Iter() (*IDIterator, error) {
+	s.sort()
+	it := &IDIterator{}
+	it.sources = append(it.sources, &memorySource{ids: s.mem})
+	for _, name := range s.runs {
+		f, err := os.Open(name)
+		if err != nil {
+			it.Close()
+			return nil, err
+		}
+		it.files = append(it.files, f)
+		it.sources = append(it.sources, &fileSource{r: bufio.NewReader(f)})
+	}
+	if err := it.init(); err != nil {
+		it.Close()
+		return nil, err
+	}
+	return it, nil
+}
+
+// Count returns count of unique ids in set
+func (s *IDSet) Count() (int, error) {
+	it, err := s.Iter()
+	if err != nil {
+		return 0, err
+	}
+	defer it.Close()
+	n := 0
+	for it.Next() {
+		n++
+	}
+	return n, it.Err()
+}
+
+type idSource interface {
+	next() (ID, bool, error)
+}
+
+type memorySource struct {
+	ids []ID
+}
+
+func (s *memorySource) next() (ID, bool, error) {
+	if len(s.ids) == 0 {
+		return 0, false, nil
+	}
+	id := s.ids[0]
+	s.ids = s.ids[1:]
+	return id, true, nil
+}
+
+type fileSource struct {
+	r   *bufio.Reader
+	buf [8]byte
+}
+
+func (s *fileSource) next() (ID, bool, error) {
+	_, err := io.ReadFull(s.r, s.buf[:])
+	if err == io.EOF {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return ID(binary.BigEndian.Uint64(s.buf[:])), true, nil
+}
+
+type idHeapItem struct {
+	id     ID
+	source int
+}
+
+type idHeap []idHeapItem
+
+func (h idHeap) Len() int            { return len(h) }
+func (h idHeap) Less(i, j int) bool  { return h[i].id < h[j].id }
+func (h idHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *idHeap) Push(x interface{}) { *h = append(*h, x.(idHeapItem)) }
+func (h *idHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// mergeSources merges sorted sources, source of every
+// popped id is advanced
+type mergeSources struct {
+	sources []idSource
+	heap    idHeap
+}
+
+func (m *mergeSources) init() error {
+	for i := range m.sources {
+		if err := m.advance(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mergeSources) advance(i int) error {
+	id, ok, err := m.sources[i].next()
+	if err != nil || !ok {
+		return err
+	}
+	heap.Push(&m.heap, idHeapItem{id: id, source: i})
+	return nil
+}
+
+// pop returns least id and indexes of sources that contain it
+func (m *mergeSources) pop(in []int) (ID, []int, bool, error) {
+	in = in[:0]
+	if len(m.heap) == 0 {
+		return 0, in, false, nil
+	}
+	id := m.heap[0].id
+	for len(m.heap) != 0 && m.heap[0].id == id {
+		item := heap.Pop(&m.heap).(idHeapItem)
+		if len(in) == 0 || in[len(in)-1] != item.source {
+			in = append(in, item.source)
+		}
+		if err := m.advance(item.source); err != nil {
+			return 0, in, false, err
+		}
+	}
+	sort.Ints(in)
+	return id, in, true, nil
+}
+
+// IDIterator iterates over unique ids of IDSet:
+//
+//	it, err := set.Iter()
+//	defer it.Close()
+//	for it.Next() {
+//		id := it.ID()
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle error
+//	}
+type IDIterator struct {
+	mergeSources
+	files []*os.File
+	id    ID
+	in    []int
+	err   error
+}
+
+// Next advances to next id
+func (it *IDIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	id, in, ok, err := it.pop(it.in)
+	it.id, it.in, it.err = id, in, err
+	return ok && err == nil
+}
+
+// ID returns current id
+func (it *IDIterator) ID() ID {
+	return it.id
+}
+
+// Err returns error occurred during iteration
+func (it *IDIterator) Err() error {
+	return it.err
+}
+
+// Close closes spill files opened by iterator
+func (it *IDIterator) Close() error {
+	var err error
+	for _, f := range it.files {
+		if cErr := f.Close(); cErr != nil && err == nil {
+			err = cErr
+		}
+	}
+	it.files = nil
+	return err
+}
+
+// iteratorSource is IDIterator as source of merge
+type iteratorSource struct {
+	it *IDIterator
+}
+
+func (s iteratorSource) next() (ID, bool, error) {
+	if !s.it.Next() {
+		return 0, false, s.it.Err()
+	}
+	return s.it.ID(), true, nil
+}
+
+// MergeIDSets calls fn for every id of sets in ascending order with
+// indexes of sets that contain it, so intersections and unions are
+// computed in one pass
+func MergeIDSets(sets []*IDSet, fn func(id ID, in []int) error) error {
+	m := mergeSources{}
+	for _, s := range sets {
+		it, err := s.Iter()
+		if err != nil {
+			return err
+		}
+		defer it.Close()
+		m.sources = append(m.sources, iteratorSource{it})
+	}
+	if err := m.init(); err != nil {
+		return err
+	}
+	var in []int
+	for {
+		id, sets, ok, err := m.pop(in)
+		if err != nil || !ok {
+			return err
+		}
+		in = sets
+		if err = fn(id, sets); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package vk
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func setIDs(s *IDSet) []ID {
+	it, err := s.Iter()
+	So(err, ShouldBeNil)
+	defer it.Close()
+	var ids []ID
+	for it.Next() {
+		ids = append(ids, it.ID())
+	}
+	So(it.Err(), ShouldBeNil)
+	return ids
+}
+
+func TestIDSet(t *testing.T) {
+	Convey("IDSet", t, func() {
+		dir, err := ioutil.TempDir("", "vk-idset")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		s := &IDSet{SpillSize: 3, Dir: dir}
+		for _, id := range []ID{5, 1, 3, 1, 9, 2, 5, 7} {
+			So(s.Add(id), ShouldBeNil)
+		}
+		files, _ := ioutil.ReadDir(dir)
+		So(files, ShouldHaveLength, 2)
+		So(setIDs(s), ShouldResemble, []ID{1, 2, 3, 5, 7, 9})
+		n, err := s.Count()
+		So(err, ShouldBeNil)
+		So(n, ShouldEqual, 6)
+		So(s.Close(), ShouldBeNil)
+		files, _ = ioutil.ReadDir(dir)
+		So(files, ShouldBeEmpty)
+
+		Convey("Merge", func() {
+			a, b := &IDSet{}, &IDSet{SpillSize: 2, Dir: dir}
+			for _, id := range []ID{1, 2, 3} {
+				So(a.Add(id), ShouldBeNil)
+			}
+			for _, id := range []ID{3, 4, 2} {
+				So(b.Add(id), ShouldBeNil)
+			}
+			defer b.Close()
+			var merged [][]int
+			So(MergeIDSets([]*IDSet{a, b}, func(id ID, in []int) error {
+				merged = append(merged, append([]int{int(id)}, in...))
+				return nil
+			}), ShouldBeNil)
+			So(merged, ShouldResemble, [][]int{{1, 0}, {2, 0, 1}, {3, 0, 1}, {4, 1}})
+		})
+	})
+}
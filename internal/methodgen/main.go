@@ -0,0 +1,93 @@
+// Command methodgen generates constants of vk api method names
+//
+//	methodgen -in methods.txt -out methods.go
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var initialisms = regexp.MustCompile(`(Id|Ids|Url|Sms)([A-Z]|$)`)
+
+// constName returns name of constant for method, like
+// MethodUsersGetByID for users.getById
+func constName(method string) string {
+	name := "Method"
+	for _, part := range strings.Split(method, ".") {
+		name += strings.ToUpper(part[:1]) + part[1:]
+	}
+	return initialisms.ReplaceAllStringFunc(name, func(s string) string {
+		switch {
+		case strings.HasPrefix(s, "Ids"):
+			return "IDs" + s[3:]
+		case strings.HasPrefix(s, "Id"):
+			return "ID" + s[2:]
+		case strings.HasPrefix(s, "Url"):
+			return "URL" + s[3:]
+		default:
+			return "SMS" + s[3:]
+		}
+	})
+}
+
+func main() {
+	in := flag.String("in", "internal/methodgen/methods.txt", "list of methods")
+	out := flag.String("out", "methods.go", "output file")
+	flag.Parse()
+
+	f, err := os.Open(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	var methods []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		methods = append(methods, line)
+	}
+	if err = scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+	sort.Strings(methods)
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintln(buf, "// Code generated by methodgen; DO NOT EDIT.")
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "package vk")
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "// Names of vk api methods")
+	fmt.Fprintln(buf, "const (")
+	for _, m := range methods {
+		fmt.Fprintf(buf, "\t%s = %q\n", constName(m), m)
+	}
+	fmt.Fprintln(buf, ")")
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "// knownMethods is set of all method names")
+	fmt.Fprintln(buf, "var knownMethods = map[string]struct{}{")
+	for _, m := range methods {
+		fmt.Fprintf(buf, "\t%s: {},\n", constName(m))
+	}
+	fmt.Fprintln(buf, "}")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = ioutil.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
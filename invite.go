@@ -0,0 +1,158 @@
+package vk
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	methodMessagesGetInviteLink        = "messages.getInviteLink"
+	methodMessagesJoinChatByInviteLink = "messages.joinChatByInviteLink"
+	methodGroupsInvite                 = "groups.invite"
+	methodGroupsGetInvitedUsers        = "groups.getInvitedUsers"
+
+	inviteChatHost = "vk.me"
+	inviteHost     = "vk.com"
+	inviteJoinPath = "join"
+)
+
+// ErrInvalidInviteLink is returned by ParseInviteLink for
+// links that are not chat or community links
+var ErrInvalidInviteLink = errors.New("invalid invite link")
+
+type messagesGetInviteLinkFields struct {
+	PeerID  ID   `url:"peer_id"`
+	Reset   Bool `url:"reset,omitempty"`
+	GroupID ID   `url:"group_id,omitempty"`
+}
+
+// GetInviteLink returns invite link of chat, reset revokes
+// previous link, groupID is set for community chats
+func (m Messages) GetInviteLink(peerID ID, reset bool, groupID ID) (link string, err error) {
+	result := struct {
+		Link string `json:"link"`
+	}{}
+	fields := messagesGetInviteLinkFields{peerID, Bool(reset), groupID}
+	return result.Link, m.Decode(m.Request(methodMessagesGetInviteLink, fields), &result)
+}
+
+type messagesJoinChatByInviteLinkFields struct {
+	Link string `url:"link"`
+}
+
+// JoinChatByInviteLink joins chat and returns its id
+func (m Messages) JoinChatByInviteLink(link string) (chatID ID, err error) {
+	result := struct {
+		ChatID ID `json:"chat_id"`
+	}{}
+	fields := messagesJoinChatByInviteLinkFields{link}
+	return result.ChatID, m.Decode(m.Request(methodMessagesJoinChatByInviteLink, fields), &result)
+}
+
+type groupsInviteFields struct {
+	GroupID ID `url:"group_id"`
+	UserID  ID `url:"user_id"`
+}
+
+// Invite invites friend of current user to community
+func (g Groups) Invite(groupID, userID ID) error {
+	var result int
+	return g.Decode(g.Request(methodGroupsInvite, groupsInviteFields{groupID, userID}), &result)
+}
+
+type GroupsGetInvitedUsersFields struct {
+	GroupID ID     `url:"group_id"`
+	Offset  int    `url:"offset,omitempty"`
+	Count   int    `url:"count,omitempty"`
+	Fields  Fields `url:"fields,omitempty"`
+}
+
+type GroupsGetInvitedUsersResult struct {
+	Count int    `json:"count"`
+	Items []User `json:"items"`
+}
+
+// GetInvitedUsers returns users invited to community
+func (g Groups) GetInvitedUsers(fields GroupsGetInvitedUsersFields) (result GroupsGetInvitedUsersResult, err error) {
+	return result, g.Decode(g.Request(methodGroupsGetInvitedUsers, fields), &result)
+}
+
+// InviteKind is kind of invite link
+type InviteKind string
+
+const (
+	InviteChat      InviteKind = "chat"
+	InviteCommunity InviteKind = "community"
+)
+
+// InviteLink is parsed invite link, Hash is set for chats and
+// GroupID or ScreenName for communities
+type InviteLink struct {
+	Kind       InviteKind
+	Hash       string
+	GroupID    ID
+	ScreenName string
+}
+
+var (
+	reInviteHash      = regexp.MustCompile(`^[A-Za-z0-9_\-/=]+$`)
+	reInviteCommunity = regexp.MustCompile(`^(club|public|event)(\d+)$`)
+	reScreenName      = regexp.MustCompile(`^[A-Za-z0-9_.]{2,32}$`)
+)
+
+// ParseInviteLink parses chat invite link like https://vk.me/join/AJQ1d
+// or community link like https://vk.com/club1 or https://vk.com/apiclub
+func ParseInviteLink(s string) (InviteLink, error) {
+	s = strings.TrimSpace(s)
+	if !strings.Contains(s, "://") {
+		s = "https://" + s
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return InviteLink{}, ErrInvalidInviteLink
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	host = strings.TrimPrefix(host, "m.")
+	path := strings.Trim(u.Path, "/")
+	switch host {
+	case inviteChatHost, inviteHost:
+	default:
+		return InviteLink{}, ErrInvalidInviteLink
+	}
+	if strings.HasPrefix(path, inviteJoinPath+"/") {
+		hash := strings.TrimPrefix(path, inviteJoinPath+"/")
+		if !reInviteHash.MatchString(hash) {
+			return InviteLink{}, ErrInvalidInviteLink
+		}
+		return InviteLink{Kind: InviteChat, Hash: hash}, nil
+	}
+	if host != inviteHost || path == inviteJoinPath || strings.Contains(path, "/") {
+		return InviteLink{}, ErrInvalidInviteLink
+	}
+	if m := reInviteCommunity.FindStringSubmatch(path); m != nil {
+		id, err := strconv.ParseInt(m[2], 10, 64)
+		if err != nil || id == 0 {
+			return InviteLink{}, ErrInvalidInviteLink
+		}
+		return InviteLink{Kind: InviteCommunity, GroupID: ID(id)}, nil
+	}
+	if !reScreenName.MatchString(path) || reObjectID.MatchString(path) {
+		return InviteLink{}, ErrInvalidInviteLink
+	}
+	return InviteLink{Kind: InviteCommunity, ScreenName: path}, nil
+}
+
+// String returns canonical url of link
+func (l InviteLink) String() string {
+	switch {
+	case l.Kind == InviteChat:
+		return "https://" + inviteChatHost + "/" + inviteJoinPath + "/" + l.Hash
+	case l.GroupID != 0:
+		return "https://" + inviteHost + "/club" + int64s(int64(l.GroupID))
+	default:
+		return "https://" + inviteHost + "/" + l.ScreenName
+	}
+}
@@ -0,0 +1,68 @@
+package vk
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestInvite(t *testing.T) {
+	Convey("Invite", t, func() {
+		var calls []string
+		response := `{"response": 1}`
+		client := New(WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			q.Del(paramVersion)
+			q.Del(paramHTTPS)
+			calls = append(calls, req.URL.Path[len("/method/"):]+" "+q.Encode())
+			return jsonResponse(http.StatusOK, response), nil
+		})))
+		Convey("Chat", func() {
+			response = `{"response": {"link": "https://vk.me/join/AJQ1d"}}`
+			link, err := client.Messages.GetInviteLink(2000000001, true, 0)
+			So(err, ShouldBeNil)
+			So(link, ShouldEqual, "https://vk.me/join/AJQ1d")
+			response = `{"response": {"chat_id": 5}}`
+			id, err := client.Messages.JoinChatByInviteLink(link)
+			So(err, ShouldBeNil)
+			So(id, ShouldEqual, 5)
+			So(calls[0], ShouldEqual, "messages.getInviteLink peer_id=2000000001&reset=1")
+		})
+		Convey("Community", func() {
+			So(client.Groups.Invite(1, 2), ShouldBeNil)
+			response = `{"response": {"count": 1, "items": [{"id": 2}]}}`
+			invited, err := client.Groups.GetInvitedUsers(GroupsGetInvitedUsersFields{GroupID: 1})
+			So(err, ShouldBeNil)
+			So(invited.Items[0].ID, ShouldEqual, 2)
+			So(calls, ShouldResemble, []string{"groups.invite group_id=1&user_id=2", "groups.getInvitedUsers group_id=1"})
+		})
+		Convey("Parse", func() {
+			for s, expected := range map[string]InviteLink{
+				"https://vk.me/join/AJQ1d_x-y=":   {Kind: InviteChat, Hash: "AJQ1d_x-y="},
+				"vk.com/join/AJQ1d":               {Kind: InviteChat, Hash: "AJQ1d"},
+				"https://m.vk.com/club1":          {Kind: InviteCommunity, GroupID: 1},
+				"https://vk.com/public22/":        {Kind: InviteCommunity, GroupID: 22},
+				"http://www.vk.com/apiclub?w=123": {Kind: InviteCommunity, ScreenName: "apiclub"},
+			} {
+				link, err := ParseInviteLink(s)
+				So(err, ShouldBeNil)
+				So(link, ShouldResemble, expected)
+			}
+			for _, s := range []string{
+				"https://example.com/join/AJQ1d",
+				"https://vk.me/apiclub",
+				"https://vk.com/id1",
+				"https://vk.com/wall-1_2/x",
+				"https://vk.com/join/",
+				"https://vk.com/club0",
+			} {
+				_, err := ParseInviteLink(s)
+				So(err, ShouldEqual, ErrInvalidInviteLink)
+			}
+			So(InviteLink{Kind: InviteChat, Hash: "a"}.String(), ShouldEqual, "https://vk.me/join/a")
+			So(InviteLink{Kind: InviteCommunity, GroupID: 1}.String(), ShouldEqual, "https://vk.com/club1")
+			So(InviteLink{Kind: InviteCommunity, ScreenName: "apiclub"}.String(), ShouldEqual, "https://vk.com/apiclub")
+		})
+	})
+}
@@ -0,0 +1,32 @@
+package vk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+)
+
+// keyExcludedParams are not part of request key, as they do not
+// change result or are secret
+var keyExcludedParams = []string{paramToken, paramHTTPS}
+
+// Canonical returns stable form of request: method and parameters
+// sorted by name, token is excluded
+func (r Request) Canonical() string {
+	values := make(url.Values, len(r.Values))
+	for k, v := range r.Values {
+		values[k] = v
+	}
+	for _, k := range keyExcludedParams {
+		delete(values, k)
+	}
+	return r.Method + "?" + values.Encode()
+}
+
+// Key returns hash of canonical form of request, that is equal for
+// requests with same method and parameters made with any token,
+// suitable as cache or deduplication key
+func (r Request) Key() string {
+	h := sha256.Sum256([]byte(r.Canonical()))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,23 @@
+package vk
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRequestKey(t *testing.T) {
+	Convey("Request key", t, func() {
+		a := Factory{Token: "a"}.Request(methodUsersGet, UsersGetFields{UserIDs: []ID{1, 2}, Fields: Fields{"sex"}})
+		b := Factory{Token: "b"}.Request(methodUsersGet, UsersGetFields{Fields: Fields{"sex"}, UserIDs: []ID{1, 2}})
+		So(a.Canonical(), ShouldEqual, "users.get?fields=sex&user_ids=1%2C2")
+		So(a.Key(), ShouldEqual, b.Key())
+		So(a.Key(), ShouldHaveLength, 64)
+		b.Values.Set(paramToken, "secret")
+		So(b.Canonical(), ShouldNotContainSubstring, "secret")
+		So(a.Key(), ShouldEqual, b.Key())
+		c := Request{Method: methodUsersGet, Values: map[string][]string{"user_ids": {"1,3"}}}
+		So(c.Key(), ShouldNotEqual, a.Key())
+		So(Request{Method: methodWallGet}.Key(), ShouldNotEqual, Request{Method: methodUsersGet}.Key())
+	})
+}
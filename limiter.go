@@ -0,0 +1,75 @@
+package vk
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter limits rate of requests to vk api
+type Limiter interface {
+	// Wait blocks until request can be made
+	Wait(ctx context.Context) error
+}
+
+// MemoryLimiter is in-memory Limiter that spaces requests
+// by Interval, suitable for single process
+type MemoryLimiter struct {
+	Interval time.Duration
+	// Clock is SystemClock if nil
+	Clock Clock
+
+	mux  sync.Mutex
+	next time.Time
+}
+
+// NewLimiter returns in-memory limiter for rps requests per second,
+// default vk limit for user tokens is used if rps is zero
+func NewLimiter(rps int) *MemoryLimiter {
+	if rps <= 0 {
+		return &MemoryLimiter{Interval: minimumRate}
+	}
+	return &MemoryLimiter{Interval: time.Second / time.Duration(rps)}
+}
+
+func (l *MemoryLimiter) Wait(ctx context.Context) error {
+	clock := clockOrSystem(l.Clock)
+	l.mux.Lock()
+	now := clock.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.Interval)
+	l.mux.Unlock()
+	return clock.Sleep(ctx, wait)
+}
+
+// sleep pauses for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// WithLimiter sets rate limiter for requests, nil disables limiting
+func WithLimiter(limiter Limiter) Option {
+	return func(c *Client) {
+		c.SetLimiter(limiter)
+	}
+}
+
+// SetLimiter sets rate limiter for requests, nil disables limiting
+func (c *Client) SetLimiter(limiter Limiter) {
+	c.mux.Lock()
+	c.limiter = limiter
+	c.mux.Unlock()
+}
@@ -0,0 +1,49 @@
+package vk
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMemoryLimiter(t *testing.T) {
+	Convey("Memory limiter", t, func() {
+		So(NewLimiter(0).Interval, ShouldEqual, minimumRate)
+		l := NewLimiter(100)
+		So(l.Interval, ShouldEqual, 10*time.Millisecond)
+		clock := NewFakeClock(time.Unix(1000, 0))
+		l.Clock = clock
+		ctx := context.Background()
+		for i := 0; i < 4; i++ {
+			So(l.Wait(ctx), ShouldBeNil)
+		}
+		So(clock.Slept(), ShouldResemble, []time.Duration{0, 10 * time.Millisecond, 10 * time.Millisecond, 10 * time.Millisecond})
+		So(clock.Now(), ShouldResemble, time.Unix(1000, 0).Add(30*time.Millisecond))
+		Convey("Canceled", func() {
+			l := NewLimiter(1)
+			So(l.Wait(ctx), ShouldBeNil)
+			ctx, cancel := context.WithCancel(ctx)
+			cancel()
+			So(l.Wait(ctx), ShouldEqual, context.Canceled)
+		})
+		Convey("Client", func() {
+			client := New()
+			client.SetLimiter(failingLimiter{})
+			body := ioutil.NopCloser(bytes.NewBufferString(`{"response": 1}`))
+			client.SetHTTPClient(simpleHTTPClientMock{response: &http.Response{Body: body, StatusCode: http.StatusOK}})
+			_, err := client.Do(Request{Method: "users.get"})
+			So(err, ShouldResemble, context.DeadlineExceeded)
+		})
+	})
+}
+
+type failingLimiter struct{}
+
+func (failingLimiter) Wait(ctx context.Context) error {
+	return context.DeadlineExceeded
+}
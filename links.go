@@ -0,0 +1,152 @@
+package vk
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// LinkVariant is kind of generated link
+type LinkVariant int
+
+const (
+	// LinkDesktop is https://vk.com link
+	LinkDesktop LinkVariant = iota
+	// LinkMobile is https://m.vk.com link
+	LinkMobile
+	// LinkDeep is vk:// link that is opened by mobile application
+	LinkDeep
+)
+
+const (
+	linkHost       = "vk.com"
+	linkMobileHost = "m.vk.com"
+	linkDeepScheme = "vk"
+
+	// chatPeerOffset is added to chat id to get peer id
+	chatPeerOffset = 2000000000
+)
+
+// Link is canonical reference to vk object, inverse of Resolve
+type Link struct {
+	Path     string
+	Query    url.Values
+	Fragment string
+}
+
+// URL returns link of variant
+func (l Link) URL(variant LinkVariant) string {
+	u := url.URL{
+		Scheme:   defaultScheme,
+		Host:     linkHost,
+		Path:     "/" + l.Path,
+		RawQuery: l.Query.Encode(),
+		Fragment: l.Fragment,
+	}
+	switch variant {
+	case LinkMobile:
+		u.Host = linkMobileHost
+	case LinkDeep:
+		u.Scheme = linkDeepScheme
+	}
+	return u.String()
+}
+
+// String returns desktop link
+func (l Link) String() string {
+	return l.URL(LinkDesktop)
+}
+
+// UserLink returns link to user profile
+func UserLink(id ID) Link {
+	return Link{Path: fmt.Sprintf("id%d", id)}
+}
+
+// GroupLink returns link to community, id can be negative
+func GroupLink(id ID) Link {
+	if id < 0 {
+		id = -id
+	}
+	return Link{Path: fmt.Sprintf("club%d", id)}
+}
+
+// OwnerLink returns link to user or community by owner id
+func OwnerLink(owner ID) Link {
+	if owner < 0 {
+		return GroupLink(owner)
+	}
+	return UserLink(owner)
+}
+
+// PostLink returns link to wall post
+func PostLink(owner ID, id int) Link {
+	return Link{Path: fmt.Sprintf("wall%d_%d", owner, id)}
+}
+
+// PhotoLink returns link to photo
+func PhotoLink(owner ID, id int) Link {
+	return Link{Path: fmt.Sprintf("photo%d_%d", owner, id)}
+}
+
+// ConversationLink returns link to conversation with peer
+func ConversationLink(peer ID) Link {
+	sel := fmt.Sprint(peer)
+	if peer > chatPeerOffset {
+		sel = fmt.Sprintf("c%d", peer-chatPeerOffset)
+	}
+	return Link{Path: "im", Query: url.Values{"sel": {sel}}}
+}
+
+// MessageLink returns link to message in conversation with peer
+func MessageLink(peer, id ID) Link {
+	l := ConversationLink(peer)
+	l.Query.Set("msgid", fmt.Sprint(id))
+	return l
+}
+
+// AppLink returns link to mini app, hash is passed to
+// application as location hash and can be blank
+func AppLink(id ID, hash string) Link {
+	return Link{Path: fmt.Sprintf("app%d", id), Fragment: hash}
+}
+
+// Link returns link to user profile
+func (u User) Link() Link {
+	return UserLink(u.ID)
+}
+
+// Link returns link to community
+func (g Group) Link() Link {
+	return GroupLink(g.ID)
+}
+
+// Link returns link to post
+func (p Post) Link() Link {
+	return PostLink(p.OwnerID, p.ID)
+}
+
+// Link returns link to photo
+func (p Photo) Link() Link {
+	return PhotoLink(p.OwnerID, p.ID)
+}
+
+// Link returns link to message
+func (m Message) Link() Link {
+	return MessageLink(m.PeerID, m.ID)
+}
+
+// Link returns canonical link to resolved object
+func (r Resolved) Link() Link {
+	switch r.Type {
+	case ObjectUser:
+		return UserLink(r.ID)
+	case ObjectGroup:
+		return GroupLink(r.ID)
+	case ObjectApplication:
+		return AppLink(r.ID, "")
+	case ObjectPost:
+		return PostLink(r.OwnerID, int(r.ID))
+	case ObjectPhoto:
+		return PhotoLink(r.OwnerID, int(r.ID))
+	}
+	return Link{}
+}
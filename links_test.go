@@ -0,0 +1,34 @@
+package vk
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLinks(t *testing.T) {
+	Convey("Links", t, func() {
+		So(UserLink(1).String(), ShouldEqual, "https://vk.com/id1")
+		So(UserLink(1).URL(LinkMobile), ShouldEqual, "https://m.vk.com/id1")
+		So(UserLink(1).URL(LinkDeep), ShouldEqual, "vk://vk.com/id1")
+		So(GroupLink(-5).String(), ShouldEqual, "https://vk.com/club5")
+		So(OwnerLink(-5), ShouldResemble, GroupLink(5))
+		So(Post{ID: 2, OwnerID: -1}.Link().String(), ShouldEqual, "https://vk.com/wall-1_2")
+		So(Photo{ID: 2, OwnerID: 1}.Link().URL(LinkDeep), ShouldEqual, "vk://vk.com/photo1_2")
+		So(ConversationLink(2000000003).String(), ShouldEqual, "https://vk.com/im?sel=c3")
+		So(Message{ID: 10, PeerID: -1}.Link().String(), ShouldEqual, "https://vk.com/im?msgid=10&sel=-1")
+		So(AppLink(7, "page=1").URL(LinkMobile), ShouldEqual, "https://m.vk.com/app7#page=1")
+		Convey("Resolved", func() {
+			for _, r := range []Resolved{
+				{Type: ObjectUser, ID: 1},
+				{Type: ObjectGroup, ID: -1},
+				{Type: ObjectPost, ID: 2, OwnerID: -1},
+				{Type: ObjectPhoto, ID: 2, OwnerID: 1},
+				{Type: ObjectApplication, ID: 7},
+			} {
+				So(resolveTarget(r.Link().String()), ShouldEqual, r.Link().Path)
+			}
+			So(Resolved{}.Link(), ShouldResemble, Link{})
+		})
+	})
+}
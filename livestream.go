@@ -0,0 +1,111 @@
+package vk
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// LiveStatusFinished is live_status of finished stream
+	LiveStatusFinished = "finished"
+
+	defaultLiveStreamInterval = 10 * time.Second
+)
+
+// RTMP returns ready to use url of rtmp server with stream key
+func (s LiveStreamServer) RTMP() string {
+	return strings.TrimSuffix(s.URL, "/") + "/" + s.Key
+}
+
+// LiveStreamer starts live stream, monitors its status and stops
+// it when context is done, for relaying live streams into vk
+type LiveStreamer struct {
+	Video  Video
+	Fields VideoStartStreamingFields
+	// OnStatus is called on every change of live status
+	OnStatus func(video VideoItem)
+	// Interval of status checks
+	Interval time.Duration
+	// Clock is SystemClock if nil
+	Clock Clock
+
+	mux    sync.Mutex
+	stream *LiveStream
+}
+
+// Start creates live stream, if it is not started yet, and
+// returns it, RTMP url for encoder is stream.Stream.RTMP()
+func (s *LiveStreamer) Start() (LiveStream, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.stream != nil {
+		return *s.stream, nil
+	}
+	stream, err := s.Video.StartStreaming(s.Fields)
+	if err != nil {
+		return stream, err
+	}
+	s.stream = &stream
+	return stream, nil
+}
+
+// Status returns current video of stream
+func (s *LiveStreamer) Status() (VideoItem, error) {
+	stream, err := s.Start()
+	if err != nil {
+		return VideoItem{}, err
+	}
+	videos := int64s(int64(stream.OwnerID)) + "_" + int64s(int64(stream.VideoID))
+	if len(stream.AccessKey) != 0 {
+		videos += "_" + stream.AccessKey
+	}
+	result, err := s.Video.Get(VideoGetFields{Videos: videos})
+	if err != nil || len(result.Items) == 0 {
+		return VideoItem{}, err
+	}
+	return result.Items[0], nil
+}
+
+// Run starts stream and checks its status until it is finished or
+// ctx is done, then stream is stopped. LiveStreamer is Component.
+func (s *LiveStreamer) Run(ctx context.Context) (err error) {
+	stream, err := s.Start()
+	if err != nil {
+		return err
+	}
+	status := ""
+	// stream is stopped on every exit unless it has finished itself
+	defer func() {
+		if status == LiveStatusFinished {
+			return
+		}
+		if _, stopErr := s.Video.StopStreaming(stream.OwnerID, stream.VideoID); err == nil {
+			err = stopErr
+		}
+	}()
+	clock := clockOrSystem(s.Clock)
+	interval := s.Interval
+	if interval == 0 {
+		interval = defaultLiveStreamInterval
+	}
+	for {
+		video, err := s.Status()
+		if err != nil {
+			return err
+		}
+		if video.LiveStatus != status {
+			status = video.LiveStatus
+			if s.OnStatus != nil {
+				s.OnStatus(video)
+			}
+		}
+		if status == LiveStatusFinished {
+			return nil
+		}
+		if clock.Sleep(ctx, interval) != nil {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package vk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLiveStreamer(t *testing.T) {
+	Convey("Live streamer", t, func() {
+		So(LiveStreamServer{URL: "rtmp://stream.vk.com/live/", Key: "k"}.RTMP(), ShouldEqual, "rtmp://stream.vk.com/live/k")
+		So(LiveStreamServer{URL: "rtmp://stream.vk.com/live", Key: "k"}.RTMP(), ShouldEqual, "rtmp://stream.vk.com/live/k")
+
+		client := New()
+		var calls []string
+		statuses := []string{"waiting", "started", "started"}
+		client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			calls = append(calls, req.URL.Path[len("/method/"):])
+			switch req.URL.Path {
+			case "/method/video.startStreaming":
+				return jsonResponse(http.StatusOK, `{"response": {"video_id": 4, "owner_id": -2, "access_key": "ak", "stream": {"url": "rtmp://s/live", "key": "k"}}}`), nil
+			case "/method/video.get":
+				So(q.Get("videos"), ShouldEqual, "-2_4_ak")
+				status := "finished"
+				if len(statuses) > 0 {
+					status, statuses = statuses[0], statuses[1:]
+				}
+				return jsonResponse(http.StatusOK, `{"response": {"count": 1, "items": [{"id": 4, "live_status": "`+status+`"}]}}`), nil
+			}
+			return jsonResponse(http.StatusOK, `{"response": {"unique_viewers": 1}}`), nil
+		}))
+		var seen []string
+		s := &LiveStreamer{
+			Video:  client.Video,
+			Fields: VideoStartStreamingFields{Name: "live"},
+			OnStatus: func(v VideoItem) {
+				seen = append(seen, v.LiveStatus)
+			},
+			Clock: NewFakeClock(time.Unix(0, 0)),
+		}
+		stream, err := s.Start()
+		So(err, ShouldBeNil)
+		So(stream.Stream.RTMP(), ShouldEqual, "rtmp://s/live/k")
+		Convey("Finished", func() {
+			So(s.Run(context.Background()), ShouldBeNil)
+			So(seen, ShouldResemble, []string{"waiting", "started", "finished"})
+			So(calls, ShouldNotContain, "video.stopStreaming")
+			So(calls[0], ShouldEqual, "video.startStreaming")
+		})
+		Convey("Canceled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			s.OnStatus = func(v VideoItem) {
+				if v.LiveStatus == "started" {
+					cancel()
+				}
+			}
+			So(s.Run(ctx), ShouldBeNil)
+			So(calls[len(calls)-1], ShouldEqual, "video.stopStreaming")
+		})
+		Convey("Status error", func() {
+			s.Video = client.WithOptions(WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, req.URL.Path[len("/method/"):])
+				if req.URL.Path == "/method/video.get" {
+					return jsonResponse(http.StatusOK, `{"error": {"error_code": 10, "error_msg": "internal"}}`), nil
+				}
+				return jsonResponse(http.StatusOK, `{"response": {"video_id": 4, "owner_id": -2}}`), nil
+			}))).Video
+			So(s.Run(context.Background()), ShouldNotBeNil)
+			So(calls[len(calls)-1], ShouldEqual, "video.stopStreaming")
+		})
+	})
+}
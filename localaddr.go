@@ -0,0 +1,43 @@
+package vk
+
+import (
+	"fmt"
+	"net"
+)
+
+// WithLocalAddr sets http client with default timeouts, that makes
+// connections from ip, so tokens of different clients can use
+// different egress addresses on one host
+func WithLocalAddr(ip net.IP) Option {
+	return WithDial(DialConfig{LocalAddr: &net.TCPAddr{IP: ip}})
+}
+
+// InterfaceAddr returns first ip address of network interface
+// with name, ipv4 is preferred, for use with WithLocalAddr
+func InterfaceAddr(name string) (net.IP, error) {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil, err
+	}
+	var result net.IP
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok {
+			continue
+		}
+		if ipNet.IP.To4() != nil {
+			return ipNet.IP, nil
+		}
+		if result == nil {
+			result = ipNet.IP
+		}
+	}
+	if result == nil {
+		return nil, fmt.Errorf("interface %s has no ip addresses", name)
+	}
+	return result, nil
+}
@@ -0,0 +1,39 @@
+package vk
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLocalAddr(t *testing.T) {
+	Convey("Local addr", t, func() {
+		var remote string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			remote = r.RemoteAddr
+		}))
+		defer server.Close()
+		client := New(WithLocalAddr(net.ParseIP("127.0.0.1")))
+		httpClient, _ := client.transport()
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		So(err, ShouldBeNil)
+		res, err := httpClient.Do(req)
+		So(err, ShouldBeNil)
+		res.Body.Close()
+		host, _, err := net.SplitHostPort(remote)
+		So(err, ShouldBeNil)
+		So(host, ShouldEqual, "127.0.0.1")
+
+		Convey("Interface", func() {
+			ip, err := InterfaceAddr("lo")
+			if err == nil {
+				So(ip.IsLoopback(), ShouldBeTrue)
+			}
+			_, err = InterfaceAddr("no-such-interface")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,139 @@
+package vk
+
+import "sync"
+
+const (
+	// LangEnglish is default language of error messages
+	LangEnglish = "en"
+	LangRussian = "ru"
+)
+
+var (
+	errorMessagesMux sync.RWMutex
+	errorMessages    = map[string]map[ServerError]string{
+		LangEnglish: {
+			ErrUnknown:                   "Something went wrong, please try again later",
+			ErrApplicationDisabled:       "The application is disabled",
+			ErrAuthFailed:                "Authorization failed, please sign in again",
+			ErrTooManyRequests:           "Too many requests, please wait a bit",
+			ErrInsufficientPermissions:   "The application has no permission for this action",
+			ErrTooManyOneTypeRequests:    "Too many similar actions, please wait a bit",
+			ErrInternalServerError:       "VK is temporarily unavailable, please try again later",
+			ErrCaptchaNeeded:             "Please solve the captcha to continue",
+			ErrNotAllowed:                "This action is not allowed",
+			ErrNeedValidation:            "Please confirm your account to continue",
+			ErrUserDeleted:               "The user is deleted or banned",
+			ErrMethodDisabled:            "This feature is disabled",
+			ErrNeedConfirmation:          "Please confirm the action",
+			ErrRateLimit:                 "Daily limit is reached, please try again tomorrow",
+			ErrPrivateProfile:            "The profile is private",
+			ErrOneOfParametersInvalid:    "The request is invalid",
+			ErrAlbumAccessProhibited:     "Access to the album is denied",
+			ErrGroupAccessProhibited:     "Access to the community is denied",
+			ErrPostAddDenied:             "Posting is not allowed",
+			ErrAlbumOverflow:             "The album is full",
+			ErrMoneyTransferNotAllowed:   "Money transfers are not allowed",
+			ErrInsufficientPermissionsAd: "No permission to manage the ad account",
+		},
+		LangRussian: {
+			ErrUnknown:                   "Что-то пошло не так, попробуйте позже",
+			ErrApplicationDisabled:       "Приложение отключено",
+			ErrAuthFailed:                "Ошибка авторизации, войдите заново",
+			ErrTooManyRequests:           "Слишком много запросов, подождите немного",
+			ErrInsufficientPermissions:   "У приложения нет прав на это действие",
+			ErrTooManyOneTypeRequests:    "Слишком много однотипных действий, подождите немного",
+			ErrInternalServerError:       "ВКонтакте временно недоступен, попробуйте позже",
+			ErrCaptchaNeeded:             "Введите капчу, чтобы продолжить",
+			ErrNotAllowed:                "Это действие запрещено",
+			ErrNeedValidation:            "Подтвердите аккаунт, чтобы продолжить",
+			ErrUserDeleted:               "Пользователь удалён или заблокирован",
+			ErrMethodDisabled:            "Эта функция отключена",
+			ErrNeedConfirmation:          "Подтвердите действие",
+			ErrRateLimit:                 "Достигнут дневной лимит, попробуйте завтра",
+			ErrPrivateProfile:            "Профиль закрыт",
+			ErrOneOfParametersInvalid:    "Неверный запрос",
+			ErrAlbumAccessProhibited:     "Доступ к альбому запрещён",
+			ErrGroupAccessProhibited:     "Доступ к сообществу запрещён",
+			ErrPostAddDenied:             "Публикация записей запрещена",
+			ErrAlbumOverflow:             "Альбом переполнен",
+			ErrMoneyTransferNotAllowed:   "Денежные переводы запрещены",
+			ErrInsufficientPermissionsAd: "Нет прав на управление рекламным кабинетом",
+		},
+	}
+	// categoryMessages are used for codes without message
+	categoryMessages = map[string]map[ErrorCategory]string{
+		LangEnglish: {
+			CategoryUnknown:    "Something went wrong",
+			CategoryTemporary:  "Something went wrong, please try again later",
+			CategoryPermanent:  "This action can not be done",
+			CategoryAuth:       "Authorization failed, please sign in again",
+			CategoryCaptcha:    "Please solve the captcha to continue",
+			CategoryValidation: "Please confirm your account to continue",
+		},
+		LangRussian: {
+			CategoryUnknown:    "Что-то пошло не так",
+			CategoryTemporary:  "Что-то пошло не так, попробуйте позже",
+			CategoryPermanent:  "Это действие невозможно выполнить",
+			CategoryAuth:       "Ошибка авторизации, войдите заново",
+			CategoryCaptcha:    "Введите капчу, чтобы продолжить",
+			CategoryValidation: "Подтвердите аккаунт, чтобы продолжить",
+		},
+	}
+)
+
+// RegisterErrorMessages adds messages of lang, replacing existing ones
+func RegisterErrorMessages(lang string, messages map[ServerError]string) {
+	errorMessagesMux.Lock()
+	defer errorMessagesMux.Unlock()
+	if errorMessages[lang] == nil {
+		errorMessages[lang] = make(map[ServerError]string)
+	}
+	for code, m := range messages {
+		errorMessages[lang][code] = m
+	}
+}
+
+// Localize returns human-readable message of error in lang, falling
+// back to message of error category and then to english
+func (e ServerError) Localize(lang string) string {
+	errorMessagesMux.RLock()
+	defer errorMessagesMux.RUnlock()
+	for _, l := range []string{lang, LangEnglish} {
+		if m, ok := errorMessages[l][e]; ok {
+			return m
+		}
+		if m, ok := categoryMessages[l][e.Category()]; ok {
+			return m
+		}
+	}
+	return e.String()
+}
+
+// Localize returns human-readable message of error code in lang
+func (e Error) Localize(lang string) string {
+	return e.Code.Localize(lang)
+}
+
+// LocalizeError returns human-readable message of err in lang,
+// that is suitable to show to end users
+func LocalizeError(err error, lang string) string {
+	switch err := err.(type) {
+	case Error:
+		return err.Localize(lang)
+	case ServerError:
+		return err.Localize(lang)
+	case ExecuteError:
+		return err.Code.Localize(lang)
+	}
+	return CategoryOf(err).Localize(lang)
+}
+
+// Localize returns human-readable message of category in lang
+func (c ErrorCategory) Localize(lang string) string {
+	for _, l := range []string{lang, LangEnglish} {
+		if m, ok := categoryMessages[l][c]; ok {
+			return m
+		}
+	}
+	return categoryMessages[LangEnglish][CategoryUnknown]
+}
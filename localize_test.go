@@ -0,0 +1,34 @@
+package vk
+
+import (
+	"io"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLocalize(t *testing.T) {
+	Convey("Localize", t, func() {
+		So(ErrAuthFailed.Localize(LangRussian), ShouldEqual, "Ошибка авторизации, войдите заново")
+		So(ErrAuthFailed.Localize(LangEnglish), ShouldEqual, "Authorization failed, please sign in again")
+		So(ErrUserDeleted.Localize("de"), ShouldEqual, "The user is deleted or banned")
+		So(ErrStandaloneOnly.Localize(LangRussian), ShouldEqual, "Это действие невозможно выполнить")
+		So(Error{Code: ErrCaptchaNeeded}.Localize(LangEnglish), ShouldEqual, "Please solve the captcha to continue")
+		So(LocalizeError(Error{Code: ErrPrivateProfile}, LangRussian), ShouldEqual, "Профиль закрыт")
+		So(LocalizeError(ExecuteError{Code: ErrPrivateProfile}, LangEnglish), ShouldEqual, "The profile is private")
+		So(LocalizeError(ErrRateLimit, LangEnglish), ShouldEqual, "Daily limit is reached, please try again tomorrow")
+		So(LocalizeError(io.EOF, LangRussian), ShouldEqual, "Что-то пошло не так")
+		So(ErrorCategory(42).Localize("de"), ShouldEqual, "Something went wrong")
+
+		Convey("Register", func() {
+			RegisterErrorMessages("de", map[ServerError]string{ErrUserDeleted: "Der Benutzer wurde gelöscht"})
+			defer func() {
+				errorMessagesMux.Lock()
+				delete(errorMessages, "de")
+				errorMessagesMux.Unlock()
+			}()
+			So(ErrUserDeleted.Localize("de"), ShouldEqual, "Der Benutzer wurde gelöscht")
+			So(ErrAuthFailed.Localize("de"), ShouldEqual, "Authorization failed, please sign in again")
+		})
+	})
+}
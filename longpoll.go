@@ -0,0 +1,346 @@
+package vk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	methodGroupsGetLongPollServer = "groups.getLongPollServer"
+
+	defaultLongPollWait       = 25
+	defaultLongPollRetryDelay = time.Second
+
+	// failed codes of long poll response
+	longPollHistoryOutdated = 1
+	longPollKeyExpired      = 2
+	longPollInfoLost        = 3
+)
+
+// LongPollServer is bots long poll server of community
+type LongPollServer struct {
+	Key    string `json:"key"`
+	Server string `json:"server"`
+	TS     string `json:"ts"`
+}
+
+type groupsGetLongPollServerFields struct {
+	GroupID ID `url:"group_id"`
+}
+
+// GetLongPollServer returns bots long poll server of community
+func (g Groups) GetLongPollServer(groupID ID) (result LongPollServer, err error) {
+	return result, g.Decode(g.Request(methodGroupsGetLongPollServer, groupsGetLongPollServerFields{groupID}), &result)
+}
+
+type longPollResponse struct {
+	TS      json.RawMessage `json:"ts"`
+	Failed  int             `json:"failed"`
+	Updates []Event         `json:"updates"`
+}
+
+// ts returns ts that is string or number
+func (r longPollResponse) ts() string {
+	var s string
+	if json.Unmarshal(r.TS, &s) == nil {
+		return s
+	}
+	return string(r.TS)
+}
+
+// BotsLongPoll receives events of community from bots long poll
+// server and passes them to Handler. Events are not redelivered,
+// so handler errors are only reported.
+type BotsLongPoll struct {
+	Groups     Groups
+	HTTPClient HTTPClient
+	GroupID    ID
+	Handler    EventHandler
+	// Wait is long poll timeout in seconds, 25 if zero
+	Wait int
+	// OnError is called on handler errors and failed polls
+	OnError func(err error)
+	// Clock is SystemClock if nil
+	Clock Clock
+	// Cursors stores ts, so polling continues after restart or
+	// on other replica, like RedisStorage. Not stored if nil.
+	// Ts is stored after batch is handled, so delivery is at least
+	// once: events of interrupted batch are handled again.
+	Cursors Storage
+	// CursorKey is key of ts in Cursors, "longpoll:<group id>" if empty
+	CursorKey string
+
+	server LongPollServer
+}
+
+// NewBotsLongPoll returns long poll of community, client
+// should have token of community
+func NewBotsLongPoll(c *Client, groupID ID, handler EventHandler) *BotsLongPoll {
+	return &BotsLongPoll{
+		Groups:     c.Groups,
+		HTTPClient: clientTransport{c},
+		GroupID:    groupID,
+		Handler:    handler,
+		Clock:      c.clock,
+	}
+}
+
+// Run polls until ctx is done, failed polls are retried
+// after delay. BotsLongPoll is Component.
+func (p *BotsLongPoll) Run(ctx context.Context) error {
+	clock := clockOrSystem(p.Clock)
+	for ctx.Err() == nil {
+		if err := p.Poll(ctx); err != nil && ctx.Err() == nil {
+			p.report(err)
+			if clock.Sleep(ctx, defaultLongPollRetryDelay) != nil {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (p *BotsLongPoll) report(err error) {
+	if p.OnError != nil {
+		p.OnError(err)
+	}
+}
+
+func (p *BotsLongPoll) cursorKey() string {
+	if len(p.CursorKey) != 0 {
+		return p.CursorKey
+	}
+	return "longpoll:" + int64s(int64(p.GroupID))
+}
+
+// cursor returns stored ts or ts if there is none
+func (p *BotsLongPoll) cursor(ts string) (string, error) {
+	if p.Cursors == nil {
+		return ts, nil
+	}
+	value, err := p.Cursors.Get(p.cursorKey())
+	if err == ErrKeyNotFound {
+		return ts, nil
+	}
+	if err != nil {
+		return ts, err
+	}
+	return string(value), nil
+}
+
+// setTS updates ts and stores it
+func (p *BotsLongPoll) setTS(ts string) error {
+	p.server.TS = ts
+	if p.Cursors == nil {
+		return nil
+	}
+	return p.Cursors.Set(p.cursorKey(), []byte(ts), 0)
+}
+
+// Poll makes one long poll request, getting server first if needed
+func (p *BotsLongPoll) Poll(ctx context.Context) error {
+	if len(p.server.Key) == 0 {
+		server, err := p.Groups.GetLongPollServer(p.GroupID)
+		if err != nil {
+			return err
+		}
+		// ts is kept if only key has expired
+		if len(p.server.TS) != 0 {
+			server.TS = p.server.TS
+		} else if server.TS, err = p.cursor(server.TS); err != nil {
+			return err
+		}
+		p.server = server
+	}
+	wait := p.Wait
+	if wait == 0 {
+		wait = defaultLongPollWait
+	}
+	u, err := url.Parse(p.server.Server)
+	if err != nil {
+		return err
+	}
+	q := u.Query()
+	q.Set("act", "a_check")
+	q.Set("key", p.server.Key)
+	q.Set("ts", p.server.TS)
+	q.Set("wait", strconv.Itoa(wait))
+	u.RawQuery = q.Encode()
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	res, err := p.HTTPClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return HTTPError{Status: res.StatusCode}
+	}
+	var response longPollResponse
+	if err = json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return err
+	}
+	switch response.Failed {
+	case 0:
+	case longPollHistoryOutdated:
+		return p.setTS(response.ts())
+	case longPollKeyExpired:
+		p.server.Key = ""
+		return nil
+	case longPollInfoLost:
+		// stored ts is invalid too, so ts of new server is used
+		p.server = LongPollServer{}
+		if p.Cursors != nil {
+			return p.Cursors.Delete(p.cursorKey())
+		}
+		return nil
+	}
+	for _, event := range response.Updates {
+		if event.GroupID == 0 {
+			event.GroupID = p.GroupID
+		}
+		if err = p.Handler(event); err != nil {
+			p.report(err)
+		}
+	}
+	// ts is stored after events are handled, so batch
+	// interrupted by crash is polled again after restart
+	return p.setTS(response.ts())
+}
+
+// LongPollMux runs bots long poll of many communities with tokens
+// of communities, sharing http client and limiter of Client, and
+// passes events tagged with group id to one Handler
+type LongPollMux struct {
+	Client  *Client
+	Handler EventHandler
+	// Wait is long poll timeout in seconds, 25 if zero
+	Wait int
+	// OnError is called on handler errors and failed polls
+	OnError func(groupID ID, err error)
+	// Cursors stores ts of communities, see BotsLongPoll
+	Cursors Storage
+
+	mux      sync.Mutex
+	ctx      context.Context
+	wg       sync.WaitGroup
+	tokens   map[ID]string
+	sessions map[ID]*longPollSession
+}
+
+// longPollSession is running poller of community
+type longPollSession struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// AddGroup starts polling of community, replacing its previous
+// token and session. Can be called before and while running.
+func (m *LongPollMux) AddGroup(token string, groupID ID) {
+	m.mux.Lock()
+	if m.tokens == nil {
+		m.tokens = make(map[ID]string)
+	}
+	m.tokens[groupID] = token
+	previous := m.detach(groupID)
+	m.mux.Unlock()
+	// previous session is done before new one starts,
+	// so events of community are not handled twice
+	previous.wait()
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if _, running := m.sessions[groupID]; running || m.ctx == nil || m.tokens[groupID] != token {
+		// group was changed concurrently or mux is not running
+		return
+	}
+	m.start(groupID, token)
+}
+
+// RemoveGroup stops polling of community and waits until its
+// session is done, so no events of community are handled after
+// return. Handler of community should not remove it, as session
+// would wait for itself.
+func (m *LongPollMux) RemoveGroup(groupID ID) {
+	m.mux.Lock()
+	delete(m.tokens, groupID)
+	session := m.detach(groupID)
+	m.mux.Unlock()
+	session.wait()
+}
+
+// Groups returns count of added communities
+func (m *LongPollMux) Groups() int {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	return len(m.tokens)
+}
+
+// detach cancels session of community and removes it, mux is held.
+// Session should be waited for after mux is released.
+func (m *LongPollMux) detach(groupID ID) *longPollSession {
+	session, ok := m.sessions[groupID]
+	if !ok {
+		return nil
+	}
+	session.cancel()
+	delete(m.sessions, groupID)
+	return session
+}
+
+// wait blocks until session is done, nil session is done
+func (s *longPollSession) wait() {
+	if s != nil {
+		<-s.done
+	}
+}
+
+// start runs poller of community, mux is held
+func (m *LongPollMux) start(groupID ID, token string) {
+	if m.sessions == nil {
+		m.sessions = make(map[ID]*longPollSession)
+	}
+	ctx, cancel := context.WithCancel(m.ctx)
+	session := &longPollSession{cancel: cancel, done: make(chan struct{})}
+	m.sessions[groupID] = session
+	p := NewBotsLongPoll(m.Client.WithOptions(WithToken(NewToken(token))), groupID, m.Handler)
+	p.Wait = m.Wait
+	p.Cursors = m.Cursors
+	p.OnError = func(err error) {
+		if m.OnError != nil {
+			m.OnError(groupID, err)
+		}
+	}
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		defer close(session.done)
+		p.Run(ctx)
+	}()
+}
+
+// Run polls added communities until ctx is done, communities
+// can be added and removed while running. LongPollMux is Component.
+func (m *LongPollMux) Run(ctx context.Context) error {
+	m.mux.Lock()
+	m.ctx = ctx
+	for groupID, token := range m.tokens {
+		m.start(groupID, token)
+	}
+	m.mux.Unlock()
+	<-ctx.Done()
+	m.mux.Lock()
+	for groupID := range m.sessions {
+		m.detach(groupID)
+	}
+	m.ctx = nil
+	m.mux.Unlock()
+	m.wg.Wait()
+	return nil
+}
@@ -0,0 +1,203 @@
+package vk
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBotsLongPoll(t *testing.T) {
+	Convey("Bots long poll", t, func() {
+		var calls []string
+		lp := []string{
+			`{"ts": "2", "updates": [{"type": "message_new", "object": {}}]}`,
+			`{"failed": 1, "ts": "5"}`,
+			`{"failed": 2}`,
+		}
+		client := New(WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			if req.URL.Host == "lp.vk.com" {
+				calls = append(calls, "a_check "+q.Get("key")+" "+q.Get("ts")+" "+q.Get("wait"))
+				body := lp[0]
+				lp = lp[1:]
+				return jsonResponse(http.StatusOK, body), nil
+			}
+			calls = append(calls, req.URL.Path[len("/method/"):]+" "+q.Get("group_id"))
+			return jsonResponse(http.StatusOK, `{"response": {"key": "k", "server": "https://lp.vk.com/wh1", "ts": "1"}}`), nil
+		})))
+		var events []Event
+		p := NewBotsLongPoll(client, 1, func(e Event) error {
+			events = append(events, e)
+			return nil
+		})
+		for i := 0; i < 3; i++ {
+			So(p.Poll(context.Background()), ShouldBeNil)
+		}
+		So(events, ShouldHaveLength, 1)
+		So(events[0].GroupID, ShouldEqual, 1)
+		So(events[0].Type, ShouldEqual, "message_new")
+		So(calls, ShouldResemble, []string{
+			"groups.getLongPollServer 1",
+			"a_check k 1 25",
+			"a_check k 2 25",
+			"a_check k 5 25",
+		})
+		So(p.server.Key, ShouldBeEmpty)
+		So(p.server.TS, ShouldEqual, "5")
+
+		Convey("Cursors", func() {
+			calls = nil
+			lp = []string{
+				`{"ts": "8", "updates": []}`,
+				`{"failed": 1, "ts": "10"}`,
+				`{"failed": 3}`,
+				`{"ts": "3", "updates": []}`,
+			}
+			cursors := &MemoryStorage{}
+			So(cursors.Set("longpoll:2", []byte("7"), 0), ShouldBeNil)
+			p := NewBotsLongPoll(client, 2, p.Handler)
+			p.Cursors = cursors
+			for i := 0; i < 2; i++ {
+				So(p.Poll(context.Background()), ShouldBeNil)
+			}
+			ts, err := cursors.Get("longpoll:2")
+			So(err, ShouldBeNil)
+			So(string(ts), ShouldEqual, "10")
+			So(p.Poll(context.Background()), ShouldBeNil)
+			_, err = cursors.Get("longpoll:2")
+			So(err, ShouldEqual, ErrKeyNotFound)
+			So(p.Poll(context.Background()), ShouldBeNil)
+			ts, _ = cursors.Get("longpoll:2")
+			So(string(ts), ShouldEqual, "3")
+			So(calls, ShouldResemble, []string{
+				"groups.getLongPollServer 2",
+				"a_check k 7 25",
+				"a_check k 8 25",
+				"a_check k 10 25",
+				"groups.getLongPollServer 2",
+				"a_check k 1 25",
+			})
+		})
+		Convey("Cursor is stored after handler", func() {
+			lp = []string{`{"ts": "9", "updates": [{"type": "message_new", "object": {}}]}`}
+			cursors := &MemoryStorage{}
+			var stored []string
+			p := NewBotsLongPoll(client, 2, func(e Event) error {
+				ts, _ := cursors.Get("longpoll:2")
+				stored = append(stored, string(ts))
+				return nil
+			})
+			p.Cursors = cursors
+			So(p.Poll(context.Background()), ShouldBeNil)
+			So(stored, ShouldResemble, []string{""})
+			ts, _ := cursors.Get("longpoll:2")
+			So(string(ts), ShouldEqual, "9")
+		})
+	})
+}
+
+func TestLongPollMux(t *testing.T) {
+	Convey("Long poll multiplexer", t, func() {
+		var mux sync.Mutex
+		tokens := map[string]string{}
+		client := New(WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			if req.URL.Host == "lp.vk.com" {
+				select {
+				case <-req.Context().Done():
+					return nil, req.Context().Err()
+				case <-time.After(time.Millisecond):
+				}
+				return jsonResponse(http.StatusOK, `{"ts": 1, "updates": [{"type": "wall_post_new", "object": {}}]}`), nil
+			}
+			mux.Lock()
+			tokens[q.Get("group_id")] = q.Get(paramToken)
+			mux.Unlock()
+			return jsonResponse(http.StatusOK, `{"response": {"key": "k", "server": "https://lp.vk.com/wh`+q.Get("group_id")+`", "ts": "1"}}`), nil
+		})))
+		seen := make(chan ID, 100)
+		m := &LongPollMux{Client: client, Handler: func(e Event) error {
+			select {
+			case seen <- e.GroupID:
+			default:
+			}
+			return nil
+		}}
+		m.AddGroup("a", 1)
+		m.AddGroup("b", 2)
+		m.AddGroup("c", 3)
+		m.RemoveGroup(3)
+		So(m.Groups(), ShouldEqual, 2)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			m.Run(ctx)
+			close(done)
+		}()
+		got := map[ID]bool{}
+		for len(got) < 2 {
+			got[<-seen] = true
+		}
+		So(got, ShouldResemble, map[ID]bool{1: true, 2: true})
+
+		Convey("Groups are added and removed at runtime", func() {
+			m.AddGroup("d", 4)
+			for <-seen != 4 {
+			}
+			m.RemoveGroup(1)
+			m.RemoveGroup(4)
+			So(m.Groups(), ShouldEqual, 1)
+			// drain events that were sent before removal
+			for len(seen) > 0 {
+				<-seen
+			}
+			for i := 0; i < 10; i++ {
+				So(<-seen, ShouldEqual, 2)
+			}
+			cancel()
+			<-done
+			mux.Lock()
+			So(tokens, ShouldResemble, map[string]string{"1": "a", "2": "b", "4": "d"})
+			mux.Unlock()
+		})
+	})
+	Convey("Handler uses multiplexer", t, func() {
+		client := New(WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Host == "lp.vk.com" {
+				select {
+				case <-req.Context().Done():
+					return nil, req.Context().Err()
+				case <-time.After(time.Millisecond):
+				}
+				return jsonResponse(http.StatusOK, `{"ts": 1, "updates": [{"type": "wall_post_new", "object": {}}]}`), nil
+			}
+			return jsonResponse(http.StatusOK, `{"response": {"key": "k", "server": "https://lp.vk.com/wh", "ts": "1"}}`), nil
+		})))
+		removed := make(chan int, 1)
+		m := &LongPollMux{Client: client}
+		m.Handler = func(e Event) error {
+			// handlers of both groups use mux while group 2 is removed
+			if m.Groups() == 2 && e.GroupID == 1 {
+				m.RemoveGroup(2)
+				removed <- m.Groups()
+			}
+			return nil
+		}
+		m.AddGroup("a", 1)
+		m.AddGroup("b", 2)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go m.Run(ctx)
+		select {
+		case n := <-removed:
+			So(n, ShouldEqual, 1)
+		case <-time.After(5 * time.Second):
+			So("deadlock", ShouldBeEmpty)
+		}
+	})
+}
@@ -0,0 +1,188 @@
+package vk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+const (
+	methodMarketEditOrder     = "market.editOrder"
+	methodMarketGetOrderItems = "market.getOrderItems"
+
+	eventMarketOrderNew  = "market_order_new"
+	eventMarketOrderEdit = "market_order_edit"
+)
+
+// Market resource
+type Market struct {
+	Resource
+}
+
+// OrderStatus is status of market order
+type OrderStatus int
+
+const (
+	OrderNew OrderStatus = iota
+	OrderApproved
+	OrderAssembling
+	OrderDelivering
+	OrderCompleted
+	OrderCanceled
+	OrderReturned
+)
+
+var orderStatusNames = [...]string{"new", "approved", "assembling", "delivering", "completed", "canceled", "returned"}
+
+func (s OrderStatus) String() string {
+	if s < 0 || int(s) >= len(orderStatusNames) {
+		return fmt.Sprintf("OrderStatus(%d)", int(s))
+	}
+	return orderStatusNames[s]
+}
+
+func (s OrderStatus) EncodeValues(key string, v *url.Values) error {
+	v.Add(key, strconv.Itoa(int(s)))
+	return nil
+}
+
+// orderTransitions are allowed changes of order status
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderNew:        {OrderApproved, OrderAssembling, OrderCanceled},
+	OrderApproved:   {OrderAssembling, OrderDelivering, OrderCanceled},
+	OrderAssembling: {OrderDelivering, OrderCompleted, OrderCanceled},
+	OrderDelivering: {OrderCompleted, OrderReturned, OrderCanceled},
+	OrderCompleted:  {OrderReturned},
+}
+
+// CanTransition reports whether order status can be changed to next
+func (s OrderStatus) CanTransition(next OrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// OrderTransitionError is returned on not allowed change of order status
+type OrderTransitionError struct {
+	From OrderStatus
+	To   OrderStatus
+}
+
+func (e OrderTransitionError) Error() string {
+	return fmt.Sprintf("market order: transition from %s to %s is not allowed", e.From, e.To)
+}
+
+// OrderDelivery is delivery info of order
+type OrderDelivery struct {
+	Address     string `json:"address"`
+	Type        string `json:"type"`
+	TrackNumber string `json:"track_number,omitempty"`
+	TrackLink   string `json:"track_link,omitempty"`
+}
+
+// OrderRecipient is recipient of order
+type OrderRecipient struct {
+	Name        string `json:"name"`
+	Phone       string `json:"phone"`
+	DisplayText string `json:"display_text"`
+}
+
+// OrderItem is item of order
+type OrderItem struct {
+	OwnerID  ID     `json:"owner_id"`
+	ItemID   int    `json:"item_id"`
+	Price    Price  `json:"price"`
+	Quantity int    `json:"quantity"`
+	Title    string `json:"title"`
+	Item     Raw    `json:"item,omitempty"`
+}
+
+// Order is market order
+type Order struct {
+	ID              int            `json:"id"`
+	GroupID         ID             `json:"group_id"`
+	UserID          ID             `json:"user_id"`
+	Date            Time           `json:"date"`
+	Status          OrderStatus    `json:"status"`
+	ItemsCount      int            `json:"items_count"`
+	TotalPrice      Price          `json:"total_price"`
+	DisplayOrderID  string         `json:"display_order_id"`
+	Comment         string         `json:"comment"`
+	MerchantComment string         `json:"merchant_comment,omitempty"`
+	PreviewItems    []OrderItem    `json:"preview_order_items"`
+	Delivery        OrderDelivery  `json:"delivery"`
+	Recipient       OrderRecipient `json:"recipient"`
+}
+
+type MarketEditOrderFields struct {
+	UserID          ID           `url:"user_id"`
+	OrderID         int          `url:"order_id"`
+	MerchantComment string       `url:"merchant_comment,omitempty"`
+	Status          *OrderStatus `url:"status,omitempty"`
+	TrackNumber     string       `url:"track_number,omitempty"`
+	PaymentStatus   string       `url:"payment_status,omitempty"`
+	DeliveryPrice   int          `url:"delivery_price,omitempty"`
+}
+
+// EditOrder edits order without checking status transition
+func (m Market) EditOrder(fields MarketEditOrderFields) error {
+	var result int
+	return m.Decode(m.Request(methodMarketEditOrder, fields), &result)
+}
+
+// SetOrderStatus changes status of order if transition is allowed,
+// returning OrderTransitionError otherwise
+func (m Market) SetOrderStatus(order Order, status OrderStatus) error {
+	if !order.Status.CanTransition(status) {
+		return OrderTransitionError{From: order.Status, To: status}
+	}
+	return m.EditOrder(MarketEditOrderFields{UserID: order.UserID, OrderID: order.ID, Status: &status})
+}
+
+type marketGetOrderItemsFields struct {
+	UserID  ID  `url:"user_id,omitempty"`
+	OrderID int `url:"order_id"`
+	Offset  int `url:"offset,omitempty"`
+	Count   int `url:"count,omitempty"`
+}
+
+type MarketGetOrderItemsResult struct {
+	Count int         `json:"count"`
+	Items []OrderItem `json:"items"`
+}
+
+// GetOrderItems returns items of order
+func (m Market) GetOrderItems(userID ID, orderID int) (result MarketGetOrderItemsResult, err error) {
+	return result, m.Decode(m.Request(methodMarketGetOrderItems, marketGetOrderItemsFields{UserID: userID, OrderID: orderID}), &result)
+}
+
+// OrderHandler handles market_order_new and market_order_edit events
+type OrderHandler struct {
+	// OnNew is called for new orders
+	OnNew func(order Order) error
+	// OnEdit is called for edited orders
+	OnEdit func(order Order) error
+}
+
+// HandleEvent decodes order from event, it is EventHandler
+func (h OrderHandler) HandleEvent(event Event) error {
+	var handle func(order Order) error
+	switch event.Type {
+	case eventMarketOrderNew:
+		handle = h.OnNew
+	case eventMarketOrderEdit:
+		handle = h.OnEdit
+	}
+	if handle == nil {
+		return nil
+	}
+	var order Order
+	if err := json.Unmarshal(event.Object, &order); err != nil {
+		return err
+	}
+	return handle(order)
+}
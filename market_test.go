@@ -0,0 +1,56 @@
+package vk
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMarketOrders(t *testing.T) {
+	Convey("Market orders", t, func() {
+		client := New()
+		var edits []string
+		client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			if req.URL.Path == "/method/market.getOrderItems" {
+				return jsonResponse(http.StatusOK, `{"response": {"count": 1, "items": [{"item_id": 5, "quantity": 2, "price": {"amount": "10000", "currency": {"id": 643, "name": "RUB"}, "text": "100 руб."}}]}}`), nil
+			}
+			So(req.URL.Path, ShouldEqual, "/method/market.editOrder")
+			edits = append(edits, q.Get("user_id")+" "+q.Get("order_id")+" "+q.Get("status")+" "+q.Get("track_number"))
+			return jsonResponse(http.StatusOK, `{"response": 1}`), nil
+		}))
+		var orders []Order
+		h := OrderHandler{OnNew: func(o Order) error {
+			orders = append(orders, o)
+			return nil
+		}}
+		So(h.HandleEvent(Event{Type: "market_order_edit", Object: Raw(`{}`)}), ShouldBeNil)
+		So(h.HandleEvent(Event{Type: "market_order_new", Object: Raw(`{
+			"id": 7, "group_id": 1, "user_id": 2, "status": 0, "items_count": 2,
+			"total_price": {"amount": "20000", "currency": {"id": 643, "name": "RUB"}, "text": "200 руб."},
+			"delivery": {"address": "Moscow", "type": "courier"},
+			"recipient": {"name": "Ivan", "phone": "+7000"}
+		}`)}), ShouldBeNil)
+		So(orders, ShouldHaveLength, 1)
+		order := orders[0]
+		So(order.TotalPrice.Currency.Name, ShouldEqual, "RUB")
+		So(order.Delivery.Address, ShouldEqual, "Moscow")
+		So(order.Status.String(), ShouldEqual, "new")
+
+		items, err := client.Market.GetOrderItems(order.UserID, order.ID)
+		So(err, ShouldBeNil)
+		So(items.Items[0].Quantity, ShouldEqual, 2)
+
+		So(client.Market.SetOrderStatus(order, OrderApproved), ShouldBeNil)
+		order.Status = OrderApproved
+		err = client.Market.SetOrderStatus(order, OrderReturned)
+		So(err, ShouldResemble, OrderTransitionError{From: OrderApproved, To: OrderReturned})
+		So(err.Error(), ShouldEqual, "market order: transition from approved to returned is not allowed")
+		So(OrderCanceled.CanTransition(OrderNew), ShouldBeFalse)
+		So(OrderStatus(10).String(), ShouldEqual, "OrderStatus(10)")
+
+		So(client.Market.EditOrder(MarketEditOrderFields{UserID: 2, OrderID: 7, TrackNumber: "RA1"}), ShouldBeNil)
+		So(edits, ShouldResemble, []string{"2 7 1 ", "2 7  RA1"})
+	})
+}
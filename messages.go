@@ -0,0 +1,118 @@
+package vk
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/url"
+)
+
+const (
+	methodMessagesSend = "messages.send"
+)
+
+// Messages resource
+type Messages struct {
+	Resource
+}
+
+// Forward is the forward parameter of messages.send, that
+// describes forwarded messages or a reply to a message
+type Forward struct {
+	PeerID                 ID    `json:"peer_id,omitempty"`
+	ConversationMessageIDs []int `json:"conversation_message_ids,omitempty"`
+	MessageIDs             []int `json:"message_ids,omitempty"`
+	IsReply                bool  `json:"is_reply,omitempty"`
+}
+
+// NewForward returns Forward of conversation messages from peer
+func NewForward(peerID ID, ids ...int) *Forward {
+	return &Forward{PeerID: peerID, ConversationMessageIDs: ids}
+}
+
+// ReplyTo returns Forward that replies to message in its peer
+func ReplyTo(m Message) *Forward {
+	return NewForward(m.PeerID, m.ConversationMessageID).Reply()
+}
+
+// Add appends conversation message ids to forward
+func (f *Forward) Add(ids ...int) *Forward {
+	f.ConversationMessageIDs = append(f.ConversationMessageIDs, ids...)
+	return f
+}
+
+// Reply marks forward as reply
+func (f *Forward) Reply() *Forward {
+	f.IsReply = true
+	return f
+}
+
+// EncodeValues encodes forward as JSON
+func (f Forward) EncodeValues(key string, v *url.Values) error {
+	data, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	v.Add(key, string(data))
+	return nil
+}
+
+type MessageSendFields struct {
+	UserID          int      `url:"user_id,omitempty"`
+	PeerID          int      `url:"peer_id,omitempty"`
+	Domain          string   `url:"domain,omitempty"`
+	ChatID          int      `url:"chat_id,omitempty"`
+	RandomID        int32    `url:"random_id"`
+	Message         string   `url:"message,omitempty"`
+	Attachment      string   `url:"attachment,omitempty"`
+	Forward         *Forward `url:"forward,omitempty"`
+	StickerID       int      `url:"sticker_id,omitempty"`
+	DontParseLinks  Bool     `url:"dont_parse_links,omitempty"`
+	DisableMentions Bool     `url:"disable_mentions,omitempty"`
+	// Latitude and Longitude are pointers, so zero coordinates are sent
+	Latitude  *float64 `url:"lat,omitempty"`
+	Longitude *float64 `url:"long,omitempty"`
+}
+
+// SetLocation attaches coordinates to message
+func (f *MessageSendFields) SetLocation(c Coordinates) {
+	f.Latitude = &c.Latitude
+	f.Longitude = &c.Longitude
+}
+
+// Message is a private or chat message
+type Message struct {
+	ID                    ID             `json:"id"`
+	Date                  Time           `json:"date"`
+	PeerID                ID             `json:"peer_id"`
+	FromID                ID             `json:"from_id"`
+	Text                  string         `json:"text"`
+	RandomID              int            `json:"random_id"`
+	ConversationMessageID int            `json:"conversation_message_id"`
+	Out                   Bool           `json:"out"`
+	Attachments           []Raw          `json:"attachments"`
+	Geo                   *Geo           `json:"geo,omitempty"`
+	FwdMessages           []Message      `json:"fwd_messages"`
+	ReplyMessage          *Message       `json:"reply_message,omitempty"`
+	Action                *MessageAction `json:"action,omitempty"`
+	// ReactionID is reaction of current user
+	ReactionID int               `json:"reaction_id,omitempty"`
+	Reactions  []MessageReaction `json:"reactions,omitempty"`
+}
+
+// Location returns coordinates of message geo and
+// false if message has no geo
+func (m Message) Location() (Coordinates, bool) {
+	if m.Geo == nil {
+		return Coordinates{}, false
+	}
+	return m.Geo.Coordinates, true
+}
+
+// Send sends message and returns its id,
+// random_id is generated if not set
+func (m Messages) Send(fields MessageSendFields) (id int, err error) {
+	if fields.RandomID == 0 {
+		fields.RandomID = rand.Int31()
+	}
+	return id, m.Decode(m.Request(methodMessagesSend, fields), &id)
+}
@@ -0,0 +1,46 @@
+package vk
+
+import (
+	"net/url"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestForward(t *testing.T) {
+	Convey("Forward", t, func() {
+		Convey("Reply", func() {
+			v := &url.Values{}
+			So(ReplyTo(Message{PeerID: 1, ConversationMessageID: 15}).EncodeValues("forward", v), ShouldBeNil)
+			So(v.Get("forward"), ShouldEqual, `{"peer_id":1,"conversation_message_ids":[15],"is_reply":true}`)
+		})
+		Convey("Builder", func() {
+			v := &url.Values{}
+			f := NewForward(2000000001, 1, 2).Add(3)
+			So(f.EncodeValues("forward", v), ShouldBeNil)
+			So(v.Get("forward"), ShouldEqual, `{"peer_id":2000000001,"conversation_message_ids":[1,2,3]}`)
+			So(f.Reply().IsReply, ShouldBeTrue)
+		})
+	})
+}
+
+func TestMessagesMethods(t *testing.T) {
+	Convey("Messages methods", t, func() {
+		Convey(methodMessagesSend, func() {
+			mock := newApiMock(`{"response": 42}`, nil)
+			f := rf()
+			m := Messages{record(mock, &f)}
+			id, err := m.Send(MessageSendFields{PeerID: 1, Message: "hi", Forward: ReplyTo(Message{PeerID: 1, ConversationMessageID: 10})})
+			So(err, ShouldBeNil)
+			So(id, ShouldEqual, 42)
+			So(f.request.Values.Get("peer_id"), ShouldEqual, "1")
+			So(f.request.Values.Get("random_id"), ShouldNotEqual, "0")
+			So(f.request.Values.Get("forward"), ShouldEqual, `{"peer_id":1,"conversation_message_ids":[10],"is_reply":true}`)
+			Convey("Without forward", func() {
+				_, err := m.Send(MessageSendFields{PeerID: 1})
+				So(err, ShouldBeNil)
+				So(f.request.Values.Get("forward"), ShouldBeBlank)
+			})
+		})
+	})
+}
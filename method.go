@@ -0,0 +1,27 @@
+package vk
+
+//go:generate go run ./internal/methodgen -in internal/methodgen/methods.txt -out methods.go
+
+// UnknownMethodError is returned for requests with method
+// that is not known when method validation is enabled
+type UnknownMethodError struct {
+	Method string
+}
+
+func (e UnknownMethodError) Error() string {
+	return "unknown api method " + e.Method
+}
+
+// KnownMethod reports whether method is known api method name
+func KnownMethod(method string) bool {
+	_, ok := knownMethods[method]
+	return ok
+}
+
+// WithMethodValidation makes client reject requests with
+// unknown method names instead of sending them
+func WithMethodValidation() Option {
+	return func(c *Client) {
+		c.validateMethods = true
+	}
+}
@@ -0,0 +1,40 @@
+package vk
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMethods(t *testing.T) {
+	Convey("Method names", t, func() {
+		So(MethodUsersGet, ShouldEqual, "users.get")
+		So(MethodMessagesGetByConversationMessageID, ShouldEqual, "messages.getByConversationMessageId")
+		So(KnownMethod(MethodExecute), ShouldBeTrue)
+		So(KnownMethod("user.get"), ShouldBeFalse)
+		// internal method names are known
+		for _, m := range []string{methodUsersGet, methodWallGet, methodNewsfeedGet, methodGroupsGetMembers,
+			methodAudioGetByID, methodUtilsGetServerTime, methodFriendsGetOnline, methodSecureCheckToken,
+			methodStoriesSendInteraction, methodPlacesGetCheckins, methodMessagesSendReaction, methodMessagesDeleteReaction,
+			methodAccountGetPrivacySettings, methodAccountSetPrivacy} {
+			So(KnownMethod(m), ShouldBeTrue)
+		}
+		Convey("Validation", func() {
+			calls := 0
+			httpClient := WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+				calls++
+				return jsonResponse(http.StatusOK, `{"response": 1}`), nil
+			}))
+			_, err := New(httpClient).Do(Request{Method: "user.get"})
+			So(err, ShouldBeNil)
+			client := New(httpClient, WithMethodValidation())
+			_, err = client.Do(Request{Method: "user.get"})
+			So(err, ShouldResemble, UnknownMethodError{"user.get"})
+			So(err.Error(), ShouldEqual, "unknown api method user.get")
+			_, err = client.Do(Request{Method: MethodUsersGet})
+			So(err, ShouldBeNil)
+			So(calls, ShouldEqual, 2)
+		})
+	})
+}
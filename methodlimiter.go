@@ -0,0 +1,118 @@
+package vk
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type requestMethodKey struct{}
+
+// RequestMethod returns method of request that is made with ctx,
+// it is set for Limiter.Wait and is blank outside of requests
+func RequestMethod(ctx context.Context) string {
+	m, _ := ctx.Value(requestMethodKey{}).(string)
+	return m
+}
+
+// ConcurrencyLimiter is Limiter that also limits requests in flight,
+// Acquire is called once per request and release when it is done
+type ConcurrencyLimiter interface {
+	Limiter
+	Acquire(ctx context.Context) (release func(), err error)
+}
+
+// MethodLimit is limit of requests to single method
+type MethodLimit struct {
+	// RPS is requests per second for method, method is
+	// limited by default limiter if zero
+	RPS int
+	// Concurrency is count of requests to method in flight,
+	// not limited if zero
+	Concurrency int
+}
+
+// methodLimit is state of MethodLimit
+type methodLimit struct {
+	rate  *MemoryLimiter
+	slots chan struct{}
+}
+
+// MethodLimiter is Limiter with per-method overrides, so heavy
+// traffic to some methods does not starve others. Methods with RPS
+// override are limited by it and then by Default, so all requests
+// fit under token-wide limit of vk.
+type MethodLimiter struct {
+	// Default limits all methods, no limit if nil
+	Default Limiter
+	// Clock is SystemClock if nil
+	Clock Clock
+
+	mux     sync.RWMutex
+	methods map[string]methodLimit
+}
+
+// NewMethodLimiter returns limiter with limits overrides for methods
+func NewMethodLimiter(defaultLimiter Limiter, limits map[string]MethodLimit) *MethodLimiter {
+	l := &MethodLimiter{Default: defaultLimiter}
+	for method, limit := range limits {
+		l.Set(method, limit)
+	}
+	return l
+}
+
+// Set overrides limit of method, zero limit removes override
+func (l *MethodLimiter) Set(method string, limit MethodLimit) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	if l.methods == nil {
+		l.methods = make(map[string]methodLimit)
+	}
+	if limit == (MethodLimit{}) {
+		delete(l.methods, method)
+		return
+	}
+	var m methodLimit
+	if limit.RPS > 0 {
+		m.rate = &MemoryLimiter{Interval: time.Second / time.Duration(limit.RPS), Clock: l.Clock}
+	}
+	if limit.Concurrency > 0 {
+		m.slots = make(chan struct{}, limit.Concurrency)
+	}
+	l.methods[method] = m
+}
+
+func (l *MethodLimiter) method(ctx context.Context) methodLimit {
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+	return l.methods[RequestMethod(ctx)]
+}
+
+// Wait blocks until request to method from ctx can be made
+// without exceeding method and default limits
+func (l *MethodLimiter) Wait(ctx context.Context) error {
+	if m := l.method(ctx); m.rate != nil {
+		if err := m.rate.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	if l.Default == nil {
+		return nil
+	}
+	return l.Default.Wait(ctx)
+}
+
+// Acquire blocks until request to method from ctx can be made
+// without exceeding concurrency limit of method
+func (l *MethodLimiter) Acquire(ctx context.Context) (func(), error) {
+	m := l.method(ctx)
+	if m.slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case m.slots <- struct{}{}:
+		return func() { <-m.slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
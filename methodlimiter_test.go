@@ -0,0 +1,82 @@
+package vk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMethodLimiter(t *testing.T) {
+	Convey("Method limiter", t, func() {
+		clock := NewFakeClock(time.Unix(1000, 0))
+		def := NewLimiter(100)
+		def.Clock = clock
+		l := NewMethodLimiter(def, map[string]MethodLimit{
+			methodMessagesSend: {RPS: 1, Concurrency: 1},
+		})
+		l.Clock = clock
+		l.Set(methodMessagesSend, MethodLimit{RPS: 1, Concurrency: 1})
+		send := context.WithValue(context.Background(), requestMethodKey{}, methodMessagesSend)
+		get := context.WithValue(context.Background(), requestMethodKey{}, methodUsersGet)
+		So(RequestMethod(send), ShouldEqual, methodMessagesSend)
+		So(RequestMethod(context.Background()), ShouldBeEmpty)
+
+		So(l.Wait(send), ShouldBeNil)
+		So(l.Wait(get), ShouldBeNil)
+		So(l.Wait(get), ShouldBeNil)
+		So(l.Wait(send), ShouldBeNil)
+		// overridden method waits for its own limit and then for default
+		So(clock.Slept(), ShouldResemble, []time.Duration{
+			0, 0, // send
+			10 * time.Millisecond,     // get
+			10 * time.Millisecond,     // get
+			980 * time.Millisecond, 0, // send
+		})
+		// default is shared, so get after send waits for it
+		So(l.Wait(get), ShouldBeNil)
+		So(clock.Slept()[6:], ShouldResemble, []time.Duration{10 * time.Millisecond})
+
+		Convey("Concurrency", func() {
+			release, err := l.Acquire(send)
+			So(err, ShouldBeNil)
+			ctx, cancel := context.WithTimeout(send, time.Millisecond)
+			defer cancel()
+			_, err = l.Acquire(ctx)
+			So(errors.Is(err, context.DeadlineExceeded), ShouldBeTrue)
+			free, err := l.Acquire(get)
+			So(err, ShouldBeNil)
+			free()
+			release()
+			release, err = l.Acquire(send)
+			So(err, ShouldBeNil)
+			release()
+		})
+		Convey("Removed override", func() {
+			l.Set(methodMessagesSend, MethodLimit{})
+			l.Default = nil
+			So(l.Wait(send), ShouldBeNil)
+			release, err := l.Acquire(send)
+			So(err, ShouldBeNil)
+			release()
+		})
+		Convey("Client", func() {
+			client := New(WithLimiter(l))
+			var methods []string
+			client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+				methods = append(methods, RequestMethod(req.Context()))
+				_, err := l.Acquire(req.Context())
+				So(err, ShouldNotBeNil)
+				return jsonResponse(http.StatusOK, `{"response": 1}`), nil
+			}))
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			_, err := client.DoContext(ctx, Request{Method: methodMessagesSend})
+			So(err, ShouldBeNil)
+			So(methods, ShouldResemble, []string{methodMessagesSend})
+		})
+	})
+}
@@ -0,0 +1,1017 @@
+// Code generated by methodgen; DO NOT EDIT.
+
+package vk
+
+// Names of vk api methods
+const (
+	MethodAccountBan                           = "account.ban"
+	MethodAccountChangePassword                = "account.changePassword"
+	MethodAccountGetActiveOffers               = "account.getActiveOffers"
+	MethodAccountGetAppPermissions             = "account.getAppPermissions"
+	MethodAccountGetBanned                     = "account.getBanned"
+	MethodAccountGetCounters                   = "account.getCounters"
+	MethodAccountGetInfo                       = "account.getInfo"
+	MethodAccountGetPrivacySettings            = "account.getPrivacySettings"
+	MethodAccountGetProfileInfo                = "account.getProfileInfo"
+	MethodAccountGetPushSettings               = "account.getPushSettings"
+	MethodAccountRegisterDevice                = "account.registerDevice"
+	MethodAccountSaveProfileInfo               = "account.saveProfileInfo"
+	MethodAccountSetInfo                       = "account.setInfo"
+	MethodAccountSetNameInMenu                 = "account.setNameInMenu"
+	MethodAccountSetOffline                    = "account.setOffline"
+	MethodAccountSetOnline                     = "account.setOnline"
+	MethodAccountSetPrivacy                    = "account.setPrivacy"
+	MethodAccountSetPushSettings               = "account.setPushSettings"
+	MethodAccountSetSilenceMode                = "account.setSilenceMode"
+	MethodAccountUnban                         = "account.unban"
+	MethodAccountUnregisterDevice              = "account.unregisterDevice"
+	MethodAdsAddOfficeUsers                    = "ads.addOfficeUsers"
+	MethodAdsCheckLink                         = "ads.checkLink"
+	MethodAdsCreateAds                         = "ads.createAds"
+	MethodAdsCreateCampaigns                   = "ads.createCampaigns"
+	MethodAdsCreateClients                     = "ads.createClients"
+	MethodAdsCreateTargetGroup                 = "ads.createTargetGroup"
+	MethodAdsDeleteAds                         = "ads.deleteAds"
+	MethodAdsDeleteCampaigns                   = "ads.deleteCampaigns"
+	MethodAdsDeleteClients                     = "ads.deleteClients"
+	MethodAdsDeleteTargetGroup                 = "ads.deleteTargetGroup"
+	MethodAdsGetAccounts                       = "ads.getAccounts"
+	MethodAdsGetAds                            = "ads.getAds"
+	MethodAdsGetAdsLayout                      = "ads.getAdsLayout"
+	MethodAdsGetAdsTargeting                   = "ads.getAdsTargeting"
+	MethodAdsGetBudget                         = "ads.getBudget"
+	MethodAdsGetCampaigns                      = "ads.getCampaigns"
+	MethodAdsGetCategories                     = "ads.getCategories"
+	MethodAdsGetClients                        = "ads.getClients"
+	MethodAdsGetDemographics                   = "ads.getDemographics"
+	MethodAdsGetFloodStats                     = "ads.getFloodStats"
+	MethodAdsGetOfficeUsers                    = "ads.getOfficeUsers"
+	MethodAdsGetPostsReach                     = "ads.getPostsReach"
+	MethodAdsGetRejectionReason                = "ads.getRejectionReason"
+	MethodAdsGetStatistics                     = "ads.getStatistics"
+	MethodAdsGetSuggestions                    = "ads.getSuggestions"
+	MethodAdsGetTargetGroups                   = "ads.getTargetGroups"
+	MethodAdsGetTargetingStats                 = "ads.getTargetingStats"
+	MethodAdsGetUploadURL                      = "ads.getUploadURL"
+	MethodAdsGetVideoUploadURL                 = "ads.getVideoUploadURL"
+	MethodAdsImportTargetContacts              = "ads.importTargetContacts"
+	MethodAdsRemoveOfficeUsers                 = "ads.removeOfficeUsers"
+	MethodAdsUpdateAds                         = "ads.updateAds"
+	MethodAdsUpdateCampaigns                   = "ads.updateCampaigns"
+	MethodAdsUpdateClients                     = "ads.updateClients"
+	MethodAdsUpdateTargetGroup                 = "ads.updateTargetGroup"
+	MethodAppWidgetsGetAppImageUploadServer    = "appWidgets.getAppImageUploadServer"
+	MethodAppWidgetsGetAppImages               = "appWidgets.getAppImages"
+	MethodAppWidgetsGetGroupImageUploadServer  = "appWidgets.getGroupImageUploadServer"
+	MethodAppWidgetsGetGroupImages             = "appWidgets.getGroupImages"
+	MethodAppWidgetsGetImagesByID              = "appWidgets.getImagesById"
+	MethodAppWidgetsSaveAppImage               = "appWidgets.saveAppImage"
+	MethodAppWidgetsSaveGroupImage             = "appWidgets.saveGroupImage"
+	MethodAppWidgetsUpdate                     = "appWidgets.update"
+	MethodAppsDeleteAppRequests                = "apps.deleteAppRequests"
+	MethodAppsGet                              = "apps.get"
+	MethodAppsGetCatalog                       = "apps.getCatalog"
+	MethodAppsGetFriendsList                   = "apps.getFriendsList"
+	MethodAppsGetLeaderboard                   = "apps.getLeaderboard"
+	MethodAppsGetScopes                        = "apps.getScopes"
+	MethodAppsGetScore                         = "apps.getScore"
+	MethodAppsPromoHasActiveGift               = "apps.promoHasActiveGift"
+	MethodAppsPromoUseGift                     = "apps.promoUseGift"
+	MethodAppsSendRequest                      = "apps.sendRequest"
+	MethodAudioGet                             = "audio.get"
+	MethodAudioGetByID                         = "audio.getById"
+	MethodAudioGetUploadServer                 = "audio.getUploadServer"
+	MethodAudioSave                            = "audio.save"
+	MethodAudioSearch                          = "audio.search"
+	MethodAuthExchangeSilentAuthToken          = "auth.exchangeSilentAuthToken"
+	MethodAuthRestore                          = "auth.restore"
+	MethodBoardAddTopic                        = "board.addTopic"
+	MethodBoardCloseTopic                      = "board.closeTopic"
+	MethodBoardCreateComment                   = "board.createComment"
+	MethodBoardDeleteComment                   = "board.deleteComment"
+	MethodBoardDeleteTopic                     = "board.deleteTopic"
+	MethodBoardEditComment                     = "board.editComment"
+	MethodBoardEditTopic                       = "board.editTopic"
+	MethodBoardFixTopic                        = "board.fixTopic"
+	MethodBoardGetComments                     = "board.getComments"
+	MethodBoardGetTopics                       = "board.getTopics"
+	MethodBoardOpenTopic                       = "board.openTopic"
+	MethodBoardRestoreComment                  = "board.restoreComment"
+	MethodBoardUnfixTopic                      = "board.unfixTopic"
+	MethodDatabaseGetChairs                    = "database.getChairs"
+	MethodDatabaseGetCities                    = "database.getCities"
+	MethodDatabaseGetCitiesByID                = "database.getCitiesById"
+	MethodDatabaseGetCountries                 = "database.getCountries"
+	MethodDatabaseGetCountriesByID             = "database.getCountriesById"
+	MethodDatabaseGetFaculties                 = "database.getFaculties"
+	MethodDatabaseGetMetroStations             = "database.getMetroStations"
+	MethodDatabaseGetMetroStationsByID         = "database.getMetroStationsById"
+	MethodDatabaseGetRegions                   = "database.getRegions"
+	MethodDatabaseGetSchoolClasses             = "database.getSchoolClasses"
+	MethodDatabaseGetSchools                   = "database.getSchools"
+	MethodDatabaseGetUniversities              = "database.getUniversities"
+	MethodDocsAdd                              = "docs.add"
+	MethodDocsDelete                           = "docs.delete"
+	MethodDocsEdit                             = "docs.edit"
+	MethodDocsGet                              = "docs.get"
+	MethodDocsGetByID                          = "docs.getById"
+	MethodDocsGetMessagesUploadServer          = "docs.getMessagesUploadServer"
+	MethodDocsGetTypes                         = "docs.getTypes"
+	MethodDocsGetUploadServer                  = "docs.getUploadServer"
+	MethodDocsGetWallUploadServer              = "docs.getWallUploadServer"
+	MethodDocsSave                             = "docs.save"
+	MethodDocsSearch                           = "docs.search"
+	MethodExecute                              = "execute"
+	MethodFaveAddArticle                       = "fave.addArticle"
+	MethodFaveAddLink                          = "fave.addLink"
+	MethodFaveAddPage                          = "fave.addPage"
+	MethodFaveAddPost                          = "fave.addPost"
+	MethodFaveAddProduct                       = "fave.addProduct"
+	MethodFaveAddTag                           = "fave.addTag"
+	MethodFaveAddVideo                         = "fave.addVideo"
+	MethodFaveEditTag                          = "fave.editTag"
+	MethodFaveGet                              = "fave.get"
+	MethodFaveGetPages                         = "fave.getPages"
+	MethodFaveGetTags                          = "fave.getTags"
+	MethodFaveMarkSeen                         = "fave.markSeen"
+	MethodFaveRemoveArticle                    = "fave.removeArticle"
+	MethodFaveRemoveLink                       = "fave.removeLink"
+	MethodFaveRemovePage                       = "fave.removePage"
+	MethodFaveRemovePost                       = "fave.removePost"
+	MethodFaveRemoveProduct                    = "fave.removeProduct"
+	MethodFaveRemoveTag                        = "fave.removeTag"
+	MethodFaveRemoveVideo                      = "fave.removeVideo"
+	MethodFaveReorderTags                      = "fave.reorderTags"
+	MethodFaveSetPageTags                      = "fave.setPageTags"
+	MethodFaveSetTags                          = "fave.setTags"
+	MethodFaveTrackPageInteraction             = "fave.trackPageInteraction"
+	MethodFriendsAdd                           = "friends.add"
+	MethodFriendsAddList                       = "friends.addList"
+	MethodFriendsAreFriends                    = "friends.areFriends"
+	MethodFriendsDelete                        = "friends.delete"
+	MethodFriendsDeleteAllRequests             = "friends.deleteAllRequests"
+	MethodFriendsDeleteList                    = "friends.deleteList"
+	MethodFriendsEdit                          = "friends.edit"
+	MethodFriendsEditList                      = "friends.editList"
+	MethodFriendsGet                           = "friends.get"
+	MethodFriendsGetAppUsers                   = "friends.getAppUsers"
+	MethodFriendsGetByPhones                   = "friends.getByPhones"
+	MethodFriendsGetLists                      = "friends.getLists"
+	MethodFriendsGetMutual                     = "friends.getMutual"
+	MethodFriendsGetOnline                     = "friends.getOnline"
+	MethodFriendsGetRecent                     = "friends.getRecent"
+	MethodFriendsGetRequests                   = "friends.getRequests"
+	MethodFriendsGetSuggestions                = "friends.getSuggestions"
+	MethodFriendsSearch                        = "friends.search"
+	MethodGiftsGet                             = "gifts.get"
+	MethodGroupsAddAddress                     = "groups.addAddress"
+	MethodGroupsAddCallbackServer              = "groups.addCallbackServer"
+	MethodGroupsAddLink                        = "groups.addLink"
+	MethodGroupsApproveRequest                 = "groups.approveRequest"
+	MethodGroupsBan                            = "groups.ban"
+	MethodGroupsCreate                         = "groups.create"
+	MethodGroupsDeleteAddress                  = "groups.deleteAddress"
+	MethodGroupsDeleteCallbackServer           = "groups.deleteCallbackServer"
+	MethodGroupsDeleteLink                     = "groups.deleteLink"
+	MethodGroupsDisableOnline                  = "groups.disableOnline"
+	MethodGroupsEdit                           = "groups.edit"
+	MethodGroupsEditAddress                    = "groups.editAddress"
+	MethodGroupsEditCallbackServer             = "groups.editCallbackServer"
+	MethodGroupsEditLink                       = "groups.editLink"
+	MethodGroupsEditManager                    = "groups.editManager"
+	MethodGroupsEnableOnline                   = "groups.enableOnline"
+	MethodGroupsGet                            = "groups.get"
+	MethodGroupsGetAddresses                   = "groups.getAddresses"
+	MethodGroupsGetBanned                      = "groups.getBanned"
+	MethodGroupsGetByID                        = "groups.getById"
+	MethodGroupsGetCallbackConfirmationCode    = "groups.getCallbackConfirmationCode"
+	MethodGroupsGetCallbackServers             = "groups.getCallbackServers"
+	MethodGroupsGetCallbackSettings            = "groups.getCallbackSettings"
+	MethodGroupsGetCatalog                     = "groups.getCatalog"
+	MethodGroupsGetCatalogInfo                 = "groups.getCatalogInfo"
+	MethodGroupsGetInvitedUsers                = "groups.getInvitedUsers"
+	MethodGroupsGetInvites                     = "groups.getInvites"
+	MethodGroupsGetLongPollServer              = "groups.getLongPollServer"
+	MethodGroupsGetLongPollSettings            = "groups.getLongPollSettings"
+	MethodGroupsGetMembers                     = "groups.getMembers"
+	MethodGroupsGetRequests                    = "groups.getRequests"
+	MethodGroupsGetSettings                    = "groups.getSettings"
+	MethodGroupsGetTagList                     = "groups.getTagList"
+	MethodGroupsGetTokenPermissions            = "groups.getTokenPermissions"
+	MethodGroupsInvite                         = "groups.invite"
+	MethodGroupsIsMember                       = "groups.isMember"
+	MethodGroupsJoin                           = "groups.join"
+	MethodGroupsLeave                          = "groups.leave"
+	MethodGroupsRemoveUser                     = "groups.removeUser"
+	MethodGroupsReorderLink                    = "groups.reorderLink"
+	MethodGroupsSearch                         = "groups.search"
+	MethodGroupsSetCallbackSettings            = "groups.setCallbackSettings"
+	MethodGroupsSetLongPollSettings            = "groups.setLongPollSettings"
+	MethodGroupsSetSettings                    = "groups.setSettings"
+	MethodGroupsSetUserNote                    = "groups.setUserNote"
+	MethodGroupsTagAdd                         = "groups.tagAdd"
+	MethodGroupsTagBind                        = "groups.tagBind"
+	MethodGroupsTagDelete                      = "groups.tagDelete"
+	MethodGroupsTagUpdate                      = "groups.tagUpdate"
+	MethodGroupsUnban                          = "groups.unban"
+	MethodLeadsCheckUser                       = "leads.checkUser"
+	MethodLeadsComplete                        = "leads.complete"
+	MethodLeadsGetStats                        = "leads.getStats"
+	MethodLeadsGetUsers                        = "leads.getUsers"
+	MethodLeadsMetricHit                       = "leads.metricHit"
+	MethodLeadsStart                           = "leads.start"
+	MethodLikesAdd                             = "likes.add"
+	MethodLikesDelete                          = "likes.delete"
+	MethodLikesGetList                         = "likes.getList"
+	MethodLikesIsLiked                         = "likes.isLiked"
+	MethodMarketAdd                            = "market.add"
+	MethodMarketAddAlbum                       = "market.addAlbum"
+	MethodMarketAddToAlbum                     = "market.addToAlbum"
+	MethodMarketCreateComment                  = "market.createComment"
+	MethodMarketDelete                         = "market.delete"
+	MethodMarketDeleteAlbum                    = "market.deleteAlbum"
+	MethodMarketDeleteComment                  = "market.deleteComment"
+	MethodMarketEdit                           = "market.edit"
+	MethodMarketEditAlbum                      = "market.editAlbum"
+	MethodMarketEditComment                    = "market.editComment"
+	MethodMarketEditOrder                      = "market.editOrder"
+	MethodMarketGet                            = "market.get"
+	MethodMarketGetAlbumByID                   = "market.getAlbumById"
+	MethodMarketGetAlbums                      = "market.getAlbums"
+	MethodMarketGetByID                        = "market.getById"
+	MethodMarketGetCategories                  = "market.getCategories"
+	MethodMarketGetComments                    = "market.getComments"
+	MethodMarketGetGroupOrders                 = "market.getGroupOrders"
+	MethodMarketGetOrderByID                   = "market.getOrderById"
+	MethodMarketGetOrderItems                  = "market.getOrderItems"
+	MethodMarketGetOrders                      = "market.getOrders"
+	MethodMarketRemoveFromAlbum                = "market.removeFromAlbum"
+	MethodMarketReorderAlbums                  = "market.reorderAlbums"
+	MethodMarketReorderItems                   = "market.reorderItems"
+	MethodMarketReport                         = "market.report"
+	MethodMarketReportComment                  = "market.reportComment"
+	MethodMarketRestore                        = "market.restore"
+	MethodMarketRestoreComment                 = "market.restoreComment"
+	MethodMarketSearch                         = "market.search"
+	MethodMessagesAddChatUser                  = "messages.addChatUser"
+	MethodMessagesAllowMessagesFromGroup       = "messages.allowMessagesFromGroup"
+	MethodMessagesCreateChat                   = "messages.createChat"
+	MethodMessagesDelete                       = "messages.delete"
+	MethodMessagesDeleteChatPhoto              = "messages.deleteChatPhoto"
+	MethodMessagesDeleteConversation           = "messages.deleteConversation"
+	MethodMessagesDeleteReaction               = "messages.deleteReaction"
+	MethodMessagesDenyMessagesFromGroup        = "messages.denyMessagesFromGroup"
+	MethodMessagesEdit                         = "messages.edit"
+	MethodMessagesEditChat                     = "messages.editChat"
+	MethodMessagesGetByConversationMessageID   = "messages.getByConversationMessageId"
+	MethodMessagesGetByID                      = "messages.getById"
+	MethodMessagesGetChat                      = "messages.getChat"
+	MethodMessagesGetChatPreview               = "messages.getChatPreview"
+	MethodMessagesGetConversationMembers       = "messages.getConversationMembers"
+	MethodMessagesGetConversations             = "messages.getConversations"
+	MethodMessagesGetConversationsByID         = "messages.getConversationsById"
+	MethodMessagesGetHistory                   = "messages.getHistory"
+	MethodMessagesGetHistoryAttachments        = "messages.getHistoryAttachments"
+	MethodMessagesGetImportantMessages         = "messages.getImportantMessages"
+	MethodMessagesGetInviteLink                = "messages.getInviteLink"
+	MethodMessagesGetLastActivity              = "messages.getLastActivity"
+	MethodMessagesGetLongPollHistory           = "messages.getLongPollHistory"
+	MethodMessagesGetLongPollServer            = "messages.getLongPollServer"
+	MethodMessagesIsMessagesFromGroupAllowed   = "messages.isMessagesFromGroupAllowed"
+	MethodMessagesJoinChatByInviteLink         = "messages.joinChatByInviteLink"
+	MethodMessagesMarkAsAnsweredConversation   = "messages.markAsAnsweredConversation"
+	MethodMessagesMarkAsImportant              = "messages.markAsImportant"
+	MethodMessagesMarkAsImportantConversation  = "messages.markAsImportantConversation"
+	MethodMessagesMarkAsRead                   = "messages.markAsRead"
+	MethodMessagesPin                          = "messages.pin"
+	MethodMessagesRemoveChatUser               = "messages.removeChatUser"
+	MethodMessagesRestore                      = "messages.restore"
+	MethodMessagesSearch                       = "messages.search"
+	MethodMessagesSearchConversations          = "messages.searchConversations"
+	MethodMessagesSend                         = "messages.send"
+	MethodMessagesSendMessageEventAnswer       = "messages.sendMessageEventAnswer"
+	MethodMessagesSendReaction                 = "messages.sendReaction"
+	MethodMessagesSetActivity                  = "messages.setActivity"
+	MethodMessagesSetChatPhoto                 = "messages.setChatPhoto"
+	MethodMessagesUnpin                        = "messages.unpin"
+	MethodNewsfeedAddBan                       = "newsfeed.addBan"
+	MethodNewsfeedDeleteBan                    = "newsfeed.deleteBan"
+	MethodNewsfeedDeleteList                   = "newsfeed.deleteList"
+	MethodNewsfeedGet                          = "newsfeed.get"
+	MethodNewsfeedGetBanned                    = "newsfeed.getBanned"
+	MethodNewsfeedGetComments                  = "newsfeed.getComments"
+	MethodNewsfeedGetLists                     = "newsfeed.getLists"
+	MethodNewsfeedGetMentions                  = "newsfeed.getMentions"
+	MethodNewsfeedGetRecommended               = "newsfeed.getRecommended"
+	MethodNewsfeedGetSuggestedSources          = "newsfeed.getSuggestedSources"
+	MethodNewsfeedIgnoreItem                   = "newsfeed.ignoreItem"
+	MethodNewsfeedSaveList                     = "newsfeed.saveList"
+	MethodNewsfeedSearch                       = "newsfeed.search"
+	MethodNewsfeedUnignoreItem                 = "newsfeed.unignoreItem"
+	MethodNewsfeedUnsubscribe                  = "newsfeed.unsubscribe"
+	MethodNotesAdd                             = "notes.add"
+	MethodNotesCreateComment                   = "notes.createComment"
+	MethodNotesDelete                          = "notes.delete"
+	MethodNotesDeleteComment                   = "notes.deleteComment"
+	MethodNotesEdit                            = "notes.edit"
+	MethodNotesEditComment                     = "notes.editComment"
+	MethodNotesGet                             = "notes.get"
+	MethodNotesGetByID                         = "notes.getById"
+	MethodNotesGetComments                     = "notes.getComments"
+	MethodNotesRestoreComment                  = "notes.restoreComment"
+	MethodNotificationsGet                     = "notifications.get"
+	MethodNotificationsMarkAsViewed            = "notifications.markAsViewed"
+	MethodNotificationsSendMessage             = "notifications.sendMessage"
+	MethodOrdersCancelSubscription             = "orders.cancelSubscription"
+	MethodOrdersChangeState                    = "orders.changeState"
+	MethodOrdersGet                            = "orders.get"
+	MethodOrdersGetAmount                      = "orders.getAmount"
+	MethodOrdersGetByID                        = "orders.getById"
+	MethodOrdersGetUserSubscriptionByID        = "orders.getUserSubscriptionById"
+	MethodOrdersGetUserSubscriptions           = "orders.getUserSubscriptions"
+	MethodOrdersUpdateSubscription             = "orders.updateSubscription"
+	MethodPagesClearCache                      = "pages.clearCache"
+	MethodPagesGet                             = "pages.get"
+	MethodPagesGetHistory                      = "pages.getHistory"
+	MethodPagesGetTitles                       = "pages.getTitles"
+	MethodPagesGetVersion                      = "pages.getVersion"
+	MethodPagesParseWiki                       = "pages.parseWiki"
+	MethodPagesSave                            = "pages.save"
+	MethodPagesSaveAccess                      = "pages.saveAccess"
+	MethodPhotosConfirmTag                     = "photos.confirmTag"
+	MethodPhotosCopy                           = "photos.copy"
+	MethodPhotosCreateAlbum                    = "photos.createAlbum"
+	MethodPhotosCreateComment                  = "photos.createComment"
+	MethodPhotosDelete                         = "photos.delete"
+	MethodPhotosDeleteAlbum                    = "photos.deleteAlbum"
+	MethodPhotosDeleteComment                  = "photos.deleteComment"
+	MethodPhotosEdit                           = "photos.edit"
+	MethodPhotosEditAlbum                      = "photos.editAlbum"
+	MethodPhotosEditComment                    = "photos.editComment"
+	MethodPhotosGet                            = "photos.get"
+	MethodPhotosGetAlbums                      = "photos.getAlbums"
+	MethodPhotosGetAlbumsCount                 = "photos.getAlbumsCount"
+	MethodPhotosGetAll                         = "photos.getAll"
+	MethodPhotosGetAllComments                 = "photos.getAllComments"
+	MethodPhotosGetByID                        = "photos.getById"
+	MethodPhotosGetChatUploadServer            = "photos.getChatUploadServer"
+	MethodPhotosGetComments                    = "photos.getComments"
+	MethodPhotosGetMarketAlbumUploadServer     = "photos.getMarketAlbumUploadServer"
+	MethodPhotosGetMarketUploadServer          = "photos.getMarketUploadServer"
+	MethodPhotosGetMessagesUploadServer        = "photos.getMessagesUploadServer"
+	MethodPhotosGetNewTags                     = "photos.getNewTags"
+	MethodPhotosGetOwnerCoverPhotoUploadServer = "photos.getOwnerCoverPhotoUploadServer"
+	MethodPhotosGetOwnerPhotoUploadServer      = "photos.getOwnerPhotoUploadServer"
+	MethodPhotosGetTags                        = "photos.getTags"
+	MethodPhotosGetUploadServer                = "photos.getUploadServer"
+	MethodPhotosGetUserPhotos                  = "photos.getUserPhotos"
+	MethodPhotosGetWallUploadServer            = "photos.getWallUploadServer"
+	MethodPhotosMakeCover                      = "photos.makeCover"
+	MethodPhotosMove                           = "photos.move"
+	MethodPhotosPutTag                         = "photos.putTag"
+	MethodPhotosRemoveTag                      = "photos.removeTag"
+	MethodPhotosReorderAlbums                  = "photos.reorderAlbums"
+	MethodPhotosReorderPhotos                  = "photos.reorderPhotos"
+	MethodPhotosReport                         = "photos.report"
+	MethodPhotosReportComment                  = "photos.reportComment"
+	MethodPhotosRestore                        = "photos.restore"
+	MethodPhotosRestoreComment                 = "photos.restoreComment"
+	MethodPhotosSave                           = "photos.save"
+	MethodPhotosSaveMarketAlbumPhoto           = "photos.saveMarketAlbumPhoto"
+	MethodPhotosSaveMarketPhoto                = "photos.saveMarketPhoto"
+	MethodPhotosSaveMessagesPhoto              = "photos.saveMessagesPhoto"
+	MethodPhotosSaveOwnerCoverPhoto            = "photos.saveOwnerCoverPhoto"
+	MethodPhotosSaveOwnerPhoto                 = "photos.saveOwnerPhoto"
+	MethodPhotosSaveWallPhoto                  = "photos.saveWallPhoto"
+	MethodPhotosSearch                         = "photos.search"
+	MethodPlacesGetCheckins                    = "places.getCheckins"
+	MethodPlacesSearch                         = "places.search"
+	MethodPollsAddVote                         = "polls.addVote"
+	MethodPollsCreate                          = "polls.create"
+	MethodPollsDeleteVote                      = "polls.deleteVote"
+	MethodPollsEdit                            = "polls.edit"
+	MethodPollsGetBackgrounds                  = "polls.getBackgrounds"
+	MethodPollsGetByID                         = "polls.getById"
+	MethodPollsGetPhotoUploadServer            = "polls.getPhotoUploadServer"
+	MethodPollsGetVoters                       = "polls.getVoters"
+	MethodPollsSavePhoto                       = "polls.savePhoto"
+	MethodPrettyCardsCreate                    = "prettyCards.create"
+	MethodPrettyCardsDelete                    = "prettyCards.delete"
+	MethodPrettyCardsEdit                      = "prettyCards.edit"
+	MethodPrettyCardsGet                       = "prettyCards.get"
+	MethodPrettyCardsGetByID                   = "prettyCards.getById"
+	MethodPrettyCardsGetUploadURL              = "prettyCards.getUploadURL"
+	MethodSearchGetHints                       = "search.getHints"
+	MethodSecureAddAppEvent                    = "secure.addAppEvent"
+	MethodSecureCheckToken                     = "secure.checkToken"
+	MethodSecureGetAppBalance                  = "secure.getAppBalance"
+	MethodSecureGetSMSHistory                  = "secure.getSMSHistory"
+	MethodSecureGetTransactionsHistory         = "secure.getTransactionsHistory"
+	MethodSecureGetUserLevel                   = "secure.getUserLevel"
+	MethodSecureGiveEventSticker               = "secure.giveEventSticker"
+	MethodSecureSendNotification               = "secure.sendNotification"
+	MethodSecureSendSMSNotification            = "secure.sendSMSNotification"
+	MethodSecureSetCounter                     = "secure.setCounter"
+	MethodStatsGet                             = "stats.get"
+	MethodStatsGetPostReach                    = "stats.getPostReach"
+	MethodStatsTrackVisitor                    = "stats.trackVisitor"
+	MethodStatusGet                            = "status.get"
+	MethodStatusSet                            = "status.set"
+	MethodStorageGet                           = "storage.get"
+	MethodStorageGetKeys                       = "storage.getKeys"
+	MethodStorageSet                           = "storage.set"
+	MethodStoriesBanOwner                      = "stories.banOwner"
+	MethodStoriesDelete                        = "stories.delete"
+	MethodStoriesGet                           = "stories.get"
+	MethodStoriesGetBanned                     = "stories.getBanned"
+	MethodStoriesGetByID                       = "stories.getById"
+	MethodStoriesGetPhotoUploadServer          = "stories.getPhotoUploadServer"
+	MethodStoriesGetReplies                    = "stories.getReplies"
+	MethodStoriesGetStats                      = "stories.getStats"
+	MethodStoriesGetVideoUploadServer          = "stories.getVideoUploadServer"
+	MethodStoriesGetViewers                    = "stories.getViewers"
+	MethodStoriesHideAllReplies                = "stories.hideAllReplies"
+	MethodStoriesHideReply                     = "stories.hideReply"
+	MethodStoriesSave                          = "stories.save"
+	MethodStoriesSearch                        = "stories.search"
+	MethodStoriesSendInteraction               = "stories.sendInteraction"
+	MethodStoriesUnbanOwner                    = "stories.unbanOwner"
+	MethodStreamingGetServerURL                = "streaming.getServerUrl"
+	MethodStreamingGetSettings                 = "streaming.getSettings"
+	MethodStreamingGetStats                    = "streaming.getStats"
+	MethodStreamingGetStem                     = "streaming.getStem"
+	MethodStreamingSetSettings                 = "streaming.setSettings"
+	MethodUsersGet                             = "users.get"
+	MethodUsersGetFollowers                    = "users.getFollowers"
+	MethodUsersGetSubscriptions                = "users.getSubscriptions"
+	MethodUsersIsAppUser                       = "users.isAppUser"
+	MethodUsersReport                          = "users.report"
+	MethodUsersSearch                          = "users.search"
+	MethodUtilsCheckLink                       = "utils.checkLink"
+	MethodUtilsDeleteFromLastShortened         = "utils.deleteFromLastShortened"
+	MethodUtilsGetLastShortenedLinks           = "utils.getLastShortenedLinks"
+	MethodUtilsGetLinkStats                    = "utils.getLinkStats"
+	MethodUtilsGetServerTime                   = "utils.getServerTime"
+	MethodUtilsGetShortLink                    = "utils.getShortLink"
+	MethodUtilsResolveScreenName               = "utils.resolveScreenName"
+	MethodVideoAdd                             = "video.add"
+	MethodVideoAddAlbum                        = "video.addAlbum"
+	MethodVideoAddToAlbum                      = "video.addToAlbum"
+	MethodVideoCreateComment                   = "video.createComment"
+	MethodVideoDelete                          = "video.delete"
+	MethodVideoDeleteAlbum                     = "video.deleteAlbum"
+	MethodVideoDeleteComment                   = "video.deleteComment"
+	MethodVideoEdit                            = "video.edit"
+	MethodVideoEditAlbum                       = "video.editAlbum"
+	MethodVideoEditComment                     = "video.editComment"
+	MethodVideoGet                             = "video.get"
+	MethodVideoGetAlbumByID                    = "video.getAlbumById"
+	MethodVideoGetAlbums                       = "video.getAlbums"
+	MethodVideoGetAlbumsByVideo                = "video.getAlbumsByVideo"
+	MethodVideoGetComments                     = "video.getComments"
+	MethodVideoLiveGetCategories               = "video.liveGetCategories"
+	MethodVideoRemoveFromAlbum                 = "video.removeFromAlbum"
+	MethodVideoReorderAlbums                   = "video.reorderAlbums"
+	MethodVideoReorderVideos                   = "video.reorderVideos"
+	MethodVideoReport                          = "video.report"
+	MethodVideoReportComment                   = "video.reportComment"
+	MethodVideoRestore                         = "video.restore"
+	MethodVideoRestoreComment                  = "video.restoreComment"
+	MethodVideoSave                            = "video.save"
+	MethodVideoSearch                          = "video.search"
+	MethodVideoStartStreaming                  = "video.startStreaming"
+	MethodVideoStopStreaming                   = "video.stopStreaming"
+	MethodWallCheckCopyrightLink               = "wall.checkCopyrightLink"
+	MethodWallCloseComments                    = "wall.closeComments"
+	MethodWallCreateComment                    = "wall.createComment"
+	MethodWallDelete                           = "wall.delete"
+	MethodWallDeleteComment                    = "wall.deleteComment"
+	MethodWallEdit                             = "wall.edit"
+	MethodWallEditAdsStealth                   = "wall.editAdsStealth"
+	MethodWallEditComment                      = "wall.editComment"
+	MethodWallGet                              = "wall.get"
+	MethodWallGetByID                          = "wall.getById"
+	MethodWallGetComment                       = "wall.getComment"
+	MethodWallGetComments                      = "wall.getComments"
+	MethodWallGetReposts                       = "wall.getReposts"
+	MethodWallOpenComments                     = "wall.openComments"
+	MethodWallPin                              = "wall.pin"
+	MethodWallPost                             = "wall.post"
+	MethodWallPostAdsStealth                   = "wall.postAdsStealth"
+	MethodWallReportComment                    = "wall.reportComment"
+	MethodWallReportPost                       = "wall.reportPost"
+	MethodWallRepost                           = "wall.repost"
+	MethodWallRestore                          = "wall.restore"
+	MethodWallRestoreComment                   = "wall.restoreComment"
+	MethodWallSearch                           = "wall.search"
+	MethodWallUnpin                            = "wall.unpin"
+	MethodWidgetsGetComments                   = "widgets.getComments"
+	MethodWidgetsGetPages                      = "widgets.getPages"
+)
+
+// knownMethods is set of all method names
+var knownMethods = map[string]struct{}{
+	MethodAccountBan:                           {},
+	MethodAccountChangePassword:                {},
+	MethodAccountGetActiveOffers:               {},
+	MethodAccountGetAppPermissions:             {},
+	MethodAccountGetBanned:                     {},
+	MethodAccountGetCounters:                   {},
+	MethodAccountGetInfo:                       {},
+	MethodAccountGetPrivacySettings:            {},
+	MethodAccountGetProfileInfo:                {},
+	MethodAccountGetPushSettings:               {},
+	MethodAccountRegisterDevice:                {},
+	MethodAccountSaveProfileInfo:               {},
+	MethodAccountSetInfo:                       {},
+	MethodAccountSetNameInMenu:                 {},
+	MethodAccountSetOffline:                    {},
+	MethodAccountSetOnline:                     {},
+	MethodAccountSetPrivacy:                    {},
+	MethodAccountSetPushSettings:               {},
+	MethodAccountSetSilenceMode:                {},
+	MethodAccountUnban:                         {},
+	MethodAccountUnregisterDevice:              {},
+	MethodAdsAddOfficeUsers:                    {},
+	MethodAdsCheckLink:                         {},
+	MethodAdsCreateAds:                         {},
+	MethodAdsCreateCampaigns:                   {},
+	MethodAdsCreateClients:                     {},
+	MethodAdsCreateTargetGroup:                 {},
+	MethodAdsDeleteAds:                         {},
+	MethodAdsDeleteCampaigns:                   {},
+	MethodAdsDeleteClients:                     {},
+	MethodAdsDeleteTargetGroup:                 {},
+	MethodAdsGetAccounts:                       {},
+	MethodAdsGetAds:                            {},
+	MethodAdsGetAdsLayout:                      {},
+	MethodAdsGetAdsTargeting:                   {},
+	MethodAdsGetBudget:                         {},
+	MethodAdsGetCampaigns:                      {},
+	MethodAdsGetCategories:                     {},
+	MethodAdsGetClients:                        {},
+	MethodAdsGetDemographics:                   {},
+	MethodAdsGetFloodStats:                     {},
+	MethodAdsGetOfficeUsers:                    {},
+	MethodAdsGetPostsReach:                     {},
+	MethodAdsGetRejectionReason:                {},
+	MethodAdsGetStatistics:                     {},
+	MethodAdsGetSuggestions:                    {},
+	MethodAdsGetTargetGroups:                   {},
+	MethodAdsGetTargetingStats:                 {},
+	MethodAdsGetUploadURL:                      {},
+	MethodAdsGetVideoUploadURL:                 {},
+	MethodAdsImportTargetContacts:              {},
+	MethodAdsRemoveOfficeUsers:                 {},
+	MethodAdsUpdateAds:                         {},
+	MethodAdsUpdateCampaigns:                   {},
+	MethodAdsUpdateClients:                     {},
+	MethodAdsUpdateTargetGroup:                 {},
+	MethodAppWidgetsGetAppImageUploadServer:    {},
+	MethodAppWidgetsGetAppImages:               {},
+	MethodAppWidgetsGetGroupImageUploadServer:  {},
+	MethodAppWidgetsGetGroupImages:             {},
+	MethodAppWidgetsGetImagesByID:              {},
+	MethodAppWidgetsSaveAppImage:               {},
+	MethodAppWidgetsSaveGroupImage:             {},
+	MethodAppWidgetsUpdate:                     {},
+	MethodAppsDeleteAppRequests:                {},
+	MethodAppsGet:                              {},
+	MethodAppsGetCatalog:                       {},
+	MethodAppsGetFriendsList:                   {},
+	MethodAppsGetLeaderboard:                   {},
+	MethodAppsGetScopes:                        {},
+	MethodAppsGetScore:                         {},
+	MethodAppsPromoHasActiveGift:               {},
+	MethodAppsPromoUseGift:                     {},
+	MethodAppsSendRequest:                      {},
+	MethodAudioGet:                             {},
+	MethodAudioGetByID:                         {},
+	MethodAudioGetUploadServer:                 {},
+	MethodAudioSave:                            {},
+	MethodAudioSearch:                          {},
+	MethodAuthExchangeSilentAuthToken:          {},
+	MethodAuthRestore:                          {},
+	MethodBoardAddTopic:                        {},
+	MethodBoardCloseTopic:                      {},
+	MethodBoardCreateComment:                   {},
+	MethodBoardDeleteComment:                   {},
+	MethodBoardDeleteTopic:                     {},
+	MethodBoardEditComment:                     {},
+	MethodBoardEditTopic:                       {},
+	MethodBoardFixTopic:                        {},
+	MethodBoardGetComments:                     {},
+	MethodBoardGetTopics:                       {},
+	MethodBoardOpenTopic:                       {},
+	MethodBoardRestoreComment:                  {},
+	MethodBoardUnfixTopic:                      {},
+	MethodDatabaseGetChairs:                    {},
+	MethodDatabaseGetCities:                    {},
+	MethodDatabaseGetCitiesByID:                {},
+	MethodDatabaseGetCountries:                 {},
+	MethodDatabaseGetCountriesByID:             {},
+	MethodDatabaseGetFaculties:                 {},
+	MethodDatabaseGetMetroStations:             {},
+	MethodDatabaseGetMetroStationsByID:         {},
+	MethodDatabaseGetRegions:                   {},
+	MethodDatabaseGetSchoolClasses:             {},
+	MethodDatabaseGetSchools:                   {},
+	MethodDatabaseGetUniversities:              {},
+	MethodDocsAdd:                              {},
+	MethodDocsDelete:                           {},
+	MethodDocsEdit:                             {},
+	MethodDocsGet:                              {},
+	MethodDocsGetByID:                          {},
+	MethodDocsGetMessagesUploadServer:          {},
+	MethodDocsGetTypes:                         {},
+	MethodDocsGetUploadServer:                  {},
+	MethodDocsGetWallUploadServer:              {},
+	MethodDocsSave:                             {},
+	MethodDocsSearch:                           {},
+	MethodExecute:                              {},
+	MethodFaveAddArticle:                       {},
+	MethodFaveAddLink:                          {},
+	MethodFaveAddPage:                          {},
+	MethodFaveAddPost:                          {},
+	MethodFaveAddProduct:                       {},
+	MethodFaveAddTag:                           {},
+	MethodFaveAddVideo:                         {},
+	MethodFaveEditTag:                          {},
+	MethodFaveGet:                              {},
+	MethodFaveGetPages:                         {},
+	MethodFaveGetTags:                          {},
+	MethodFaveMarkSeen:                         {},
+	MethodFaveRemoveArticle:                    {},
+	MethodFaveRemoveLink:                       {},
+	MethodFaveRemovePage:                       {},
+	MethodFaveRemovePost:                       {},
+	MethodFaveRemoveProduct:                    {},
+	MethodFaveRemoveTag:                        {},
+	MethodFaveRemoveVideo:                      {},
+	MethodFaveReorderTags:                      {},
+	MethodFaveSetPageTags:                      {},
+	MethodFaveSetTags:                          {},
+	MethodFaveTrackPageInteraction:             {},
+	MethodFriendsAdd:                           {},
+	MethodFriendsAddList:                       {},
+	MethodFriendsAreFriends:                    {},
+	MethodFriendsDelete:                        {},
+	MethodFriendsDeleteAllRequests:             {},
+	MethodFriendsDeleteList:                    {},
+	MethodFriendsEdit:                          {},
+	MethodFriendsEditList:                      {},
+	MethodFriendsGet:                           {},
+	MethodFriendsGetAppUsers:                   {},
+	MethodFriendsGetByPhones:                   {},
+	MethodFriendsGetLists:                      {},
+	MethodFriendsGetMutual:                     {},
+	MethodFriendsGetOnline:                     {},
+	MethodFriendsGetRecent:                     {},
+	MethodFriendsGetRequests:                   {},
+	MethodFriendsGetSuggestions:                {},
+	MethodFriendsSearch:                        {},
+	MethodGiftsGet:                             {},
+	MethodGroupsAddAddress:                     {},
+	MethodGroupsAddCallbackServer:              {},
+	MethodGroupsAddLink:                        {},
+	MethodGroupsApproveRequest:                 {},
+	MethodGroupsBan:                            {},
+	MethodGroupsCreate:                         {},
+	MethodGroupsDeleteAddress:                  {},
+	MethodGroupsDeleteCallbackServer:           {},
+	MethodGroupsDeleteLink:                     {},
+	MethodGroupsDisableOnline:                  {},
+	MethodGroupsEdit:                           {},
+	MethodGroupsEditAddress:                    {},
+	MethodGroupsEditCallbackServer:             {},
+	MethodGroupsEditLink:                       {},
+	MethodGroupsEditManager:                    {},
+	MethodGroupsEnableOnline:                   {},
+	MethodGroupsGet:                            {},
+	MethodGroupsGetAddresses:                   {},
+	MethodGroupsGetBanned:                      {},
+	MethodGroupsGetByID:                        {},
+	MethodGroupsGetCallbackConfirmationCode:    {},
+	MethodGroupsGetCallbackServers:             {},
+	MethodGroupsGetCallbackSettings:            {},
+	MethodGroupsGetCatalog:                     {},
+	MethodGroupsGetCatalogInfo:                 {},
+	MethodGroupsGetInvitedUsers:                {},
+	MethodGroupsGetInvites:                     {},
+	MethodGroupsGetLongPollServer:              {},
+	MethodGroupsGetLongPollSettings:            {},
+	MethodGroupsGetMembers:                     {},
+	MethodGroupsGetRequests:                    {},
+	MethodGroupsGetSettings:                    {},
+	MethodGroupsGetTagList:                     {},
+	MethodGroupsGetTokenPermissions:            {},
+	MethodGroupsInvite:                         {},
+	MethodGroupsIsMember:                       {},
+	MethodGroupsJoin:                           {},
+	MethodGroupsLeave:                          {},
+	MethodGroupsRemoveUser:                     {},
+	MethodGroupsReorderLink:                    {},
+	MethodGroupsSearch:                         {},
+	MethodGroupsSetCallbackSettings:            {},
+	MethodGroupsSetLongPollSettings:            {},
+	MethodGroupsSetSettings:                    {},
+	MethodGroupsSetUserNote:                    {},
+	MethodGroupsTagAdd:                         {},
+	MethodGroupsTagBind:                        {},
+	MethodGroupsTagDelete:                      {},
+	MethodGroupsTagUpdate:                      {},
+	MethodGroupsUnban:                          {},
+	MethodLeadsCheckUser:                       {},
+	MethodLeadsComplete:                        {},
+	MethodLeadsGetStats:                        {},
+	MethodLeadsGetUsers:                        {},
+	MethodLeadsMetricHit:                       {},
+	MethodLeadsStart:                           {},
+	MethodLikesAdd:                             {},
+	MethodLikesDelete:                          {},
+	MethodLikesGetList:                         {},
+	MethodLikesIsLiked:                         {},
+	MethodMarketAdd:                            {},
+	MethodMarketAddAlbum:                       {},
+	MethodMarketAddToAlbum:                     {},
+	MethodMarketCreateComment:                  {},
+	MethodMarketDelete:                         {},
+	MethodMarketDeleteAlbum:                    {},
+	MethodMarketDeleteComment:                  {},
+	MethodMarketEdit:                           {},
+	MethodMarketEditAlbum:                      {},
+	MethodMarketEditComment:                    {},
+	MethodMarketEditOrder:                      {},
+	MethodMarketGet:                            {},
+	MethodMarketGetAlbumByID:                   {},
+	MethodMarketGetAlbums:                      {},
+	MethodMarketGetByID:                        {},
+	MethodMarketGetCategories:                  {},
+	MethodMarketGetComments:                    {},
+	MethodMarketGetGroupOrders:                 {},
+	MethodMarketGetOrderByID:                   {},
+	MethodMarketGetOrderItems:                  {},
+	MethodMarketGetOrders:                      {},
+	MethodMarketRemoveFromAlbum:                {},
+	MethodMarketReorderAlbums:                  {},
+	MethodMarketReorderItems:                   {},
+	MethodMarketReport:                         {},
+	MethodMarketReportComment:                  {},
+	MethodMarketRestore:                        {},
+	MethodMarketRestoreComment:                 {},
+	MethodMarketSearch:                         {},
+	MethodMessagesAddChatUser:                  {},
+	MethodMessagesAllowMessagesFromGroup:       {},
+	MethodMessagesCreateChat:                   {},
+	MethodMessagesDelete:                       {},
+	MethodMessagesDeleteChatPhoto:              {},
+	MethodMessagesDeleteConversation:           {},
+	MethodMessagesDeleteReaction:               {},
+	MethodMessagesDenyMessagesFromGroup:        {},
+	MethodMessagesEdit:                         {},
+	MethodMessagesEditChat:                     {},
+	MethodMessagesGetByConversationMessageID:   {},
+	MethodMessagesGetByID:                      {},
+	MethodMessagesGetChat:                      {},
+	MethodMessagesGetChatPreview:               {},
+	MethodMessagesGetConversationMembers:       {},
+	MethodMessagesGetConversations:             {},
+	MethodMessagesGetConversationsByID:         {},
+	MethodMessagesGetHistory:                   {},
+	MethodMessagesGetHistoryAttachments:        {},
+	MethodMessagesGetImportantMessages:         {},
+	MethodMessagesGetInviteLink:                {},
+	MethodMessagesGetLastActivity:              {},
+	MethodMessagesGetLongPollHistory:           {},
+	MethodMessagesGetLongPollServer:            {},
+	MethodMessagesIsMessagesFromGroupAllowed:   {},
+	MethodMessagesJoinChatByInviteLink:         {},
+	MethodMessagesMarkAsAnsweredConversation:   {},
+	MethodMessagesMarkAsImportant:              {},
+	MethodMessagesMarkAsImportantConversation:  {},
+	MethodMessagesMarkAsRead:                   {},
+	MethodMessagesPin:                          {},
+	MethodMessagesRemoveChatUser:               {},
+	MethodMessagesRestore:                      {},
+	MethodMessagesSearch:                       {},
+	MethodMessagesSearchConversations:          {},
+	MethodMessagesSend:                         {},
+	MethodMessagesSendMessageEventAnswer:       {},
+	MethodMessagesSendReaction:                 {},
+	MethodMessagesSetActivity:                  {},
+	MethodMessagesSetChatPhoto:                 {},
+	MethodMessagesUnpin:                        {},
+	MethodNewsfeedAddBan:                       {},
+	MethodNewsfeedDeleteBan:                    {},
+	MethodNewsfeedDeleteList:                   {},
+	MethodNewsfeedGet:                          {},
+	MethodNewsfeedGetBanned:                    {},
+	MethodNewsfeedGetComments:                  {},
+	MethodNewsfeedGetLists:                     {},
+	MethodNewsfeedGetMentions:                  {},
+	MethodNewsfeedGetRecommended:               {},
+	MethodNewsfeedGetSuggestedSources:          {},
+	MethodNewsfeedIgnoreItem:                   {},
+	MethodNewsfeedSaveList:                     {},
+	MethodNewsfeedSearch:                       {},
+	MethodNewsfeedUnignoreItem:                 {},
+	MethodNewsfeedUnsubscribe:                  {},
+	MethodNotesAdd:                             {},
+	MethodNotesCreateComment:                   {},
+	MethodNotesDelete:                          {},
+	MethodNotesDeleteComment:                   {},
+	MethodNotesEdit:                            {},
+	MethodNotesEditComment:                     {},
+	MethodNotesGet:                             {},
+	MethodNotesGetByID:                         {},
+	MethodNotesGetComments:                     {},
+	MethodNotesRestoreComment:                  {},
+	MethodNotificationsGet:                     {},
+	MethodNotificationsMarkAsViewed:            {},
+	MethodNotificationsSendMessage:             {},
+	MethodOrdersCancelSubscription:             {},
+	MethodOrdersChangeState:                    {},
+	MethodOrdersGet:                            {},
+	MethodOrdersGetAmount:                      {},
+	MethodOrdersGetByID:                        {},
+	MethodOrdersGetUserSubscriptionByID:        {},
+	MethodOrdersGetUserSubscriptions:           {},
+	MethodOrdersUpdateSubscription:             {},
+	MethodPagesClearCache:                      {},
+	MethodPagesGet:                             {},
+	MethodPagesGetHistory:                      {},
+	MethodPagesGetTitles:                       {},
+	MethodPagesGetVersion:                      {},
+	MethodPagesParseWiki:                       {},
+	MethodPagesSave:                            {},
+	MethodPagesSaveAccess:                      {},
+	MethodPhotosConfirmTag:                     {},
+	MethodPhotosCopy:                           {},
+	MethodPhotosCreateAlbum:                    {},
+	MethodPhotosCreateComment:                  {},
+	MethodPhotosDelete:                         {},
+	MethodPhotosDeleteAlbum:                    {},
+	MethodPhotosDeleteComment:                  {},
+	MethodPhotosEdit:                           {},
+	MethodPhotosEditAlbum:                      {},
+	MethodPhotosEditComment:                    {},
+	MethodPhotosGet:                            {},
+	MethodPhotosGetAlbums:                      {},
+	MethodPhotosGetAlbumsCount:                 {},
+	MethodPhotosGetAll:                         {},
+	MethodPhotosGetAllComments:                 {},
+	MethodPhotosGetByID:                        {},
+	MethodPhotosGetChatUploadServer:            {},
+	MethodPhotosGetComments:                    {},
+	MethodPhotosGetMarketAlbumUploadServer:     {},
+	MethodPhotosGetMarketUploadServer:          {},
+	MethodPhotosGetMessagesUploadServer:        {},
+	MethodPhotosGetNewTags:                     {},
+	MethodPhotosGetOwnerCoverPhotoUploadServer: {},
+	MethodPhotosGetOwnerPhotoUploadServer:      {},
+	MethodPhotosGetTags:                        {},
+	MethodPhotosGetUploadServer:                {},
+	MethodPhotosGetUserPhotos:                  {},
+	MethodPhotosGetWallUploadServer:            {},
+	MethodPhotosMakeCover:                      {},
+	MethodPhotosMove:                           {},
+	MethodPhotosPutTag:                         {},
+	MethodPhotosRemoveTag:                      {},
+	MethodPhotosReorderAlbums:                  {},
+	MethodPhotosReorderPhotos:                  {},
+	MethodPhotosReport:                         {},
+	MethodPhotosReportComment:                  {},
+	MethodPhotosRestore:                        {},
+	MethodPhotosRestoreComment:                 {},
+	MethodPhotosSave:                           {},
+	MethodPhotosSaveMarketAlbumPhoto:           {},
+	MethodPhotosSaveMarketPhoto:                {},
+	MethodPhotosSaveMessagesPhoto:              {},
+	MethodPhotosSaveOwnerCoverPhoto:            {},
+	MethodPhotosSaveOwnerPhoto:                 {},
+	MethodPhotosSaveWallPhoto:                  {},
+	MethodPhotosSearch:                         {},
+	MethodPlacesGetCheckins:                    {},
+	MethodPlacesSearch:                         {},
+	MethodPollsAddVote:                         {},
+	MethodPollsCreate:                          {},
+	MethodPollsDeleteVote:                      {},
+	MethodPollsEdit:                            {},
+	MethodPollsGetBackgrounds:                  {},
+	MethodPollsGetByID:                         {},
+	MethodPollsGetPhotoUploadServer:            {},
+	MethodPollsGetVoters:                       {},
+	MethodPollsSavePhoto:                       {},
+	MethodPrettyCardsCreate:                    {},
+	MethodPrettyCardsDelete:                    {},
+	MethodPrettyCardsEdit:                      {},
+	MethodPrettyCardsGet:                       {},
+	MethodPrettyCardsGetByID:                   {},
+	MethodPrettyCardsGetUploadURL:              {},
+	MethodSearchGetHints:                       {},
+	MethodSecureAddAppEvent:                    {},
+	MethodSecureCheckToken:                     {},
+	MethodSecureGetAppBalance:                  {},
+	MethodSecureGetSMSHistory:                  {},
+	MethodSecureGetTransactionsHistory:         {},
+	MethodSecureGetUserLevel:                   {},
+	MethodSecureGiveEventSticker:               {},
+	MethodSecureSendNotification:               {},
+	MethodSecureSendSMSNotification:            {},
+	MethodSecureSetCounter:                     {},
+	MethodStatsGet:                             {},
+	MethodStatsGetPostReach:                    {},
+	MethodStatsTrackVisitor:                    {},
+	MethodStatusGet:                            {},
+	MethodStatusSet:                            {},
+	MethodStorageGet:                           {},
+	MethodStorageGetKeys:                       {},
+	MethodStorageSet:                           {},
+	MethodStoriesBanOwner:                      {},
+	MethodStoriesDelete:                        {},
+	MethodStoriesGet:                           {},
+	MethodStoriesGetBanned:                     {},
+	MethodStoriesGetByID:                       {},
+	MethodStoriesGetPhotoUploadServer:          {},
+	MethodStoriesGetReplies:                    {},
+	MethodStoriesGetStats:                      {},
+	MethodStoriesGetVideoUploadServer:          {},
+	MethodStoriesGetViewers:                    {},
+	MethodStoriesHideAllReplies:                {},
+	MethodStoriesHideReply:                     {},
+	MethodStoriesSave:                          {},
+	MethodStoriesSearch:                        {},
+	MethodStoriesSendInteraction:               {},
+	MethodStoriesUnbanOwner:                    {},
+	MethodStreamingGetServerURL:                {},
+	MethodStreamingGetSettings:                 {},
+	MethodStreamingGetStats:                    {},
+	MethodStreamingGetStem:                     {},
+	MethodStreamingSetSettings:                 {},
+	MethodUsersGet:                             {},
+	MethodUsersGetFollowers:                    {},
+	MethodUsersGetSubscriptions:                {},
+	MethodUsersIsAppUser:                       {},
+	MethodUsersReport:                          {},
+	MethodUsersSearch:                          {},
+	MethodUtilsCheckLink:                       {},
+	MethodUtilsDeleteFromLastShortened:         {},
+	MethodUtilsGetLastShortenedLinks:           {},
+	MethodUtilsGetLinkStats:                    {},
+	MethodUtilsGetServerTime:                   {},
+	MethodUtilsGetShortLink:                    {},
+	MethodUtilsResolveScreenName:               {},
+	MethodVideoAdd:                             {},
+	MethodVideoAddAlbum:                        {},
+	MethodVideoAddToAlbum:                      {},
+	MethodVideoCreateComment:                   {},
+	MethodVideoDelete:                          {},
+	MethodVideoDeleteAlbum:                     {},
+	MethodVideoDeleteComment:                   {},
+	MethodVideoEdit:                            {},
+	MethodVideoEditAlbum:                       {},
+	MethodVideoEditComment:                     {},
+	MethodVideoGet:                             {},
+	MethodVideoGetAlbumByID:                    {},
+	MethodVideoGetAlbums:                       {},
+	MethodVideoGetAlbumsByVideo:                {},
+	MethodVideoGetComments:                     {},
+	MethodVideoLiveGetCategories:               {},
+	MethodVideoRemoveFromAlbum:                 {},
+	MethodVideoReorderAlbums:                   {},
+	MethodVideoReorderVideos:                   {},
+	MethodVideoReport:                          {},
+	MethodVideoReportComment:                   {},
+	MethodVideoRestore:                         {},
+	MethodVideoRestoreComment:                  {},
+	MethodVideoSave:                            {},
+	MethodVideoSearch:                          {},
+	MethodVideoStartStreaming:                  {},
+	MethodVideoStopStreaming:                   {},
+	MethodWallCheckCopyrightLink:               {},
+	MethodWallCloseComments:                    {},
+	MethodWallCreateComment:                    {},
+	MethodWallDelete:                           {},
+	MethodWallDeleteComment:                    {},
+	MethodWallEdit:                             {},
+	MethodWallEditAdsStealth:                   {},
+	MethodWallEditComment:                      {},
+	MethodWallGet:                              {},
+	MethodWallGetByID:                          {},
+	MethodWallGetComment:                       {},
+	MethodWallGetComments:                      {},
+	MethodWallGetReposts:                       {},
+	MethodWallOpenComments:                     {},
+	MethodWallPin:                              {},
+	MethodWallPost:                             {},
+	MethodWallPostAdsStealth:                   {},
+	MethodWallReportComment:                    {},
+	MethodWallReportPost:                       {},
+	MethodWallRepost:                           {},
+	MethodWallRestore:                          {},
+	MethodWallRestoreComment:                   {},
+	MethodWallSearch:                           {},
+	MethodWallUnpin:                            {},
+	MethodWidgetsGetComments:                   {},
+	MethodWidgetsGetPages:                      {},
+}
@@ -0,0 +1,178 @@
+package vk
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	eventWallReplyNew  = "wall_reply_new"
+	eventBoardPostNew  = "board_post_new"
+	eventWallReplyEdit = "wall_reply_edit"
+	eventBoardPostEdit = "board_post_edit"
+)
+
+// Comment is wall comment or board post from callback event
+type Comment struct {
+	ID           int    `json:"id"`
+	FromID       ID     `json:"from_id"`
+	Date         Time   `json:"date"`
+	Text         string `json:"text"`
+	PostID       int    `json:"post_id,omitempty"`
+	PostOwnerID  ID     `json:"post_owner_id,omitempty"`
+	OwnerID      ID     `json:"owner_id,omitempty"`
+	TopicID      int    `json:"topic_id,omitempty"`
+	TopicOwnerID ID     `json:"topic_owner_id,omitempty"`
+}
+
+// Board reports whether comment is post in board topic
+func (c Comment) Board() bool {
+	return c.TopicID != 0
+}
+
+// CommentFilter returns non-empty reason if comment violates rules
+type CommentFilter func(c Comment) (reason string)
+
+// RegexpFilter rejects comments matching re
+func RegexpFilter(re *regexp.Regexp) CommentFilter {
+	return func(c Comment) string {
+		if re.MatchString(c.Text) {
+			return "matches " + re.String()
+		}
+		return ""
+	}
+}
+
+// StopWordsFilter rejects comments containing any of words, ignoring case
+func StopWordsFilter(words ...string) CommentFilter {
+	lower := make([]string, len(words))
+	for i, w := range words {
+		lower[i] = strings.ToLower(w)
+	}
+	return func(c Comment) string {
+		text := strings.ToLower(c.Text)
+		for _, w := range lower {
+			if strings.Contains(text, w) {
+				return "contains " + w
+			}
+		}
+		return ""
+	}
+}
+
+// ModerationAction is what Moderator does with rejected comment
+type ModerationAction int
+
+const (
+	// ModerationDelete deletes comment
+	ModerationDelete ModerationAction = iota
+	// ModerationReport reports wall comment as spam and deletes it,
+	// board posts are only deleted
+	ModerationReport
+	// ModerationLog only records comment to log
+	ModerationLog
+)
+
+var moderationActionNames = [...]string{"delete", "report", "log"}
+
+func (a ModerationAction) String() string {
+	if a < 0 || int(a) >= len(moderationActionNames) {
+		return "unknown"
+	}
+	return moderationActionNames[a]
+}
+
+// ModerationRecord is audit trail entry of Moderator
+type ModerationRecord struct {
+	Time    time.Time        `json:"time"`
+	Comment Comment          `json:"comment"`
+	Reason  string           `json:"reason"`
+	Action  ModerationAction `json:"action"`
+	Error   string           `json:"error,omitempty"`
+}
+
+// Moderator checks new and edited comments from callback or
+// bots long poll events and removes ones rejected by filters
+type Moderator struct {
+	Wall  Wall
+	Board Board
+	// Filters are checked in order, first rejection is applied
+	Filters []CommentFilter
+	Action  ModerationAction
+	// Log receives record of every rejected comment
+	Log func(record ModerationRecord)
+	// Clock is SystemClock if nil
+	Clock Clock
+
+	mux sync.Mutex
+}
+
+// Check returns reason of rejection of comment by filters
+func (m *Moderator) Check(c Comment) string {
+	for _, filter := range m.Filters {
+		if reason := filter(c); len(reason) != 0 {
+			return reason
+		}
+	}
+	return ""
+}
+
+// HandleEvent moderates wall_reply_new, wall_reply_edit,
+// board_post_new and board_post_edit events, it is EventHandler
+func (m *Moderator) HandleEvent(event Event) error {
+	switch event.Type {
+	case eventWallReplyNew, eventWallReplyEdit, eventBoardPostNew, eventBoardPostEdit:
+	default:
+		return nil
+	}
+	var c Comment
+	if err := json.Unmarshal(event.Object, &c); err != nil {
+		return err
+	}
+	reason := m.Check(c)
+	if len(reason) == 0 {
+		return nil
+	}
+	err := m.apply(c)
+	record := ModerationRecord{
+		Time:    clockOrSystem(m.Clock).Now(),
+		Comment: c,
+		Reason:  reason,
+		Action:  m.Action,
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	if m.Log != nil {
+		m.mux.Lock()
+		m.Log(record)
+		m.mux.Unlock()
+	}
+	return err
+}
+
+func (m *Moderator) apply(c Comment) error {
+	if m.Action == ModerationLog {
+		return nil
+	}
+	if c.Board() {
+		return m.Board.DeleteComment(BoardCommentFields{
+			GroupID:   -c.TopicOwnerID,
+			TopicID:   c.TopicID,
+			CommentID: c.ID,
+		})
+	}
+	fields := WallCommentFields{OwnerID: c.PostOwnerID, CommentID: c.ID}
+	if fields.OwnerID == 0 {
+		fields.OwnerID = c.OwnerID
+	}
+	if m.Action == ModerationReport {
+		if err := m.Wall.ReportComment(fields, ReportSpam); err != nil {
+			return err
+		}
+	}
+	return m.Wall.DeleteComment(fields)
+}
@@ -0,0 +1,75 @@
+package vk
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestModerator(t *testing.T) {
+	Convey("Moderator", t, func() {
+		client := New()
+		var calls []string
+		client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			calls = append(calls, req.URL.Path[len("/method/"):]+" "+q.Get("owner_id")+q.Get("group_id")+":"+q.Get("comment_id")+q.Get("reason"))
+			return jsonResponse(http.StatusOK, `{"response": 1}`), nil
+		}))
+		var records []ModerationRecord
+		m := &Moderator{
+			Wall:  client.Wall,
+			Board: client.Board,
+			Filters: []CommentFilter{
+				StopWordsFilter("Casino"),
+				RegexpFilter(regexp.MustCompile(`https?://`)),
+				func(c Comment) string {
+					if c.FromID == 666 {
+						return "banned"
+					}
+					return ""
+				},
+			},
+			Log: func(r ModerationRecord) {
+				records = append(records, r)
+			},
+			Clock: NewFakeClock(time.Unix(1000, 0)),
+		}
+		wallComment := func(text string) Event {
+			return Event{Type: "wall_reply_new", Object: Raw(`{"id": 5, "from_id": 1, "post_owner_id": -10, "post_id": 3, "text": "` + text + `"}`)}
+		}
+		Convey("Clean", func() {
+			So(m.HandleEvent(wallComment("hello")), ShouldBeNil)
+			So(m.HandleEvent(Event{Type: "message_new", Object: Raw(`{}`)}), ShouldBeNil)
+			So(calls, ShouldBeEmpty)
+			So(records, ShouldBeEmpty)
+		})
+		Convey("Delete", func() {
+			So(m.HandleEvent(wallComment("best CASINO")), ShouldBeNil)
+			So(calls, ShouldResemble, []string{"wall.deleteComment -10:5"})
+			So(records, ShouldHaveLength, 1)
+			So(records[0].Reason, ShouldEqual, "contains casino")
+			So(records[0].Time, ShouldResemble, time.Unix(1000, 0))
+		})
+		Convey("Report", func() {
+			m.Action = ModerationReport
+			So(m.HandleEvent(wallComment("see http://spam")), ShouldBeNil)
+			So(calls, ShouldResemble, []string{"wall.reportComment -10:50", "wall.deleteComment -10:5"})
+			So(records[0].Action.String(), ShouldEqual, "report")
+		})
+		Convey("Board", func() {
+			event := Event{Type: "board_post_new", Object: Raw(`{"id": 7, "from_id": 666, "topic_id": 2, "topic_owner_id": -10, "text": "hi"}`)}
+			So(m.HandleEvent(event), ShouldBeNil)
+			So(calls, ShouldResemble, []string{"board.deleteComment 10:7"})
+			So(records[0].Reason, ShouldEqual, "banned")
+		})
+		Convey("Log only", func() {
+			m.Action = ModerationLog
+			So(m.HandleEvent(wallComment("casino")), ShouldBeNil)
+			So(calls, ShouldBeEmpty)
+			So(records, ShouldHaveLength, 1)
+		})
+	})
+}
@@ -0,0 +1,101 @@
+package vk
+
+import "context"
+
+const (
+	methodFriendsGetMutual = "friends.getMutual"
+
+	maxMutualTargets = 100
+	maxGroupsCount   = 1000
+)
+
+type FriendsGetMutualFields struct {
+	SourceUID  ID     `url:"source_uid,omitempty"`
+	TargetUIDs []ID   `url:"target_uids,comma"`
+	Order      string `url:"order,omitempty"`
+	Count      int    `url:"count,omitempty"`
+	Offset     int    `url:"offset,omitempty"`
+}
+
+// MutualFriends is common friends of source user and ID
+type MutualFriends struct {
+	ID            ID   `json:"id"`
+	CommonFriends []ID `json:"common_friends"`
+	CommonCount   int  `json:"common_count"`
+}
+
+// GetMutual returns common friends of source user, current user if
+// not set, and up to 100 target users
+func (f Friends) GetMutual(fields FriendsGetMutualFields) (result []MutualFriends, err error) {
+	return result, f.Decode(f.Request(methodFriendsGetMutual, fields), &result)
+}
+
+func (b *Batch) FriendsGetMutual(fields FriendsGetMutualFields, dest *[]MutualFriends) *Batch {
+	return b.Add(methodFriendsGetMutual, fields, dest)
+}
+
+// MutualFriends returns common friends of source and any count of
+// targets, packing up to 2500 targets into one execute request.
+// Targets with hidden friends are missing from result.
+func (c *Client) MutualFriends(ctx context.Context, source ID, targets []ID) ([]MutualFriends, error) {
+	b := c.Batch()
+	pages := make([][]MutualFriends, 0, len(targets)/maxMutualTargets+1)
+	for start := 0; start < len(targets); start += maxMutualTargets {
+		end := start + maxMutualTargets
+		if end > len(targets) {
+			end = len(targets)
+		}
+		pages = append(pages, nil)
+		b.FriendsGetMutual(FriendsGetMutualFields{
+			SourceUID:  source,
+			TargetUIDs: targets[start:end],
+		}, &pages[len(pages)-1])
+	}
+	err := b.Commit(ctx)
+	if _, partial := err.(Errors); err != nil && !partial {
+		return nil, err
+	}
+	result := make([]MutualFriends, 0, len(targets))
+	for _, page := range pages {
+		result = append(result, page...)
+	}
+	return result, err
+}
+
+// CommonGroups is communities of both users
+type CommonGroups struct {
+	Users [2]ID
+	// Counts are total counts of communities of users
+	Counts [2]int
+	// Groups are common communities in order of first user
+	Groups []Group
+}
+
+// CommonGroups returns common communities of users, fetching
+// communities of both in one execute request
+func (c *Client) CommonGroups(ctx context.Context, first, second ID) (CommonGroups, error) {
+	result := CommonGroups{Users: [2]ID{first, second}}
+	var groups [2]GroupGetResult
+	b := c.Batch()
+	for i, id := range result.Users {
+		b.GroupsGet(GroupGetFields{
+			UserID:   int(id),
+			Extended: true,
+			Count:    maxGroupsCount,
+		}, &groups[i])
+	}
+	if err := b.Commit(ctx); err != nil {
+		return result, err
+	}
+	seen := make(map[ID]bool, len(groups[1].Items))
+	for _, g := range groups[1].Items {
+		seen[g.ID] = true
+	}
+	for _, g := range groups[0].Items {
+		if seen[g.ID] {
+			result.Groups = append(result.Groups, g)
+		}
+	}
+	result.Counts = [2]int{groups[0].Count, groups[1].Count}
+	return result, nil
+}
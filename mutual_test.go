@@ -0,0 +1,52 @@
+package vk
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMutual(t *testing.T) {
+	Convey("Mutual", t, func() {
+		var codes []string
+		response := ""
+		client := NewWithToken("t", WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			codes = append(codes, req.URL.Query().Get("code"))
+			return jsonResponse(http.StatusOK, response), nil
+		})))
+		ctx := context.Background()
+		Convey("Friends", func() {
+			targets := make([]ID, maxMutualTargets+1)
+			for i := range targets {
+				targets[i] = ID(i + 2)
+			}
+			response = `{"response": [[{"id": 2, "common_friends": [10, 11], "common_count": 2}], ` +
+				`[{"id": 102, "common_friends": [], "common_count": 0}]]}`
+			mutual, err := client.MutualFriends(ctx, 1, targets)
+			So(err, ShouldBeNil)
+			So(mutual, ShouldResemble, []MutualFriends{
+				{ID: 2, CommonFriends: []ID{10, 11}, CommonCount: 2},
+				{ID: 102, CommonFriends: []ID{}},
+			})
+			So(codes, ShouldHaveLength, 1)
+			So(strings.Count(codes[0], "API.friends.getMutual("), ShouldEqual, 2)
+			So(codes[0], ShouldContainSubstring, `"target_uids":"102"`)
+		})
+		Convey("Groups", func() {
+			response = `{"response": [` +
+				`{"count": 3, "items": [{"id": 1, "name": "a"}, {"id": 2, "name": "b"}, {"id": 3, "name": "c"}]}, ` +
+				`{"count": 2, "items": [{"id": 3, "name": "c"}, {"id": 1, "name": "a"}]}]}`
+			common, err := client.CommonGroups(ctx, 5, 6)
+			So(err, ShouldBeNil)
+			So(common.Users, ShouldResemble, [2]ID{5, 6})
+			So(common.Counts, ShouldResemble, [2]int{3, 2})
+			So(common.Groups, ShouldHaveLength, 2)
+			So(common.Groups[0].Name, ShouldEqual, "a")
+			So(common.Groups[1].Name, ShouldEqual, "c")
+			So(codes[0], ShouldContainSubstring, `API.groups.get({"count":"1000","extended":"1","user_id":"5"})`)
+		})
+	})
+}
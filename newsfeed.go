@@ -0,0 +1,183 @@
+package vk
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	methodNewsfeedGet = "newsfeed.get"
+
+	defaultWatchInterval = time.Minute
+)
+
+// NewsfeedGetFields are fields of newsfeed.get
+type NewsfeedGetFields struct {
+	Filters   []string `url:"filters,comma,omitempty"`
+	SourceIDs []ID     `url:"source_ids,comma,omitempty"`
+	StartTime Time     `url:"start_time,omitempty"`
+	EndTime   Time     `url:"end_time,omitempty"`
+	StartFrom string   `url:"start_from,omitempty"`
+	Count     int      `url:"count,omitempty"`
+	Fields    Fields   `url:"fields,omitempty"`
+}
+
+// NewsfeedItem is item of newsfeed, for posts SourceID
+// and PostID are owner and id of post
+type NewsfeedItem struct {
+	Post
+	Type     string `json:"type"`
+	SourceID ID     `json:"source_id"`
+	PostID   int    `json:"post_id"`
+}
+
+// ToPost returns post of item with owner and id set
+func (i NewsfeedItem) ToPost() Post {
+	p := i.Post
+	if p.OwnerID == 0 {
+		p.OwnerID = i.SourceID
+	}
+	if p.ID == 0 {
+		p.ID = i.PostID
+	}
+	return p
+}
+
+type NewsfeedGetResult struct {
+	Items    []NewsfeedItem `json:"items"`
+	NextFrom string         `json:"next_from"`
+}
+
+func (n Newsfeed) Get(fields NewsfeedGetFields) (result NewsfeedGetResult, err error) {
+	return result, n.Decode(n.Request(methodNewsfeedGet, fields), &result)
+}
+
+// PostKey returns "{owner_id}_{id}" identifier of post
+func PostKey(p Post) string {
+	return fmt.Sprintf("%d_%d", p.OwnerID, p.ID)
+}
+
+// NewsfeedWatcher is Component that polls newsfeed of Sources
+// and newsfeed search by Queries, calling OnPost once for every
+// new post. Newsfeed of current user is polled if both are empty.
+type NewsfeedWatcher struct {
+	Newsfeed Newsfeed
+	Sources  []ID
+	Queries  []string
+	// OnPost is called for every new post, post is
+	// delivered again on next poll if error is returned
+	OnPost func(post Post) error
+	// Interval between polls, one minute if zero
+	Interval time.Duration
+	// Dedupe is in-memory store if nil
+	Dedupe DedupeStore
+	// Clock is SystemClock if nil
+	Clock Clock
+
+	since Time
+}
+
+// Run polls newsfeed until ctx is done or OnPost fails
+func (w *NewsfeedWatcher) Run(ctx context.Context) error {
+	clock := clockOrSystem(w.Clock)
+	if w.since.IsZero() {
+		w.since = Time{clock.Now()}
+	}
+	interval := w.Interval
+	if interval == 0 {
+		interval = defaultWatchInterval
+	}
+	for {
+		if err := w.Poll(); err != nil {
+			return err
+		}
+		if err := clock.Sleep(ctx, interval); err != nil {
+			return nil
+		}
+	}
+}
+
+// Poll fetches posts published since previous poll and emits new
+// ones, following next_from until last seen post is reached
+func (w *NewsfeedWatcher) Poll() error {
+	var posts []Post
+	if len(w.Sources) != 0 || len(w.Queries) == 0 {
+		fields := NewsfeedGetFields{
+			Filters:   []string{"post"},
+			SourceIDs: w.Sources,
+			StartTime: w.since,
+		}
+		for {
+			result, err := w.Newsfeed.Get(fields)
+			if err != nil {
+				return err
+			}
+			page := make([]Post, 0, len(result.Items))
+			for _, item := range result.Items {
+				page = append(page, item.ToPost())
+			}
+			posts = append(posts, page...)
+			if w.reached(page, result.NextFrom) {
+				break
+			}
+			fields.StartFrom = result.NextFrom
+		}
+	}
+	for _, query := range w.Queries {
+		fields := NewsfeedSearchFields{Query: query, StartTime: w.since}
+		for {
+			result, err := w.Newsfeed.Search(fields)
+			if err != nil {
+				return err
+			}
+			posts = append(posts, result.Items...)
+			if w.reached(result.Items, result.NextFrom) {
+				break
+			}
+			fields.StartFrom = result.NextFrom
+		}
+	}
+	if w.Dedupe == nil {
+		w.Dedupe = NewMemoryDedupe(0)
+	}
+	since := w.since
+	for _, p := range posts {
+		if err := w.emit(p); err != nil {
+			return err
+		}
+		if p.Date.After(since.Time) {
+			since = p.Date
+		}
+	}
+	// posts with same date are filtered out by dedupe
+	w.since = since
+	return nil
+}
+
+// reached reports whether newsfeed page is last one or contains
+// posts not newer than previous poll, as newsfeed is newest first
+func (w *NewsfeedWatcher) reached(page []Post, nextFrom string) bool {
+	if len(nextFrom) == 0 || len(page) == 0 {
+		return true
+	}
+	for _, p := range page {
+		if !p.Date.After(w.since.Time) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *NewsfeedWatcher) emit(p Post) error {
+	key := PostKey(p)
+	seen, err := w.Dedupe.Seen(key)
+	if err != nil || seen {
+		return err
+	}
+	if err = w.OnPost(p); err != nil {
+		w.Dedupe.Forget(key)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package vk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewsfeedWatcher(t *testing.T) {
+	Convey("Newsfeed watcher", t, func() {
+		var requests []*http.Request
+		responses := map[string]string{
+			"newsfeed.get": `{"response": {"items": [
+				{"type": "post", "source_id": -1, "post_id": 10, "date": 1010, "text": "a"},
+				{"type": "post", "source_id": -2, "post_id": 10, "date": 1020, "text": "b"}]}}`,
+			"newsfeed.search": `{"response": {"count": 1, "items": [
+				{"id": 10, "owner_id": -1, "date": 1010, "text": "a"},
+				{"id": 5, "owner_id": 3, "date": 1005, "text": "c"}]}}`,
+		}
+		client := New(WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req)
+			key := req.URL.Path[len("/method/"):]
+			if from := req.URL.Query().Get("start_from"); len(from) != 0 {
+				key += " " + from
+			}
+			return jsonResponse(http.StatusOK, responses[key]), nil
+		})))
+		var posts []string
+		w := &NewsfeedWatcher{
+			Newsfeed: client.Newsfeed,
+			Sources:  []ID{-1, -2},
+			Queries:  []string{"q"},
+			OnPost: func(post Post) error {
+				posts = append(posts, PostKey(post)+":"+post.Text)
+				return nil
+			},
+		}
+		So(w.Poll(), ShouldBeNil)
+		So(posts, ShouldResemble, []string{"-1_10:a", "-2_10:b", "3_5:c"})
+		query := requests[0].URL.Query()
+		So(query.Get("source_ids"), ShouldEqual, "-1,-2")
+		So(query.Get("filters"), ShouldEqual, "post")
+		So(requests[1].URL.Query().Get("q"), ShouldEqual, "q")
+
+		Convey("Next poll", func() {
+			So(w.Poll(), ShouldBeNil)
+			So(posts, ShouldHaveLength, 3)
+			So(requests[2].URL.Query().Get("start_time"), ShouldEqual, "1020")
+		})
+		Convey("Pagination", func() {
+			responses["newsfeed.get"] = `{"response": {"next_from": "p2", "items": [
+				{"type": "post", "source_id": -1, "post_id": 13, "date": 1040, "text": "f"}]}}`
+			responses["newsfeed.get p2"] = `{"response": {"next_from": "p3", "items": [
+				{"type": "post", "source_id": -1, "post_id": 12, "date": 1030, "text": "e"},
+				{"type": "post", "source_id": -2, "post_id": 10, "date": 1020, "text": "b"}]}}`
+			responses["newsfeed.search"] = `{"response": {"count": 0, "items": []}}`
+			So(w.Poll(), ShouldBeNil)
+			So(posts[3:], ShouldResemble, []string{"-1_13:f", "-1_12:e"})
+			// last seen post is reached on second page, so third is not requested
+			So(requests, ShouldHaveLength, 5)
+			So(requests[3].URL.Query().Get("start_from"), ShouldEqual, "p2")
+			So(w.since, ShouldResemble, Unix(1040))
+		})
+		Convey("Handler error", func() {
+			responses["newsfeed.get"] = `{"response": {"items": [
+				{"type": "post", "source_id": -1, "post_id": 11, "date": 1030, "text": "d"}]}}`
+			failure := errors.New("failure")
+			w.OnPost = func(post Post) error {
+				return failure
+			}
+			So(w.Poll(), ShouldEqual, failure)
+			So(w.since, ShouldResemble, Unix(1020))
+			w.OnPost = func(post Post) error {
+				posts = append(posts, PostKey(post))
+				return nil
+			}
+			So(w.Poll(), ShouldBeNil)
+			So(posts[3], ShouldEqual, "-1_11")
+		})
+		Convey("Run", func() {
+			clock := NewFakeClock(time.Unix(2000, 0))
+			ctx, cancel := context.WithCancel(context.Background())
+			w := &NewsfeedWatcher{Newsfeed: client.Newsfeed, Clock: clock, Interval: time.Second}
+			w.OnPost = func(post Post) error {
+				cancel()
+				return nil
+			}
+			So(w.Run(ctx), ShouldBeNil)
+			So(requests[len(requests)-1].URL.Query().Get("start_time"), ShouldEqual, "2000")
+		})
+	})
+}
@@ -0,0 +1,200 @@
+package vk
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	oauthTokenPath = "/token"
+
+	paramUsername      = "username"
+	paramPassword      = "password"
+	paramTwoFASupport  = "2fa_supported"
+	paramCaptchaSID    = "captcha_sid"
+	paramCaptchaKey    = "captcha_key"
+	grantPassword      = "password"
+	oauthNeedCaptcha   = "need_captcha"
+	oauthNeedTwoFactor = "need_validation"
+
+	defaultDirectAuthAttempts = 5
+)
+
+// OfficialClient is client_id and client_secret pair of official
+// vk application, some methods (audio, user messages) are available
+// only for tokens issued to such applications
+type OfficialClient struct {
+	Name      string
+	ID        int64
+	Secret    string
+	UserAgent string
+}
+
+var (
+	// ClientAndroid is official android application
+	ClientAndroid = OfficialClient{
+		Name:      "android",
+		ID:        2274003,
+		Secret:    "hHbZxrka2uZ6jB1inYsH",
+		UserAgent: "VKAndroidApp/5.52-4543 (Android 5.1.1; SDK 22; x86_64; unknown Android SDK built for x86_64; en; 320x240)",
+	}
+	// ClientIPhone is official iphone application
+	ClientIPhone = OfficialClient{
+		Name:      "iphone",
+		ID:        3140623,
+		Secret:    "VeWdmVclDCtn6ihuP1nt",
+		UserAgent: "com.vk.vkclient/1654 (iPhone, iOS 12.2, iPhone8,1, Scale/2.000000)",
+	}
+	// ClientVKAdmin is official community management application
+	ClientVKAdmin = OfficialClient{
+		Name:      "vkadmin",
+		ID:        6121396,
+		Secret:    "L3yBidmMBtFRKO9hPCgF",
+		UserAgent: "VKAdmin/1.0 (Android 9; SDK 28; arm64-v8a)",
+	}
+)
+
+// WithOfficialClient switches client to official-client mode, in which
+// requests are made on behalf of preset application and signed with its
+// secret as official applications do, see OfficialSign, and methods that
+// are restricted to official applications are enabled. Signing set by
+// WithSigning takes precedence.
+func WithOfficialClient(preset OfficialClient) Option {
+	return func(c *Client) {
+		c.official = &preset
+	}
+}
+
+// OfficialSign returns sig of official application request, that is
+// md5 hex of path and query as sent, like "/method/users.get?v=5.92",
+// followed by secret
+func OfficialSign(path, query, secret string) string {
+	h := md5.Sum([]byte(path + "?" + query + secret))
+	return hex.EncodeToString(h[:])
+}
+
+// sign adds sig to query of official application request
+func (o OfficialClient) sign(u *url.URL) {
+	query := u.Query()
+	query.Del(paramSig)
+	u.RawQuery = query.Encode()
+	u.RawQuery += "&" + paramSig + "=" + OfficialSign(u.Path, u.RawQuery, o.Secret)
+}
+
+// Official returns official client preset and true if client
+// is in official-client mode
+func (c *Client) Official() (OfficialClient, bool) {
+	if c.official == nil {
+		return OfficialClient{}, false
+	}
+	return *c.official, true
+}
+
+// DirectAuth obtains user token by login and password
+// with grant_type=password, that is allowed only for
+// official applications
+type DirectAuth struct {
+	Client   OfficialClient
+	Login    string
+	Password string
+	Scope    Scope
+	// TwoFactor returns two-factor authentication code
+	TwoFactor func() (string, error)
+	// Captcha returns text of captcha image from url
+	Captcha    func(imageURL string) (string, error)
+	HTTPClient HTTPClient
+	// URL of token endpoint, https://oauth.vk.com/token if empty
+	URL string
+}
+
+type directAuthResponse struct {
+	Token
+	OAuthError
+	CaptchaSID string `json:"captcha_sid"`
+	CaptchaImg string `json:"captcha_img"`
+}
+
+// Token performs authorization and returns access token
+func (a DirectAuth) Token(ctx context.Context) (token Token, err error) {
+	values := url.Values{}
+	values.Set(paramGrantType, grantPassword)
+	values.Set(paramAppID, int64s(a.Client.ID))
+	values.Set(paramClientSecret, a.Client.Secret)
+	values.Set(paramUsername, a.Login)
+	values.Set(paramPassword, a.Password)
+	values.Set(paramTwoFASupport, "1")
+	values.Set(paramVersion, defaultVersion)
+	if len(a.Scope) != 0 {
+		values.Set(paramScope, a.Scope.String())
+	}
+	for attempt := 0; attempt < defaultDirectAuthAttempts; attempt++ {
+		body, err := a.do(ctx, values)
+		if err != nil {
+			return token, err
+		}
+		switch body.OAuthError.Code {
+		case "":
+			body.Token.setExpiration(time.Now())
+			return body.Token, nil
+		case oauthNeedTwoFactor:
+			if a.TwoFactor == nil {
+				return token, ErrTwoFactorRequired
+			}
+			code, err := a.TwoFactor()
+			if err != nil {
+				return token, err
+			}
+			values.Set(paramCode, code)
+		case oauthNeedCaptcha:
+			if a.Captcha == nil {
+				return token, ErrCaptchaNeeded
+			}
+			key, err := a.Captcha(body.CaptchaImg)
+			if err != nil {
+				return token, err
+			}
+			values.Set(paramCaptchaSID, body.CaptchaSID)
+			values.Set(paramCaptchaKey, key)
+		default:
+			return token, body.OAuthError
+		}
+	}
+	return token, ErrAuthFailed
+}
+
+func (a DirectAuth) do(ctx context.Context, values url.Values) (body directAuthResponse, err error) {
+	u := a.URL
+	if len(u) == 0 {
+		u = (&url.URL{Scheme: oauthScheme, Host: oauthHost, Path: oauthTokenPath}).String()
+	}
+	req, err := http.NewRequest(http.MethodPost, u, strings.NewReader(values.Encode()))
+	if err != nil {
+		return body, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if len(a.Client.UserAgent) != 0 {
+		req.Header.Set("User-Agent", a.Client.UserAgent)
+	}
+	client := a.HTTPClient
+	if client == nil {
+		client = defaultHTTPClient
+	}
+	res, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return body, err
+	}
+	defer res.Body.Close()
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return body, err
+	}
+	if len(body.OAuthError.Code) == 0 && res.StatusCode != http.StatusOK {
+		return body, HTTPError{Status: res.StatusCode}
+	}
+	return body, nil
+}
@@ -0,0 +1,106 @@
+package vk
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDirectAuth(t *testing.T) {
+	Convey("Direct auth", t, func() {
+		var forms []url.Values
+		var responses []string
+		a := DirectAuth{
+			Client:   ClientAndroid,
+			Login:    "user",
+			Password: "secret",
+			Scope:    NewScope(PermOffline, PermAudio),
+		}
+		a.HTTPClient = httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			So(req.URL.String(), ShouldEqual, "https://oauth.vk.com/token")
+			So(req.Header.Get("User-Agent"), ShouldEqual, ClientAndroid.UserAgent)
+			body, _ := ioutil.ReadAll(req.Body)
+			form, _ := url.ParseQuery(string(body))
+			forms = append(forms, form)
+			response := responses[0]
+			responses = responses[1:]
+			return jsonResponse(http.StatusOK, response), nil
+		})
+		ctx := context.Background()
+		Convey("Ok", func() {
+			responses = []string{`{"access_token": "abc", "expires_in": 0, "user_id": 1}`}
+			token, err := a.Token(ctx)
+			So(err, ShouldBeNil)
+			So(token.AccessToken, ShouldEqual, "abc")
+			So(token.UserID, ShouldEqual, 1)
+			So(forms[0].Get("grant_type"), ShouldEqual, "password")
+			So(forms[0].Get("client_id"), ShouldEqual, "2274003")
+			So(forms[0].Get("client_secret"), ShouldEqual, ClientAndroid.Secret)
+			So(forms[0].Get("username"), ShouldEqual, "user")
+			So(forms[0].Get("password"), ShouldEqual, "secret")
+			So(forms[0].Get("scope"), ShouldEqual, "audio,offline")
+		})
+		Convey("Two-factor and captcha", func() {
+			responses = []string{`{"error": "need_validation", "error_description": "use app code"}`}
+			_, err := a.Token(ctx)
+			So(err, ShouldEqual, ErrTwoFactorRequired)
+
+			forms = nil
+			responses = []string{
+				`{"error": "need_validation", "error_description": "use app code"}`,
+				`{"error": "need_captcha", "captcha_sid": "7", "captcha_img": "https://api.vk.com/captcha.php?sid=7"}`,
+				`{"access_token": "abc"}`,
+			}
+			a.TwoFactor = func() (string, error) { return "123456", nil }
+			a.Captcha = func(imageURL string) (string, error) {
+				So(imageURL, ShouldEqual, "https://api.vk.com/captcha.php?sid=7")
+				return "42", nil
+			}
+			token, err := a.Token(ctx)
+			So(err, ShouldBeNil)
+			So(token.AccessToken, ShouldEqual, "abc")
+			So(forms, ShouldHaveLength, 3)
+			So(forms[1].Get("code"), ShouldEqual, "123456")
+			So(forms[2].Get("captcha_sid"), ShouldEqual, "7")
+			So(forms[2].Get("captcha_key"), ShouldEqual, "42")
+		})
+		Convey("Invalid credentials", func() {
+			responses = []string{`{"error": "invalid_client", "error_description": "Username or password is incorrect"}`}
+			_, err := a.Token(ctx)
+			So(err, ShouldResemble, OAuthError{"invalid_client", "Username or password is incorrect"})
+		})
+	})
+	Convey("Official client mode", t, func() {
+		c := New()
+		_, ok := c.Official()
+		So(ok, ShouldBeFalse)
+		c = New(WithOfficialClient(ClientIPhone))
+		preset, ok := c.Official()
+		So(ok, ShouldBeTrue)
+		So(preset.ID, ShouldEqual, 3140623)
+		// preset secret does not switch token validation to secure.checkToken
+		So(c.clientSecret, ShouldBeEmpty)
+		So(c.signing, ShouldBeNil)
+		So(OfficialSign("/method/users.get", "user_ids=1&v=5.92", "secret"), ShouldEqual, "4b910ab797b6e9b5f5000d1116e1bd92")
+		var query string
+		c.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			So(req.Header.Get("User-Agent"), ShouldEqual, ClientIPhone.UserAgent)
+			query = req.URL.RawQuery
+			return jsonResponse(http.StatusOK, `{"response": 1}`), nil
+		}))
+		_, err := c.Do(Request{Method: "users.get", Token: "t"})
+		So(err, ShouldBeNil)
+		i := strings.LastIndex(query, "&sig=")
+		So(i, ShouldBeGreaterThan, 0)
+		So(query[i+len("&sig="):], ShouldEqual, OfficialSign("/method/users.get", query[:i], ClientIPhone.Secret))
+		c = New(WithClientSecret("own"), WithOfficialClient(ClientVKAdmin))
+		So(c.clientSecret, ShouldEqual, "own")
+		c = New(WithSigning(Signing{Secret: "app"}), WithOfficialClient(ClientVKAdmin))
+		So(c.signing.Secret, ShouldEqual, "app")
+	})
+}
@@ -0,0 +1,40 @@
+package vk
+
+// offsetPager iterates over items of offset-based api methods,
+// that return page of items and total count, like groups.getMembers.
+// Iterator embeds it, stores fetched page and returns its item at pos.
+type offsetPager struct {
+	// offset of next page
+	offset int
+	// pos is index of current item in page of size items
+	pos   int
+	size  int
+	total int
+	err   error
+	done  bool
+}
+
+// next advances to next item, fetching next page with fetch when
+// current one is over, and returns false when items are over or error
+// occurred. Fetch returns count of items in page and total count.
+func (p *offsetPager) next(fetch func(offset int) (n, total int, err error)) bool {
+	if p.pos+1 < p.size {
+		p.pos++
+		return true
+	}
+	p.pos, p.size = 0, 0
+	if p.done || p.err != nil {
+		return false
+	}
+	n, total, err := fetch(p.offset)
+	if err != nil {
+		p.err = err
+		return false
+	}
+	p.total, p.size = total, n
+	p.offset += n
+	if n == 0 || p.offset >= total {
+		p.done = true
+	}
+	return n != 0
+}
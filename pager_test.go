@@ -0,0 +1,46 @@
+package vk
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestOffsetPager(t *testing.T) {
+	Convey("Offset pager", t, func() {
+		items := []int{1, 2, 3, 4, 5}
+		var (
+			offsets []int
+			page    []int
+		)
+		fetch := func(offset int) (int, int, error) {
+			offsets = append(offsets, offset)
+			end := offset + 2
+			if end > len(items) {
+				end = len(items)
+			}
+			page = items[offset:end]
+			return len(page), len(items), nil
+		}
+		p := offsetPager{offset: 1}
+		var got []int
+		for p.next(fetch) {
+			got = append(got, page[p.pos])
+		}
+		So(got, ShouldResemble, []int{2, 3, 4, 5})
+		So(offsets, ShouldResemble, []int{1, 3})
+		So(p.total, ShouldEqual, 5)
+		So(p.next(fetch), ShouldBeFalse)
+
+		Convey("Error", func() {
+			p := offsetPager{}
+			So(p.next(func(int) (int, int, error) { return 0, 0, ErrUnknown }), ShouldBeFalse)
+			So(p.err, ShouldEqual, ErrUnknown)
+		})
+		Convey("Empty page", func() {
+			p := offsetPager{}
+			So(p.next(func(int) (int, int, error) { return 0, 10, nil }), ShouldBeFalse)
+			So(p.done, ShouldBeTrue)
+		})
+	})
+}
@@ -0,0 +1,92 @@
+package vk
+
+import "io"
+
+const (
+	methodPhotosGetOwnerCoverPhotoUploadServer = "photos.getOwnerCoverPhotoUploadServer"
+	methodPhotosSaveOwnerCoverPhoto            = "photos.saveOwnerCoverPhoto"
+	methodPhotosGetChatUploadServer            = "photos.getChatUploadServer"
+	methodMessagesSetChatPhoto                 = "messages.setChatPhoto"
+)
+
+// Photos resource
+type Photos struct {
+	Resource
+}
+
+// CoverCrop is crop area of community cover,
+// zero value uses default 795x200 area
+type CoverCrop struct {
+	X  int `url:"crop_x,omitempty"`
+	Y  int `url:"crop_y,omitempty"`
+	X2 int `url:"crop_x2,omitempty"`
+	Y2 int `url:"crop_y2,omitempty"`
+}
+
+type CoverUploadFields struct {
+	GroupID int `url:"group_id"`
+	CoverCrop
+}
+
+type coverSaveFields struct {
+	Hash  string `url:"hash" json:"hash"`
+	Photo string `url:"photo" json:"photo"`
+}
+
+// CoverImage is one of community cover copies
+type CoverImage struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+type CoverUploadResult struct {
+	Images []CoverImage `json:"images"`
+}
+
+// UploadOwnerCover uploads community cover from r
+func (p Photos) UploadOwnerCover(fields CoverUploadFields, name string, r io.Reader) (result CoverUploadResult, err error) {
+	server := uploadServer{}
+	if err = p.Decode(p.Request(methodPhotosGetOwnerCoverPhotoUploadServer, fields), &server); err != nil {
+		return result, err
+	}
+	saved := coverSaveFields{}
+	if err = p.Uploader.Upload(server.UploadURL, "photo", name, r, &saved); err != nil {
+		return result, err
+	}
+	return result, p.Decode(p.Request(methodPhotosSaveOwnerCoverPhoto, saved), &result)
+}
+
+// ChatPhotoCrop is square crop area of chat photo
+type ChatPhotoCrop struct {
+	X     int `url:"crop_x,omitempty"`
+	Y     int `url:"crop_y,omitempty"`
+	Width int `url:"crop_width,omitempty"`
+}
+
+type ChatPhotoUploadFields struct {
+	ChatID int `url:"chat_id"`
+	ChatPhotoCrop
+}
+
+type chatPhotoSaveFields struct {
+	File string `url:"file" json:"response"`
+}
+
+type ChatPhotoUploadResult struct {
+	MessageID int `json:"message_id"`
+	Chat      Raw `json:"chat"`
+}
+
+// UploadChatPhoto uploads and sets chat photo from r
+func (p Photos) UploadChatPhoto(fields ChatPhotoUploadFields, name string, r io.Reader) (result ChatPhotoUploadResult, err error) {
+	server := uploadServer{}
+	if err = p.Decode(p.Request(methodPhotosGetChatUploadServer, fields), &server); err != nil {
+		return result, err
+	}
+	saved := chatPhotoSaveFields{}
+	if err = p.Uploader.Upload(server.UploadURL, "file", name, r, &saved); err != nil {
+		return result, err
+	}
+	return result, p.Decode(p.Request(methodMessagesSetChatPhoto, saved), &result)
+}
@@ -0,0 +1,120 @@
+package vk
+
+const (
+	methodPlacesSearch      = "places.search"
+	methodPlacesGetCheckins = "places.getCheckins"
+)
+
+// Places resource
+type Places struct {
+	Resource
+}
+
+// Coordinates of a point
+type Coordinates struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Place is a place from places or geo attachment
+type Place struct {
+	ID        ID      `json:"id"`
+	Title     string  `json:"title"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Created   Time    `json:"created"`
+	Icon      string  `json:"icon"`
+	Checkins  int     `json:"checkins"`
+	Type      int     `json:"type"`
+	Country   int     `json:"country"`
+	City      int     `json:"city"`
+	Address   string  `json:"address"`
+}
+
+// Coordinates of place
+func (p Place) Coordinates() Coordinates {
+	return Coordinates{Latitude: p.Latitude, Longitude: p.Longitude}
+}
+
+// Geo is geo object of a message or a post
+type Geo struct {
+	Type        string      `json:"type"`
+	Coordinates Coordinates `json:"coordinates"`
+	Place       *Place      `json:"place,omitempty"`
+}
+
+// PlaceRadius is search radius for places.search
+type PlaceRadius int
+
+const (
+	PlaceRadius300m PlaceRadius = 1
+	PlaceRadius2km  PlaceRadius = 2
+	PlaceRadius7km  PlaceRadius = 3
+	PlaceRadius50km PlaceRadius = 4
+)
+
+type PlaceSearchFields struct {
+	Query     string      `url:"q,omitempty"`
+	City      int         `url:"city,omitempty"`
+	Latitude  float64     `url:"latitude"`
+	Longitude float64     `url:"longitude"`
+	Radius    PlaceRadius `url:"radius,omitempty"`
+	Offset    int         `url:"offset,omitempty"`
+	Count     int         `url:"count,omitempty"`
+}
+
+type PlaceSearchResult struct {
+	Count int     `json:"count"`
+	Items []Place `json:"items"`
+}
+
+func (p Places) Search(fields PlaceSearchFields) (result PlaceSearchResult, err error) {
+	return result, p.Decode(p.Request(methodPlacesSearch, fields), &result)
+}
+
+// Near returns places around provided coordinates
+func (p Places) Near(c Coordinates, radius PlaceRadius) (result PlaceSearchResult, err error) {
+	return p.Search(PlaceSearchFields{
+		Latitude:  c.Latitude,
+		Longitude: c.Longitude,
+		Radius:    radius,
+	})
+}
+
+// Checkin is user checkin in place
+type Checkin struct {
+	ID        int     `json:"id"`
+	UserID    ID      `json:"user_id"`
+	Date      Time    `json:"date"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	PlaceID   ID      `json:"place_id"`
+	Text      string  `json:"text"`
+	Distance  int     `json:"distance,omitempty"`
+}
+
+// Coordinates of checkin
+func (c Checkin) Coordinates() Coordinates {
+	return Coordinates{Latitude: c.Latitude, Longitude: c.Longitude}
+}
+
+type PlaceGetCheckinsFields struct {
+	Latitude    float64 `url:"latitude,omitempty"`
+	Longitude   float64 `url:"longitude,omitempty"`
+	Place       ID      `url:"place,omitempty"`
+	UserID      ID      `url:"user_id,omitempty"`
+	Offset      int     `url:"offset,omitempty"`
+	Count       int     `url:"count,omitempty"`
+	Timestamp   Time    `url:"timestamp,omitempty"`
+	FriendsOnly Bool    `url:"friends_only,omitempty"`
+	NeedPlaces  Bool    `url:"need_places,omitempty"`
+}
+
+type PlaceGetCheckinsResult struct {
+	Count int       `json:"count"`
+	Items []Checkin `json:"items"`
+}
+
+func (p Places) GetCheckins(fields PlaceGetCheckinsFields) (result PlaceGetCheckinsResult, err error) {
+	return result, p.Decode(p.Request(methodPlacesGetCheckins, fields), &result)
+}
@@ -0,0 +1,66 @@
+package vk
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPlaces(t *testing.T) {
+	Convey("Places", t, func() {
+		Convey(methodPlacesSearch, func() {
+			mock := newApiMock(`{"response": {"count": 1, "items": [
+				{"id": 5, "title": "Red Square", "latitude": 55.7539, "longitude": 37.6208}
+			]}}`, nil)
+			f := rf()
+			p := Places{record(mock, &f)}
+			result, err := p.Near(Coordinates{55.75, 37.62}, PlaceRadius2km)
+			So(err, ShouldBeNil)
+			So(result.Count, ShouldEqual, 1)
+			So(result.Items[0].Coordinates(), ShouldResemble, Coordinates{55.7539, 37.6208})
+			So(f.request.Values.Get("latitude"), ShouldEqual, "55.75")
+			So(f.request.Values.Get("longitude"), ShouldEqual, "37.62")
+			So(f.request.Values.Get("radius"), ShouldEqual, "2")
+		})
+		Convey("Message geo", func() {
+			data := []byte(`{"id": 1, "text": "", "geo": {"type": "point",
+				"coordinates": {"latitude": 59.93, "longitude": 30.31},
+				"place": {"title": "Saint Petersburg", "city": 2}}}`)
+			m := Message{}
+			So(json.Unmarshal(data, &m), ShouldBeNil)
+			c, ok := m.Location()
+			So(ok, ShouldBeTrue)
+			So(c, ShouldResemble, Coordinates{59.93, 30.31})
+			So(m.Geo.Place.City, ShouldEqual, 2)
+
+			Convey("Round-trip", func() {
+				fields := MessageSendFields{PeerID: 1}
+				fields.SetLocation(c)
+				mock := newApiMock(`{"response": 1}`, nil)
+				f := rf()
+				_, err := Messages{record(mock, &f)}.Send(fields)
+				So(err, ShouldBeNil)
+				So(f.request.Values.Get("lat"), ShouldEqual, "59.93")
+				So(f.request.Values.Get("long"), ShouldEqual, "30.31")
+			})
+			Convey("Zero coordinates", func() {
+				fields := MessageSendFields{PeerID: 1}
+				fields.SetLocation(Coordinates{})
+				mock := newApiMock(`{"response": 1}`, nil)
+				f := rf()
+				_, err := Messages{record(mock, &f)}.Send(fields)
+				So(err, ShouldBeNil)
+				So(f.request.Values.Get("lat"), ShouldEqual, "0")
+				So(f.request.Values.Get("long"), ShouldEqual, "0")
+				_, err = Messages{record(mock, &f)}.Send(MessageSendFields{PeerID: 1})
+				So(err, ShouldBeNil)
+				So(f.request.Values, ShouldNotContainKey, "lat")
+			})
+			Convey("No geo", func() {
+				_, ok := Message{}.Location()
+				So(ok, ShouldBeFalse)
+			})
+		})
+	})
+}
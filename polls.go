@@ -0,0 +1,260 @@
+package vk
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	methodPollsGetByID   = "polls.getById"
+	methodPollsGetVoters = "polls.getVoters"
+
+	maxPollVotersCount       = 1000
+	defaultPollWatchInterval = 30 * time.Second
+)
+
+// Polls resource
+type Polls struct {
+	Resource
+}
+
+// PollAnswer is answer option of poll
+type PollAnswer struct {
+	ID    int     `json:"id"`
+	Text  string  `json:"text"`
+	Votes int     `json:"votes"`
+	Rate  float64 `json:"rate"`
+}
+
+// Poll object
+type Poll struct {
+	ID        int          `json:"id"`
+	OwnerID   ID           `json:"owner_id"`
+	Created   Time         `json:"created"`
+	Question  string       `json:"question"`
+	Votes     int          `json:"votes"`
+	Answers   []PollAnswer `json:"answers"`
+	Anonymous Bool         `json:"anonymous"`
+	Multiple  Bool         `json:"multiple"`
+	EndDate   Time         `json:"end_date"`
+	Closed    Bool         `json:"closed"`
+}
+
+// PollRef identifies poll
+type PollRef struct {
+	OwnerID ID   `url:"owner_id"`
+	PollID  int  `url:"poll_id"`
+	IsBoard Bool `url:"is_board,omitempty"`
+}
+
+func (p Polls) GetByID(poll PollRef) (result Poll, err error) {
+	return result, p.Decode(p.Request(methodPollsGetByID, poll), &result)
+}
+
+type PollsGetVotersFields struct {
+	PollRef
+	AnswerIDs []int `url:"answer_ids,comma"`
+	Offset    int   `url:"offset,omitempty"`
+	Count     int   `url:"count,omitempty"`
+}
+
+// PollVoters are voters for answer
+type PollVoters struct {
+	AnswerID int `json:"answer_id"`
+	Users    struct {
+		Count int  `json:"count"`
+		Items []ID `json:"items"`
+	} `json:"users"`
+}
+
+func (p Polls) GetVoters(fields PollsGetVotersFields) (result []PollVoters, err error) {
+	return result, p.Decode(p.Request(methodPollsGetVoters, fields), &result)
+}
+
+// PollChange is change of poll results since previous check
+type PollChange struct {
+	Poll Poll
+	// Votes is change of total votes
+	Votes int
+	// Answers is change of votes by answer id
+	Answers map[int]int
+	// Voters are new voters by answer id, set if PollWatcher.Voters
+	Voters map[int][]ID
+}
+
+// PollWatcher periodically checks tracked polls and emits changes
+// of results, e.g. for live vote displays
+type PollWatcher struct {
+	Polls    Polls
+	OnChange func(change PollChange) error
+	// Voters enables fetching of new voters of non-anonymous polls
+	Voters bool
+	// Interval of Run polls
+	Interval time.Duration
+	// Clock is SystemClock if nil
+	Clock Clock
+
+	mux    sync.Mutex
+	polls  map[PollRef]*Poll
+	voters map[PollRef]map[pollVote]bool
+}
+
+// Track adds poll to tracked ones
+func (w *PollWatcher) Track(poll PollRef) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	if w.polls == nil {
+		w.polls = make(map[PollRef]*Poll)
+	}
+	if _, ok := w.polls[poll]; !ok {
+		w.polls[poll] = nil
+	}
+}
+
+// Untrack removes poll from tracked ones
+func (w *PollWatcher) Untrack(poll PollRef) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	delete(w.polls, poll)
+	delete(w.voters, poll)
+}
+
+// Poll checks all tracked polls
+func (w *PollWatcher) Poll() error {
+	w.mux.Lock()
+	refs := make([]PollRef, 0, len(w.polls))
+	for ref := range w.polls {
+		refs = append(refs, ref)
+	}
+	w.mux.Unlock()
+	for _, ref := range refs {
+		if err := w.check(ref); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *PollWatcher) check(ref PollRef) error {
+	poll, err := w.Polls.GetByID(ref)
+	if err != nil {
+		return err
+	}
+	w.mux.Lock()
+	prev, tracked := w.polls[ref]
+	w.mux.Unlock()
+	if !tracked {
+		return nil
+	}
+	change := PollChange{Poll: poll, Votes: poll.Votes, Answers: make(map[int]int)}
+	before := make(map[int]int)
+	if prev != nil {
+		change.Votes -= prev.Votes
+		for _, a := range prev.Answers {
+			before[a.ID] = a.Votes
+		}
+	}
+	var changed []int
+	for _, a := range poll.Answers {
+		if d := a.Votes - before[a.ID]; d != 0 {
+			change.Answers[a.ID] = d
+			changed = append(changed, a.ID)
+		}
+	}
+	if len(changed) == 0 && change.Votes == 0 {
+		return nil
+	}
+	var votes []pollVote
+	if w.Voters && !bool(poll.Anonymous) && len(changed) != 0 {
+		if change.Voters, votes, err = w.newVoters(ref, changed); err != nil {
+			return err
+		}
+	}
+	if err = w.OnChange(change); err != nil {
+		return err
+	}
+	// poll and voters are saved after change is delivered,
+	// so failed delivery is retried on next poll
+	w.mux.Lock()
+	if _, ok := w.polls[ref]; ok {
+		w.polls[ref] = &poll
+		if len(votes) != 0 {
+			if w.voters == nil {
+				w.voters = make(map[PollRef]map[pollVote]bool)
+			}
+			seen := w.voters[ref]
+			if seen == nil {
+				seen = make(map[pollVote]bool)
+				w.voters[ref] = seen
+			}
+			for _, v := range votes {
+				seen[v] = true
+			}
+		}
+	}
+	w.mux.Unlock()
+	return nil
+}
+
+// pollVote is vote of user for answer
+type pollVote struct {
+	answer int
+	user   ID
+}
+
+// newVoters returns voters of answers that were not seen before
+// and their votes, that should be marked as seen after delivery
+func (w *PollWatcher) newVoters(ref PollRef, answers []int) (map[int][]ID, []pollVote, error) {
+	var votes []pollVote
+	fields := PollsGetVotersFields{PollRef: ref, AnswerIDs: answers, Count: maxPollVotersCount}
+	for {
+		page, err := w.Polls.GetVoters(fields)
+		if err != nil {
+			return nil, nil, err
+		}
+		more := false
+		for _, v := range page {
+			for _, id := range v.Users.Items {
+				votes = append(votes, pollVote{v.AnswerID, id})
+			}
+			if len(v.Users.Items) != 0 && fields.Offset+len(v.Users.Items) < v.Users.Count {
+				more = true
+			}
+		}
+		if !more {
+			break
+		}
+		fields.Offset += maxPollVotersCount
+	}
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	seen := w.voters[ref]
+	var unseen []pollVote
+	result := make(map[int][]ID)
+	for _, v := range votes {
+		if seen[v] {
+			continue
+		}
+		unseen = append(unseen, v)
+		result[v.answer] = append(result[v.answer], v.user)
+	}
+	return result, unseen, nil
+}
+
+// Run checks polls until ctx is done. PollWatcher is Component.
+func (w *PollWatcher) Run(ctx context.Context) error {
+	clock := clockOrSystem(w.Clock)
+	interval := w.Interval
+	if interval == 0 {
+		interval = defaultPollWatchInterval
+	}
+	for {
+		if err := w.Poll(); err != nil {
+			return err
+		}
+		if err := clock.Sleep(ctx, interval); err != nil {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package vk
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPollWatcher(t *testing.T) {
+	Convey("Poll watcher", t, func() {
+		client := New()
+		poll := `{"id": 3, "owner_id": -1, "votes": 3, "answers": [{"id": 10, "votes": 2}, {"id": 11, "votes": 1}]}`
+		voters := `[{"answer_id": 10, "users": {"count": 2, "items": [1, 2]}}, {"answer_id": 11, "users": {"count": 1, "items": [3]}}]`
+		var answerIDs []string
+		client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			So(q.Get("poll_id"), ShouldEqual, "3")
+			if req.URL.Path == "/method/polls.getVoters" {
+				answerIDs = append(answerIDs, q.Get("answer_ids"))
+				return jsonResponse(http.StatusOK, `{"response": `+voters+`}`), nil
+			}
+			return jsonResponse(http.StatusOK, `{"response": `+poll+`}`), nil
+		}))
+		var changes []PollChange
+		w := &PollWatcher{Polls: client.Polls, Voters: true, OnChange: func(c PollChange) error {
+			changes = append(changes, c)
+			return nil
+		}}
+		ref := PollRef{OwnerID: -1, PollID: 3}
+		w.Track(ref)
+		So(w.Poll(), ShouldBeNil)
+		So(changes, ShouldHaveLength, 1)
+		So(changes[0].Votes, ShouldEqual, 3)
+		So(changes[0].Answers, ShouldResemble, map[int]int{10: 2, 11: 1})
+		So(changes[0].Voters, ShouldResemble, map[int][]ID{10: {1, 2}, 11: {3}})
+
+		Convey("No changes", func() {
+			So(w.Poll(), ShouldBeNil)
+			So(changes, ShouldHaveLength, 1)
+		})
+		Convey("New vote", func() {
+			poll = `{"id": 3, "owner_id": -1, "votes": 4, "answers": [{"id": 10, "votes": 2}, {"id": 11, "votes": 2}]}`
+			voters = `[{"answer_id": 11, "users": {"count": 2, "items": [3, 4]}}]`
+			So(w.Poll(), ShouldBeNil)
+			So(changes, ShouldHaveLength, 2)
+			So(changes[1].Votes, ShouldEqual, 1)
+			So(changes[1].Answers, ShouldResemble, map[int]int{11: 1})
+			So(changes[1].Voters, ShouldResemble, map[int][]ID{11: {4}})
+			So(answerIDs, ShouldResemble, []string{"10,11", "11"})
+		})
+		Convey("Failed delivery", func() {
+			poll = `{"id": 3, "owner_id": -1, "votes": 4, "answers": [{"id": 10, "votes": 2}, {"id": 11, "votes": 2}]}`
+			voters = `[{"answer_id": 11, "users": {"count": 2, "items": [3, 4]}}]`
+			w.OnChange = func(c PollChange) error {
+				return errors.New("failed")
+			}
+			So(w.Poll(), ShouldNotBeNil)
+			w.OnChange = func(c PollChange) error {
+				changes = append(changes, c)
+				return nil
+			}
+			So(w.Poll(), ShouldBeNil)
+			So(changes, ShouldHaveLength, 2)
+			So(changes[1].Voters, ShouldResemble, map[int][]ID{11: {4}})
+		})
+		Convey("Untrack", func() {
+			w.Untrack(ref)
+			poll = `{"id": 3, "owner_id": -1, "votes": 5, "answers": []}`
+			So(w.Poll(), ShouldBeNil)
+			So(changes, ShouldHaveLength, 1)
+		})
+	})
+}
@@ -0,0 +1,59 @@
+package vk
+
+import "time"
+
+const (
+	// MaxPostponedPosts is limit of deferred posts on wall
+	MaxPostponedPosts = 10
+
+	wallFilterPostponed = "postponed"
+)
+
+// Postponed returns deferred posts of wall
+func (w Wall) Postponed(owner ID) ([]Post, error) {
+	result, err := w.Get(WallGetFields{OwnerID: owner, Filter: wallFilterPostponed, Count: 100})
+	return result.Items, err
+}
+
+// Reschedule changes publish date of deferred post
+func (w Wall) Reschedule(owner ID, postID int, at time.Time) error {
+	return w.Edit(WallEditFields{OwnerID: owner, PostID: postID, PublishDate: Time{at}})
+}
+
+// PostponedPoster creates deferred posts, falling back to Scheduler
+// when native queue of wall is full
+type PostponedPoster struct {
+	Wall      Wall
+	Scheduler *Scheduler
+}
+
+// Postponed is deferred post created by PostponedPoster, either
+// PostID of native deferred post or JobID of scheduler job is set
+type Postponed struct {
+	PostID int
+	JobID  string
+}
+
+// Post creates post that will be published at fields.PublishDate.
+// If wall already has MaxPostponedPosts deferred posts or vk denies
+// adding post, it is scheduled without publish_date in Scheduler.
+func (p PostponedPoster) Post(fields WallPostFields) (Postponed, error) {
+	posts, err := p.Wall.Postponed(fields.OwnerID)
+	if err != nil {
+		return Postponed{}, err
+	}
+	if len(posts) < MaxPostponedPosts {
+		id, err := p.Wall.Post(fields)
+		if err == nil || !isPostAddDenied(err) {
+			return Postponed{PostID: id}, err
+		}
+	}
+	at := fields.PublishDate.Time
+	fields.PublishDate = Time{}
+	id, err := p.Scheduler.Schedule(at, p.Wall.Request(methodWallPost, fields))
+	return Postponed{JobID: id}, err
+}
+
+func isPostAddDenied(err error) bool {
+	return IsServerError(err) && GetServerError(err).Code == ErrPostAddDenied
+}
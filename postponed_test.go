@@ -0,0 +1,65 @@
+package vk
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPostponed(t *testing.T) {
+	Convey("Postponed", t, func() {
+		client := New()
+		postponed := 3
+		var posted []string
+		client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			switch req.URL.Path {
+			case "/method/wall.get":
+				So(q.Get("filter"), ShouldEqual, "postponed")
+				items := strings.TrimSuffix(strings.Repeat(`{"id": 1},`, postponed), ",")
+				return jsonResponse(http.StatusOK, `{"response": {"count": 1, "items": [`+items+`]}}`), nil
+			case "/method/wall.post":
+				posted = append(posted, q.Get("publish_date"))
+				if postponed == 9 {
+					return jsonResponse(http.StatusOK, `{"error": {"error_code": 214, "error_msg": "denied"}}`), nil
+				}
+				return jsonResponse(http.StatusOK, `{"response": {"post_id": 42}}`), nil
+			case "/method/wall.edit":
+				So(q.Get("post_id"), ShouldEqual, "42")
+				So(q.Get("publish_date"), ShouldEqual, "2000")
+				return jsonResponse(http.StatusOK, `{"response": {"post_id": 42}}`), nil
+			}
+			panic(req.URL.Path)
+		}))
+		clock := NewFakeClock(time.Unix(1000, 0))
+		p := PostponedPoster{Wall: client.Wall, Scheduler: &Scheduler{Client: client, Clock: clock}}
+		fields := WallPostFields{OwnerID: -1, Message: "hello", PublishDate: Unix(1500)}
+		Convey("Native", func() {
+			res, err := p.Post(fields)
+			So(err, ShouldBeNil)
+			So(res, ShouldResemble, Postponed{PostID: 42})
+			So(posted, ShouldResemble, []string{"1500"})
+			So(client.Wall.Reschedule(-1, 42, time.Unix(2000, 0)), ShouldBeNil)
+		})
+		Convey("Full", func() {
+			postponed = MaxPostponedPosts
+			res, err := p.Post(fields)
+			So(err, ShouldBeNil)
+			So(res.JobID, ShouldNotBeEmpty)
+			So(posted, ShouldBeEmpty)
+			clock.Advance(time.Second * 500)
+			So(p.Scheduler.Poll(context.Background()), ShouldBeNil)
+			So(posted, ShouldResemble, []string{""})
+		})
+		Convey("Denied", func() {
+			postponed = 9
+			res, err := p.Post(fields)
+			So(err, ShouldBeNil)
+			So(res.JobID, ShouldNotBeEmpty)
+		})
+	})
+}
@@ -0,0 +1,162 @@
+package vk
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// user long poll event codes
+	longPollFriendOnline  = 8
+	longPollFriendOffline = 9
+)
+
+// Presence is online status of user
+type Presence struct {
+	UserID   ID
+	Online   bool
+	Platform int
+	// LastSeen is time of change for online users
+	// and last activity reported by vk for offline ones
+	LastSeen Time
+}
+
+// PresenceTracker is Component that tracks online status of friends
+// by friends.getOnline polling and user long poll events 8 and 9,
+// calling OnPresenceChange once for every change of status.
+// First poll only remembers current status.
+type PresenceTracker struct {
+	Friends Friends
+	// Users are used to get last_seen of users that went offline
+	Users            Users
+	OnPresenceChange func(p Presence) error
+	// Interval between polls, one minute if zero
+	Interval time.Duration
+	// Clock is SystemClock if nil
+	Clock Clock
+
+	mux    sync.Mutex
+	online map[ID]bool
+}
+
+// Online returns ids of online friends
+func (t *PresenceTracker) Online() []ID {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	ids := make([]ID, 0, len(t.online))
+	for id := range t.online {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// Run polls online friends until ctx is done or OnPresenceChange fails
+func (t *PresenceTracker) Run(ctx context.Context) error {
+	clock := clockOrSystem(t.Clock)
+	interval := t.Interval
+	if interval == 0 {
+		interval = defaultWatchInterval
+	}
+	for {
+		if err := t.Poll(); err != nil {
+			return err
+		}
+		if err := clock.Sleep(ctx, interval); err != nil {
+			return nil
+		}
+	}
+}
+
+// Poll fetches online friends and emits changes since previous poll
+func (t *PresenceTracker) Poll() error {
+	ids, err := t.Friends.GetOnline(FriendsGetOnlineFields{})
+	if err != nil {
+		return err
+	}
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	current := make(map[ID]bool, len(ids))
+	for _, id := range ids {
+		current[id] = true
+	}
+	if t.online == nil {
+		t.online = current
+		return nil
+	}
+	now := Time{clockOrSystem(t.Clock).Now()}
+	for _, id := range ids {
+		if !t.online[id] {
+			if err = t.change(Presence{UserID: id, Online: true, LastSeen: now}); err != nil {
+				return err
+			}
+		}
+	}
+	var offline []ID
+	for id := range t.online {
+		if !current[id] {
+			offline = append(offline, id)
+		}
+	}
+	if len(offline) == 0 {
+		return nil
+	}
+	sort.Slice(offline, func(i, j int) bool { return offline[i] < offline[j] })
+	users, err := t.Users.Get(UsersGetFields{UserIDs: offline, Fields: NewFields(FieldOnline, FieldLastSeen)})
+	if err != nil {
+		return err
+	}
+	for _, u := range users {
+		if u.Online {
+			// friends.getOnline is not consistent with users.get
+			continue
+		}
+		p := Presence{UserID: u.ID, LastSeen: u.LastSeen.Time, Platform: u.LastSeen.Platform}
+		if err = t.change(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HandleLongPollUpdate accepts user long poll update, events 8
+// [8, -user_id, platform, timestamp] and 9 [9, -user_id, flags, timestamp]
+// are converted to presence changes, other updates are ignored
+func (t *PresenceTracker) HandleLongPollUpdate(update []int64) error {
+	if len(update) < 4 {
+		return nil
+	}
+	p := Presence{UserID: ID(-update[1]), LastSeen: Unix(update[3])}
+	switch update[0] {
+	case longPollFriendOnline:
+		p.Online = true
+		p.Platform = int(update[2])
+	case longPollFriendOffline:
+	default:
+		return nil
+	}
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	if t.online == nil {
+		t.online = make(map[ID]bool)
+	}
+	if t.online[p.UserID] == p.Online {
+		return nil
+	}
+	return t.change(p)
+}
+
+// change delivers presence change and remembers it
+func (t *PresenceTracker) change(p Presence) error {
+	if err := t.OnPresenceChange(p); err != nil {
+		return err
+	}
+	if p.Online {
+		t.online[p.UserID] = true
+	} else {
+		delete(t.online, p.UserID)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package vk
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPresenceTracker(t *testing.T) {
+	Convey("Presence tracker", t, func() {
+		online := `{"response": [1, 2]}`
+		var usersQuery string
+		client := New(WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			if strings.HasSuffix(req.URL.Path, "users.get") {
+				usersQuery = req.URL.RawQuery
+				return jsonResponse(http.StatusOK, `{"response": [
+					{"id": 1, "online": 0, "last_seen": {"time": 900, "platform": 7}}]}`), nil
+			}
+			return jsonResponse(http.StatusOK, online), nil
+		})))
+		var changes []Presence
+		tracker := &PresenceTracker{
+			Friends: client.Friends,
+			Users:   client.Users,
+			Clock:   NewFakeClock(time.Unix(1000, 0)),
+			OnPresenceChange: func(p Presence) error {
+				changes = append(changes, p)
+				return nil
+			},
+		}
+		So(tracker.Poll(), ShouldBeNil)
+		So(changes, ShouldBeEmpty)
+		So(tracker.Online(), ShouldResemble, []ID{1, 2})
+
+		Convey("Polling", func() {
+			online = `{"response": [2, 3]}`
+			So(tracker.Poll(), ShouldBeNil)
+			So(changes, ShouldResemble, []Presence{
+				{UserID: 3, Online: true, LastSeen: Unix(1000)},
+				{UserID: 1, Platform: 7, LastSeen: Unix(900)},
+			})
+			So(usersQuery, ShouldContainSubstring, "user_ids=1")
+			So(tracker.Online(), ShouldResemble, []ID{2, 3})
+		})
+		Convey("Long poll", func() {
+			So(tracker.HandleLongPollUpdate([]int64{8, -5, 4, 1100}), ShouldBeNil)
+			So(tracker.HandleLongPollUpdate([]int64{8, -5, 4, 1101}), ShouldBeNil)
+			So(tracker.HandleLongPollUpdate([]int64{9, -1, 1, 1102}), ShouldBeNil)
+			So(tracker.HandleLongPollUpdate([]int64{4, 1, 2, 3, 4}), ShouldBeNil)
+			So(changes, ShouldResemble, []Presence{
+				{UserID: 5, Online: true, Platform: 4, LastSeen: Unix(1100)},
+				{UserID: 1, LastSeen: Unix(1102)},
+			})
+			So(tracker.Online(), ShouldResemble, []ID{2, 5})
+			// polling is consistent with long poll state
+			online = `{"response": [2, 5]}`
+			So(tracker.Poll(), ShouldBeNil)
+			So(changes, ShouldHaveLength, 2)
+		})
+	})
+}
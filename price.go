@@ -0,0 +1,122 @@
+package vk
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const minorUnits = 100
+
+// ErrCurrencyMismatch is returned on arithmetic with prices in different currencies
+var ErrCurrencyMismatch = errors.New("price: currency mismatch")
+
+// Amount is money amount in minor units, like kopecks
+type Amount int64
+
+// ParseAmount parses decimal amount in major units, like "1.50"
+func ParseAmount(s string) (Amount, error) {
+	s = strings.TrimSpace(s)
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	parts := strings.SplitN(s, ".", 2)
+	major, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	var minor int64
+	if len(parts) == 2 {
+		fraction := parts[1]
+		if len(fraction) == 0 || len(fraction) > 2 || strings.Trim(fraction, "0123456789") != "" {
+			return 0, fmt.Errorf("price: bad amount %q", s)
+		}
+		if len(fraction) == 1 {
+			fraction += "0"
+		}
+		if minor, err = strconv.ParseInt(fraction, 10, 64); err != nil {
+			return 0, err
+		}
+	}
+	a := Amount(major*minorUnits + minor)
+	if negative {
+		a = -a
+	}
+	return a, nil
+}
+
+// String returns amount in major units with two decimal places
+func (a Amount) String() string {
+	sign := ""
+	if a < 0 {
+		sign, a = "-", -a
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, a/minorUnits, a%minorUnits)
+}
+
+// UnmarshalJSON decodes minor units from number or string, as
+// vk returns amounts as strings
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(data, `"`)
+	if len(data) == 0 || string(data) == "null" {
+		*a = 0
+		return nil
+	}
+	v, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return err
+	}
+	*a = Amount(v)
+	return nil
+}
+
+// MarshalJSON encodes minor units as string, like vk does
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(strconv.FormatInt(int64(a), 10))), nil
+}
+
+// Currency of price, Name is currency code like RUB
+type Currency struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Price of item, order or ads spendings
+type Price struct {
+	Amount   Amount   `json:"amount"`
+	Currency Currency `json:"currency"`
+	Text     string   `json:"text"`
+}
+
+// Add returns sum of prices in same currency
+func (p Price) Add(other Price) (Price, error) {
+	if p.Currency != other.Currency {
+		return Price{}, ErrCurrencyMismatch
+	}
+	return Price{Amount: p.Amount + other.Amount, Currency: p.Currency}, nil
+}
+
+// Sub returns difference of prices in same currency
+func (p Price) Sub(other Price) (Price, error) {
+	if p.Currency != other.Currency {
+		return Price{}, ErrCurrencyMismatch
+	}
+	return Price{Amount: p.Amount - other.Amount, Currency: p.Currency}, nil
+}
+
+// Mul returns price multiplied by n, like price of n items
+func (p Price) Mul(n int) Price {
+	return Price{Amount: p.Amount * Amount(n), Currency: p.Currency}
+}
+
+// String returns Text if it is set or amount with currency code
+func (p Price) String() string {
+	if len(p.Text) != 0 {
+		return p.Text
+	}
+	if len(p.Currency.Name) == 0 {
+		return p.Amount.String()
+	}
+	return p.Amount.String() + " " + p.Currency.Name
+}
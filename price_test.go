@@ -0,0 +1,61 @@
+package vk
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPrice(t *testing.T) {
+	Convey("Price", t, func() {
+		Convey("Parse", func() {
+			for s, expected := range map[string]Amount{
+				"1.50":  150,
+				"1.5":   150,
+				"12":    1200,
+				"-0.05": -5,
+				"0.99":  99,
+			} {
+				a, err := ParseAmount(s)
+				So(err, ShouldBeNil)
+				So(a, ShouldEqual, expected)
+			}
+			for _, s := range []string{"", "1.", "1.505", "1.-5", "a"} {
+				_, err := ParseAmount(s)
+				So(err, ShouldNotBeNil)
+			}
+			So(Amount(150).String(), ShouldEqual, "1.50")
+			So(Amount(-5).String(), ShouldEqual, "-0.05")
+		})
+		Convey("JSON", func() {
+			p := Price{}
+			So(json.Unmarshal([]byte(`{"amount": "10050", "currency": {"id": 643, "name": "RUB"}, "text": "100.50 руб."}`), &p), ShouldBeNil)
+			So(p.Amount, ShouldEqual, 10050)
+			So(p.String(), ShouldEqual, "100.50 руб.")
+			So(json.Unmarshal([]byte(`{"amount": 5}`), &p), ShouldBeNil)
+			So(p.Amount, ShouldEqual, 5)
+			data, err := json.Marshal(Amount(42))
+			So(err, ShouldBeNil)
+			So(string(data), ShouldEqual, `"42"`)
+		})
+		Convey("Arithmetic", func() {
+			rub := Currency{ID: 643, Name: "RUB"}
+			a := Price{Amount: 1000, Currency: rub, Text: "10 руб."}
+			sum, err := a.Add(a.Mul(2))
+			So(err, ShouldBeNil)
+			So(sum, ShouldResemble, Price{Amount: 3000, Currency: rub})
+			So(sum.String(), ShouldEqual, "30.00 RUB")
+			diff, err := sum.Sub(a)
+			So(err, ShouldBeNil)
+			So(diff.Amount, ShouldEqual, 2000)
+			_, err = a.Add(Price{Amount: 1, Currency: Currency{ID: 840, Name: "USD"}})
+			So(err, ShouldEqual, ErrCurrencyMismatch)
+		})
+		Convey("Ads", func() {
+			spent, err := AdsStats{Spent: "1.50"}.SpentAmount()
+			So(err, ShouldBeNil)
+			So(spent, ShouldEqual, 150)
+		})
+	})
+}
@@ -0,0 +1,267 @@
+package vk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	methodAccountGetPrivacySettings = "account.getPrivacySettings"
+	methodAccountSetPrivacy         = "account.setPrivacy"
+
+	privacyListPrefix = "list"
+)
+
+// PrivacyCategory is base audience of privacy setting
+type PrivacyCategory string
+
+const (
+	PrivacyAll                  PrivacyCategory = "all"
+	PrivacyFriends              PrivacyCategory = "friends"
+	PrivacyFriendsOfFriends     PrivacyCategory = "friends_of_friends"
+	PrivacyFriendsOfFriendsOnly PrivacyCategory = "friends_of_friends_only"
+	PrivacyNobody               PrivacyCategory = "nobody"
+	PrivacyOnlyMe               PrivacyCategory = "only_me"
+)
+
+// Privacy is audience of privacy setting: category with allowed
+// and excluded users and friend lists. It is decoded from object
+// form of account.getPrivacySettings and from list form like
+// ["friends", "12", "-13", "list5"] of albums and users.
+//
+// Privacy can be built like
+//
+//	NewPrivacy(PrivacyFriends).Exclude(13).AllowList(5)
+type Privacy struct {
+	Category       PrivacyCategory
+	AllowedOwners  []ID
+	ExcludedOwners []ID
+	AllowedLists   []int
+	ExcludedLists  []int
+}
+
+// NewPrivacy returns privacy of category
+func NewPrivacy(category PrivacyCategory) Privacy {
+	return Privacy{Category: category}
+}
+
+// Allow returns copy of privacy that allows users
+func (p Privacy) Allow(ids ...ID) Privacy {
+	p.AllowedOwners = append(append([]ID(nil), p.AllowedOwners...), ids...)
+	return p
+}
+
+// Exclude returns copy of privacy that excludes users
+func (p Privacy) Exclude(ids ...ID) Privacy {
+	p.ExcludedOwners = append(append([]ID(nil), p.ExcludedOwners...), ids...)
+	return p
+}
+
+// AllowList returns copy of privacy that allows friend lists
+func (p Privacy) AllowList(ids ...int) Privacy {
+	p.AllowedLists = append(append([]int(nil), p.AllowedLists...), ids...)
+	return p
+}
+
+// ExcludeList returns copy of privacy that excludes friend lists
+func (p Privacy) ExcludeList(ids ...int) Privacy {
+	p.ExcludedLists = append(append([]int(nil), p.ExcludedLists...), ids...)
+	return p
+}
+
+// Values returns list form of privacy
+func (p Privacy) Values() []string {
+	var values []string
+	if p.Category != "" {
+		values = append(values, string(p.Category))
+	}
+	for _, id := range p.AllowedOwners {
+		values = append(values, int64s(int64(id)))
+	}
+	for _, id := range p.ExcludedOwners {
+		values = append(values, "-"+int64s(int64(id)))
+	}
+	for _, id := range p.AllowedLists {
+		values = append(values, privacyListPrefix+strconv.Itoa(id))
+	}
+	for _, id := range p.ExcludedLists {
+		values = append(values, "-"+privacyListPrefix+strconv.Itoa(id))
+	}
+	return values
+}
+
+// String returns value of account.setPrivacy, like "friends,-13,list5"
+func (p Privacy) String() string {
+	return strings.Join(p.Values(), ",")
+}
+
+// EncodeValues encodes privacy as comma separated list
+func (p Privacy) EncodeValues(key string, v *url.Values) error {
+	v.Add(key, p.String())
+	return nil
+}
+
+// ParsePrivacy parses list form of privacy, like "friends,-13,list5"
+func ParsePrivacy(s string) (Privacy, error) {
+	return parsePrivacyValues(strings.Split(s, ","))
+}
+
+func parsePrivacyValues(values []string) (p Privacy, err error) {
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if len(v) == 0 {
+			continue
+		}
+		excluded := strings.HasPrefix(v, "-")
+		name := strings.TrimPrefix(v, "-")
+		if strings.HasPrefix(name, privacyListPrefix) {
+			id, err := strconv.Atoi(strings.TrimPrefix(name, privacyListPrefix))
+			if err != nil {
+				return p, fmt.Errorf("privacy: bad list %q", v)
+			}
+			if excluded {
+				p.ExcludedLists = append(p.ExcludedLists, id)
+			} else {
+				p.AllowedLists = append(p.AllowedLists, id)
+			}
+			continue
+		}
+		id, err := strconv.ParseInt(name, 10, 64)
+		if err != nil {
+			if excluded || p.Category != "" {
+				return p, fmt.Errorf("privacy: bad value %q", v)
+			}
+			p.Category = PrivacyCategory(v)
+			continue
+		}
+		if excluded {
+			p.ExcludedOwners = append(p.ExcludedOwners, ID(id))
+		} else {
+			p.AllowedOwners = append(p.AllowedOwners, ID(id))
+		}
+	}
+	return p, nil
+}
+
+type privacyIDs struct {
+	Allowed  []ID `json:"allowed,omitempty"`
+	Excluded []ID `json:"excluded,omitempty"`
+}
+
+type privacyLists struct {
+	Allowed  []int `json:"allowed,omitempty"`
+	Excluded []int `json:"excluded,omitempty"`
+}
+
+type privacyObject struct {
+	Category PrivacyCategory `json:"category,omitempty"`
+	Owners   *privacyIDs     `json:"owners,omitempty"`
+	Lists    *privacyLists   `json:"lists,omitempty"`
+}
+
+// UnmarshalJSON decodes privacy from object, list or string form
+func (p *Privacy) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || string(data) == "null" {
+		*p = Privacy{}
+		return nil
+	}
+	switch data[0] {
+	case '[':
+		var values []string
+		if err := json.Unmarshal(data, &values); err != nil {
+			return err
+		}
+		parsed, err := parsePrivacyValues(values)
+		*p = parsed
+		return err
+	case '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		parsed, err := ParsePrivacy(s)
+		*p = parsed
+		return err
+	}
+	var o privacyObject
+	if err := json.Unmarshal(data, &o); err != nil {
+		return err
+	}
+	*p = Privacy{Category: o.Category}
+	if o.Owners != nil {
+		p.AllowedOwners, p.ExcludedOwners = o.Owners.Allowed, o.Owners.Excluded
+	}
+	if o.Lists != nil {
+		p.AllowedLists, p.ExcludedLists = o.Lists.Allowed, o.Lists.Excluded
+	}
+	return nil
+}
+
+// MarshalJSON encodes privacy in object form
+func (p Privacy) MarshalJSON() ([]byte, error) {
+	o := privacyObject{Category: p.Category}
+	if len(p.AllowedOwners) != 0 || len(p.ExcludedOwners) != 0 {
+		o.Owners = &privacyIDs{p.AllowedOwners, p.ExcludedOwners}
+	}
+	if len(p.AllowedLists) != 0 || len(p.ExcludedLists) != 0 {
+		o.Lists = &privacyLists{p.AllowedLists, p.ExcludedLists}
+	}
+	return json.Marshal(o)
+}
+
+// PrivacySetting is privacy setting of account
+type PrivacySetting struct {
+	Key     string  `json:"key"`
+	Title   string  `json:"title"`
+	Section string  `json:"section"`
+	Value   Privacy `json:"value"`
+}
+
+// PrivacySection is group of privacy settings
+type PrivacySection struct {
+	Name  string `json:"name"`
+	Title string `json:"title"`
+}
+
+// PrivacyCategoryInfo is category supported by settings
+type PrivacyCategoryInfo struct {
+	Value PrivacyCategory `json:"value"`
+	Title string          `json:"title"`
+}
+
+// PrivacySettings is result of account.getPrivacySettings
+type PrivacySettings struct {
+	Settings            []PrivacySetting      `json:"settings"`
+	Sections            []PrivacySection      `json:"sections"`
+	SupportedCategories []PrivacyCategoryInfo `json:"supported_categories"`
+}
+
+// Get returns value of setting by key
+func (s PrivacySettings) Get(key string) (Privacy, bool) {
+	for _, setting := range s.Settings {
+		if setting.Key == key {
+			return setting.Value, true
+		}
+	}
+	return Privacy{}, false
+}
+
+// GetPrivacySettings returns privacy settings of current user
+func (a Account) GetPrivacySettings() (result PrivacySettings, err error) {
+	return result, a.Decode(a.Request(methodAccountGetPrivacySettings, nil), &result)
+}
+
+type accountSetPrivacyFields struct {
+	Key   string  `url:"key"`
+	Value Privacy `url:"value"`
+}
+
+// SetPrivacy sets privacy setting by key and returns new value
+func (a Account) SetPrivacy(key string, value Privacy) (result Privacy, err error) {
+	return result, a.Decode(a.Request(methodAccountSetPrivacy, accountSetPrivacyFields{key, value}), &result)
+}
@@ -0,0 +1,67 @@
+package vk
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPrivacy(t *testing.T) {
+	Convey("Privacy", t, func() {
+		Convey("Builder", func() {
+			base := NewPrivacy(PrivacyFriends)
+			p := base.Allow(12).Exclude(13).AllowList(5).ExcludeList(6)
+			So(p.String(), ShouldEqual, "friends,12,-13,list5,-list6")
+			So(base.AllowedOwners, ShouldBeEmpty)
+			parsed, err := ParsePrivacy(p.String())
+			So(err, ShouldBeNil)
+			So(parsed, ShouldResemble, p)
+			_, err = ParsePrivacy("friends,nobody")
+			So(err, ShouldNotBeNil)
+			_, err = ParsePrivacy("-listx")
+			So(err, ShouldNotBeNil)
+		})
+		Convey("JSON", func() {
+			var p Privacy
+			So(json.Unmarshal([]byte(`{"category": "friends", "owners": {"excluded": [13]}, "lists": {"allowed": [5]}}`), &p), ShouldBeNil)
+			So(p, ShouldResemble, NewPrivacy(PrivacyFriends).Exclude(13).AllowList(5))
+			data, err := json.Marshal(p)
+			So(err, ShouldBeNil)
+			So(string(data), ShouldEqual, `{"category":"friends","owners":{"excluded":[13]},"lists":{"allowed":[5]}}`)
+			So(json.Unmarshal([]byte(`["only_me", "12"]`), &p), ShouldBeNil)
+			So(p, ShouldResemble, NewPrivacy(PrivacyOnlyMe).Allow(12))
+			So(json.Unmarshal([]byte(`"all"`), &p), ShouldBeNil)
+			So(p, ShouldResemble, NewPrivacy(PrivacyAll))
+		})
+		Convey("Account", func() {
+			var values []string
+			client := New(WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+				switch req.URL.Path {
+				case "/method/account.setPrivacy":
+					q := req.URL.Query()
+					values = append(values, q.Get("key")+"="+q.Get("value"))
+					return jsonResponse(http.StatusOK, `{"response": {"category": "friends", "owners": {"excluded": [13]}}}`), nil
+				}
+				return jsonResponse(http.StatusOK, `{"response": {"settings": [`+
+					`{"key": "mail_send", "title": "Who can message me", "section": "contacts", "value": {"category": "friends"}}], `+
+					`"sections": [{"name": "contacts", "title": "Contacts"}], `+
+					`"supported_categories": [{"value": "friends", "title": "Friends"}]}}`), nil
+			})))
+			settings, err := client.Account.GetPrivacySettings()
+			So(err, ShouldBeNil)
+			p, ok := settings.Get("mail_send")
+			So(ok, ShouldBeTrue)
+			So(p.Category, ShouldEqual, PrivacyFriends)
+			_, ok = settings.Get("unknown")
+			So(ok, ShouldBeFalse)
+			So(settings.SupportedCategories[0].Value, ShouldEqual, PrivacyFriends)
+
+			p, err = client.Account.SetPrivacy("mail_send", p.Exclude(13))
+			So(err, ShouldBeNil)
+			So(values, ShouldResemble, []string{"mail_send=friends,-13"})
+			So(p.ExcludedOwners, ShouldResemble, []ID{13})
+		})
+	})
+}
@@ -0,0 +1,193 @@
+package vk
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const maxUsersGetIDs = 1000
+
+// ProfileChangeKind is changed part of profile
+type ProfileChangeKind string
+
+const (
+	ProfileName   ProfileChangeKind = "name"
+	ProfilePhoto  ProfileChangeKind = "photo"
+	ProfileCity   ProfileChangeKind = "city"
+	ProfileStatus ProfileChangeKind = "status"
+)
+
+// ProfileChange is change of user profile between snapshots
+type ProfileChange struct {
+	UserID ID
+	Kind   ProfileChangeKind
+	Old    string
+	New    string
+	// Time is time of snapshot where change is found
+	Time Time
+}
+
+// SnapshotStore keeps last profile snapshots of users
+type SnapshotStore interface {
+	// Load returns snapshot of user, ok is false if there is none
+	Load(id ID) (u User, ok bool, err error)
+	Save(u User) error
+}
+
+// MemorySnapshots is in-memory SnapshotStore
+type MemorySnapshots struct {
+	mux   sync.Mutex
+	users map[ID]User
+}
+
+func (s *MemorySnapshots) Load(id ID) (User, bool, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	u, ok := s.users[id]
+	return u, ok, nil
+}
+
+func (s *MemorySnapshots) Save(u User) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.users == nil {
+		s.users = make(map[ID]User)
+	}
+	s.users[u.ID] = u
+	return nil
+}
+
+// StorageSnapshots is SnapshotStore that keeps
+// snapshots as JSON in Storage
+type StorageSnapshots struct {
+	Storage Storage
+	Prefix  string
+}
+
+func (s StorageSnapshots) key(id ID) string {
+	return s.Prefix + strconv.FormatInt(int64(id), 10)
+}
+
+func (s StorageSnapshots) Load(id ID) (u User, ok bool, err error) {
+	data, err := s.Storage.Get(s.key(id))
+	if err == ErrKeyNotFound {
+		return u, false, nil
+	}
+	if err != nil {
+		return u, false, err
+	}
+	return u, true, json.Unmarshal(data, &u)
+}
+
+func (s StorageSnapshots) Save(u User) error {
+	data, err := json.Marshal(u)
+	if err != nil {
+		return err
+	}
+	return s.Storage.Set(s.key(u.ID), data, 0)
+}
+
+// profileFields are fields of users.get that are compared
+var profileFields = NewFields(FieldPhotoID, FieldPhotoMax, FieldCity, FieldStatus)
+
+// diffProfiles returns changes from old to current profile
+func diffProfiles(old, current User) []ProfileChange {
+	var changes []ProfileChange
+	add := func(kind ProfileChangeKind, o, n string) {
+		if o != n {
+			changes = append(changes, ProfileChange{UserID: current.ID, Kind: kind, Old: o, New: n})
+		}
+	}
+	add(ProfileName, old.FirstName+" "+old.LastName, current.FirstName+" "+current.LastName)
+	if old.PhotoID != "" || current.PhotoID != "" {
+		add(ProfilePhoto, old.PhotoID, current.PhotoID)
+	} else {
+		// photo_id is missing without avatar, url is used instead
+		add(ProfilePhoto, old.PhotoMax, current.PhotoMax)
+	}
+	add(ProfileCity, old.City.Title, current.City.Title)
+	add(ProfileStatus, old.Status, current.Status)
+	return changes
+}
+
+// ProfileWatcher is Component that periodically snapshots profiles
+// of users and calls OnChange for every change since last snapshot.
+// Users without snapshot are only remembered.
+type ProfileWatcher struct {
+	Users    Users
+	IDs      []ID
+	OnChange func(c ProfileChange) error
+	// Store is MemorySnapshots if nil
+	Store SnapshotStore
+	// Interval between polls, one minute if zero
+	Interval time.Duration
+	// Clock is SystemClock if nil
+	Clock Clock
+
+	once sync.Once
+}
+
+func (w *ProfileWatcher) store() SnapshotStore {
+	w.once.Do(func() {
+		if w.Store == nil {
+			w.Store = new(MemorySnapshots)
+		}
+	})
+	return w.Store
+}
+
+// Run polls profiles until ctx is done or OnChange fails
+func (w *ProfileWatcher) Run(ctx context.Context) error {
+	clock := clockOrSystem(w.Clock)
+	interval := w.Interval
+	if interval == 0 {
+		interval = defaultWatchInterval
+	}
+	for {
+		if err := w.Poll(); err != nil {
+			return err
+		}
+		if err := clock.Sleep(ctx, interval); err != nil {
+			return nil
+		}
+	}
+}
+
+// Poll snapshots profiles and emits changes since previous snapshot
+func (w *ProfileWatcher) Poll() error {
+	store := w.store()
+	now := Time{clockOrSystem(w.Clock).Now()}
+	for start := 0; start < len(w.IDs); start += maxUsersGetIDs {
+		end := start + maxUsersGetIDs
+		if end > len(w.IDs) {
+			end = len(w.IDs)
+		}
+		users, err := w.Users.Get(UsersGetFields{UserIDs: w.IDs[start:end], Fields: profileFields})
+		if err != nil {
+			return err
+		}
+		for _, u := range users {
+			old, ok, err := store.Load(u.ID)
+			if err != nil {
+				return err
+			}
+			if ok {
+				for _, c := range diffProfiles(old, u) {
+					c.Time = now
+					if err = w.OnChange(c); err != nil {
+						return err
+					}
+				}
+			}
+			// snapshot is saved after changes are delivered,
+			// so failed delivery is retried on next poll
+			if err = store.Save(u); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
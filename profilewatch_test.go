@@ -0,0 +1,66 @@
+package vk
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestProfileWatcher(t *testing.T) {
+	Convey("Profile watcher", t, func() {
+		response := `{"response": [{"id": 1, "first_name": "Pavel", "last_name": "Durov", "photo_id": "1_1", ` +
+			`"city": {"id": 1, "title": "Moscow"}, "status": "a"}, {"id": 2, "first_name": "A", "last_name": "B"}]}`
+		var fields []string
+		client := New(WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			So(req.URL.Path, ShouldEqual, "/method/users.get")
+			So(req.URL.Query().Get("user_ids"), ShouldEqual, "1,2")
+			fields = append(fields, req.URL.Query().Get("fields"))
+			return jsonResponse(http.StatusOK, response), nil
+		})))
+		clock := NewFakeClock(time.Unix(100, 0))
+		var changes []ProfileChange
+		w := &ProfileWatcher{
+			Users: client.Users,
+			IDs:   []ID{1, 2},
+			Store: StorageSnapshots{Storage: &MemoryStorage{Clock: clock}, Prefix: "profile:"},
+			Clock: clock,
+			OnChange: func(c ProfileChange) error {
+				changes = append(changes, c)
+				return nil
+			},
+		}
+		So(w.Poll(), ShouldBeNil)
+		So(changes, ShouldBeEmpty)
+		So(fields[0], ShouldEqual, "photo_id,photo_max,city,status")
+
+		response = `{"response": [{"id": 1, "first_name": "Pavel", "last_name": "Durov", "photo_id": "1_2", ` +
+			`"city": {"id": 2, "title": "Dubai"}, "status": "a"}, {"id": 2, "first_name": "A", "last_name": "C"}]}`
+		clock.Advance(time.Minute)
+		So(w.Poll(), ShouldBeNil)
+		now := Unix(160)
+		So(changes, ShouldResemble, []ProfileChange{
+			{UserID: 1, Kind: ProfilePhoto, Old: "1_1", New: "1_2", Time: now},
+			{UserID: 1, Kind: ProfileCity, Old: "Moscow", New: "Dubai", Time: now},
+			{UserID: 2, Kind: ProfileName, Old: "A B", New: "A C", Time: now},
+		})
+
+		Convey("Failed delivery is retried", func() {
+			failed := errors.New("failed")
+			response = `{"response": [{"id": 1, "first_name": "Pavel", "last_name": "Durov", "photo_id": "1_2", ` +
+				`"city": {"id": 2, "title": "Dubai"}, "status": "b"}]}`
+			w.OnChange = func(c ProfileChange) error { return failed }
+			So(w.Poll(), ShouldEqual, failed)
+			changes = nil
+			w.OnChange = func(c ProfileChange) error {
+				changes = append(changes, c)
+				return nil
+			}
+			So(w.Poll(), ShouldBeNil)
+			So(changes, ShouldHaveLength, 1)
+			So(changes[0].New, ShouldEqual, "b")
+		})
+	})
+}
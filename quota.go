@@ -0,0 +1,171 @@
+package vk
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultQuotas are documented daily limits of methods per token
+var DefaultQuotas = map[string]int{
+	methodWallPost: 150,
+}
+
+// QuotaStore counts calls by key in daily windows
+type QuotaStore interface {
+	// Add adds n to count of key for day and returns new count,
+	// n can be zero to only get count
+	Add(key string, day time.Time, n int) (int, error)
+}
+
+// MemoryQuota is in-memory QuotaStore, that keeps only last day
+type MemoryQuota struct {
+	mux    sync.Mutex
+	day    time.Time
+	counts map[string]int
+}
+
+func (m *MemoryQuota) Add(key string, day time.Time, n int) (int, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if !m.day.Equal(day) {
+		if day.Before(m.day) {
+			// count of previous day is not kept
+			return 0, nil
+		}
+		m.day = day
+		m.counts = make(map[string]int)
+	}
+	m.counts[key] += n
+	return m.counts[key], nil
+}
+
+// QuotaPolicy is behaviour of Quotas when call exceeds limit
+type QuotaPolicy int
+
+const (
+	// QuotaRefuse fails calls that exceed limit with QuotaError
+	QuotaRefuse QuotaPolicy = iota
+	// QuotaDefer blocks calls that exceed limit until next day or
+	// until context is done
+	QuotaDefer
+	// QuotaTrack only counts calls
+	QuotaTrack
+)
+
+// QuotaError is returned for calls that exceed daily limit
+type QuotaError struct {
+	Method string
+	Limit  int
+	// Reset is start of next day, when quota is restored
+	Reset time.Time
+}
+
+func (e QuotaError) Error() string {
+	return fmt.Sprintf("quota of %s exceeded: %d per day, reset at %s",
+		e.Method, e.Limit, e.Reset.Format(time.RFC3339))
+}
+
+// Quotas tracks daily limits of methods per token. Calls are counted
+// before they are made, so failed calls are counted too.
+type Quotas struct {
+	// Limits are calls per day by method, DefaultQuotas if nil
+	Limits map[string]int
+	// Store is MemoryQuota if nil
+	Store  QuotaStore
+	Policy QuotaPolicy
+	// Location defines start of day, UTC if nil
+	Location *time.Location
+	// Clock is SystemClock if nil
+	Clock Clock
+
+	once sync.Once
+}
+
+// WithQuotas enables tracking of daily quotas of methods
+func WithQuotas(q *Quotas) Option {
+	return func(c *Client) {
+		c.quotas = q
+	}
+}
+
+func (q *Quotas) init() {
+	q.once.Do(func() {
+		if q.Store == nil {
+			q.Store = new(MemoryQuota)
+		}
+		if q.Limits == nil {
+			q.Limits = DefaultQuotas
+		}
+		if q.Location == nil {
+			q.Location = time.UTC
+		}
+	})
+}
+
+// day returns start of day of t
+func (q *Quotas) day(t time.Time) time.Time {
+	t = t.In(q.Location)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, q.Location)
+}
+
+// quotaKey returns store key of method for token, token
+// is hashed to not keep it in store
+func quotaKey(token, method string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:8]) + ":" + method
+}
+
+// Remaining returns count of calls of method with token left for
+// today, -1 if method has no limit
+func (q *Quotas) Remaining(token, method string) (int, error) {
+	q.init()
+	limit, ok := q.Limits[method]
+	if !ok {
+		return -1, nil
+	}
+	day := q.day(clockOrSystem(q.Clock).Now())
+	n, err := q.Store.Add(quotaKey(token, method), day, 0)
+	if err != nil {
+		return 0, err
+	}
+	if n > limit {
+		return 0, nil
+	}
+	return limit - n, nil
+}
+
+// Take counts call of request, failing or blocking according to
+// Policy if it exceeds limit
+func (q *Quotas) Take(ctx context.Context, request Request) error {
+	q.init()
+	limit, ok := q.Limits[request.Method]
+	if !ok {
+		return nil
+	}
+	clock := clockOrSystem(q.Clock)
+	key := quotaKey(request.Token, request.Method)
+	for {
+		day := q.day(clock.Now())
+		n, err := q.Store.Add(key, day, 1)
+		if err != nil {
+			return err
+		}
+		if n <= limit || q.Policy == QuotaTrack {
+			return nil
+		}
+		if _, err = q.Store.Add(key, day, -1); err != nil {
+			return err
+		}
+		reset := day.AddDate(0, 0, 1)
+		if q.Policy == QuotaRefuse {
+			return QuotaError{Method: request.Method, Limit: limit, Reset: reset}
+		}
+		if err = clock.Sleep(ctx, reset.Sub(clock.Now())); err != nil {
+			return err
+		}
+	}
+}
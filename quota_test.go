@@ -0,0 +1,72 @@
+package vk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestQuotas(t *testing.T) {
+	Convey("Quotas", t, func() {
+		clock := NewFakeClock(time.Date(2020, 1, 1, 23, 0, 0, 0, time.UTC))
+		q := &Quotas{Limits: map[string]int{methodWallPost: 2}, Clock: clock}
+		ctx := context.Background()
+		post := Request{Method: methodWallPost, Token: "a"}
+		So(q.Take(ctx, Request{Method: methodUsersGet}), ShouldBeNil)
+		n, err := q.Remaining("a", methodUsersGet)
+		So(err, ShouldBeNil)
+		So(n, ShouldEqual, -1)
+
+		So(q.Take(ctx, post), ShouldBeNil)
+		So(q.Take(ctx, post), ShouldBeNil)
+		n, err = q.Remaining("a", methodWallPost)
+		So(err, ShouldBeNil)
+		So(n, ShouldEqual, 0)
+		n, err = q.Remaining("b", methodWallPost)
+		So(err, ShouldBeNil)
+		So(n, ShouldEqual, 2)
+
+		Convey("Refuse", func() {
+			err := q.Take(ctx, post)
+			So(err, ShouldResemble, QuotaError{
+				Method: methodWallPost, Limit: 2,
+				Reset: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+			})
+			So(err.Error(), ShouldEqual, "quota of wall.post exceeded: 2 per day, reset at 2020-01-02T00:00:00Z")
+			clock.Advance(time.Hour)
+			So(q.Take(ctx, post), ShouldBeNil)
+		})
+		Convey("Defer", func() {
+			q.Policy = QuotaDefer
+			So(q.Take(ctx, post), ShouldBeNil)
+			So(clock.Slept(), ShouldResemble, []time.Duration{time.Hour})
+			n, err := q.Remaining("a", methodWallPost)
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 1)
+		})
+		Convey("Track", func() {
+			q.Policy = QuotaTrack
+			So(q.Take(ctx, post), ShouldBeNil)
+			n, err := q.Remaining("a", methodWallPost)
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 0)
+		})
+		Convey("Client", func() {
+			client := NewWithToken("a", WithQuotas(q))
+			client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+				return jsonResponse(http.StatusOK, `{"response": {"post_id": 1}}`), nil
+			}))
+			_, err := client.Wall.Post(WallPostFields{Message: "hi"})
+			So(err, ShouldHaveSameTypeAs, QuotaError{})
+		})
+		Convey("Defaults", func() {
+			q := new(Quotas)
+			n, err := q.Remaining("a", methodWallPost)
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, DefaultQuotas[methodWallPost])
+		})
+	})
+}
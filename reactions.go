@@ -0,0 +1,86 @@
+package vk
+
+import "encoding/json"
+
+const (
+	methodMessagesSendReaction   = "messages.sendReaction"
+	methodMessagesDeleteReaction = "messages.deleteReaction"
+
+	eventMessageReaction = "message_reaction_event"
+)
+
+// MessageReaction is count of reaction on message, UserIDs are
+// last users that reacted
+type MessageReaction struct {
+	ReactionID int  `json:"reaction_id"`
+	Count      int  `json:"count"`
+	UserIDs    []ID `json:"user_ids,omitempty"`
+}
+
+// PostReaction is count of reaction on post
+type PostReaction struct {
+	ID    int `json:"id"`
+	Count int `json:"count"`
+}
+
+// PostReactions are reactions on post
+type PostReactions struct {
+	CanView Bool           `json:"can_view"`
+	Items   []PostReaction `json:"items"`
+}
+
+type MessagesSendReactionFields struct {
+	PeerID ID `url:"peer_id"`
+	// CMID is conversation message id
+	CMID       int `url:"cmid"`
+	ReactionID int `url:"reaction_id"`
+}
+
+// SendReaction sets reaction of current user on message
+func (m Messages) SendReaction(fields MessagesSendReactionFields) error {
+	var result int
+	return m.Decode(m.Request(methodMessagesSendReaction, fields), &result)
+}
+
+type messagesDeleteReactionFields struct {
+	PeerID ID  `url:"peer_id"`
+	CMID   int `url:"cmid"`
+}
+
+// DeleteReaction removes reaction of current user from message
+func (m Messages) DeleteReaction(peerID ID, cmid int) error {
+	var result int
+	return m.Decode(m.Request(methodMessagesDeleteReaction, messagesDeleteReactionFields{peerID, cmid}), &result)
+}
+
+// ReactionEvent is message_reaction_event of callback or bots
+// long poll api, ReactionID is zero if reaction is removed
+type ReactionEvent struct {
+	ReactedID  ID  `json:"reacted_id"`
+	PeerID     ID  `json:"peer_id"`
+	CMID       int `json:"cmid"`
+	ReactionID int `json:"reaction_id,omitempty"`
+}
+
+// Removed reports whether reaction is removed
+func (e ReactionEvent) Removed() bool {
+	return e.ReactionID == 0
+}
+
+// ReactionHandler calls OnReaction for reaction events,
+// other events are ignored
+type ReactionHandler struct {
+	OnReaction func(e ReactionEvent) error
+}
+
+// HandleEvent is EventHandler
+func (h ReactionHandler) HandleEvent(event Event) error {
+	if event.Type != eventMessageReaction || h.OnReaction == nil {
+		return nil
+	}
+	var e ReactionEvent
+	if err := json.Unmarshal(event.Object, &e); err != nil {
+		return err
+	}
+	return h.OnReaction(e)
+}
@@ -0,0 +1,53 @@
+package vk
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestReactions(t *testing.T) {
+	Convey("Reactions", t, func() {
+		var calls []string
+		client := New(WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			q.Del(paramVersion)
+			q.Del(paramHTTPS)
+			calls = append(calls, req.URL.Path[len("/method/"):]+" "+q.Encode())
+			return jsonResponse(http.StatusOK, `{"response": 1}`), nil
+		})))
+		So(client.Messages.SendReaction(MessagesSendReactionFields{PeerID: 2000000001, CMID: 10, ReactionID: 3}), ShouldBeNil)
+		So(client.Messages.DeleteReaction(2000000001, 10), ShouldBeNil)
+		So(calls, ShouldResemble, []string{
+			"messages.sendReaction cmid=10&peer_id=2000000001&reaction_id=3",
+			"messages.deleteReaction cmid=10&peer_id=2000000001",
+		})
+
+		Convey("Models", func() {
+			var m Message
+			So(json.Unmarshal([]byte(`{"id": 1, "reaction_id": 2, "reactions": [{"reaction_id": 2, "count": 5, "user_ids": [1, 3]}]}`), &m), ShouldBeNil)
+			So(m.ReactionID, ShouldEqual, 2)
+			So(m.Reactions, ShouldResemble, []MessageReaction{{ReactionID: 2, Count: 5, UserIDs: []ID{1, 3}}})
+			var p Post
+			So(json.Unmarshal([]byte(`{"id": 1, "reactions": {"can_view": 1, "items": [{"id": 1, "count": 7}]}}`), &p), ShouldBeNil)
+			So(bool(p.Reactions.CanView), ShouldBeTrue)
+			So(p.Reactions.Items, ShouldResemble, []PostReaction{{ID: 1, Count: 7}})
+		})
+		Convey("Events", func() {
+			var events []ReactionEvent
+			h := ReactionHandler{OnReaction: func(e ReactionEvent) error {
+				events = append(events, e)
+				return nil
+			}}
+			So(h.HandleEvent(Event{Type: eventMessageNew, Object: Raw(`{}`)}), ShouldBeNil)
+			So(h.HandleEvent(Event{Type: eventMessageReaction, Object: Raw(`{"reacted_id": 1, "peer_id": 2, "cmid": 3, "reaction_id": 4}`)}), ShouldBeNil)
+			So(h.HandleEvent(Event{Type: eventMessageReaction, Object: Raw(`{"reacted_id": 1, "peer_id": 2, "cmid": 3}`)}), ShouldBeNil)
+			So(events, ShouldHaveLength, 2)
+			So(events[0], ShouldResemble, ReactionEvent{ReactedID: 1, PeerID: 2, CMID: 3, ReactionID: 4})
+			So(events[0].Removed(), ShouldBeFalse)
+			So(events[1].Removed(), ShouldBeTrue)
+		})
+	})
+}
@@ -0,0 +1,102 @@
+package vk
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrInvalidRPS is returned by RedisLimiter if RPS is not positive
+var ErrInvalidRPS = errors.New("limiter: rps should be positive")
+
+// RedisClient is subset of redis commands used by redis-backed
+// stores, implement it as adapter to any redis library
+type RedisClient interface {
+	// SetNX sets key if it does not exist and reports whether it was set
+	SetNX(key, value string, ttl time.Duration) (bool, error)
+	// Incr increments key, setting ttl if key is new, and returns new value
+	Incr(key string, ttl time.Duration) (int64, error)
+	// Del deletes key
+	Del(key string) error
+	// Get returns value of key or ErrKeyNotFound if it does not exist
+	Get(key string) (string, error)
+	// Set sets key, without ttl if it is zero
+	Set(key, value string, ttl time.Duration) error
+}
+
+// RedisLimiter is Limiter shared by all processes using same
+// redis key, that allows RPS requests per second window
+type RedisLimiter struct {
+	Client RedisClient
+	Key    string
+	RPS    int
+	// Clock is SystemClock if nil
+	Clock Clock
+}
+
+func (l RedisLimiter) Wait(ctx context.Context) error {
+	if l.RPS <= 0 {
+		return ErrInvalidRPS
+	}
+	clock := clockOrSystem(l.Clock)
+	for {
+		window := clock.Now().Truncate(time.Second)
+		n, err := l.Client.Incr(l.Key+":"+int64s(window.Unix()), 2*time.Second)
+		if err != nil {
+			return err
+		}
+		if n <= int64(l.RPS) {
+			return nil
+		}
+		if err = clock.Sleep(ctx, window.Add(time.Second).Sub(clock.Now())); err != nil {
+			return err
+		}
+	}
+}
+
+// RedisDedupe is DedupeStore shared by all processes using same prefix
+type RedisDedupe struct {
+	Client RedisClient
+	Prefix string
+	TTL    time.Duration
+}
+
+func (d RedisDedupe) Seen(id string) (bool, error) {
+	ttl := d.TTL
+	if ttl == 0 {
+		ttl = defaultDedupeTTL
+	}
+	set, err := d.Client.SetNX(d.Prefix+id, "1", ttl)
+	return !set, err
+}
+
+func (d RedisDedupe) Forget(id string) error {
+	return d.Client.Del(d.Prefix + id)
+}
+
+// RedisStorage is Storage shared by all processes using same
+// prefix, like long poll cursors of replicas
+type RedisStorage struct {
+	Client RedisClient
+	Prefix string
+}
+
+func (s RedisStorage) Get(key string) ([]byte, error) {
+	value, err := s.Client.Get(s.Prefix + key)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(value), nil
+}
+
+func (s RedisStorage) Set(key string, value []byte, ttl time.Duration) error {
+	return s.Client.Set(s.Prefix+key, string(value), ttl)
+}
+
+func (s RedisStorage) SetNX(key string, value []byte, ttl time.Duration) (bool, error) {
+	return s.Client.SetNX(s.Prefix+key, string(value), ttl)
+}
+
+func (s RedisStorage) Delete(key string) error {
+	return s.Client.Del(s.Prefix + key)
+}
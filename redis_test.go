@@ -0,0 +1,108 @@
+package vk
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// redisMock is in-memory RedisClient without expiration
+type redisMock struct {
+	mux     sync.Mutex
+	values  map[string]int64
+	strings map[string]string
+}
+
+func newRedisMock() *redisMock {
+	return &redisMock{values: make(map[string]int64), strings: make(map[string]string)}
+}
+
+func (r *redisMock) SetNX(key, value string, ttl time.Duration) (bool, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if _, ok := r.values[key]; ok {
+		return false, nil
+	}
+	r.values[key] = 1
+	return true, nil
+}
+
+func (r *redisMock) Incr(key string, ttl time.Duration) (int64, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.values[key]++
+	return r.values[key], nil
+}
+
+func (r *redisMock) Del(key string) error {
+	r.mux.Lock()
+	delete(r.values, key)
+	delete(r.strings, key)
+	r.mux.Unlock()
+	return nil
+}
+
+func (r *redisMock) Get(key string) (string, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	value, ok := r.strings[key]
+	if !ok {
+		return "", ErrKeyNotFound
+	}
+	return value, nil
+}
+
+func (r *redisMock) Set(key, value string, ttl time.Duration) error {
+	r.mux.Lock()
+	r.strings[key] = value
+	r.mux.Unlock()
+	return nil
+}
+
+func TestRedis(t *testing.T) {
+	Convey("Redis", t, func() {
+		redis := newRedisMock()
+		Convey("Dedupe", func() {
+			d := RedisDedupe{Client: redis, Prefix: "vk:event:"}
+			seen, err := d.Seen("a")
+			So(err, ShouldBeNil)
+			So(seen, ShouldBeFalse)
+			seen, _ = d.Seen("a")
+			So(seen, ShouldBeTrue)
+			So(redis.values, ShouldContainKey, "vk:event:a")
+			So(d.Forget("a"), ShouldBeNil)
+			seen, _ = d.Seen("a")
+			So(seen, ShouldBeFalse)
+		})
+		Convey("Limiter", func() {
+			clock := NewFakeClock(time.Unix(1000, int64(300*time.Millisecond)))
+			l := RedisLimiter{Client: redis, Key: "vk:rps", RPS: 2, Clock: clock}
+			ctx := context.Background()
+			So(l.Wait(ctx), ShouldBeNil)
+			So(l.Wait(ctx), ShouldBeNil)
+			So(clock.Slept(), ShouldBeEmpty)
+			// third request in same second waits for next window
+			So(l.Wait(ctx), ShouldBeNil)
+			So(clock.Slept(), ShouldResemble, []time.Duration{700 * time.Millisecond})
+			So(redis.values, ShouldContainKey, "vk:rps:1001")
+			l.RPS = 0
+			So(l.Wait(ctx), ShouldEqual, ErrInvalidRPS)
+		})
+		Convey("Storage", func() {
+			s := RedisStorage{Client: redis, Prefix: "vk:"}
+			_, err := s.Get("a")
+			So(err, ShouldEqual, ErrKeyNotFound)
+			So(s.Set("a", []byte("1"), 0), ShouldBeNil)
+			So(redis.strings, ShouldContainKey, "vk:a")
+			value, err := s.Get("a")
+			So(err, ShouldBeNil)
+			So(string(value), ShouldEqual, "1")
+			So(s.Delete("a"), ShouldBeNil)
+			_, err = s.Get("a")
+			So(err, ShouldEqual, ErrKeyNotFound)
+		})
+	})
+}
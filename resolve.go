@@ -0,0 +1,256 @@
+package vk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	methodUtilsResolveScreenName = "utils.resolveScreenName"
+	methodGroupsGetByID          = "groups.getById"
+	methodWallGetByID            = "wall.getById"
+	methodPhotosGetByID          = "photos.getById"
+)
+
+// ErrNotResolved is returned when object is not found
+var ErrNotResolved = errors.New("object not resolved")
+
+// ObjectType is type of resolved object
+type ObjectType string
+
+const (
+	ObjectUser        ObjectType = "user"
+	ObjectGroup       ObjectType = "group"
+	ObjectApplication ObjectType = "application"
+	ObjectPost        ObjectType = "post"
+	ObjectPhoto       ObjectType = "photo"
+
+	// objectWall is attachment prefix of posts
+	objectWall ObjectType = "wall"
+	// objectPage and objectEvent are types of public pages
+	// and events returned by utils.resolveScreenName
+	objectPage  ObjectType = "page"
+	objectEvent ObjectType = "event"
+)
+
+// ScreenName is result of utils.resolveScreenName
+type ScreenName struct {
+	Type     ObjectType `json:"type"`
+	ObjectID ID         `json:"object_id"`
+}
+
+type screenNameFields struct {
+	ScreenName string `url:"screen_name"`
+}
+
+// ResolveScreenName returns type and id of object by screen
+// name, Type is empty if screen name is not found
+func (u Utils) ResolveScreenName(name string) (result ScreenName, err error) {
+	var raw Raw
+	if err = u.Decode(u.Request(methodUtilsResolveScreenName, screenNameFields{name}), &raw); err != nil {
+		return result, err
+	}
+	return parseScreenName(raw)
+}
+
+// parseScreenName decodes resolveScreenName response,
+// that is empty array for unknown names
+func parseScreenName(raw Raw) (result ScreenName, err error) {
+	if len(raw) == 0 || raw[0] != '{' {
+		return result, nil
+	}
+	return result, json.Unmarshal(raw, &result)
+}
+
+// Application is vk application returned by apps.get
+type Application struct {
+	ID           ID     `json:"id"`
+	Title        string `json:"title"`
+	ScreenName   string `json:"screen_name"`
+	Type         string `json:"type"`
+	Section      string `json:"section"`
+	AuthorID     ID     `json:"author_owner_id"`
+	MembersCount int    `json:"members_count"`
+	Icon         string `json:"icon_75"`
+}
+
+type appsGetResult struct {
+	Count int           `json:"count"`
+	Items []Application `json:"items"`
+}
+
+// Resolved is resolved object, field according to Type is set
+type Resolved struct {
+	Type ObjectType
+	// ID of object, negative for groups
+	ID ID
+	// OwnerID of post or photo
+	OwnerID     ID
+	User        *User
+	Group       *Group
+	Application *Application
+	Post        *Post
+	Photo       *Photo
+}
+
+var (
+	reObjectID   = regexp.MustCompile(`^(id|club|public|event|app)(\d+)$`)
+	reAttachment = regexp.MustCompile(`^(wall|photo)(-?\d+)_(\d+)(?:_([0-9a-f]+))?$`)
+)
+
+// resolveTarget extracts object reference from url, removing
+// scheme, host and query, taking w or z query parameter if present
+func resolveTarget(s string) string {
+	s = strings.TrimSpace(s)
+	if u, err := url.Parse(s); err == nil && (len(u.Host) != 0 || strings.Contains(s, "/")) {
+		q := u.Query()
+		for _, key := range []string{"w", "z"} {
+			if v := q.Get(key); len(v) != 0 {
+				return strings.Split(v, "/")[0]
+			}
+		}
+		s = u.Path
+		if len(u.Host) == 0 {
+			// host without scheme is parsed as path
+			if i := strings.Index(s, "/"); i >= 0 {
+				s = s[i:]
+			}
+		}
+	}
+	s = strings.Trim(s, "/")
+	if i := strings.Index(s, "/"); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimPrefix(s, "@")
+}
+
+// Resolve returns object by url like https://vk.com/durov, screen
+// name, id123 or club1 form or attachment string like wall-1_2
+func (c *Client) Resolve(ctx context.Context, s string) (Resolved, error) {
+	target := resolveTarget(s)
+	if m := reAttachment.FindStringSubmatch(target); m != nil {
+		owner, _ := strconv.ParseInt(m[2], 10, 64)
+		id, _ := strconv.ParseInt(m[3], 10, 64)
+		return c.resolveAttachment(ctx, ObjectType(m[1]), ID(owner), ID(id), m[4])
+	}
+	if m := reObjectID.FindStringSubmatch(target); m != nil {
+		id, _ := strconv.ParseInt(m[2], 10, 64)
+		switch m[1] {
+		case "id":
+			return c.resolveObject(ctx, ObjectUser, ID(id))
+		case "app":
+			return c.resolveObject(ctx, ObjectApplication, ID(id))
+		default:
+			return c.resolveObject(ctx, ObjectGroup, ID(id))
+		}
+	}
+	if len(target) == 0 {
+		return Resolved{}, ErrNotResolved
+	}
+	res, err := c.DoContext(ctx, c.Utils.Request(methodUtilsResolveScreenName, screenNameFields{target}))
+	if err != nil {
+		return Resolved{}, err
+	}
+	name, err := parseScreenName(res.Response)
+	if err != nil {
+		return Resolved{}, err
+	}
+	if name.Type == objectPage || name.Type == objectEvent {
+		name.Type = ObjectGroup
+	}
+	return c.resolveObject(ctx, name.Type, name.ObjectID)
+}
+
+// decode performs request with ctx and decodes response to v
+func (c *Client) decode(ctx context.Context, request Request, v interface{}) error {
+	res, err := c.DoContext(ctx, request)
+	if err != nil {
+		return err
+	}
+	return res.To(v)
+}
+
+func (c *Client) resolveObject(ctx context.Context, t ObjectType, id ID) (Resolved, error) {
+	o := Resolved{Type: t, ID: id}
+	switch t {
+	case ObjectUser:
+		var users []User
+		err := c.decode(ctx, c.Users.Request(methodUsersGet, UsersGetFields{UserIDs: []ID{id}}), &users)
+		if err != nil {
+			return o, err
+		}
+		if len(users) == 0 {
+			return o, ErrNotResolved
+		}
+		o.User = &users[0]
+	case ObjectGroup:
+		var groups []Group
+		err := c.decode(ctx, c.Groups.Request(methodGroupsGetByID, struct {
+			GroupID ID `url:"group_id"`
+		}{id}), &groups)
+		if err != nil {
+			return o, err
+		}
+		if len(groups) == 0 {
+			return o, ErrNotResolved
+		}
+		o.ID = -id
+		o.Group = &groups[0]
+	case ObjectApplication:
+		var result appsGetResult
+		err := c.decode(ctx, c.Utils.Request(MethodAppsGet, struct {
+			AppID ID `url:"app_id"`
+		}{id}), &result)
+		if err != nil {
+			return o, err
+		}
+		if len(result.Items) == 0 {
+			return o, ErrNotResolved
+		}
+		o.Application = &result.Items[0]
+	default:
+		return o, ErrNotResolved
+	}
+	return o, nil
+}
+
+func (c *Client) resolveAttachment(ctx context.Context, t ObjectType, owner, id ID, accessKey string) (Resolved, error) {
+	o := Resolved{Type: t, ID: id, OwnerID: owner}
+	ref := int64s(int64(owner)) + "_" + int64s(int64(id))
+	if len(accessKey) != 0 {
+		ref += "_" + accessKey
+	}
+	switch t {
+	case objectWall:
+		o.Type = ObjectPost
+		var posts []Post
+		err := c.decode(ctx, c.Wall.Request(methodWallGetByID, struct {
+			Posts string `url:"posts"`
+		}{ref}), &posts)
+		if err != nil {
+			return o, err
+		}
+		if len(posts) == 0 {
+			return o, ErrNotResolved
+		}
+		o.Post = &posts[0]
+	case ObjectPhoto:
+		var photos []Photo
+		err := c.decode(ctx, c.Photos.Request(methodPhotosGetByID, struct {
+			Photos string `url:"photos"`
+		}{ref}), &photos)
+		if err != nil {
+			return o, err
+		}
+		if len(photos) == 0 {
+			return o, ErrNotResolved
+		}
+		o.Photo = &photos[0]
+	}
+	return o, nil
+}
@@ -0,0 +1,108 @@
+package vk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestResolve(t *testing.T) {
+	Convey("Resolve", t, func() {
+		Convey("Target", func() {
+			for s, target := range map[string]string{
+				"https://vk.com/durov":                    "durov",
+				"http://m.vk.com/durov?from=search":       "durov",
+				"vk.com/club1/":                           "club1",
+				"@durov":                                  "durov",
+				"https://vk.com/feed?w=wall-1_2":          "wall-1_2",
+				"https://vk.com/durov?z=photo1_2%2Falbum": "photo1_2",
+				" id1 ": "id1",
+			} {
+				So(resolveTarget(s), ShouldEqual, target)
+			}
+		})
+		client := New()
+		ctx := context.Background()
+		var calls []string
+		client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			calls = append(calls, req.URL.Path[len("/method/"):])
+			switch req.URL.Path {
+			case "/method/utils.resolveScreenName":
+				switch q.Get("screen_name") {
+				case "durov":
+					return jsonResponse(http.StatusOK, `{"response": {"type": "user", "object_id": 1}}`), nil
+				case "team":
+					return jsonResponse(http.StatusOK, `{"response": {"type": "page", "object_id": 22822305}}`), nil
+				case "meetup":
+					return jsonResponse(http.StatusOK, `{"response": {"type": "event", "object_id": 7}}`), nil
+				}
+				return jsonResponse(http.StatusOK, `{"response": []}`), nil
+			case "/method/users.get":
+				return jsonResponse(http.StatusOK, `{"response": [{"id": `+q.Get("user_ids")+`, "first_name": "Pavel"}]}`), nil
+			case "/method/groups.getById":
+				return jsonResponse(http.StatusOK, `{"response": [{"id": `+q.Get("group_id")+`, "name": "Team"}]}`), nil
+			case "/method/apps.get":
+				return jsonResponse(http.StatusOK, `{"response": {"count": 1, "items": [{"id": `+q.Get("app_id")+`, "title": "Game"}]}}`), nil
+			case "/method/wall.getById":
+				So(q.Get("posts"), ShouldEqual, "-1_2")
+				return jsonResponse(http.StatusOK, `{"response": [{"id": 2, "owner_id": -1, "text": "hello"}]}`), nil
+			case "/method/photos.getById":
+				So(q.Get("photos"), ShouldEqual, "1_2_ff")
+				return jsonResponse(http.StatusOK, `{"response": [{"id": 2, "owner_id": 1}]}`), nil
+			}
+			return jsonResponse(http.StatusOK, `{"response": []}`), nil
+		}))
+		Convey("Screen name", func() {
+			o, err := client.Resolve(ctx, "https://vk.com/durov")
+			So(err, ShouldBeNil)
+			So(o.Type, ShouldEqual, ObjectUser)
+			So(o.ID, ShouldEqual, 1)
+			So(o.User.FirstName, ShouldEqual, "Pavel")
+			So(calls, ShouldResemble, []string{"utils.resolveScreenName", "users.get"})
+
+			name, err := client.Utils.ResolveScreenName("unknown")
+			So(err, ShouldBeNil)
+			So(name.Type, ShouldBeEmpty)
+			_, err = client.Resolve(ctx, "unknown")
+			So(err, ShouldEqual, ErrNotResolved)
+		})
+		Convey("Public page and event", func() {
+			o, err := client.Resolve(ctx, "https://vk.com/team")
+			So(err, ShouldBeNil)
+			So(o.Type, ShouldEqual, ObjectGroup)
+			So(o.ID, ShouldEqual, -22822305)
+			So(o.Group.Name, ShouldEqual, "Team")
+			o, err = client.Resolve(ctx, "meetup")
+			So(err, ShouldBeNil)
+			So(o.Type, ShouldEqual, ObjectGroup)
+			So(o.ID, ShouldEqual, -7)
+		})
+		Convey("Ids", func() {
+			o, err := client.Resolve(ctx, "club5")
+			So(err, ShouldBeNil)
+			So(o.Type, ShouldEqual, ObjectGroup)
+			So(o.ID, ShouldEqual, -5)
+			So(o.Group.Name, ShouldEqual, "Team")
+			o, err = client.Resolve(ctx, "app10")
+			So(err, ShouldBeNil)
+			So(o.Type, ShouldEqual, ObjectApplication)
+			So(o.ID, ShouldEqual, 10)
+			So(o.Application.Title, ShouldEqual, "Game")
+			So(calls, ShouldResemble, []string{"groups.getById", "apps.get"})
+		})
+		Convey("Attachments", func() {
+			o, err := client.Resolve(ctx, "https://vk.com/wall-1_2")
+			So(err, ShouldBeNil)
+			So(o.Type, ShouldEqual, ObjectPost)
+			So(o.OwnerID, ShouldEqual, -1)
+			So(o.Post.Text, ShouldEqual, "hello")
+			o, err = client.Resolve(ctx, "photo1_2_ff")
+			So(err, ShouldBeNil)
+			So(o.Type, ShouldEqual, ObjectPhoto)
+			So(o.Photo.ID, ShouldEqual, 2)
+		})
+	})
+}
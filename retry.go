@@ -0,0 +1,40 @@
+package vk
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultAttempts   = 4
+	defaultRetryDelay = 3 * time.Second
+	// maxRetryAfter caps delay requested by server
+	maxRetryAfter = time.Minute
+)
+
+// retryAfter reports whether response should be retried and delay
+// before retry: 429 is always retried, 5xx only with Retry-After
+func retryAfter(res *http.Response, now time.Time) (time.Duration, bool) {
+	header := strings.TrimSpace(res.Header.Get("Retry-After"))
+	switch {
+	case res.StatusCode == http.StatusTooManyRequests:
+	case res.StatusCode >= http.StatusInternalServerError && len(header) != 0:
+	default:
+		return 0, false
+	}
+	d := defaultRetryDelay
+	if seconds, err := strconv.Atoi(header); err == nil {
+		d = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(header); err == nil {
+		d = date.Sub(now)
+	}
+	if d < 0 {
+		d = 0
+	}
+	if d > maxRetryAfter {
+		d = maxRetryAfter
+	}
+	return d, true
+}
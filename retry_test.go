@@ -0,0 +1,56 @@
+package vk
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRetryAfter(t *testing.T) {
+	Convey("Retry-After", t, func() {
+		now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		response := func(status int, header string) *http.Response {
+			res := jsonResponse(status, `{"response": 1}`)
+			if len(header) != 0 {
+				res.Header.Set("Retry-After", header)
+			}
+			return res
+		}
+		d, ok := retryAfter(response(http.StatusTooManyRequests, "5"), now)
+		So(ok, ShouldBeTrue)
+		So(d, ShouldEqual, 5*time.Second)
+		d, ok = retryAfter(response(http.StatusTooManyRequests, ""), now)
+		So(ok, ShouldBeTrue)
+		So(d, ShouldEqual, defaultRetryDelay)
+		d, ok = retryAfter(response(http.StatusServiceUnavailable, "Wed, 01 Jan 2020 00:00:10 GMT"), now)
+		So(ok, ShouldBeTrue)
+		So(d, ShouldEqual, 10*time.Second)
+		d, _ = retryAfter(response(http.StatusServiceUnavailable, "3600"), now)
+		So(d, ShouldEqual, maxRetryAfter)
+		_, ok = retryAfter(response(http.StatusBadGateway, ""), now)
+		So(ok, ShouldBeFalse)
+		_, ok = retryAfter(response(http.StatusBadRequest, "5"), now)
+		So(ok, ShouldBeFalse)
+
+		Convey("Client", func() {
+			clock := NewFakeClock(now)
+			statuses := []int{http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusOK}
+			client := New(WithClock(clock), WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+				status := statuses[0]
+				statuses = statuses[1:]
+				return response(status, "2"), nil
+			})))
+			_, err := client.Do(Request{Method: MethodUsersGet})
+			So(err, ShouldBeNil)
+			So(statuses, ShouldBeEmpty)
+			So(clock.Slept(), ShouldResemble, []time.Duration{2 * time.Second, 2 * time.Second})
+
+			statuses = []int{429, 429, 429, 429, 200}
+			_, err = client.Do(Request{Method: MethodUsersGet})
+			So(err.(HTTPError).Status, ShouldEqual, http.StatusTooManyRequests)
+			So(statuses, ShouldHaveLength, 1)
+		})
+	})
+}
@@ -0,0 +1,169 @@
+package vk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultMinBackoff = time.Second
+	defaultMaxBackoff = time.Minute
+)
+
+// Component is long-running part of application, like
+// long poll or callback server, that runs until ctx is done
+type Component interface {
+	Run(ctx context.Context) error
+}
+
+// ComponentFunc is function Component
+type ComponentFunc func(ctx context.Context) error
+
+func (f ComponentFunc) Run(ctx context.Context) error {
+	return f(ctx)
+}
+
+type fatalError struct {
+	err error
+}
+
+func (e fatalError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns marked error, so vk errors are matched with errors.Is
+func (e fatalError) Unwrap() error {
+	return e.err
+}
+
+// Fatal marks err as fatal, so Runner stops all components
+// instead of restarting the failed one
+func Fatal(err error) error {
+	return fatalError{err}
+}
+
+// IsFatal returns true if err or error wrapped by it is marked by Fatal
+func IsFatal(err error) bool {
+	var fatal fatalError
+	return errors.As(err, &fatal)
+}
+
+type runnerComponent struct {
+	name      string
+	component Component
+}
+
+// Runner supervises components: failed components are restarted with
+// exponential backoff and all of them are stopped on fatal error,
+// cancellation of context or signal
+type Runner struct {
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// Signals that stop runner, interrupt and SIGTERM if empty
+	Signals []os.Signal
+	// OnError is called on every component failure
+	OnError func(name string, err error)
+	// Clock is SystemClock if nil
+	Clock Clock
+
+	components []runnerComponent
+}
+
+// Add adds named component to runner
+func (r *Runner) Add(name string, c Component) {
+	r.components = append(r.components, runnerComponent{name, c})
+}
+
+// Run starts all components and blocks until they are stopped,
+// returning first fatal error if any
+func (r *Runner) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	signals := r.Signals
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, signals...)
+	defer signal.Stop(sig)
+	go func() {
+		select {
+		case <-sig:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	var (
+		wg    sync.WaitGroup
+		once  sync.Once
+		fatal error
+	)
+	for _, c := range r.components {
+		wg.Add(1)
+		go func(c runnerComponent) {
+			defer wg.Done()
+			if err := r.supervise(ctx, c); err != nil {
+				once.Do(func() {
+					fatal = err
+					cancel()
+				})
+			}
+		}(c)
+	}
+	wg.Wait()
+	return fatal
+}
+
+// supervise runs component until it finishes without error,
+// ctx is done or fatal error is returned
+func (r *Runner) supervise(ctx context.Context, c runnerComponent) error {
+	min, max := r.MinBackoff, r.MaxBackoff
+	if min == 0 {
+		min = defaultMinBackoff
+	}
+	if max == 0 {
+		max = defaultMaxBackoff
+	}
+	clock := clockOrSystem(r.Clock)
+	backoff := min
+	for {
+		start := clock.Now()
+		err := runComponent(ctx, c.component)
+		if err == nil || ctx.Err() != nil {
+			return nil
+		}
+		if r.OnError != nil {
+			r.OnError(c.name, err)
+		}
+		if IsFatal(err) {
+			return fmt.Errorf("%s: %w", c.name, err)
+		}
+		if clock.Now().Sub(start) > max {
+			backoff = min
+		}
+		if clock.Sleep(ctx, backoff) != nil {
+			return nil
+		}
+		if backoff *= 2; backoff > max {
+			backoff = max
+		}
+	}
+}
+
+// runComponent runs component, converting panic to error
+func runComponent(ctx context.Context, c Component) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New(fmt.Sprint("panic: ", r))
+		}
+	}()
+	return c.Run(ctx)
+}
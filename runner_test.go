@@ -0,0 +1,67 @@
+package vk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRunner(t *testing.T) {
+	Convey("Runner", t, func() {
+		r := &Runner{MinBackoff: time.Millisecond, MaxBackoff: 4 * time.Millisecond}
+		var failures int32
+		r.OnError = func(name string, err error) {
+			atomic.AddInt32(&failures, 1)
+		}
+		Convey("Restart and fatal", func() {
+			var crashes int32
+			r.Add("crashing", ComponentFunc(func(ctx context.Context) error {
+				if atomic.AddInt32(&crashes, 1) == 3 {
+					panic("boom")
+				}
+				if atomic.LoadInt32(&crashes) < 5 {
+					return errors.New("crash")
+				}
+				return Fatal(errors.New("broken"))
+			}))
+			stopped := make(chan struct{})
+			r.Add("poller", ComponentFunc(func(ctx context.Context) error {
+				<-ctx.Done()
+				close(stopped)
+				return ctx.Err()
+			}))
+			err := r.Run(context.Background())
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldEqual, "crashing: broken")
+			So(atomic.LoadInt32(&crashes), ShouldEqual, 5)
+			So(atomic.LoadInt32(&failures), ShouldEqual, 5)
+			<-stopped
+		})
+		Convey("Context", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+			defer cancel()
+			r.Add("poller", ComponentFunc(func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}))
+			r.Add("done", ComponentFunc(func(ctx context.Context) error {
+				return nil
+			}))
+			So(r.Run(ctx), ShouldBeNil)
+			So(atomic.LoadInt32(&failures), ShouldEqual, 0)
+		})
+		Convey("Fatal", func() {
+			err := errors.New("test")
+			So(IsFatal(Fatal(err)), ShouldBeTrue)
+			So(IsFatal(err), ShouldBeFalse)
+			So(IsFatal(fmt.Errorf("poller: %w", Fatal(ErrAuthFailed))), ShouldBeTrue)
+			So(errors.Is(Fatal(fmt.Errorf("poller: %w", ErrAuthFailed)), ErrAuthFailed), ShouldBeTrue)
+			So(Fatal(err).Error(), ShouldEqual, "test")
+		})
+	})
+}
@@ -0,0 +1,75 @@
+package vk
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Envelopes of methods that return scalar in response, like
+// messages.send. They can be decoded from raw body of api response:
+//
+//	var r IntResponse
+//	if err := json.NewDecoder(body).Decode(&r); err != nil { ... }
+//	if err := r.Err(); err != nil { ... }
+
+// IntResponse is envelope of number response
+type IntResponse struct {
+	Error    `json:"error"`
+	Response int64 `json:"response"`
+}
+
+// BoolResponse is envelope of 1/0 or true/false response
+type BoolResponse struct {
+	Error    `json:"error"`
+	Response Bool `json:"response"`
+}
+
+// StringResponse is envelope of string response
+type StringResponse struct {
+	Error    `json:"error"`
+	Response string `json:"response"`
+}
+
+// IDsResponse is envelope of list of ids response
+type IDsResponse struct {
+	Error    `json:"error"`
+	Response []ID `json:"response"`
+}
+
+// errOf returns e if it is not empty
+func errOf(e Error) error {
+	if e.Code == ErrZero {
+		return nil
+	}
+	return e
+}
+
+func (r IntResponse) Err() error    { return errOf(r.Error) }
+func (r BoolResponse) Err() error   { return errOf(r.Error) }
+func (r StringResponse) Err() error { return errOf(r.Error) }
+func (r IDsResponse) Err() error    { return errOf(r.Error) }
+
+// DecodeScalar decodes envelope from r and returns its error
+func DecodeScalar(r io.Reader, envelope interface{ Err() error }) error {
+	if err := json.NewDecoder(r).Decode(envelope); err != nil {
+		return err
+	}
+	return envelope.Err()
+}
+
+// DecodeInt performs request and returns number response
+func (r Resource) DecodeInt(request Request) (v int64, err error) {
+	return v, r.Decode(request, &v)
+}
+
+// DecodeBool performs request and returns 1/0 response as bool
+func (r Resource) DecodeBool(request Request) (bool, error) {
+	var v Bool
+	err := r.Decode(request, &v)
+	return bool(v), err
+}
+
+// DecodeString performs request and returns string response
+func (r Resource) DecodeString(request Request) (v string, err error) {
+	return v, r.Decode(request, &v)
+}
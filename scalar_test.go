@@ -0,0 +1,44 @@
+package vk
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestScalarResponses(t *testing.T) {
+	Convey("Scalar responses", t, func() {
+		Convey("Envelopes", func() {
+			var i IntResponse
+			So(DecodeScalar(bytes.NewBufferString(`{"response": 123}`), &i), ShouldBeNil)
+			So(i.Response, ShouldEqual, 123)
+			var b BoolResponse
+			So(DecodeScalar(bytes.NewBufferString(`{"response": 1}`), &b), ShouldBeNil)
+			So(b.Response, ShouldEqual, true)
+			var s StringResponse
+			So(DecodeScalar(bytes.NewBufferString(`{"response": "https://vk.cc/x"}`), &s), ShouldBeNil)
+			So(s.Response, ShouldEqual, "https://vk.cc/x")
+			var ids IDsResponse
+			So(DecodeScalar(bytes.NewBufferString(`{"response": [1, "2"]}`), &ids), ShouldBeNil)
+			So(ids.Response, ShouldResemble, []ID{1, 2})
+			err := DecodeScalar(bytes.NewBufferString(`{"error": {"error_code": 5, "error_msg": "auth"}}`), &i)
+			So(ErrAuthFailed.Is(err), ShouldBeTrue)
+			So(DecodeScalar(bytes.NewBufferString(`{`), &i), ShouldNotBeNil)
+		})
+		Convey("Resource", func() {
+			f := rf()
+			r := record(newApiMock(`{"response": 1}`, nil), &f)
+			n, err := r.DecodeInt(r.Request("messages.send", nil))
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 1)
+			ok, err := r.DecodeBool(r.Request("groups.isMember", nil))
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+			r = record(newApiMock(`{"response": "ok"}`, nil), &f)
+			s, err := r.DecodeString(r.Request("groups.getCallbackConfirmationCode", nil))
+			So(err, ShouldBeNil)
+			So(s, ShouldEqual, "ok")
+		})
+	})
+}
@@ -0,0 +1,145 @@
+package vk
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const defaultSchedulerInterval = time.Second
+
+// ScheduledJob is Job that should be executed at time
+type ScheduledJob struct {
+	Job
+	At time.Time `json:"at"`
+}
+
+// ScheduleStore persists scheduled jobs, implement it
+// to keep jobs in database between restarts
+type ScheduleStore interface {
+	// Add saves job, replacing job with same id
+	Add(job ScheduledJob) error
+	// Due returns jobs that should be executed at now, earliest first
+	Due(now time.Time) ([]ScheduledJob, error)
+	// Remove deletes job by id
+	Remove(id string) error
+}
+
+// MemorySchedule is in-memory ScheduleStore
+type MemorySchedule struct {
+	mux  sync.Mutex
+	jobs map[string]ScheduledJob
+}
+
+func (s *MemorySchedule) Add(job ScheduledJob) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.jobs == nil {
+		s.jobs = make(map[string]ScheduledJob)
+	}
+	s.jobs[job.ID] = job
+	return nil
+}
+
+func (s *MemorySchedule) Due(now time.Time) ([]ScheduledJob, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	var due []ScheduledJob
+	for _, job := range s.jobs {
+		if !job.At.After(now) {
+			due = append(due, job)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].At.Before(due[j].At)
+	})
+	return due, nil
+}
+
+func (s *MemorySchedule) Remove(id string) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	delete(s.jobs, id)
+	return nil
+}
+
+// Scheduler executes requests at provided time with Client, so
+// they are rate limited by client limiter
+type Scheduler struct {
+	Client *Client
+	// Store is in-memory if nil
+	Store ScheduleStore
+	// OnResult is called after job execution
+	OnResult func(job ScheduledJob, result Result)
+	// Interval of checking store for due jobs
+	Interval time.Duration
+	// Clock is SystemClock if nil
+	Clock Clock
+
+	once sync.Once
+	seq  int64
+}
+
+func (s *Scheduler) store() ScheduleStore {
+	s.once.Do(func() {
+		if s.Store == nil {
+			s.Store = new(MemorySchedule)
+		}
+	})
+	return s.Store
+}
+
+// Schedule saves request to be executed at time and returns job id
+func (s *Scheduler) Schedule(at time.Time, request Request) (string, error) {
+	now := clockOrSystem(s.Clock).Now()
+	id := strconv.FormatInt(now.UnixNano(), 36) + "-" + strconv.FormatInt(atomic.AddInt64(&s.seq, 1), 36)
+	job := ScheduledJob{Job: Job{ID: id, Request: request}, At: at}
+	return id, s.store().Add(job)
+}
+
+// Cancel removes scheduled job
+func (s *Scheduler) Cancel(id string) error {
+	return s.store().Remove(id)
+}
+
+// Poll executes all due jobs. Job is removed from store
+// before execution, so it is executed at most once.
+func (s *Scheduler) Poll(ctx context.Context) error {
+	due, err := s.store().Due(clockOrSystem(s.Clock).Now())
+	if err != nil {
+		return err
+	}
+	for _, job := range due {
+		if err = s.store().Remove(job.ID); err != nil {
+			return err
+		}
+		result := executeJob(ctx, s.Client, job.Job)
+		if s.OnResult != nil {
+			s.OnResult(job, result)
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+	return nil
+}
+
+// Run polls store until ctx is done. Scheduler is Component.
+func (s *Scheduler) Run(ctx context.Context) error {
+	clock := clockOrSystem(s.Clock)
+	interval := s.Interval
+	if interval == 0 {
+		interval = defaultSchedulerInterval
+	}
+	for {
+		if err := s.Poll(ctx); err != nil {
+			return err
+		}
+		if err := clock.Sleep(ctx, interval); err != nil {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package vk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestScheduler(t *testing.T) {
+	Convey("Scheduler", t, func() {
+		client := New()
+		var methods []string
+		client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			methods = append(methods, req.URL.Path)
+			return jsonResponse(http.StatusOK, `{"response": 1}`), nil
+		}))
+		clock := NewFakeClock(time.Unix(1000, 0))
+		var results []Result
+		s := &Scheduler{Client: client, Clock: clock, OnResult: func(job ScheduledJob, result Result) {
+			results = append(results, result)
+		}}
+		ctx := context.Background()
+		late, err := s.Schedule(time.Unix(1060, 0), Request{Method: "wall.post"})
+		So(err, ShouldBeNil)
+		early, err := s.Schedule(time.Unix(1030, 0), Request{Method: "messages.send"})
+		So(err, ShouldBeNil)
+		So(early, ShouldNotEqual, late)
+		canceled, _ := s.Schedule(time.Unix(1010, 0), Request{Method: "users.get"})
+		So(s.Cancel(canceled), ShouldBeNil)
+
+		So(s.Poll(ctx), ShouldBeNil)
+		So(results, ShouldBeEmpty)
+
+		clock.Advance(time.Minute)
+		So(s.Poll(ctx), ShouldBeNil)
+		So(methods, ShouldResemble, []string{"/method/messages.send", "/method/wall.post"})
+		So(results, ShouldHaveLength, 2)
+		So(results[0].ID, ShouldEqual, early)
+		So(results[0].Response.String(), ShouldEqual, "1")
+
+		Convey("Executed once", func() {
+			So(s.Poll(ctx), ShouldBeNil)
+			So(results, ShouldHaveLength, 2)
+		})
+		Convey("Run", func() {
+			ctx, cancel := context.WithCancel(ctx)
+			s.OnResult = func(job ScheduledJob, result Result) {
+				cancel()
+			}
+			s.Schedule(time.Unix(1100, 0), Request{Method: "wall.post"})
+			So(s.Run(ctx), ShouldBeNil)
+			So(clock.Now(), ShouldResemble, time.Unix(1100, 0))
+		})
+	})
+}
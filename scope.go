@@ -52,3 +52,63 @@ func NewScope(permissions ...Permission) Scope {
 	s.Add(permissions...)
 	return s
 }
+
+const (
+	PermNotify        Permission = "notify"
+	PermAudio         Permission = "audio"
+	PermVideo         Permission = "video"
+	PermStories       Permission = "stories"
+	PermPages         Permission = "pages"
+	PermStatus        Permission = "status"
+	PermNotes         Permission = "notes"
+	PermMessages      Permission = "messages"
+	PermWall          Permission = "wall"
+	PermAds           Permission = "ads"
+	PermDocs          Permission = "docs"
+	PermNotifications Permission = "notifications"
+	PermStats         Permission = "stats"
+	PermEmail         Permission = "email"
+	PermMarket        Permission = "market"
+)
+
+// permissionBits are bit masks of user permissions
+var permissionBits = map[Permission]int{
+	PermNotify:        1 << 0,
+	PermFriends:       1 << 1,
+	PermPhotos:        1 << 2,
+	PermAudio:         1 << 3,
+	PermVideo:         1 << 4,
+	PermStories:       1 << 6,
+	PermPages:         1 << 7,
+	PermStatus:        1 << 10,
+	PermNotes:         1 << 11,
+	PermMessages:      1 << 12,
+	PermWall:          1 << 13,
+	PermAds:           1 << 15,
+	PermOffline:       1 << 16,
+	PermDocs:          1 << 17,
+	PermGroups:        1 << 18,
+	PermNotifications: 1 << 19,
+	PermStats:         1 << 20,
+	PermEmail:         1 << 22,
+	PermMarket:        1 << 27,
+}
+
+// ScopeFromMask returns scope from permissions bit mask
+func ScopeFromMask(mask int) Scope {
+	s := Scope{}
+	for p, bit := range permissionBits {
+		if mask&bit != 0 {
+			s.Add(p)
+		}
+	}
+	return s
+}
+
+// Mask returns permissions bit mask of scope
+func (s Scope) Mask() (mask int) {
+	for p := range s {
+		mask |= permissionBits[p]
+	}
+	return mask
+}
@@ -38,3 +38,12 @@ func TestScope(t *testing.T) {
 		})
 	})
 }
+
+func TestScopeMask(t *testing.T) {
+	Convey("Scope mask", t, func() {
+		s := NewScope(PermFriends, PermOffline, PermMessages)
+		So(s.Mask(), ShouldEqual, 2+65536+4096)
+		So(ScopeFromMask(s.Mask()), ShouldResemble, s)
+		So(ScopeFromMask(0), ShouldBeEmpty)
+	})
+}
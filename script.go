@@ -0,0 +1,79 @@
+package vk
+
+import (
+	"bytes"
+	"context"
+)
+
+// Script builds code of execute request from api calls
+type Script struct {
+	code  bytes.Buffer
+	calls int
+}
+
+// NewScript returns empty script
+func NewScript() *Script {
+	return new(Script)
+}
+
+// Var assigns result of api call to variable name
+func (s *Script) Var(name string, r Request) *Script {
+	s.calls++
+	return s.Line("var " + name + " = " + r.JS() + ";")
+}
+
+// Push appends result of api call to array variable name
+func (s *Script) Push(name string, r Request) *Script {
+	s.calls++
+	return s.Line(name + ".push(" + r.JS() + ");")
+}
+
+// Call returns expression of api call to be used in statements
+func (s *Script) Call(r Request) string {
+	s.calls++
+	return r.JS()
+}
+
+// If appends statements of then that are executed if cond is true
+func (s *Script) If(cond string, then *Script) *Script {
+	s.calls += then.calls
+	return s.Line("if (" + cond + ") {" + then.String() + "}")
+}
+
+// Line appends raw statement to script
+func (s *Script) Line(statement string) *Script {
+	s.code.WriteString(statement)
+	return s
+}
+
+// Return appends return of expression
+func (s *Script) Return(expr string) *Script {
+	return s.Line("return " + expr + ";")
+}
+
+// Calls returns count of api calls in script, that
+// should not exceed 25
+func (s *Script) Calls() int {
+	return s.calls
+}
+
+// String returns code of script
+func (s *Script) String() string {
+	return s.code.String()
+}
+
+// Request returns execute request of script
+func (s *Script) Request(factory RequestFactory) Request {
+	return factory.Request(methodExecute, executeFields{s.String()})
+}
+
+// ExecuteScript makes script and decodes its result into v, returning
+// errors of failed api calls if result is decoded
+func (c *Client) ExecuteScript(ctx context.Context, s *Script, v interface{}) (Errors, error) {
+	res, err := c.DoContext(ctx, s.Request(c.Users.RequestFactory))
+	failed, partial := err.(Errors)
+	if err != nil && !partial {
+		return nil, err
+	}
+	return failed, res.To(v)
+}
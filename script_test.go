@@ -0,0 +1,25 @@
+package vk
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestScript(t *testing.T) {
+	Convey("Script", t, func() {
+		f := Factory{Token: "t"}
+		s := NewScript().
+			Var("user", f.Request(methodUsersGet, UsersGetFields{UserIDs: []ID{1}})).
+			Line("var walls = [];").
+			Push("walls", f.Request(methodWallGet, WallGetFields{OwnerID: 1})).
+			Return(`{"user": user, "walls": walls}`)
+		So(s.Calls(), ShouldEqual, 2)
+		So(s.String(), ShouldEqual, `var user = API.users.get({"user_ids":"1"});var walls = [];`+
+			`walls.push(API.wall.get({"owner_id":"1"}));return {"user": user, "walls": walls};`)
+		req := s.Request(f)
+		So(req.Method, ShouldEqual, methodExecute)
+		So(req.Token, ShouldEqual, "t")
+		So(req.Values.Get("code"), ShouldEqual, s.String())
+	})
+}
@@ -0,0 +1,170 @@
+package vk
+
+const (
+	methodSearchGetHints = "search.getHints"
+	methodUsersSearch    = "users.search"
+	methodGroupsSearch   = "groups.search"
+	methodNewsfeedSearch = "newsfeed.search"
+
+	SearchItemProfile = "profile"
+	SearchItemGroup   = "group"
+	SearchItemPost    = "post"
+)
+
+// Search resource
+type Search struct {
+	Resource
+}
+
+// Users resource
+type Users struct {
+	Resource
+}
+
+// Newsfeed resource
+type Newsfeed struct {
+	Resource
+}
+
+// SearchItem is search result of any type
+type SearchItem struct {
+	Type        string `json:"type"`
+	Section     string `json:"section,omitempty"`
+	Description string `json:"description,omitempty"`
+	Global      Bool   `json:"global,omitempty"`
+	User        *User  `json:"profile,omitempty"`
+	Group       *Group `json:"group,omitempty"`
+	Post        *Post  `json:"-"`
+}
+
+// Searcher finds objects by query
+type Searcher interface {
+	Find(query string, count int) ([]SearchItem, error)
+}
+
+type SearchHintsFields struct {
+	Query        string   `url:"q"`
+	Offset       int      `url:"offset,omitempty"`
+	Limit        int      `url:"limit,omitempty"`
+	Filters      []string `url:"filters,omitempty,comma"`
+	Fields       Fields   `url:"fields,omitempty"`
+	SearchGlobal Bool     `url:"search_global,omitempty"`
+}
+
+type SearchHintsResult struct {
+	Count int          `json:"count"`
+	Items []SearchItem `json:"items"`
+}
+
+func (s Search) GetHints(fields SearchHintsFields) (result SearchHintsResult, err error) {
+	return result, s.Decode(s.Request(methodSearchGetHints, fields), &result)
+}
+
+// Find returns hints for query
+func (s Search) Find(query string, count int) ([]SearchItem, error) {
+	result, err := s.GetHints(SearchHintsFields{Query: query, Limit: count, SearchGlobal: true})
+	return result.Items, err
+}
+
+type UserSearchFields struct {
+	Query   string `url:"q,omitempty"`
+	Offset  int    `url:"offset,omitempty"`
+	Count   int    `url:"count,omitempty"`
+	Fields  Fields `url:"fields,omitempty"`
+	City    int    `url:"city,omitempty"`
+	Country int    `url:"country,omitempty"`
+	Sex     Sex    `url:"sex,omitempty"`
+	AgeFrom int    `url:"age_from,omitempty"`
+	AgeTo   int    `url:"age_to,omitempty"`
+	GroupID int    `url:"group_id,omitempty"`
+}
+
+type UserSearchResult struct {
+	Count int    `json:"count"`
+	Items []User `json:"items"`
+}
+
+func (u Users) Search(fields UserSearchFields) (result UserSearchResult, err error) {
+	return result, u.Decode(u.Request(methodUsersSearch, fields), &result)
+}
+
+// Find returns users found by query
+func (u Users) Find(query string, count int) ([]SearchItem, error) {
+	result, err := u.Search(UserSearchFields{Query: query, Count: count})
+	items := make([]SearchItem, len(result.Items))
+	for i := range result.Items {
+		items[i] = SearchItem{Type: SearchItemProfile, User: &result.Items[i]}
+	}
+	return items, err
+}
+
+// GroupQueryFields are fields of groups.search
+type GroupQueryFields struct {
+	Query   string `url:"q"`
+	Type    string `url:"type,omitempty"`
+	Country int    `url:"country_id,omitempty"`
+	City    int    `url:"city_id,omitempty"`
+	Sort    int    `url:"sort,omitempty"`
+	Offset  int    `url:"offset,omitempty"`
+	Count   int    `url:"count,omitempty"`
+}
+
+func (g Groups) Search(fields GroupQueryFields) (result GroupGetResult, err error) {
+	return result, g.Decode(g.Request(methodGroupsSearch, fields), &result)
+}
+
+// Find returns communities found by query
+func (g Groups) Find(query string, count int) ([]SearchItem, error) {
+	result, err := g.Search(GroupQueryFields{Query: query, Count: count})
+	items := make([]SearchItem, len(result.Items))
+	for i := range result.Items {
+		items[i] = SearchItem{Type: SearchItemGroup, Group: &result.Items[i]}
+	}
+	return items, err
+}
+
+type NewsfeedSearchFields struct {
+	Query     string  `url:"q"`
+	Extended  Bool    `url:"extended,omitempty"`
+	Count     int     `url:"count,omitempty"`
+	Latitude  float64 `url:"latitude,omitempty"`
+	Longitude float64 `url:"longitude,omitempty"`
+	StartTime Time    `url:"start_time,omitempty"`
+	EndTime   Time    `url:"end_time,omitempty"`
+	StartFrom string  `url:"start_from,omitempty"`
+	Fields    Fields  `url:"fields,omitempty"`
+}
+
+type NewsfeedSearchResult struct {
+	Count      int    `json:"count"`
+	TotalCount int    `json:"total_count"`
+	Items      []Post `json:"items"`
+	NextFrom   string `json:"next_from"`
+}
+
+func (n Newsfeed) Search(fields NewsfeedSearchFields) (result NewsfeedSearchResult, err error) {
+	return result, n.Decode(n.Request(methodNewsfeedSearch, fields), &result)
+}
+
+// Find returns posts found by query
+func (n Newsfeed) Find(query string, count int) ([]SearchItem, error) {
+	result, err := n.Search(NewsfeedSearchFields{Query: query, Count: count})
+	items := make([]SearchItem, len(result.Items))
+	for i := range result.Items {
+		items[i] = SearchItem{Type: SearchItemPost, Post: &result.Items[i]}
+	}
+	return items, err
+}
+
+// SearchAll finds users, communities and posts by query,
+// returning up to count results of each type
+func (c *Client) SearchAll(query string, count int) (items []SearchItem, err error) {
+	for _, s := range []Searcher{c.Users, c.Groups, c.Newsfeed} {
+		found, err := s.Find(query, count)
+		if err != nil {
+			return items, err
+		}
+		items = append(items, found...)
+	}
+	return items, nil
+}
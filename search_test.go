@@ -0,0 +1,55 @@
+package vk
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSearch(t *testing.T) {
+	Convey("Search", t, func() {
+		mock := &apiMethodMock{responses: map[string][]string{
+			methodSearchGetHints: {`{"response": {"count": 2, "items": [
+				{"type": "group", "section": "groups", "group": {"id": 1, "name": "VK API"}},
+				{"type": "profile", "global": 1, "profile": {"id": 1, "first_name": "Pavel"}}
+			]}}`},
+			methodUsersSearch:    {`{"response": {"count": 1, "items": [{"id": 1, "first_name": "Pavel"}]}}`},
+			methodGroupsSearch:   {`{"response": {"count": 1, "items": [{"id": 1, "name": "VK API"}]}}`},
+			methodNewsfeedSearch: {`{"response": {"count": 1, "items": [{"id": 5, "owner_id": -1, "text": "vk api"}]}}`},
+		}}
+		resource := Resource{APIClient: mock, RequestFactory: DefaultFactory}
+		Convey(methodSearchGetHints, func() {
+			s := Search{resource}
+			result, err := s.GetHints(SearchHintsFields{Query: "vk", Filters: []string{"groups", "friends"}})
+			So(err, ShouldBeNil)
+			So(result.Count, ShouldEqual, 2)
+			So(result.Items[0].Group.Name, ShouldEqual, "VK API")
+			So(result.Items[1].User.FirstName, ShouldEqual, "Pavel")
+			So(result.Items[1].Global, ShouldEqual, true)
+			So(mock.requests[0].Values.Get("filters"), ShouldEqual, "groups,friends")
+		})
+		Convey("All", func() {
+			c := &Client{Users: Users{resource}, Groups: Groups{resource}, Newsfeed: Newsfeed{resource}}
+			items, err := c.SearchAll("vk", 10)
+			So(err, ShouldBeNil)
+			So(len(items), ShouldEqual, 3)
+			So(items[0].Type, ShouldEqual, SearchItemProfile)
+			So(items[0].User.ID, ShouldEqual, 1)
+			So(items[1].Type, ShouldEqual, SearchItemGroup)
+			So(items[1].Group.Name, ShouldEqual, "VK API")
+			So(items[2].Type, ShouldEqual, SearchItemPost)
+			So(items[2].Post.OwnerID, ShouldEqual, -1)
+			So(mock.methods(), ShouldResemble, []string{methodUsersSearch, methodGroupsSearch, methodNewsfeedSearch})
+			for _, r := range mock.requests {
+				So(r.Values.Get("q"), ShouldEqual, "vk")
+				So(r.Values.Get("count"), ShouldEqual, "10")
+			}
+			Convey("Error", func() {
+				delete(mock.responses, methodGroupsSearch)
+				items, err := c.SearchAll("vk", 10)
+				So(err, ShouldEqual, ErrUnknownMethod)
+				So(len(items), ShouldEqual, 1)
+			})
+		})
+	})
+}
@@ -5,55 +5,79 @@ package vk
 import "fmt"
 
 const (
-	_ServerError_name_0 = "ErrBadResponseCodeErrZeroErrUnknownErrApplicationDisabledErrUnknownMethodErrInvalidSignatureErrAuthFailedErrTooManyRequestsErrInsufficientPermissionsErrInvalidRequestErrTooManyOneTypeRequestsErrInternalServerErrorErrAppInTestModeErrCaptchaNeededErrNotAllowedErrHttpsOnlyErrNeedValidationErrStandaloneOnlyErrStandaloneOpenAPIOnlyErrMethodDisabledErrNeedConfirmation"
-	_ServerError_name_1 = "ErrOneOfParametersInvalidErrInvalidAPIID"
-	_ServerError_name_2 = "ErrInvalidAUserID"
-	_ServerError_name_3 = "ErrInvalidTimestamp"
-	_ServerError_name_4 = "ErrAlbumAccessProhibited"
-	_ServerError_name_5 = "ErrGroupAccessProhibited"
-	_ServerError_name_6 = "ErrAlbumOverflow"
-	_ServerError_name_7 = "ErrMoneyTransferNotAllowed"
-	_ServerError_name_8 = "ErrInsufficientPermissionsAd"
-	_ServerError_name_9 = "ErrInternalServerErrorAd"
+	_ServerError_name_0  = "ErrBadResponseCodeErrZeroErrUnknownErrApplicationDisabledErrUnknownMethodErrInvalidSignatureErrAuthFailedErrTooManyRequestsErrInsufficientPermissionsErrInvalidRequestErrTooManyOneTypeRequestsErrInternalServerErrorErrAppInTestMode"
+	_ServerError_name_1  = "ErrCaptchaNeededErrNotAllowedErrHttpsOnlyErrNeedValidationErrUserDeleted"
+	_ServerError_name_2  = "ErrStandaloneOnlyErrStandaloneOpenAPIOnly"
+	_ServerError_name_3  = "ErrMethodDisabledErrNeedConfirmation"
+	_ServerError_name_4  = "ErrRateLimitErrPrivateProfile"
+	_ServerError_name_5  = "ErrOneOfParametersInvalidErrInvalidAPIID"
+	_ServerError_name_6  = "ErrInvalidAUserID"
+	_ServerError_name_7  = "ErrInvalidTimestamp"
+	_ServerError_name_8  = "ErrAlbumAccessProhibited"
+	_ServerError_name_9  = "ErrGroupAccessProhibited"
+	_ServerError_name_10 = "ErrPostAddDenied"
+	_ServerError_name_11 = "ErrAlbumOverflow"
+	_ServerError_name_12 = "ErrMoneyTransferNotAllowed"
+	_ServerError_name_13 = "ErrInsufficientPermissionsAd"
+	_ServerError_name_14 = "ErrInternalServerErrorAd"
 )
 
 var (
-	_ServerError_index_0 = [...]uint16{0, 18, 25, 35, 57, 73, 92, 105, 123, 149, 166, 191, 213, 229, 245, 258, 270, 287, 304, 328, 345, 364}
-	_ServerError_index_1 = [...]uint8{0, 25, 40}
-	_ServerError_index_2 = [...]uint8{0, 17}
-	_ServerError_index_3 = [...]uint8{0, 19}
-	_ServerError_index_4 = [...]uint8{0, 24}
-	_ServerError_index_5 = [...]uint8{0, 24}
-	_ServerError_index_6 = [...]uint8{0, 16}
-	_ServerError_index_7 = [...]uint8{0, 26}
-	_ServerError_index_8 = [...]uint8{0, 28}
-	_ServerError_index_9 = [...]uint8{0, 24}
+	_ServerError_index_0  = [...]uint8{0, 18, 25, 35, 57, 73, 92, 105, 123, 149, 166, 191, 213, 229}
+	_ServerError_index_1  = [...]uint8{0, 16, 29, 41, 58, 72}
+	_ServerError_index_2  = [...]uint8{0, 17, 41}
+	_ServerError_index_3  = [...]uint8{0, 17, 36}
+	_ServerError_index_4  = [...]uint8{0, 12, 29}
+	_ServerError_index_5  = [...]uint8{0, 25, 40}
+	_ServerError_index_6  = [...]uint8{0, 17}
+	_ServerError_index_7  = [...]uint8{0, 19}
+	_ServerError_index_8  = [...]uint8{0, 24}
+	_ServerError_index_9  = [...]uint8{0, 24}
+	_ServerError_index_10 = [...]uint8{0, 16}
+	_ServerError_index_11 = [...]uint8{0, 16}
+	_ServerError_index_12 = [...]uint8{0, 26}
+	_ServerError_index_13 = [...]uint8{0, 28}
+	_ServerError_index_14 = [...]uint8{0, 24}
 )
 
 func (i ServerError) String() string {
 	switch {
-	case -1 <= i && i <= 19:
+	case -1 <= i && i <= 11:
 		i -= -1
 		return _ServerError_name_0[_ServerError_index_0[i]:_ServerError_index_0[i+1]]
+	case 14 <= i && i <= 18:
+		i -= 14
+		return _ServerError_name_1[_ServerError_index_1[i]:_ServerError_index_1[i+1]]
+	case 20 <= i && i <= 21:
+		i -= 20
+		return _ServerError_name_2[_ServerError_index_2[i]:_ServerError_index_2[i+1]]
+	case 23 <= i && i <= 24:
+		i -= 23
+		return _ServerError_name_3[_ServerError_index_3[i]:_ServerError_index_3[i+1]]
+	case 29 <= i && i <= 30:
+		i -= 29
+		return _ServerError_name_4[_ServerError_index_4[i]:_ServerError_index_4[i+1]]
 	case 100 <= i && i <= 101:
 		i -= 100
-		return _ServerError_name_1[_ServerError_index_1[i]:_ServerError_index_1[i+1]]
+		return _ServerError_name_5[_ServerError_index_5[i]:_ServerError_index_5[i+1]]
 	case i == 113:
-		return _ServerError_name_2
+		return _ServerError_name_6
 	case i == 150:
-		return _ServerError_name_3
+		return _ServerError_name_7
 	case i == 200:
-		return _ServerError_name_4
+		return _ServerError_name_8
 	case i == 203:
-		return _ServerError_name_5
+		return _ServerError_name_9
+	case i == 214:
+		return _ServerError_name_10
 	case i == 300:
-		return _ServerError_name_6
+		return _ServerError_name_11
 	case i == 500:
-		return _ServerError_name_7
+		return _ServerError_name_12
 	case i == 600:
-		return _ServerError_name_8
+		return _ServerError_name_13
 	case i == 603:
-		return _ServerError_name_9
+		return _ServerError_name_14
 	default:
 		return fmt.Sprintf("ServerError(%d)", i)
 	}
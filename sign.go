@@ -0,0 +1,70 @@
+package vk
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"net/url"
+	"sort"
+)
+
+const (
+	paramSig   = "sig"
+	paramAPIID = "api_id"
+)
+
+// Signing configures signed-request mode, where every request
+// has sig parameter computed with application secret
+type Signing struct {
+	Secret string
+	// ReplaceToken removes access_token from signed requests,
+	// adding api_id of AppID if it is set, for server flows
+	// authenticated by sig only
+	ReplaceToken bool
+	AppID        int64
+}
+
+// WithSigning enables signed-request mode
+func WithSigning(s Signing) Option {
+	return func(c *Client) {
+		c.signing = &s
+	}
+}
+
+// Sign returns md5 hex of parameters sorted by name, concatenated
+// as name=value without separators, followed by secret.
+// Existing sig parameter is ignored.
+func Sign(values url.Values, secret string) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		if k != paramSig {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	h := md5.New()
+	for _, k := range keys {
+		for _, v := range values[k] {
+			h.Write([]byte(k))
+			h.Write([]byte("="))
+			h.Write([]byte(v))
+		}
+	}
+	h.Write([]byte(secret))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// sign returns copy of values with sig
+func (s Signing) sign(values url.Values) url.Values {
+	signed := make(url.Values, len(values)+1)
+	for k, v := range values {
+		signed[k] = v
+	}
+	if s.ReplaceToken {
+		signed.Del(paramToken)
+		if s.AppID != 0 {
+			signed.Set(paramAPIID, int64s(s.AppID))
+		}
+	}
+	signed.Set(paramSig, Sign(signed, s.Secret))
+	return signed
+}
@@ -0,0 +1,50 @@
+package vk
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSign(t *testing.T) {
+	Convey("Sign", t, func() {
+		values := url.Values{"b": {"2"}, "a": {"1"}, "sig": {"old"}}
+		// md5("a=1b=2secret")
+		So(Sign(values, "secret"), ShouldEqual, "d37cfe88ec8ff020e497f5197bf3ba1c")
+
+		var queries []url.Values
+		transport := WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			queries = append(queries, req.URL.Query())
+			return jsonResponse(http.StatusOK, `{"response": 1}`), nil
+		}))
+		Convey("Client", func() {
+			client := NewWithToken("token", transport, WithSigning(Signing{Secret: "secret"}), WithLang("en"))
+			_, err := client.Do(client.Users.Request(methodUsersGet, UsersGetFields{UserIDs: []ID{1}}))
+			So(err, ShouldBeNil)
+			q := queries[0]
+			So(q.Get(paramToken), ShouldEqual, "token")
+			So(q.Get(paramLang), ShouldEqual, "en")
+			sig := q.Get(paramSig)
+			So(sig, ShouldEqual, Sign(q, "secret"))
+			q.Del(paramSig)
+			So(Sign(q, "secret"), ShouldEqual, sig)
+
+			Convey("Clone", func() {
+				_, err := client.Clone().Do(client.Users.Request(methodUsersGet, nil))
+				So(err, ShouldBeNil)
+				So(queries[1].Get(paramSig), ShouldNotBeEmpty)
+			})
+		})
+		Convey("Replace token", func() {
+			client := NewWithToken("token", transport, WithSigning(Signing{Secret: "secret", ReplaceToken: true, AppID: 5}))
+			_, err := client.Do(client.Users.Request(methodUsersGet, nil))
+			So(err, ShouldBeNil)
+			q := queries[0]
+			So(q.Get(paramToken), ShouldBeEmpty)
+			So(q.Get(paramAPIID), ShouldEqual, "5")
+			So(q.Get(paramSig), ShouldEqual, Sign(q, "secret"))
+		})
+	})
+}
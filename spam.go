@@ -0,0 +1,239 @@
+package vk
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	eventMessageNew = "message_new"
+
+	defaultSpamWindow      = time.Minute
+	defaultSpamMaxMessages = 5
+	defaultSpamThreshold   = 1
+)
+
+var reLink = regexp.MustCompile(`(?i)(https?://|www\.|\b[a-z0-9-]+\.(ru|com|net|org|me|ly|cc|io|su)\b)`)
+
+// SpamWeights are multipliers of spam signals in score
+type SpamWeights struct {
+	// Frequency is added when sender exceeds message rate
+	Frequency float64
+	// Links is multiplied by share of links in message words
+	Links float64
+	// NewAccount is added when sender account looks new
+	NewAccount float64
+}
+
+// DefaultSpamWeights are used when SpamGuard.Weights is zero
+var DefaultSpamWeights = SpamWeights{Frequency: 1, Links: 0.5, NewAccount: 0.5}
+
+// SpamScore is result of message scoring, Total is sum of signals
+type SpamScore struct {
+	Frequency  float64 `json:"frequency"`
+	Links      float64 `json:"links"`
+	NewAccount float64 `json:"new_account"`
+	Total      float64 `json:"total"`
+}
+
+// SpamAction is what SpamGuard does with spam message
+type SpamAction int
+
+const (
+	// SpamIgnore drops spam event
+	SpamIgnore SpamAction = iota
+	// SpamWarn passes spam event to next handler after OnSpam call
+	SpamWarn
+	// SpamBlock bans sender in community and drops event
+	SpamBlock
+)
+
+// SpamGuard scores incoming messages by sender frequency, link
+// density and account age and handles ones above threshold
+type SpamGuard struct {
+	Users  Users
+	Groups Groups
+	// Window and MaxMessages limit message rate per sender
+	Window      time.Duration
+	MaxMessages int
+	// NewAccountID is minimal id of accounts considered new, as ids
+	// grow with registration; accounts without photo are also new.
+	// Accounts are not checked with users.get if zero.
+	NewAccountID ID
+	Weights      SpamWeights
+	// Threshold of total score for message to be spam
+	Threshold float64
+	Action    SpamAction
+	// BanDuration is duration of SpamBlock ban, forever if zero
+	BanDuration time.Duration
+	// OnSpam is called for every spam message
+	OnSpam func(m Message, score SpamScore)
+	// Clock is SystemClock if nil
+	Clock Clock
+
+	mux      sync.Mutex
+	messages map[ID][]time.Time
+	accounts map[ID]bool
+	swept    time.Time
+}
+
+func (g *SpamGuard) weights() SpamWeights {
+	if g.Weights == (SpamWeights{}) {
+		return DefaultSpamWeights
+	}
+	return g.Weights
+}
+
+// frequency records message and reports whether sender exceeded rate
+func (g *SpamGuard) frequency(from ID, now time.Time) bool {
+	window, max := g.Window, g.MaxMessages
+	if window == 0 {
+		window = defaultSpamWindow
+	}
+	if max == 0 {
+		max = defaultSpamMaxMessages
+	}
+	g.mux.Lock()
+	defer g.mux.Unlock()
+	if g.messages == nil {
+		g.messages = make(map[ID][]time.Time)
+	}
+	// other senders are swept once per window, so
+	// cost of eviction is amortized over messages
+	if now.Sub(g.swept) >= window {
+		g.sweep(now, window)
+		g.swept = now
+	}
+	recent := append(recentTimes(g.messages[from], now, window), now)
+	g.messages[from] = recent
+	return len(recent) > max
+}
+
+// recentTimes returns times that are inside of window, reusing times
+func recentTimes(times []time.Time, now time.Time, window time.Duration) []time.Time {
+	recent := times[:0]
+	for _, t := range times {
+		if now.Sub(t) < window {
+			recent = append(recent, t)
+		}
+	}
+	return recent
+}
+
+// sweep forgets senders without messages inside of
+// window, so state is bounded by active senders
+func (g *SpamGuard) sweep(now time.Time, window time.Duration) {
+	for id, times := range g.messages {
+		if recent := recentTimes(times, now, window); len(recent) != 0 {
+			g.messages[id] = recent
+			continue
+		}
+		delete(g.messages, id)
+		delete(g.accounts, id)
+	}
+}
+
+// newAccount reports whether sender account looks new, result is cached
+func (g *SpamGuard) newAccount(from ID) (bool, error) {
+	if g.NewAccountID == 0 || from <= 0 {
+		return false, nil
+	}
+	g.mux.Lock()
+	isNew, ok := g.accounts[from]
+	g.mux.Unlock()
+	if ok {
+		return isNew, nil
+	}
+	users, err := g.Users.Get(UsersGetFields{UserIDs: []ID{from}, Fields: NewFields(FieldHasPhoto)})
+	if err != nil {
+		return false, err
+	}
+	isNew = from >= g.NewAccountID || len(users) == 0 || !bool(users[0].HasPhoto)
+	g.mux.Lock()
+	if g.accounts == nil {
+		g.accounts = make(map[ID]bool)
+	}
+	g.accounts[from] = isNew
+	g.mux.Unlock()
+	return isNew, nil
+}
+
+// linkDensity returns share of words of text that are links
+func linkDensity(text string) float64 {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return 0
+	}
+	links := 0
+	for _, w := range words {
+		if reLink.MatchString(w) {
+			links++
+		}
+	}
+	return float64(links) / float64(len(words))
+}
+
+// Score returns spam score of message, recording it for rate check
+func (g *SpamGuard) Score(m Message) (score SpamScore, err error) {
+	w := g.weights()
+	if g.frequency(m.FromID, clockOrSystem(g.Clock).Now()) {
+		score.Frequency = w.Frequency
+	}
+	score.Links = w.Links * linkDensity(m.Text)
+	isNew, err := g.newAccount(m.FromID)
+	if err != nil {
+		return score, err
+	}
+	if isNew {
+		score.NewAccount = w.NewAccount
+	}
+	score.Total = score.Frequency + score.Links + score.NewAccount
+	return score, nil
+}
+
+// messageNew is object of message_new event
+type messageNew struct {
+	Message Message `json:"message"`
+}
+
+// Middleware returns handler that checks message_new events
+// before passing them to next
+func (g *SpamGuard) Middleware(next EventHandler) EventHandler {
+	threshold := g.Threshold
+	if threshold == 0 {
+		threshold = defaultSpamThreshold
+	}
+	return func(event Event) error {
+		if event.Type != eventMessageNew {
+			return next(event)
+		}
+		var obj messageNew
+		if err := json.Unmarshal(event.Object, &obj); err != nil {
+			return err
+		}
+		score, err := g.Score(obj.Message)
+		if err != nil {
+			return err
+		}
+		if score.Total < threshold {
+			return next(event)
+		}
+		if g.OnSpam != nil {
+			g.OnSpam(obj.Message, score)
+		}
+		switch g.Action {
+		case SpamWarn:
+			return next(event)
+		case SpamBlock:
+			fields := GroupBanFields{GroupID: event.GroupID, OwnerID: obj.Message.FromID}
+			if g.BanDuration != 0 {
+				fields.EndDate = Time{clockOrSystem(g.Clock).Now().Add(g.BanDuration)}
+			}
+			return g.Groups.Ban(fields)
+		}
+		return nil
+	}
+}
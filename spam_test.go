@@ -0,0 +1,112 @@
+package vk
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSpamGuard(t *testing.T) {
+	Convey("Spam guard", t, func() {
+		client := New()
+		var calls []string
+		client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			switch req.URL.Path {
+			case "/method/users.get":
+				calls = append(calls, "users.get "+q.Get("user_ids"))
+				hasPhoto := "1"
+				if q.Get("user_ids") == "3" {
+					hasPhoto = "0"
+				}
+				return jsonResponse(http.StatusOK, `{"response": [{"id": `+q.Get("user_ids")+`, "has_photo": `+hasPhoto+`}]}`), nil
+			case "/method/groups.ban":
+				calls = append(calls, "groups.ban "+q.Get("group_id")+" "+q.Get("owner_id")+" "+q.Get("end_date"))
+				return jsonResponse(http.StatusOK, `{"response": 1}`), nil
+			}
+			panic(req.URL.Path)
+		}))
+		clock := NewFakeClock(time.Unix(1000, 0))
+		var spam []SpamScore
+		g := &SpamGuard{
+			Users:        client.Users,
+			Groups:       client.Groups,
+			MaxMessages:  2,
+			NewAccountID: 1000,
+			Clock:        clock,
+			OnSpam: func(m Message, score SpamScore) {
+				spam = append(spam, score)
+			},
+		}
+		var handled int
+		h := g.Middleware(func(event Event) error {
+			handled++
+			return nil
+		})
+		message := func(from ID, text string) Event {
+			return Event{Type: "message_new", GroupID: 10, Object: Raw(`{"message": {"from_id": ` + strconv.Itoa(int(from)) + `, "text": "` + text + `"}}`)}
+		}
+		Convey("Clean", func() {
+			So(h(message(1, "hello")), ShouldBeNil)
+			So(h(message(1, "see example.com")), ShouldBeNil)
+			So(h(Event{Type: "wall_post_new"}), ShouldBeNil)
+			So(handled, ShouldEqual, 3)
+			So(spam, ShouldBeEmpty)
+			So(calls, ShouldResemble, []string{"users.get 1"})
+		})
+		Convey("Frequency", func() {
+			for i := 0; i < 3; i++ {
+				So(h(message(1, "hi")), ShouldBeNil)
+			}
+			So(handled, ShouldEqual, 2)
+			So(spam, ShouldHaveLength, 1)
+			So(spam[0].Frequency, ShouldEqual, 1)
+			clock.Advance(time.Minute)
+			So(h(message(1, "hi")), ShouldBeNil)
+			So(handled, ShouldEqual, 3)
+
+		})
+		Convey("Eviction", func() {
+			So(h(message(2, "hi")), ShouldBeNil)
+			clock.Advance(10 * time.Second)
+			So(h(message(1, "hi")), ShouldBeNil)
+			So(g.messages, ShouldHaveLength, 2)
+			So(g.accounts, ShouldHaveLength, 2)
+			clock.Advance(55 * time.Second)
+			So(h(message(2, "hi")), ShouldBeNil)
+			// current sender is pruned, others wait for next sweep
+			So(g.messages[2], ShouldHaveLength, 1)
+			clock.Advance(10 * time.Second)
+			So(h(message(2, "hi")), ShouldBeNil)
+			So(g.messages, ShouldHaveLength, 2)
+			clock.Advance(50 * time.Second)
+			So(h(message(2, "hi")), ShouldBeNil)
+			So(g.messages, ShouldHaveLength, 1)
+			So(g.messages, ShouldContainKey, ID(2))
+			So(g.accounts, ShouldHaveLength, 1)
+		})
+		Convey("New account with links", func() {
+			So(h(message(3, "http://spam.ru")), ShouldBeNil)
+			So(h(message(2000, "buy at http://spam.ru")), ShouldBeNil)
+			So(handled, ShouldEqual, 1)
+			So(spam, ShouldHaveLength, 1)
+			So(spam[0], ShouldResemble, SpamScore{Links: 0.5, NewAccount: 0.5, Total: 1})
+		})
+		Convey("Warn", func() {
+			g.Action = SpamWarn
+			So(h(message(3, "http://spam.ru")), ShouldBeNil)
+			So(handled, ShouldEqual, 1)
+			So(spam, ShouldHaveLength, 1)
+		})
+		Convey("Block", func() {
+			g.Action = SpamBlock
+			g.BanDuration = time.Hour
+			So(h(message(3, "http://spam.ru")), ShouldBeNil)
+			So(handled, ShouldEqual, 0)
+			So(calls, ShouldResemble, []string{"users.get 3", "groups.ban 10 3 4600"})
+		})
+	})
+}
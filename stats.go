@@ -0,0 +1,31 @@
+package vk
+
+import (
+	"io"
+	"time"
+)
+
+// CallStats is metadata of api call
+type CallStats struct {
+	// Attempts is count of http requests made
+	Attempts int
+	// Bytes is count of response body bytes read from wire,
+	// before decompression
+	Bytes int64
+	// Latency is total duration of call, including LimiterWait
+	Latency time.Duration
+	// LimiterWait is time spent waiting for rate limiter
+	LimiterWait time.Duration
+}
+
+// countingBody counts bytes read from body
+type countingBody struct {
+	io.ReadCloser
+	n int64
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,39 @@
+package vk
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCallStats(t *testing.T) {
+	Convey("Call stats", t, func() {
+		clock := NewFakeClock(time.Unix(1000, 0))
+		limiter := NewLimiter(1)
+		limiter.Clock = clock
+		body := `{"response": 1}`
+		calls := 0
+		client := New(WithClock(clock), WithLimiter(limiter), WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return jsonResponse(http.StatusTooManyRequests, ""), nil
+			}
+			return jsonResponse(http.StatusOK, body), nil
+		})))
+		res, err := client.Do(Request{Method: methodUsersGet})
+		So(err, ShouldBeNil)
+		So(res.Stats, ShouldResemble, CallStats{
+			Attempts:    2,
+			Bytes:       int64(len(body)),
+			Latency:     defaultRetryDelay,
+			LimiterWait: 0,
+		})
+		res, err = client.Do(Request{Method: methodUsersGet})
+		So(err, ShouldBeNil)
+		So(res.Stats.Attempts, ShouldEqual, 1)
+		So(res.Stats.LimiterWait, ShouldEqual, time.Second)
+		So(res.Stats.Latency, ShouldEqual, res.Stats.LimiterWait)
+	})
+}
@@ -0,0 +1,224 @@
+package vk
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+const methodStatsGet = "stats.get"
+
+// Stats resource of community and application statistics
+type Stats struct {
+	Resource
+}
+
+type StatsGetFields struct {
+	GroupID        ID     `url:"group_id,omitempty"`
+	AppID          int64  `url:"app_id,omitempty"`
+	TimestampFrom  Time   `url:"timestamp_from,omitempty"`
+	TimestampTo    Time   `url:"timestamp_to,omitempty"`
+	Interval       string `url:"interval,omitempty"`
+	IntervalsCount int    `url:"intervals_count,omitempty"`
+	StatsGroups    string `url:"stats_groups,omitempty"`
+	Extended       Bool   `url:"extended,omitempty"`
+}
+
+// StatsSegment is count of breakdown segment, Value is segment
+// key like "f" for sex or "18-21" for age and Name is title
+// of city or country
+type StatsSegment struct {
+	Value string `json:"value"`
+	Name  string `json:"name,omitempty"`
+	Count int    `json:"count"`
+}
+
+// UnmarshalJSON decodes segment with numeric value, like city id
+func (s *StatsSegment) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Value Raw    `json:"value"`
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	s.Name, s.Count = raw.Name, raw.Count
+	s.Value = raw.Value.String()
+	if v, err := strconv.Unquote(s.Value); err == nil {
+		s.Value = v
+	}
+	return nil
+}
+
+// StatsBreakdown is audience split by sex, age, cities and countries
+type StatsBreakdown struct {
+	Sex       []StatsSegment `json:"sex,omitempty"`
+	Age       []StatsSegment `json:"age,omitempty"`
+	SexAge    []StatsSegment `json:"sex_age,omitempty"`
+	Cities    []StatsSegment `json:"cities,omitempty"`
+	Countries []StatsSegment `json:"countries,omitempty"`
+}
+
+type StatsActivity struct {
+	Comments     int `json:"comments"`
+	Copies       int `json:"copies"`
+	Hidden       int `json:"hidden"`
+	Likes        int `json:"likes"`
+	Subscribed   int `json:"subscribed"`
+	Unsubscribed int `json:"unsubscribed"`
+}
+
+type StatsVisitors struct {
+	Views       int `json:"views"`
+	Visitors    int `json:"visitors"`
+	MobileViews int `json:"mobile_views"`
+	StatsBreakdown
+}
+
+type StatsReach struct {
+	Reach            int `json:"reach"`
+	ReachSubscribers int `json:"reach_subscribers"`
+	MobileReach      int `json:"mobile_reach"`
+	StatsBreakdown
+}
+
+// StatsPeriod is statistics of one interval
+type StatsPeriod struct {
+	PeriodFrom Time          `json:"period_from"`
+	PeriodTo   Time          `json:"period_to"`
+	Activity   StatsActivity `json:"activity"`
+	Visitors   StatsVisitors `json:"visitors"`
+	Reach      StatsReach    `json:"reach"`
+}
+
+// Get returns statistics of community or application by intervals
+func (s Stats) Get(fields StatsGetFields) (result []StatsPeriod, err error) {
+	return result, s.Decode(s.Request(methodStatsGet, fields), &result)
+}
+
+// RowSink accepts rows of exported table
+type RowSink interface {
+	// Schema is called once with column names before rows
+	Schema(columns []string) error
+	Row(values []string) error
+	Close() error
+}
+
+// CSVSink is RowSink that writes CSV with header
+type CSVSink struct {
+	w *csv.Writer
+}
+
+// NewCSVSink returns CSVSink writing to w
+func NewCSVSink(w io.Writer) *CSVSink {
+	return &CSVSink{w: csv.NewWriter(w)}
+}
+
+func (s *CSVSink) Schema(columns []string) error {
+	return s.w.Write(columns)
+}
+
+func (s *CSVSink) Row(values []string) error {
+	return s.w.Write(values)
+}
+
+// Close flushes buffered rows
+func (s *CSVSink) Close() error {
+	s.w.Flush()
+	return s.w.Error()
+}
+
+// StatsColumns is schema of exported statistics. Rows are in long
+// format, one metric per row, so breakdowns are exported without
+// schema changes: metric is like "reach.reach" or "visitors.age",
+// segment is set for breakdowns, like "18-21"
+var StatsColumns = []string{"owner_id", "period_from", "period_to", "metric", "segment", "name", "value"}
+
+// StatsExporter exports stats.get of communities and applications
+type StatsExporter struct {
+	Stats Stats
+	Sink  RowSink
+
+	schema bool
+}
+
+// Export fetches statistics and writes rows, returning their count
+func (e *StatsExporter) Export(fields StatsGetFields) (rows int, err error) {
+	periods, err := e.Stats.Get(fields)
+	if err != nil {
+		return 0, err
+	}
+	if !e.schema {
+		if err = e.Sink.Schema(StatsColumns); err != nil {
+			return 0, err
+		}
+		e.schema = true
+	}
+	owner := int64(fields.GroupID)
+	if fields.AppID != 0 {
+		owner = fields.AppID
+	}
+	for _, p := range periods {
+		prefix := []string{int64s(owner), int64s(p.PeriodFrom.unix()), int64s(p.PeriodTo.unix())}
+		write := func(metric, segment, name string, value int) error {
+			rows++
+			return e.Sink.Row(append(prefix[:3:3], metric, segment, name, strconv.Itoa(value)))
+		}
+		for _, m := range p.metrics() {
+			if err = write(m.name, "", "", m.value); err != nil {
+				return rows, err
+			}
+		}
+		for _, b := range p.breakdowns() {
+			for _, s := range b.segments {
+				if err = write(b.name, s.Value, s.Name, s.Count); err != nil {
+					return rows, err
+				}
+			}
+		}
+	}
+	return rows, nil
+}
+
+type statsMetric struct {
+	name  string
+	value int
+}
+
+func (p StatsPeriod) metrics() []statsMetric {
+	return []statsMetric{
+		{"activity.comments", p.Activity.Comments},
+		{"activity.copies", p.Activity.Copies},
+		{"activity.hidden", p.Activity.Hidden},
+		{"activity.likes", p.Activity.Likes},
+		{"activity.subscribed", p.Activity.Subscribed},
+		{"activity.unsubscribed", p.Activity.Unsubscribed},
+		{"visitors.views", p.Visitors.Views},
+		{"visitors.visitors", p.Visitors.Visitors},
+		{"visitors.mobile_views", p.Visitors.MobileViews},
+		{"reach.reach", p.Reach.Reach},
+		{"reach.reach_subscribers", p.Reach.ReachSubscribers},
+		{"reach.mobile_reach", p.Reach.MobileReach},
+	}
+}
+
+type statsBreakdown struct {
+	name     string
+	segments []StatsSegment
+}
+
+func (b StatsBreakdown) named(prefix string) []statsBreakdown {
+	return []statsBreakdown{
+		{prefix + ".sex", b.Sex},
+		{prefix + ".age", b.Age},
+		{prefix + ".sex_age", b.SexAge},
+		{prefix + ".cities", b.Cities},
+		{prefix + ".countries", b.Countries},
+	}
+}
+
+func (p StatsPeriod) breakdowns() []statsBreakdown {
+	return append(p.Visitors.named("visitors"), p.Reach.named("reach")...)
+}
@@ -0,0 +1,47 @@
+package vk
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStatsExporter(t *testing.T) {
+	Convey("Stats exporter", t, func() {
+		client := New(WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			So(req.URL.Path, ShouldEqual, "/method/stats.get")
+			q := req.URL.Query()
+			So(q.Get("group_id"), ShouldEqual, "1")
+			So(q.Get("timestamp_from"), ShouldEqual, "100")
+			return jsonResponse(http.StatusOK, `{"response": [{"period_from": 100, "period_to": 200, `+
+				`"activity": {"likes": 3}, "visitors": {"views": 10, "visitors": 5, `+
+				`"sex": [{"value": "f", "count": 3}], "cities": [{"value": 1, "name": "Moscow", "count": 4}]}, `+
+				`"reach": {"reach": 20, "age": [{"value": "18-21", "count": 7}]}}]}`), nil
+		})))
+		out := new(bytes.Buffer)
+		sink := NewCSVSink(out)
+		e := &StatsExporter{Stats: client.Stats, Sink: sink}
+		rows, err := e.Export(StatsGetFields{GroupID: 1, TimestampFrom: Unix(100), Interval: "day"})
+		So(err, ShouldBeNil)
+		So(rows, ShouldEqual, 15)
+		So(sink.Close(), ShouldBeNil)
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		So(lines, ShouldHaveLength, 16)
+		So(lines[0], ShouldEqual, "owner_id,period_from,period_to,metric,segment,name,value")
+		So(lines, ShouldContain, "1,100,200,activity.likes,,,3")
+		So(lines, ShouldContain, "1,100,200,visitors.sex,f,,3")
+		So(lines, ShouldContain, "1,100,200,visitors.cities,1,Moscow,4")
+		So(lines, ShouldContain, "1,100,200,reach.age,18-21,,7")
+
+		Convey("Schema is written once", func() {
+			out.Reset()
+			_, err := e.Export(StatsGetFields{GroupID: 1, TimestampFrom: Unix(100)})
+			So(err, ShouldBeNil)
+			So(sink.Close(), ShouldBeNil)
+			So(out.String(), ShouldStartWith, "1,100,200,")
+		})
+	})
+}
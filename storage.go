@@ -0,0 +1,249 @@
+package vk
+
+import (
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrKeyNotFound is returned by Storage for missing or expired keys
+var ErrKeyNotFound = errors.New("key not found")
+
+// Storage is key-value store with expiration, that is shared by
+// subsystems like dedupe and quotas, so persistence is configured once.
+// Memory, file and redis stores are bundled, other databases are
+// plugged in by implementing Storage.
+type Storage interface {
+	// Get returns value of key or ErrKeyNotFound
+	Get(key string) ([]byte, error)
+	// Set sets value of key that expires after ttl, never if ttl is zero
+	Set(key string, value []byte, ttl time.Duration) error
+	// Delete removes key, it is not an error if key does not exist
+	Delete(key string) error
+}
+
+// AtomicStorage is Storage that sets keys atomically, so
+// it can be shared by concurrent processes
+type AtomicStorage interface {
+	Storage
+	// SetNX sets key if it does not exist and reports whether it was set
+	SetNX(key string, value []byte, ttl time.Duration) (bool, error)
+}
+
+// expiration returns time when value with ttl set at now expires
+func expiration(now time.Time, ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return now.Add(ttl)
+}
+
+func expired(expires, now time.Time) bool {
+	return !expires.IsZero() && !now.Before(expires)
+}
+
+type storageEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// MemoryStorage is in-memory Storage
+type MemoryStorage struct {
+	// Clock is SystemClock if nil
+	Clock Clock
+
+	mux     sync.Mutex
+	entries map[string]storageEntry
+}
+
+func (s *MemoryStorage) Get(key string) ([]byte, error) {
+	now := clockOrSystem(s.Clock).Now()
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	e, ok := s.entries[key]
+	if !ok || expired(e.expires, now) {
+		delete(s.entries, key)
+		return nil, ErrKeyNotFound
+	}
+	return append([]byte(nil), e.value...), nil
+}
+
+func (s *MemoryStorage) Set(key string, value []byte, ttl time.Duration) error {
+	now := clockOrSystem(s.Clock).Now()
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.entries == nil {
+		s.entries = make(map[string]storageEntry)
+	}
+	s.entries[key] = storageEntry{
+		value:   append([]byte(nil), value...),
+		expires: expiration(now, ttl),
+	}
+	return nil
+}
+
+func (s *MemoryStorage) SetNX(key string, value []byte, ttl time.Duration) (bool, error) {
+	now := clockOrSystem(s.Clock).Now()
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if e, ok := s.entries[key]; ok && !expired(e.expires, now) {
+		return false, nil
+	}
+	if s.entries == nil {
+		s.entries = make(map[string]storageEntry)
+	}
+	s.entries[key] = storageEntry{
+		value:   append([]byte(nil), value...),
+		expires: expiration(now, ttl),
+	}
+	return true, nil
+}
+
+func (s *MemoryStorage) Delete(key string) error {
+	s.mux.Lock()
+	delete(s.entries, key)
+	s.mux.Unlock()
+	return nil
+}
+
+// FileStorage is Storage that keeps every key in file of Dir,
+// with expiration time in first 8 bytes of file
+type FileStorage struct {
+	Dir string
+	// Clock is SystemClock if nil
+	Clock Clock
+}
+
+func (s FileStorage) path(key string) string {
+	return filepath.Join(s.Dir, url.PathEscape(key))
+}
+
+func (s FileStorage) Get(key string) ([]byte, error) {
+	data, err := ioutil.ReadFile(s.path(key))
+	if os.IsNotExist(err) {
+		return nil, ErrKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 8 {
+		return nil, errors.New("storage: malformed file of " + key)
+	}
+	var expires time.Time
+	if sec := int64(binary.BigEndian.Uint64(data)); sec != 0 {
+		expires = time.Unix(sec, 0)
+	}
+	if expired(expires, clockOrSystem(s.Clock).Now()) {
+		os.Remove(s.path(key))
+		return nil, ErrKeyNotFound
+	}
+	return data[8:], nil
+}
+
+func (s FileStorage) Set(key string, value []byte, ttl time.Duration) error {
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return err
+	}
+	data := make([]byte, 8, 8+len(value))
+	if expires := expiration(clockOrSystem(s.Clock).Now(), ttl); !expires.IsZero() {
+		binary.BigEndian.PutUint64(data, uint64(expires.Unix()))
+	}
+	data = append(data, value...)
+	// file is replaced atomically, so readers never see partial value
+	f, err := ioutil.TempFile(s.Dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err = f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), s.path(key))
+}
+
+func (s FileStorage) Delete(key string) error {
+	err := os.Remove(s.path(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// StorageDedupe is DedupeStore backed by Storage. Marks are atomic
+// if Storage is AtomicStorage, otherwise they are atomic only
+// in process, so it should not be shared by concurrent processes.
+type StorageDedupe struct {
+	Storage Storage
+	Prefix  string
+	TTL     time.Duration
+
+	mux sync.Mutex
+}
+
+func (d *StorageDedupe) Seen(id string) (bool, error) {
+	ttl := d.TTL
+	if ttl == 0 {
+		ttl = defaultDedupeTTL
+	}
+	if s, ok := d.Storage.(AtomicStorage); ok {
+		set, err := s.SetNX(d.Prefix+id, []byte{1}, ttl)
+		return !set, err
+	}
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	_, err := d.Storage.Get(d.Prefix + id)
+	if err == nil {
+		return true, nil
+	}
+	if err != ErrKeyNotFound {
+		return false, err
+	}
+	return false, d.Storage.Set(d.Prefix+id, []byte{1}, ttl)
+}
+
+func (d *StorageDedupe) Forget(id string) error {
+	return d.Storage.Delete(d.Prefix + id)
+}
+
+// StorageQuota is QuotaStore backed by Storage, counts are not
+// atomic, so it should not be shared by concurrent processes
+type StorageQuota struct {
+	Storage Storage
+	Prefix  string
+
+	mux sync.Mutex
+}
+
+func (q *StorageQuota) Add(key string, day time.Time, n int) (int, error) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	key = q.Prefix + key + ":" + day.Format("2006-01-02")
+	count := 0
+	data, err := q.Storage.Get(key)
+	switch err {
+	case nil:
+		if count, err = strconv.Atoi(string(data)); err != nil {
+			return 0, err
+		}
+	case ErrKeyNotFound:
+	default:
+		return 0, err
+	}
+	if n == 0 {
+		return count, nil
+	}
+	count += n
+	// counts are kept for two days to cover time zones of days
+	return count, q.Storage.Set(key, []byte(strconv.Itoa(count)), 48*time.Hour)
+}
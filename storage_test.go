@@ -0,0 +1,98 @@
+package vk
+
+import (
+	"io/ioutil"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func testStorage(s Storage, clock *FakeClock) {
+	_, err := s.Get("a/b")
+	So(err, ShouldEqual, ErrKeyNotFound)
+	So(s.Set("a/b", []byte("1"), time.Second), ShouldBeNil)
+	So(s.Set("c", []byte("2"), 0), ShouldBeNil)
+	v, err := s.Get("a/b")
+	So(err, ShouldBeNil)
+	So(string(v), ShouldEqual, "1")
+	clock.Advance(time.Second)
+	_, err = s.Get("a/b")
+	So(err, ShouldEqual, ErrKeyNotFound)
+	v, err = s.Get("c")
+	So(err, ShouldBeNil)
+	So(string(v), ShouldEqual, "2")
+	So(s.Delete("c"), ShouldBeNil)
+	So(s.Delete("c"), ShouldBeNil)
+	_, err = s.Get("c")
+	So(err, ShouldEqual, ErrKeyNotFound)
+}
+
+func TestStorage(t *testing.T) {
+	Convey("Storage", t, func() {
+		clock := NewFakeClock(time.Unix(1000, 0))
+		Convey("Memory", func() {
+			testStorage(&MemoryStorage{Clock: clock}, clock)
+		})
+		Convey("File", func() {
+			dir, err := ioutil.TempDir("", "vk-storage")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(dir)
+			testStorage(FileStorage{Dir: dir, Clock: clock}, clock)
+			files, err := ioutil.ReadDir(dir)
+			So(err, ShouldBeNil)
+			So(files, ShouldBeEmpty)
+		})
+		Convey("Dedupe", func() {
+			for _, s := range []Storage{
+				&MemoryStorage{Clock: clock},
+				// embedding hides SetNX, so marks are locked in process
+				struct{ Storage }{&MemoryStorage{Clock: clock}},
+			} {
+				d := &StorageDedupe{Storage: s, Prefix: "event:"}
+				seen, err := d.Seen("1")
+				So(err, ShouldBeNil)
+				So(seen, ShouldBeFalse)
+				seen, err = d.Seen("1")
+				So(err, ShouldBeNil)
+				So(seen, ShouldBeTrue)
+				So(d.Forget("1"), ShouldBeNil)
+				seen, err = d.Seen("1")
+				So(err, ShouldBeNil)
+				So(seen, ShouldBeFalse)
+
+				var (
+					wg  sync.WaitGroup
+					new int32
+				)
+				for i := 0; i < 10; i++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						if seen, _ := d.Seen("2"); !seen {
+							atomic.AddInt32(&new, 1)
+						}
+					}()
+				}
+				wg.Wait()
+				So(new, ShouldEqual, 1)
+			}
+		})
+		Convey("Quota", func() {
+			q := &Quotas{
+				Limits: map[string]int{methodWallPost: 1},
+				Store:  &StorageQuota{Storage: &MemoryStorage{Clock: clock}},
+				Clock:  clock,
+			}
+			post := Request{Method: methodWallPost}
+			So(q.Take(nil, post), ShouldBeNil)
+			So(q.Take(nil, post), ShouldHaveSameTypeAs, QuotaError{})
+			n, err := q.Remaining("", methodWallPost)
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 0)
+		})
+	})
+}
@@ -0,0 +1,219 @@
+package vk
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	methodStoriesGetViewers      = "stories.getViewers"
+	methodStoriesGetStats        = "stories.getStats"
+	methodStoriesSendInteraction = "stories.sendInteraction"
+
+	defaultStoryPollInterval = 10 * time.Minute
+	defaultStoryMaxSamples   = 1000
+)
+
+// Stories resource
+type Stories struct {
+	Resource
+}
+
+// StoryRef identifies story
+type StoryRef struct {
+	OwnerID ID  `url:"owner_id"`
+	StoryID int `url:"story_id"`
+}
+
+// StoryViewer is viewer of story
+type StoryViewer struct {
+	UserID  ID    `json:"user_id"`
+	IsLiked Bool  `json:"is_liked"`
+	User    *User `json:"user,omitempty"`
+}
+
+type StoriesGetViewersFields struct {
+	StoryRef
+	Offset int `url:"offset,omitempty"`
+	Count  int `url:"count,omitempty"`
+}
+
+type storiesGetViewersFields struct {
+	StoriesGetViewersFields
+	Extended Bool `url:"extended"`
+}
+
+type StoriesGetViewersResult struct {
+	Count int           `json:"count"`
+	Items []StoryViewer `json:"items"`
+}
+
+// GetViewers returns page of story viewers with like marks
+func (s Stories) GetViewers(fields StoriesGetViewersFields) (result StoriesGetViewersResult, err error) {
+	return result, s.Decode(s.Request(methodStoriesGetViewers, storiesGetViewersFields{fields, true}), &result)
+}
+
+// StoryStat is value of story statistics, State is "on" if
+// value is available, "off" or "hidden" otherwise
+type StoryStat struct {
+	State string `json:"state"`
+	Count int    `json:"count"`
+}
+
+// StoryStats is statistics of story
+type StoryStats struct {
+	Views       StoryStat `json:"views"`
+	Likes       StoryStat `json:"likes"`
+	Replies     StoryStat `json:"replies"`
+	Answer      StoryStat `json:"answer"`
+	Shares      StoryStat `json:"shares"`
+	Subscribers StoryStat `json:"subscribers"`
+	Bans        StoryStat `json:"bans"`
+	OpenLink    StoryStat `json:"open_link"`
+}
+
+// GetStats returns statistics of community story
+func (s Stories) GetStats(story StoryRef) (result StoryStats, err error) {
+	return result, s.Decode(s.Request(methodStoriesGetStats, story), &result)
+}
+
+type StoriesSendInteractionFields struct {
+	AccessKey    string `url:"access_key"`
+	Message      string `url:"message,omitempty"`
+	IsBroadcast  Bool   `url:"is_broadcast,omitempty"`
+	IsAnonymous  Bool   `url:"is_anonymous,omitempty"`
+	UnseenMarker Bool   `url:"unseen_marker,omitempty"`
+}
+
+// SendInteraction sends feedback to story interaction
+func (s Stories) SendInteraction(fields StoriesSendInteractionFields) error {
+	var result int
+	return s.Decode(s.Request(methodStoriesSendInteraction, fields), &result)
+}
+
+// StorySample is counters of story at time
+type StorySample struct {
+	Time    time.Time `json:"time"`
+	Story   StoryRef  `json:"story"`
+	Views   int       `json:"views"`
+	Likes   int       `json:"likes"`
+	Replies int       `json:"replies"`
+}
+
+// StoryTracker collects view, like and reply counters of stories
+// over time for dashboards
+type StoryTracker struct {
+	Stories Stories
+	// OnSample is called for every collected sample
+	OnSample func(sample StorySample)
+	// Interval of Run polls
+	Interval time.Duration
+	// MaxSamples is count of latest samples kept per story, 1000 if zero
+	MaxSamples int
+	// Clock is SystemClock if nil
+	Clock Clock
+
+	mux     sync.Mutex
+	tracked []StoryRef
+	series  map[StoryRef][]StorySample
+}
+
+// Track adds story to tracked ones
+func (t *StoryTracker) Track(story StoryRef) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	for _, s := range t.tracked {
+		if s == story {
+			return
+		}
+	}
+	t.tracked = append(t.tracked, story)
+}
+
+// Untrack removes story and its samples
+func (t *StoryTracker) Untrack(story StoryRef) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	for i, s := range t.tracked {
+		if s == story {
+			t.tracked = append(t.tracked[:i], t.tracked[i+1:]...)
+			break
+		}
+	}
+	delete(t.series, story)
+}
+
+// Series returns collected samples of story, oldest first
+func (t *StoryTracker) Series(story StoryRef) []StorySample {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	return append([]StorySample(nil), t.series[story]...)
+}
+
+// Sample collects counters of story
+func (t *StoryTracker) Sample(story StoryRef) (sample StorySample, err error) {
+	stats, err := t.Stories.GetStats(story)
+	if err != nil {
+		return sample, err
+	}
+	return StorySample{
+		Time:    clockOrSystem(t.Clock).Now(),
+		Story:   story,
+		Views:   stats.Views.Count,
+		Likes:   stats.Likes.Count,
+		Replies: stats.Replies.Count,
+	}, nil
+}
+
+func (t *StoryTracker) maxSamples() int {
+	if t.MaxSamples <= 0 {
+		return defaultStoryMaxSamples
+	}
+	return t.MaxSamples
+}
+
+// Poll collects samples of all tracked stories
+func (t *StoryTracker) Poll() error {
+	t.mux.Lock()
+	tracked := append([]StoryRef(nil), t.tracked...)
+	t.mux.Unlock()
+	for _, story := range tracked {
+		sample, err := t.Sample(story)
+		if err != nil {
+			return err
+		}
+		t.mux.Lock()
+		if t.series == nil {
+			t.series = make(map[StoryRef][]StorySample)
+		}
+		series := append(t.series[story], sample)
+		if max := t.maxSamples(); len(series) > max {
+			// oldest samples are dropped without keeping backing array
+			series = append([]StorySample(nil), series[len(series)-max:]...)
+		}
+		t.series[story] = series
+		t.mux.Unlock()
+		if t.OnSample != nil {
+			t.OnSample(sample)
+		}
+	}
+	return nil
+}
+
+// Run polls tracked stories until ctx is done. StoryTracker is Component.
+func (t *StoryTracker) Run(ctx context.Context) error {
+	clock := clockOrSystem(t.Clock)
+	interval := t.Interval
+	if interval == 0 {
+		interval = defaultStoryPollInterval
+	}
+	for {
+		if err := t.Poll(); err != nil {
+			return err
+		}
+		if err := clock.Sleep(ctx, interval); err != nil {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package vk
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStories(t *testing.T) {
+	Convey("Stories", t, func() {
+		client := New()
+		views := 10
+		var calls []string
+		client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			calls = append(calls, req.URL.Path[len("/method/"):]+" "+q.Get("offset"))
+			switch req.URL.Path {
+			case "/method/stories.getStats":
+				So(q.Get("owner_id"), ShouldEqual, "-1")
+				So(q.Get("story_id"), ShouldEqual, "5")
+				return jsonResponse(http.StatusOK, `{"response": {"views": {"state": "on", "count": `+int64s(int64(views))+`}, "likes": {"state": "on", "count": 2}, "replies": {"state": "on", "count": 2}}}`), nil
+			case "/method/stories.getViewers":
+				So(q.Get("extended"), ShouldEqual, "1")
+				if q.Get("offset") == "" {
+					return jsonResponse(http.StatusOK, `{"response": {"count": 3, "items": [{"user_id": 1, "is_liked": true}, {"user_id": 2, "is_liked": false}]}}`), nil
+				}
+				return jsonResponse(http.StatusOK, `{"response": {"count": 3, "items": [{"user_id": 3, "is_liked": true}]}}`), nil
+			}
+			So(q.Get("access_key"), ShouldEqual, "key")
+			return jsonResponse(http.StatusOK, `{"response": 1}`), nil
+		}))
+		So(client.Stories.SendInteraction(StoriesSendInteractionFields{AccessKey: "key", Message: "thanks"}), ShouldBeNil)
+		viewers, err := client.Stories.GetViewers(StoriesGetViewersFields{StoryRef: StoryRef{OwnerID: -1, StoryID: 5}})
+		So(err, ShouldBeNil)
+		So(viewers.Count, ShouldEqual, 3)
+		So(bool(viewers.Items[0].IsLiked), ShouldBeTrue)
+		calls = nil
+
+		clock := NewFakeClock(time.Unix(1000, 0))
+		var samples []StorySample
+		tracker := &StoryTracker{Stories: client.Stories, Clock: clock, OnSample: func(s StorySample) {
+			samples = append(samples, s)
+		}}
+		story := StoryRef{OwnerID: -1, StoryID: 5}
+		tracker.Track(story)
+		tracker.Track(story)
+		So(tracker.Poll(), ShouldBeNil)
+		views = 15
+		clock.Advance(time.Minute)
+		So(tracker.Poll(), ShouldBeNil)
+		So(samples, ShouldHaveLength, 2)
+		series := tracker.Series(story)
+		So(series, ShouldResemble, []StorySample{
+			{Time: time.Unix(1000, 0), Story: story, Views: 10, Likes: 2, Replies: 2},
+			{Time: time.Unix(1060, 0), Story: story, Views: 15, Likes: 2, Replies: 2},
+		})
+		// likes are taken from stats, viewers are not paged
+		So(calls, ShouldNotContain, "stories.getViewers ")
+		tracker.MaxSamples = 2
+		clock.Advance(time.Minute)
+		So(tracker.Poll(), ShouldBeNil)
+		series = tracker.Series(story)
+		So(series, ShouldHaveLength, 2)
+		So(series[1].Time, ShouldResemble, time.Unix(1120, 0))
+		tracker.Untrack(story)
+		So(tracker.Series(story), ShouldBeEmpty)
+		calls = nil
+		So(tracker.Poll(), ShouldBeNil)
+		So(calls, ShouldBeEmpty)
+	})
+}
@@ -0,0 +1,152 @@
+package vk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+const streamItemsKey = "items"
+
+// ErrUnexpectedToken is returned on malformed streamed response
+var ErrUnexpectedToken = errors.New("unexpected json token in response")
+
+// ItemHandler is called for every streamed item
+type ItemHandler func(item Raw) error
+
+type streamHandlerKey struct{}
+
+// streamHandler returns item handler of DoStream from ctx
+func streamHandler(ctx context.Context) ItemHandler {
+	h, _ := ctx.Value(streamHandlerKey{}).(ItemHandler)
+	return h
+}
+
+// DoStream performs request, passing elements of response.items
+// (or of response, if it is an array) to handle as they are decoded,
+// so huge collections are not held in memory. Returned response
+// contains other fields of response object, like count.
+// Items are handled before error of response is known.
+func (c *Client) DoStream(ctx context.Context, request Request, handle ItemHandler) (*Response, error) {
+	return c.DoContext(context.WithValue(ctx, streamHandlerKey{}, handle), request)
+}
+
+// ProcessStream is Process that streams response items to handle,
+// see DoStream
+func ProcessStream(input io.Reader, handle ItemHandler) (response *Response, err error) {
+	if rc, ok := input.(io.ReadCloser); ok {
+		defer rc.Close()
+	}
+	response = new(Response)
+	d := json.NewDecoder(input)
+	// numbers are kept as literals, so large ids survive scalar response
+	d.UseNumber()
+	if err = expectDelim(d, '{'); err != nil {
+		return response, err
+	}
+	for d.More() {
+		key, err := d.Token()
+		if err != nil {
+			return response, newDecodeError(err, nil)
+		}
+		switch key {
+		case "response":
+			err = streamResponse(d, response, handle)
+		case "execute_errors":
+			err = decodeToken(d, &response.Errors)
+		case "error":
+			err = decodeToken(d, &response.Error)
+		default:
+			var extra Raw
+			if err = decodeToken(d, &extra); err == nil {
+				if response.Extra == nil {
+					response.Extra = make(map[string]Raw)
+				}
+				response.Extra[key.(string)] = extra
+			}
+		}
+		if err != nil {
+			return response, err
+		}
+	}
+	if err = expectDelim(d, '}'); err != nil {
+		return response, err
+	}
+	return response, response.ServerError()
+}
+
+func expectDelim(d *json.Decoder, delim json.Delim) error {
+	t, err := d.Token()
+	if err != nil {
+		return newDecodeError(err, nil)
+	}
+	if t != delim {
+		return ErrUnexpectedToken
+	}
+	return nil
+}
+
+// decodeToken decodes next value of d to v, wrapping failure in DecodeError
+func decodeToken(d *json.Decoder, v interface{}) error {
+	if err := d.Decode(v); err != nil {
+		return newDecodeError(err, nil)
+	}
+	return nil
+}
+
+func streamResponse(d *json.Decoder, response *Response, handle ItemHandler) error {
+	t, err := d.Token()
+	if err != nil {
+		return newDecodeError(err, nil)
+	}
+	switch t {
+	case json.Delim('['):
+		return streamArray(d, handle)
+	case json.Delim('{'):
+	default:
+		response.Response, err = json.Marshal(t)
+		return err
+	}
+	rest := make(map[string]json.RawMessage)
+	for d.More() {
+		key, err := d.Token()
+		if err != nil {
+			return newDecodeError(err, nil)
+		}
+		name, _ := key.(string)
+		if name == streamItemsKey {
+			if err = expectDelim(d, '['); err != nil {
+				return err
+			}
+			if err = streamArray(d, handle); err != nil {
+				return err
+			}
+			continue
+		}
+		var v json.RawMessage
+		if err = decodeToken(d, &v); err != nil {
+			return err
+		}
+		rest[name] = v
+	}
+	if err = expectDelim(d, '}'); err != nil {
+		return err
+	}
+	response.Response, err = json.Marshal(rest)
+	return err
+}
+
+// streamArray handles elements of array which opening delimiter is read
+func streamArray(d *json.Decoder, handle ItemHandler) error {
+	for d.More() {
+		var item json.RawMessage
+		if err := decodeToken(d, &item); err != nil {
+			return err
+		}
+		if err := handle(Raw(item)); err != nil {
+			return err
+		}
+	}
+	return expectDelim(d, ']')
+}
@@ -0,0 +1,82 @@
+package vk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestProcessStream(t *testing.T) {
+	Convey("Stream", t, func() {
+		var ids []int
+		handle := func(item Raw) error {
+			var id int
+			if err := json.Unmarshal(item, &id); err != nil {
+				return err
+			}
+			ids = append(ids, id)
+			return nil
+		}
+		Convey("Items", func() {
+			res, err := ProcessStream(bytes.NewBufferString(`{"response": {"count": 3, "items": [1, 2, 3], "next": "x"}}`), handle)
+			So(err, ShouldBeNil)
+			So(ids, ShouldResemble, []int{1, 2, 3})
+			So(res.Extra, ShouldBeNil)
+			extra, err := ProcessStream(bytes.NewBufferString(`{"response": [4], "ts": 10}`), handle)
+			So(err, ShouldBeNil)
+			So(extra.Extra["ts"].String(), ShouldEqual, "10")
+			ids = ids[:3]
+			var rest struct {
+				Count int    `json:"count"`
+				Next  string `json:"next"`
+			}
+			So(res.To(&rest), ShouldBeNil)
+			So(rest.Count, ShouldEqual, 3)
+			So(rest.Next, ShouldEqual, "x")
+		})
+		Convey("Array", func() {
+			_, err := ProcessStream(bytes.NewBufferString(`{"response": [4, 5]}`), handle)
+			So(err, ShouldBeNil)
+			So(ids, ShouldResemble, []int{4, 5})
+		})
+		Convey("Scalar", func() {
+			res, err := ProcessStream(bytes.NewBufferString(`{"response": 1}`), handle)
+			So(err, ShouldBeNil)
+			So(res.Response.String(), ShouldEqual, "1")
+			res, err = ProcessStream(bytes.NewBufferString(`{"response": 12345678901234567890}`), handle)
+			So(err, ShouldBeNil)
+			So(res.Response.String(), ShouldEqual, "12345678901234567890")
+		})
+		Convey("Error", func() {
+			_, err := ProcessStream(bytes.NewBufferString(`{"error": {"error_code": 5, "error_msg": "auth"}}`), handle)
+			So(ErrAuthFailed.Is(err), ShouldBeTrue)
+			_, err = ProcessStream(bytes.NewBufferString(`{"response": [1,`), handle)
+			So(err, ShouldHaveSameTypeAs, DecodeError{})
+			_, err = ProcessStream(bytes.NewBufferString(`{"response": {"count": x}}`), handle)
+			So(err, ShouldHaveSameTypeAs, DecodeError{})
+			_, err = ProcessStream(bytes.NewBufferString(`[]`), handle)
+			So(err, ShouldEqual, ErrUnexpectedToken)
+		})
+		Convey("Handler error", func() {
+			failure := errors.New("failure")
+			_, err := ProcessStream(bytes.NewBufferString(`{"response": [1, 2]}`), func(item Raw) error {
+				return failure
+			})
+			So(err, ShouldEqual, failure)
+		})
+		Convey("Client", func() {
+			client := New(WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+				return jsonResponse(http.StatusOK, `{"response": {"count": 2, "items": [7, 8]}}`), nil
+			})))
+			res, err := client.DoStream(context.Background(), Request{Method: "groups.getMembers"}, handle)
+			So(err, ShouldBeNil)
+			So(ids, ShouldResemble, []int{7, 8})
+			So(res.Response.String(), ShouldEqual, `{"count":2}`)
+		})
+	})
+}
@@ -0,0 +1,125 @@
+package vk
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+)
+
+var (
+	// ErrInvalidChunk is returned by MultiGetScript for non-positive chunk
+	ErrInvalidChunk = errors.New("script: chunk should be positive")
+	// ErrTooManyCalls is returned when script needs more than 25 calls
+	ErrTooManyCalls = errors.New("script: more than 25 api calls")
+)
+
+// Templates are pre-written execute scripts of common patterns,
+// arguments are injected as JSON of request values, so they
+// can not change code of script.
+
+// cloneRequest returns copy of r with own values
+func cloneRequest(r Request) Request {
+	values := make(url.Values, len(r.Values))
+	for k, v := range r.Values {
+		values[k] = append([]string(nil), v...)
+	}
+	r.Values = values
+	return r
+}
+
+// PagesScript returns script that returns array of pages of
+// method of r, starting at offset, up to 25 pages of count items
+func PagesScript(r Request, offset, count, pages int) *Script {
+	s := NewScript().Line("var pages = [];")
+	for i := 0; i < pages && i < maxExecuteCalls; i++ {
+		page := cloneRequest(r)
+		page.Values.Set("offset", strconv.Itoa(offset+i*count))
+		page.Values.Set("count", strconv.Itoa(count))
+		s.Push("pages", page)
+	}
+	return s.Return("pages")
+}
+
+// MultiGetScript returns script that calls method of r for ids split
+// into chunks of param, like user_ids of users.get, and returns
+// concatenated array of results. ErrTooManyCalls is returned if
+// ids do not fit in 25 chunks.
+func MultiGetScript(r Request, param string, ids []ID, chunk int) (*Script, error) {
+	if chunk <= 0 {
+		return nil, ErrInvalidChunk
+	}
+	if (len(ids)+chunk-1)/chunk > maxExecuteCalls {
+		return nil, ErrTooManyCalls
+	}
+	s := NewScript().Line("var items = [];")
+	for start := 0; start < len(ids); start += chunk {
+		end := start + chunk
+		if end > len(ids) {
+			end = len(ids)
+		}
+		call := cloneRequest(r)
+		call.Values.Set(param, joinIDs(ids[start:end]))
+		s.Line("items = items + " + s.Call(call) + ";")
+	}
+	return s.Return("items"), nil
+}
+
+func joinIDs(ids []ID) string {
+	b := make([]byte, 0, len(ids)*10)
+	for i, id := range ids {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = strconv.AppendInt(b, int64(id), 10)
+	}
+	return string(b)
+}
+
+type likesFields struct {
+	Type    string `url:"type"`
+	OwnerID ID     `url:"owner_id"`
+	ItemID  int    `url:"item_id"`
+}
+
+type wallRepostFields struct {
+	Object  string `url:"object"`
+	Message string `url:"message,omitempty"`
+}
+
+// LikeThenRepostScript returns script that likes post and reposts
+// it if it is not reposted yet by current user, returning
+// {"likes": count, "post_id": id of repost or 0}
+func LikeThenRepostScript(f RequestFactory, owner ID, post int, message string) *Script {
+	item := likesFields{Type: "post", OwnerID: owner, ItemID: post}
+	object := "wall" + int64s(int64(owner)) + "_" + strconv.Itoa(post)
+	repost := NewScript().
+		Var("repost", f.Request(MethodWallRepost, wallRepostFields{object, message})).
+		Return(`{"likes": like.likes, "post_id": repost.post_id}`)
+	return NewScript().
+		Var("like", f.Request(MethodLikesAdd, item)).
+		Var("state", f.Request(MethodLikesIsLiked, item)).
+		If("state.copied == 0", repost).
+		Return(`{"likes": like.likes, "post_id": 0}`)
+}
+
+// LikeRepostResult is result of LikeThenRepost, PostID
+// is zero if post is already reposted
+type LikeRepostResult struct {
+	Likes  int `json:"likes"`
+	PostID int `json:"post_id"`
+}
+
+// LikeThenRepost likes post and reposts it to wall of current user
+// with message, if it is not reposted yet, in one execute request
+func (c *Client) LikeThenRepost(ctx context.Context, owner ID, post int, message string) (result LikeRepostResult, err error) {
+	s := LikeThenRepostScript(c.Wall.RequestFactory, owner, post, message)
+	failed, err := c.ExecuteScript(ctx, s, &result)
+	if err != nil {
+		return result, err
+	}
+	if len(failed) != 0 {
+		return result, failed
+	}
+	return result, nil
+}
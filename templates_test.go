@@ -0,0 +1,76 @@
+package vk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTemplates(t *testing.T) {
+	Convey("Templates", t, func() {
+		f := Factory{}
+		Convey("Pages", func() {
+			r := f.Request(methodWallGet, WallGetFields{OwnerID: -1})
+			s := PagesScript(r, 10, 100, 3)
+			So(s.Calls(), ShouldEqual, 3)
+			So(s.String(), ShouldEqual, `var pages = [];`+
+				`pages.push(API.wall.get({"count":"100","offset":"10","owner_id":"-1"}));`+
+				`pages.push(API.wall.get({"count":"100","offset":"110","owner_id":"-1"}));`+
+				`pages.push(API.wall.get({"count":"100","offset":"210","owner_id":"-1"}));`+
+				`return pages;`)
+			So(r.Values.Get("offset"), ShouldBeEmpty)
+			So(PagesScript(r, 0, 1, 100).Calls(), ShouldEqual, maxExecuteCalls)
+		})
+		Convey("Multi get", func() {
+			r := f.Request(methodUsersGet, UsersGetFields{Fields: NewFields(FieldCity)})
+			s, err := MultiGetScript(r, "user_ids", []ID{1, 2, 3}, 2)
+			So(err, ShouldBeNil)
+			So(s.Calls(), ShouldEqual, 2)
+			So(s.String(), ShouldEqual, `var items = [];`+
+				`items = items + API.users.get({"fields":"city","user_ids":"1,2"});`+
+				`items = items + API.users.get({"fields":"city","user_ids":"3"});`+
+				`return items;`)
+			_, err = MultiGetScript(r, "user_ids", []ID{1}, 0)
+			So(err, ShouldEqual, ErrInvalidChunk)
+			ids := make([]ID, 2*maxExecuteCalls+1)
+			_, err = MultiGetScript(r, "user_ids", ids, 2)
+			So(err, ShouldEqual, ErrTooManyCalls)
+			s, err = MultiGetScript(r, "user_ids", ids[:2*maxExecuteCalls], 2)
+			So(err, ShouldBeNil)
+			So(s.Calls(), ShouldEqual, maxExecuteCalls)
+		})
+		Convey("Arguments are injected as JSON", func() {
+			s := LikeThenRepostScript(f, -1, 2, `"}); API.account.ban({"owner_id": 1`)
+			So(s.Calls(), ShouldEqual, 3)
+			So(s.String(), ShouldContainSubstring, `"message":"\"}); API.account.ban({\"owner_id\": 1"`)
+			So(s.String(), ShouldContainSubstring, `if (state.copied == 0) {var repost = API.wall.repost(`)
+		})
+		Convey("Like then repost", func() {
+			var codes []string
+			client := NewWithToken("t", WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+				So(req.URL.Path, ShouldEqual, "/method/execute")
+				codes = append(codes, req.URL.Query().Get("code"))
+				return jsonResponse(http.StatusOK, `{"response": {"likes": 10, "post_id": 5}}`), nil
+			})))
+			result, err := client.LikeThenRepost(context.Background(), -1, 2, "look")
+			So(err, ShouldBeNil)
+			So(result, ShouldResemble, LikeRepostResult{Likes: 10, PostID: 5})
+			So(codes[0], ShouldStartWith, `var like = API.likes.add({"item_id":"2","owner_id":"-1","type":"post"});`)
+			So(codes[0], ShouldContainSubstring, `API.wall.repost({"message":"look","object":"wall-1_2"})`)
+		})
+		Convey("Pages result", func() {
+			client := NewWithToken("t", WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+				return jsonResponse(http.StatusOK, `{"response": [{"count": 3, "items": [{"id": 1}, {"id": 2}]}, {"count": 3, "items": [{"id": 3}]}]}`), nil
+			})))
+			var pages []WallGetResult
+			s := PagesScript(client.Wall.Request(methodWallGet, WallGetFields{OwnerID: -1}), 0, 2, 2)
+			failed, err := client.ExecuteScript(context.Background(), s, &pages)
+			So(err, ShouldBeNil)
+			So(failed, ShouldBeEmpty)
+			So(pages, ShouldHaveLength, 2)
+			So(pages[1].Items[0].ID, ShouldEqual, 3)
+		})
+	})
+}
@@ -0,0 +1,57 @@
+package vk
+
+import (
+	"bytes"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Time is time that is represented as unix seconds in vk api,
+// zero value is encoded as 0
+type Time struct {
+	time.Time
+}
+
+// Unix returns Time from unix seconds, 0 is zero Time
+func Unix(sec int64) Time {
+	if sec == 0 {
+		return Time{}
+	}
+	return Time{time.Unix(sec, 0)}
+}
+
+func (t Time) unix() int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Time.Unix()
+}
+
+func (t Time) MarshalJSON() ([]byte, error) {
+	return []byte(int64s(t.unix())), nil
+}
+
+func (t *Time) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(data, `"`)
+	if len(data) == 0 || string(data) == "null" {
+		*t = Time{}
+		return nil
+	}
+	sec, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return err
+	}
+	*t = Unix(sec)
+	return nil
+}
+
+// EncodeValues adds unix seconds to query values, zero Time is
+// skipped, because omitempty does not work for it in go-querystring
+func (t Time) EncodeValues(key string, v *url.Values) error {
+	if t.IsZero() {
+		return nil
+	}
+	v.Add(key, int64s(t.unix()))
+	return nil
+}
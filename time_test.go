@@ -0,0 +1,53 @@
+package vk
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTime(t *testing.T) {
+	Convey("Time", t, func() {
+		Convey("Unmarshal", func() {
+			v := struct {
+				Date Time `json:"date"`
+			}{}
+			So(json.Unmarshal([]byte(`{"date": 1500000000}`), &v), ShouldBeNil)
+			So(v.Date.Equal(time.Unix(1500000000, 0)), ShouldBeTrue)
+			So(json.Unmarshal([]byte(`{"date": "1500000001"}`), &v), ShouldBeNil)
+			So(v.Date.Unix(), ShouldEqual, 1500000001)
+			So(json.Unmarshal([]byte(`{"date": 0}`), &v), ShouldBeNil)
+			So(v.Date.IsZero(), ShouldBeTrue)
+			So(json.Unmarshal([]byte(`{"date": null}`), &v), ShouldBeNil)
+			So(v.Date.IsZero(), ShouldBeTrue)
+			So(json.Unmarshal([]byte(`{"date": "now"}`), &v), ShouldNotBeNil)
+		})
+		Convey("Marshal", func() {
+			data, err := json.Marshal(Unix(1500000000))
+			So(err, ShouldBeNil)
+			So(string(data), ShouldEqual, "1500000000")
+			data, err = json.Marshal(Time{})
+			So(err, ShouldBeNil)
+			So(string(data), ShouldEqual, "0")
+		})
+		Convey("Values", func() {
+			v := &url.Values{}
+			So(Unix(1500000000).EncodeValues("publish_date", v), ShouldBeNil)
+			So(v.Get("publish_date"), ShouldEqual, "1500000000")
+			So(Time{}.EncodeValues("start_time", v), ShouldBeNil)
+			_, ok := (*v)["start_time"]
+			So(ok, ShouldBeFalse)
+		})
+		Convey("Models", func() {
+			m := Message{}
+			So(json.Unmarshal([]byte(`{"date": 1500000000}`), &m), ShouldBeNil)
+			So(m.Date.Unix(), ShouldEqual, 1500000000)
+			u := User{}
+			So(json.Unmarshal([]byte(`{"last_seen": {"time": 1500000000, "platform": 7}}`), &u), ShouldBeNil)
+			So(u.LastSeen.Time.Unix(), ShouldEqual, 1500000000)
+		})
+	})
+}
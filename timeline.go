@@ -0,0 +1,130 @@
+package vk
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// ActivityKind is kind of Activity
+type ActivityKind string
+
+const (
+	ActivityPost         ActivityKind = "post"
+	ActivityRepost       ActivityKind = "repost"
+	ActivityProfilePhoto ActivityKind = "profile_photo"
+	ActivityPhoto        ActivityKind = "photo"
+
+	albumProfile = "profile"
+	albumWall    = "wall"
+
+	defaultTimelineCount = 100
+)
+
+// Activity is event of user timeline, only field of Kind is set
+type Activity struct {
+	Kind  ActivityKind `json:"kind"`
+	Date  Time         `json:"date"`
+	Post  *Post        `json:"post,omitempty"`
+	Photo *Photo       `json:"photo,omitempty"`
+}
+
+// TimelineSource returns activities of user
+type TimelineSource func(ctx context.Context, user ID) ([]Activity, error)
+
+// WallActivity returns source of last count posts and reposts of user wall
+func WallActivity(w Wall, count int) TimelineSource {
+	return func(ctx context.Context, user ID) ([]Activity, error) {
+		posts, err := w.Get(WallGetFields{OwnerID: user, Filter: "owner", Count: count})
+		if err != nil {
+			return nil, err
+		}
+		activities := make([]Activity, len(posts.Items))
+		for i := range posts.Items {
+			p := &posts.Items[i]
+			activities[i] = Activity{Kind: ActivityPost, Date: p.Date, Post: p}
+			if len(p.CopyHistory) != 0 {
+				activities[i].Kind = ActivityRepost
+			}
+		}
+		return activities, nil
+	}
+}
+
+// PhotoActivity returns source of last count profile and wall photos of user
+func PhotoActivity(p Photos, count int) TimelineSource {
+	return func(ctx context.Context, user ID) ([]Activity, error) {
+		var activities []Activity
+		for _, album := range []string{albumProfile, albumWall} {
+			photos, err := p.Get(PhotosGetFields{OwnerID: user, AlbumID: album, Rev: true, Count: count})
+			if err != nil {
+				return activities, err
+			}
+			kind := ActivityPhoto
+			if album == albumProfile {
+				kind = ActivityProfilePhoto
+			}
+			for i := range photos.Items {
+				photo := &photos.Items[i]
+				activities = append(activities, Activity{Kind: kind, Date: photo.Date, Photo: photo})
+			}
+		}
+		return activities, nil
+	}
+}
+
+// privacyError reports whether err is returned because
+// data is hidden by privacy settings or user is deleted
+func privacyError(err error) bool {
+	return ErrPrivateProfile.Is(err) || ErrUserDeleted.Is(err) ||
+		ErrNotAllowed.Is(err) || ErrAlbumAccessProhibited.Is(err)
+}
+
+// Timeline assembles activity of user from sources, that are
+// fetched concurrently and merged from newest to oldest
+type Timeline struct {
+	Sources []TimelineSource
+	// Limit is maximum count of activities, zero is unlimited
+	Limit int
+}
+
+// NewTimeline returns timeline of wall posts and photos
+func NewTimeline(c *Client) Timeline {
+	return Timeline{Sources: []TimelineSource{
+		WallActivity(c.Wall, defaultTimelineCount),
+		PhotoActivity(c.Photos, defaultTimelineCount),
+	}}
+}
+
+// Build returns timeline of user. Sources hidden by privacy
+// settings are skipped, other errors fail whole timeline.
+func (t Timeline) Build(ctx context.Context, user ID) ([]Activity, error) {
+	var (
+		wg      sync.WaitGroup
+		results = make([][]Activity, len(t.Sources))
+		errs    = make([]error, len(t.Sources))
+	)
+	for i, source := range t.Sources {
+		wg.Add(1)
+		go func(i int, source TimelineSource) {
+			defer wg.Done()
+			results[i], errs[i] = source(ctx, user)
+		}(i, source)
+	}
+	wg.Wait()
+	var activities []Activity
+	for i, err := range errs {
+		if err != nil && !privacyError(err) {
+			return nil, err
+		}
+		activities = append(activities, results[i]...)
+	}
+	// sources order is kept for activities of same time
+	sort.SliceStable(activities, func(i, j int) bool {
+		return activities[i].Date.After(activities[j].Date.Time)
+	})
+	if t.Limit > 0 && len(activities) > t.Limit {
+		activities = activities[:t.Limit]
+	}
+	return activities, nil
+}
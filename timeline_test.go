@@ -0,0 +1,56 @@
+package vk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTimeline(t *testing.T) {
+	Convey("Timeline", t, func() {
+		responses := map[string]string{
+			"wall.get": `{"response": {"count": 2, "items": [` +
+				`{"id": 1, "date": 300, "text": "a"}, {"id": 2, "date": 100, "copy_history": [{"id": 5}]}]}}`,
+			"photos.get profile": `{"response": {"count": 1, "items": [{"id": 10, "date": 200}]}}`,
+			"photos.get wall":    `{"error": {"error_code": 200, "error_msg": "album access denied"}}`,
+		}
+		client := New(WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			key := req.URL.Path[len("/method/"):]
+			if album := req.URL.Query().Get("album_id"); album != "" {
+				key += " " + album
+			}
+			return jsonResponse(http.StatusOK, responses[key]), nil
+		})))
+		ctx := context.Background()
+		activities, err := NewTimeline(client).Build(ctx, 1)
+		So(err, ShouldBeNil)
+		So(activities, ShouldHaveLength, 3)
+		So(activities[0].Kind, ShouldEqual, ActivityPost)
+		So(activities[0].Post.Text, ShouldEqual, "a")
+		So(activities[1].Kind, ShouldEqual, ActivityProfilePhoto)
+		So(activities[1].Photo.ID, ShouldEqual, 10)
+		So(activities[2].Kind, ShouldEqual, ActivityRepost)
+		So(activities[2].Date, ShouldResemble, Unix(100))
+
+		Convey("Limit", func() {
+			timeline := NewTimeline(client)
+			timeline.Limit = 1
+			activities, err := timeline.Build(ctx, 1)
+			So(err, ShouldBeNil)
+			So(activities, ShouldHaveLength, 1)
+		})
+		Convey("Private", func() {
+			responses["wall.get"] = `{"error": {"error_code": 30, "error_msg": "private"}}`
+			activities, err := NewTimeline(client).Build(ctx, 1)
+			So(err, ShouldBeNil)
+			So(activities, ShouldHaveLength, 1)
+		})
+		Convey("Error", func() {
+			responses["wall.get"] = `{"error": {"error_code": 5, "error_msg": "auth"}}`
+			_, err := NewTimeline(client).Build(ctx, 1)
+			So(ErrAuthFailed.Is(err), ShouldBeTrue)
+		})
+	})
+}
@@ -0,0 +1,136 @@
+package vk
+
+import (
+	"context"
+	"time"
+)
+
+// tokenRefreshMargin is time before expiration when token is renewed
+const tokenRefreshMargin = time.Minute
+
+// OnTokenExpired sets function that is called to obtain new token
+// when client token is near expiration or rejected by vk
+func OnTokenExpired(f func() (Token, error)) Option {
+	return func(c *Client) {
+		c.onTokenExpired = f
+	}
+}
+
+// WithToken sets token of client
+func WithToken(token Token) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// SetToken sets access token used by client resources
+func (c *Client) SetToken(token string) {
+	c.SetTokenWithExpiration(NewToken(token))
+}
+
+// SetTokenWithExpiration sets token used by client resources
+func (c *Client) SetTokenWithExpiration(token Token) {
+	c.tokenMux.Lock()
+	c.token = token
+	c.tokenMux.Unlock()
+}
+
+// Token returns current token of client
+func (c *Client) Token() Token {
+	c.tokenMux.RLock()
+	defer c.tokenMux.RUnlock()
+	return c.token
+}
+
+// tokenRenewal is renewal of client token in progress, that
+// concurrent renewals wait for instead of calling callback again
+type tokenRenewal struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+// renewToken obtains new token instead of expired one,
+// token is not renewed if it was already changed. Concurrent
+// renewals share one onTokenExpired call, and no lock is held
+// while it is called, so it can use client.
+func (c *Client) renewToken(expired string) (string, error) {
+	c.renewMux.Lock()
+	if current := c.Token().AccessToken; current != expired {
+		c.renewMux.Unlock()
+		return current, nil
+	}
+	if r := c.renewal; r != nil {
+		c.renewMux.Unlock()
+		<-r.done
+		return r.token, r.err
+	}
+	r := &tokenRenewal{done: make(chan struct{}), token: expired}
+	c.renewal = r
+	c.renewMux.Unlock()
+
+	token, err := c.onTokenExpired()
+	c.renewMux.Lock()
+	if err != nil {
+		r.err = err
+	} else {
+		token.setExpiration(c.clock.Now())
+		c.tokenMux.Lock()
+		c.token = token
+		c.tokenMux.Unlock()
+		r.token = token.AccessToken
+	}
+	c.renewal = nil
+	c.renewMux.Unlock()
+	close(r.done)
+	return r.token, r.err
+}
+
+// managedToken reports whether request uses client token that
+// can be renewed. Token is not managed while it is renewed, so
+// requests of onTokenExpired do not wait for their own renewal.
+func (c *Client) managedToken(request Request) (Token, bool) {
+	if c.onTokenExpired == nil {
+		return Token{}, false
+	}
+	c.renewMux.Lock()
+	renewing := c.renewal != nil
+	c.renewMux.Unlock()
+	if renewing {
+		return Token{}, false
+	}
+	token := c.Token()
+	return token, len(token.AccessToken) != 0 && token.AccessToken == request.Token
+}
+
+// doWithToken performs request, renewing client token
+// if it is expired or rejected
+func (c *Client) doWithToken(ctx context.Context, request Request) (*Response, error) {
+	token, managed := c.managedToken(request)
+	if !managed {
+		return c.do(ctx, request)
+	}
+	var err error
+	if token.expiresWithin(c.clock.Now(), tokenRefreshMargin) {
+		if request.Token, err = c.renewToken(token.AccessToken); err != nil {
+			return nil, err
+		}
+	}
+	res, err := c.do(ctx, request)
+	if !ErrAuthFailed.Is(err) {
+		return res, err
+	}
+	if request.Token, err = c.renewToken(request.Token); err != nil {
+		return nil, err
+	}
+	return c.do(ctx, request)
+}
+
+// clientFactory generates requests with current token of client
+type clientFactory struct {
+	client *Client
+}
+
+func (f clientFactory) Request(method string, arguments interface{}) Request {
+	return Factory{f.client.Token().AccessToken}.Request(method, arguments)
+}
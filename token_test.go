@@ -0,0 +1,137 @@
+package vk
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTokenExpiration(t *testing.T) {
+	Convey("Token expiration", t, func() {
+		So(NewToken("a").ExpiresWithin(time.Hour), ShouldBeFalse)
+		token := Token{AccessToken: "a", ExpiresIn: 30}
+		token.setExpiration(time.Now())
+		So(token.ExpiresWithin(time.Minute), ShouldBeTrue)
+		So(token.ExpiresWithin(time.Second), ShouldBeFalse)
+
+		var tokens []string
+		var renewals int
+		renewed := Token{AccessToken: "new"}
+		client := NewWithToken("old", OnTokenExpired(func() (Token, error) {
+			renewals++
+			return renewed, nil
+		}))
+		client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			token := req.URL.Query().Get("access_token")
+			tokens = append(tokens, token)
+			if token == "old" {
+				return jsonResponse(http.StatusOK, `{"error": {"error_code": 5, "error_msg": "User authorization failed"}}`), nil
+			}
+			return jsonResponse(http.StatusOK, `{"response": 1500000000}`), nil
+		}))
+		Convey("Rejected", func() {
+			_, err := client.Utils.GetServerTime()
+			So(err, ShouldBeNil)
+			So(tokens, ShouldResemble, []string{"old", "new"})
+			So(client.Token().AccessToken, ShouldEqual, "new")
+			Convey("Resources use new token", func() {
+				_, err := client.Utils.GetServerTime()
+				So(err, ShouldBeNil)
+				So(tokens, ShouldResemble, []string{"old", "new", "new"})
+				So(renewals, ShouldEqual, 1)
+			})
+		})
+		Convey("Near expiration", func() {
+			client.SetTokenWithExpiration(Token{AccessToken: "expiring", ExpiresAt: Time{time.Now().Add(time.Second)}})
+			_, err := client.Utils.GetServerTime()
+			So(err, ShouldBeNil)
+			So(tokens, ShouldResemble, []string{"new"})
+		})
+		Convey("Callback uses client", func() {
+			var client *Client
+			var previous Token
+			client = NewWithToken("old", OnTokenExpired(func() (Token, error) {
+				previous = client.Token()
+				return Token{AccessToken: "new", ExpiresIn: 3600}, nil
+			}))
+			client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+				if req.URL.Query().Get("access_token") == "old" {
+					return jsonResponse(http.StatusOK, `{"error": {"error_code": 5, "error_msg": "User authorization failed"}}`), nil
+				}
+				return jsonResponse(http.StatusOK, `{"response": 1500000000}`), nil
+			}))
+			_, err := client.Utils.GetServerTime()
+			So(err, ShouldBeNil)
+			So(previous.AccessToken, ShouldEqual, "old")
+			So(client.Token().ExpiresAt.IsZero(), ShouldBeFalse)
+			So(client.Token().ExpiresWithin(2*time.Hour), ShouldBeTrue)
+		})
+		Convey("Callback calls api", func() {
+			for _, expiring := range []bool{false, true} {
+				var client *Client
+				var calls []string
+				var callbackErr error
+				client = NewWithToken("old", OnTokenExpired(func() (Token, error) {
+					// request with expired token is not renewed again
+					_, callbackErr = client.Utils.GetServerTime()
+					return Token{AccessToken: "new"}, nil
+				}))
+				if expiring {
+					client.SetTokenWithExpiration(Token{AccessToken: "old", ExpiresAt: Time{time.Now().Add(time.Second)}})
+				}
+				client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+					token := req.URL.Query().Get("access_token")
+					calls = append(calls, token)
+					if token == "old" {
+						return jsonResponse(http.StatusOK, `{"error": {"error_code": 5, "error_msg": "User authorization failed"}}`), nil
+					}
+					return jsonResponse(http.StatusOK, `{"response": 1500000000}`), nil
+				}))
+				done := make(chan error, 1)
+				go func() {
+					_, err := client.Utils.GetServerTime()
+					done <- err
+				}()
+				select {
+				case err := <-done:
+					So(err, ShouldBeNil)
+				case <-time.After(5 * time.Second):
+					So("renewal deadlocked", ShouldBeEmpty)
+				}
+				So(ErrAuthFailed.Is(callbackErr), ShouldBeTrue)
+				So(client.Token().AccessToken, ShouldEqual, "new")
+				if expiring {
+					So(calls, ShouldResemble, []string{"old", "new"})
+				} else {
+					So(calls, ShouldResemble, []string{"old", "old", "new"})
+				}
+			}
+		})
+		Convey("Renewal fails", func() {
+			client := NewWithToken("old", OnTokenExpired(func() (Token, error) {
+				return Token{}, errors.New("no token")
+			}))
+			client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+				return jsonResponse(http.StatusOK, `{"error": {"error_code": 5, "error_msg": "User authorization failed"}}`), nil
+			}))
+			_, err := client.Utils.GetServerTime()
+			So(err.Error(), ShouldEqual, "no token")
+		})
+		Convey("Foreign token", func() {
+			_, err := client.Do(Request{Method: methodUtilsGetServerTime, Token: "other"})
+			So(ErrAuthFailed.Is(err), ShouldBeFalse)
+			So(tokens, ShouldResemble, []string{"other"})
+		})
+		Convey("Without callback", func() {
+			client := NewWithToken("old")
+			client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+				return jsonResponse(http.StatusOK, `{"error": {"error_code": 5, "error_msg": "User authorization failed"}}`), nil
+			}))
+			_, err := client.Utils.GetServerTime()
+			So(ErrAuthFailed.Is(err), ShouldBeTrue)
+		})
+	})
+}
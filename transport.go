@@ -2,6 +2,7 @@ package vk
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -55,6 +56,15 @@ func (v *Bool) UnmarshalJSON(data []byte) error {
 	if data == nil || len(data) == 0 {
 		return nil
 	}
+	// some methods return json booleans or quoted integers
+	switch string(data) {
+	case "true", `"1"`:
+		*v = true
+		return nil
+	case "false", "null", `"0"`, `""`:
+		*v = false
+		return nil
+	}
 	if len(data) != 1 {
 		return io.ErrUnexpectedEOF
 	}
@@ -82,29 +92,133 @@ func must(err error) {
 }
 
 func (c *Client) Do(request Request) (response *Response, err error) {
+	return c.DoContext(context.Background(), request)
+}
+
+// DoContext performs request with ctx
+func (c *Client) DoContext(ctx context.Context, request Request) (response *Response, err error) {
+	if c.validateMethods && !KnownMethod(request.Method) {
+		return nil, UnknownMethodError{request.Method}
+	}
+	if c.quotas != nil {
+		if err = c.quotas.Take(ctx, request); err != nil {
+			return nil, err
+		}
+	}
+	// streamed items can not be replayed, so streams are not hedged
+	if c.hedging != nil && c.hedging.allowed(request.Method) && streamHandler(ctx) == nil {
+		return c.doHedged(ctx, request)
+	}
+	return c.doObserved(ctx, request)
+}
+
+func (c *Client) do(ctx context.Context, request Request) (response *Response, err error) {
 	response = new(Response)
 	response.setRequest(request)
-	req := request.HTTP()
-	start := time.Now()
+	ctx = context.WithValue(ctx, requestMethodKey{}, request.Method)
+	req := request.HTTP().WithContext(ctx)
+	if len(c.lang) != 0 && len(req.URL.Query().Get(paramLang)) == 0 {
+		query := req.URL.Query()
+		query.Set(paramLang, c.lang)
+		req.URL.RawQuery = query.Encode()
+	}
+	// sig covers all parameters, so it is computed last
+	if c.signing != nil {
+		req.URL.RawQuery = c.signing.sign(req.URL.Query()).Encode()
+	} else if c.official != nil {
+		c.official.sign(req.URL)
+	}
+	if c.official != nil && len(c.official.UserAgent) != 0 {
+		req.Header.Set("User-Agent", c.official.UserAgent)
+	}
+	req.Header.Set(headerAcceptEncoding, defaultAcceptEncoding)
+	debug := c.debug.writer() != nil
+	if debug {
+		if err = c.debug.dumpRequest(req); err != nil {
+			return nil, err
+		}
+	}
+	start := c.clock.Now()
 	log.Println("DO", request.Method)
 	var res *http.Response
-	for attempt:= 1; attempt<5; attempt++  {
-		res, err = c.httpClient.Do(req)
+	httpClient, limiter := c.transport()
+	if cl, ok := limiter.(ConcurrencyLimiter); ok {
+		release, err := cl.Acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+	if c.retryBudget != nil {
+		c.retryBudget.Request()
+	}
+	var stats CallStats
+	for attempt := 1; attempt <= defaultAttempts; attempt++ {
+		stats.Attempts = attempt
+		if limiter != nil {
+			waitStart := c.clock.Now()
+			err = limiter.Wait(ctx)
+			stats.LimiterWait += c.clock.Now().Sub(waitStart)
+			if err != nil {
+				return nil, err
+			}
+		}
+		res, err = httpClient.Do(req)
+		delay := defaultRetryDelay
 		if err == nil {
-			break
+			d, retry := retryAfter(res, c.clock.Now())
+			if !retry || attempt == defaultAttempts {
+				break
+			}
+			log.Println("HTTP retry", res.Status, d)
+			res.Body.Close()
+			delay = d
+			if !c.retryAllowed() {
+				return nil, RetryBudgetError{Method: request.Method, Err: HTTPError{Status: res.StatusCode}}
+			}
+		} else {
+			log.Println("HTTP attempt", err, attempt)
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if attempt < defaultAttempts && !c.retryAllowed() {
+				return nil, RetryBudgetError{Method: request.Method, Err: err}
+			}
+		}
+		if sleepErr := c.clock.Sleep(ctx, delay); sleepErr != nil {
+			return nil, sleepErr
 		}
-		log.Println("HTTP attempt", err, attempt)
-		time.Sleep(time.Second * 3)
 	}
 	if err != nil {
 		log.Println("HTTP fatal", err)
 		return nil, err
 	}
-	log.Println("HTTP", res.Status, time.Now().Sub(start))
+	log.Println("HTTP", res.Status, c.clock.Now().Sub(start))
+	body := &countingBody{ReadCloser: res.Body}
+	res.Body = body
+	if err = decompress(res); err != nil {
+		return nil, err
+	}
+	if debug {
+		if err = c.debug.dumpResponse(res); err != nil {
+			return nil, err
+		}
+	}
 	if res.StatusCode != http.StatusOK {
-		return nil, ErrBadResponseCode
+		defer res.Body.Close()
+		return nil, newHTTPError(res)
+	}
+	if handle := streamHandler(ctx); handle != nil {
+		response, err = ProcessStream(res.Body, handle)
+	} else {
+		response, err = Process(res.Body)
 	}
-	return Process(res.Body)
+	if response != nil {
+		stats.Bytes = body.n
+		stats.Latency = c.clock.Now().Sub(start)
+		response.Stats = stats
+	}
+	return response, err
 }
 
 // HTTP converts to *http.Request
@@ -178,7 +292,7 @@ func (m Raw) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON sets *m to a copy of data.
 func (m *Raw) UnmarshalJSON(data []byte) error {
-	*m = data
+	*m = append(Raw(nil), data...)
 	return nil
 }
 
@@ -196,21 +310,28 @@ type Response struct {
 	Errors   Errors `json:"execute_errors,omitempty"`
 	Error    `json:"error,omitempty"`
 	Response Raw `json:"response,omitempty"`
+	// Extra are top-level fields of reply other than response and errors
+	Extra map[string]Raw `json:"-"`
+	// Stats of call that returned response
+	Stats CallStats `json:"-"`
 }
 
+// To decodes response to v, malformed response is DecodeError
 func (r Response) To(v interface{}) error {
-	return json.Unmarshal(r.Response.Bytes(), v)
+	return decodeJSON(r.Response.Bytes(), v)
 }
 
-func (d vkResponseProcessor) To(response *Response) error {
+func (d vkResponseProcessor) To(response *Response) (err error) {
 	if rc, ok := d.input.(io.ReadCloser); ok {
 		defer rc.Close()
 	}
-	decoder := json.NewDecoder(d.input)
-	if err := decoder.Decode(response); err != nil {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer bufferPool.Put(buf)
+	if _, err = buf.ReadFrom(d.input); err != nil {
 		return err
 	}
-	return response.ServerError()
+	return DecodeResponse(buf.Bytes(), response)
 }
 
 func (r Response) ServerError() error {
@@ -249,14 +370,28 @@ func Encode(input io.Reader) Encoder {
 }
 
 func getDefaultHTTPClient() HTTPClient {
+	return newHTTPClient(DialConfig{})
+}
+
+// newHTTPClient returns http client with default timeouts,
+// that dials according to config
+func newHTTPClient(config DialConfig) *http.Client {
+	d := dialer{config: config, net: &net.Dialer{
+		Timeout:       defaultHTTPTimeout,
+		KeepAlive:     defaultKeepAliveInterval,
+		LocalAddr:     config.LocalAddr,
+		FallbackDelay: config.FallbackDelay,
+	}}
+	dial := d.DialContext
+	if config.Family == IPAny && config.DNSCache == nil {
+		// net.Dialer does same without own resolving
+		dial = d.net.DialContext
+	}
 	client := &http.Client{
 		Timeout: defaultRequestTimeout,
 		Transport: &http.Transport{
-			Proxy: http.ProxyFromEnvironment,
-			Dial: (&net.Dialer{
-				Timeout:   defaultHTTPTimeout,
-				KeepAlive: defaultKeepAliveInterval,
-			}).Dial,
+			Proxy:                 http.ProxyFromEnvironment,
+			DialContext:           dial,
 			TLSHandshakeTimeout:   defaultHTTPTimeout,
 			ResponseHeaderTimeout: defaultHTTPHeadersTimeout,
 		},
@@ -3,12 +3,14 @@ package vk
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -261,10 +263,13 @@ func TestDo(t *testing.T) {
 			//			response := &Data{}
 
 			_, err := client.Do(request)
-			So(err, ShouldEqual, ErrBadResponseCode)
+			So(errors.Is(err, ErrBadResponseCode), ShouldBeTrue)
+			So(ErrBadResponseCode.Is(err), ShouldBeTrue)
+			So(err.(HTTPError).Status, ShouldEqual, http.StatusBadRequest)
 		})
 		Convey("Http error", func() {
-			client := New()
+			clock := NewFakeClock(time.Unix(1000, 0))
+			client := New(WithClock(clock))
 			httpResponse := &http.Response{Body: body, StatusCode: http.StatusBadRequest}
 			client.SetHTTPClient(simpleHTTPClientMock{response: httpResponse, err: ErrBadResponseCode})
 			request := Request{Method: "users.get"}
@@ -272,6 +277,7 @@ func TestDo(t *testing.T) {
 
 			_, err := client.Do(request)
 			So(err, ShouldEqual, ErrBadResponseCode)
+			So(clock.Slept(), ShouldHaveLength, 4)
 		})
 	})
 }
@@ -0,0 +1,103 @@
+package vk
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+)
+
+// BoolInt is boolean represented as 0 or 1
+type BoolInt = Bool
+
+// StringOrInt is string that can be represented
+// both as json string and number
+type StringOrInt string
+
+func (s *StringOrInt) UnmarshalJSON(data []byte) error {
+	if len(data) == 0 || string(data) == "null" {
+		*s = ""
+		return nil
+	}
+	if data[0] != '"' {
+		if _, err := strconv.ParseFloat(string(data), 64); err != nil {
+			return err
+		}
+		*s = StringOrInt(data)
+		return nil
+	}
+	var v string
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*s = StringOrInt(v)
+	return nil
+}
+
+// Int returns integer value of s
+func (s StringOrInt) Int() (int64, error) {
+	return strconv.ParseInt(string(s), 10, 64)
+}
+
+func (s StringOrInt) String() string {
+	return string(s)
+}
+
+// isEmptyArray reports whether data is empty json array,
+// that vk returns instead of empty object
+func isEmptyArray(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	if len(data) < 2 || data[0] != '[' || data[len(data)-1] != ']' {
+		return false
+	}
+	return len(bytes.TrimSpace(data[1:len(data)-1])) == 0
+}
+
+// Object is raw json object, that is nil if
+// vk returned null or empty array instead
+type Object Raw
+
+func (o *Object) UnmarshalJSON(data []byte) error {
+	if isEmptyArray(data) || string(data) == "null" {
+		*o = nil
+		return nil
+	}
+	*o = append((*o)[:0], data...)
+	return nil
+}
+
+func (o Object) MarshalJSON() ([]byte, error) {
+	if o == nil {
+		return []byte("null"), nil
+	}
+	return o, nil
+}
+
+// To decodes object into v, v is left unchanged if object is empty
+func (o Object) To(v interface{}) error {
+	if o == nil {
+		return nil
+	}
+	return decodeJSON(o, v)
+}
+
+// ID is identifier of vk object, that can be
+// represented both as json string and number
+type ID int64
+
+func (id *ID) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(data, `"`)
+	if len(data) == 0 || string(data) == "null" {
+		*id = 0
+		return nil
+	}
+	v, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return err
+	}
+	*id = ID(v)
+	return nil
+}
+
+func (id ID) String() string {
+	return int64s(int64(id))
+}
@@ -0,0 +1,97 @@
+package vk
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestQuirkyTypes(t *testing.T) {
+	Convey("Quirky types", t, func() {
+		Convey("BoolInt", func() {
+			v := struct {
+				A BoolInt `json:"a"`
+				B BoolInt `json:"b"`
+				C BoolInt `json:"c"`
+				D BoolInt `json:"d"`
+			}{D: true}
+			So(json.Unmarshal([]byte(`{"a": 1, "b": true, "c": "1", "d": null}`), &v), ShouldBeNil)
+			So(v.A, ShouldEqual, true)
+			So(v.B, ShouldEqual, true)
+			So(v.C, ShouldEqual, true)
+			So(v.D, ShouldEqual, false)
+			So(json.Unmarshal([]byte(`{"a": false}`), &v), ShouldBeNil)
+			So(v.A, ShouldEqual, false)
+			So(json.Unmarshal([]byte(`{"a": 2}`), &v), ShouldNotBeNil)
+		})
+		Convey("StringOrInt", func() {
+			v := struct {
+				A StringOrInt `json:"a"`
+				B StringOrInt `json:"b"`
+				C StringOrInt `json:"c"`
+			}{}
+			So(json.Unmarshal([]byte(`{"a": 123, "b": "abc", "c": null}`), &v), ShouldBeNil)
+			So(v.A, ShouldEqual, "123")
+			So(v.B.String(), ShouldEqual, "abc")
+			So(v.C, ShouldEqual, "")
+			id, err := v.A.Int()
+			So(err, ShouldBeNil)
+			So(id, ShouldEqual, 123)
+			So(json.Unmarshal([]byte(`{"a": {}}`), &v), ShouldNotBeNil)
+		})
+		Convey("Object", func() {
+			v := struct {
+				A Object `json:"a"`
+				B Object `json:"b"`
+				C Object `json:"c"`
+			}{}
+			So(json.Unmarshal([]byte(`{"a": {"id": 1}, "b": [ ], "c": null}`), &v), ShouldBeNil)
+			So(v.B, ShouldBeNil)
+			So(v.C, ShouldBeNil)
+			target := struct {
+				ID int `json:"id"`
+			}{}
+			So(v.A.To(&target), ShouldBeNil)
+			So(target.ID, ShouldEqual, 1)
+			So(v.B.To(&target), ShouldBeNil)
+			data, err := json.Marshal(v)
+			So(err, ShouldBeNil)
+			So(string(data), ShouldEqual, `{"a":{"id":1},"b":null,"c":null}`)
+		})
+		Convey("Empty array", func() {
+			So(isEmptyArray([]byte(`[]`)), ShouldBeTrue)
+			So(isEmptyArray([]byte(` [ ] `)), ShouldBeTrue)
+			So(isEmptyArray([]byte(`[1]`)), ShouldBeFalse)
+			So(isEmptyArray([]byte(`{}`)), ShouldBeFalse)
+		})
+	})
+}
+
+func TestID(t *testing.T) {
+	Convey("ID", t, func() {
+		v := struct {
+			A ID `json:"a"`
+			B ID `json:"b"`
+			C ID `json:"c"`
+		}{C: 1}
+		So(json.Unmarshal([]byte(`{"a": 2000000001, "b": "-123456789012", "c": null}`), &v), ShouldBeNil)
+		So(v.A, ShouldEqual, 2000000001)
+		So(v.B, ShouldEqual, -123456789012)
+		So(v.C, ShouldEqual, 0)
+		So(v.B.String(), ShouldEqual, "-123456789012")
+		So(json.Unmarshal([]byte(`{"a": "club1"}`), &v), ShouldNotBeNil)
+		data, err := json.Marshal(v)
+		So(err, ShouldBeNil)
+		So(string(data), ShouldEqual, `{"a":2000000001,"b":-123456789012,"c":0}`)
+		Convey("Models", func() {
+			u := User{}
+			So(json.Unmarshal([]byte(`{"id": "1"}`), &u), ShouldBeNil)
+			So(u.ID, ShouldEqual, 1)
+			m := Message{}
+			So(json.Unmarshal([]byte(`{"peer_id": 2000000001, "from_id": -1}`), &m), ShouldBeNil)
+			So(m.PeerID, ShouldEqual, 2000000001)
+			So(m.FromID, ShouldEqual, -1)
+		})
+	})
+}
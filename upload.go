@@ -0,0 +1,87 @@
+package vk
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+// UploadError is an error returned by upload server
+type UploadError string
+
+func (e UploadError) Error() string {
+	return "upload: " + string(e)
+}
+
+// Uploader posts files to upload servers returned
+// by *.get*UploadServer methods
+type Uploader struct {
+	// HTTPClient is default http client if nil
+	HTTPClient HTTPClient
+}
+
+type uploadServer struct {
+	UploadURL string `json:"upload_url"`
+}
+
+type uploadStatus struct {
+	Error string `json:"error"`
+}
+
+// Upload sends file from r as multipart form field to upload url
+// and decodes server response into v
+func (u Uploader) Upload(uploadURL, field, name string, r io.Reader, v interface{}) error {
+	body := new(bytes.Buffer)
+	w := multipart.NewWriter(body)
+	part, err := w.CreateFormFile(field, name)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(part, r); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, uploadURL, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	httpClient := u.HTTPClient
+	if httpClient == nil {
+		httpClient = defaultHTTPClient
+	}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return newHTTPError(res)
+	}
+	data := new(bytes.Buffer)
+	if _, err = data.ReadFrom(res.Body); err != nil {
+		return err
+	}
+	status := uploadStatus{}
+	if err = json.Unmarshal(data.Bytes(), &status); err != nil {
+		return err
+	}
+	if len(status.Error) != 0 {
+		return UploadError(status.Error)
+	}
+	return json.Unmarshal(data.Bytes(), v)
+}
+
+// clientTransport uses current http client of Client
+type clientTransport struct {
+	client *Client
+}
+
+func (t clientTransport) Do(req *http.Request) (*http.Response, error) {
+	httpClient, _ := t.client.transport()
+	return httpClient.Do(req)
+}
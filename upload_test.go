@@ -0,0 +1,93 @@
+package vk
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func newUploadServer(field, response string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile(field)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		data, _ := ioutil.ReadAll(file)
+		if header.Filename != "cover.png" || string(data) != "image" {
+			w.Write([]byte(`{"error": "bad file"}`))
+			return
+		}
+		w.Write([]byte(response))
+	}))
+}
+
+func TestUploader(t *testing.T) {
+	Convey("Uploader", t, func() {
+		u := Uploader{getDefaultHTTPClient()}
+		server := newUploadServer("photo", `{"hash": "h", "photo": "p"}`)
+		defer server.Close()
+		Convey("Ok", func() {
+			v := coverSaveFields{}
+			So(u.Upload(server.URL, "photo", "cover.png", bytes.NewBufferString("image"), &v), ShouldBeNil)
+			So(v.Hash, ShouldEqual, "h")
+			So(v.Photo, ShouldEqual, "p")
+		})
+		Convey("Server error", func() {
+			v := coverSaveFields{}
+			err := u.Upload(server.URL, "photo", "cover.png", bytes.NewBufferString("bad"), &v)
+			So(err, ShouldEqual, UploadError("bad file"))
+		})
+		Convey("Bad status", func() {
+			v := coverSaveFields{}
+			err := u.Upload(server.URL, "file", "cover.png", bytes.NewBufferString("image"), &v)
+			So(err, ShouldResemble, HTTPError{Status: http.StatusBadRequest, Body: []byte{}})
+		})
+		Convey("Default client", func() {
+			v := coverSaveFields{}
+			So(Uploader{}.Upload(server.URL, "photo", "cover.png", bytes.NewBufferString("image"), &v), ShouldBeNil)
+			So(v.Hash, ShouldEqual, "h")
+		})
+	})
+}
+
+func TestPhotosUpload(t *testing.T) {
+	Convey("Photos upload", t, func() {
+		Convey("Owner cover", func() {
+			server := newUploadServer("photo", `{"hash": "h", "photo": "p"}`)
+			defer server.Close()
+			mock := newApiMock(`{"response": {"upload_url": "`+server.URL+`",
+				"images": [{"url": "https://vk.com/cover.jpg", "width": 795, "height": 200}]}}`, nil)
+			f := rf()
+			resource := record(mock, &f)
+			resource.Uploader = Uploader{getDefaultHTTPClient()}
+			p := Photos{resource}
+			result, err := p.UploadOwnerCover(CoverUploadFields{GroupID: 1, CoverCrop: CoverCrop{X2: 1590, Y2: 400}},
+				"cover.png", bytes.NewBufferString("image"))
+			So(err, ShouldBeNil)
+			So(result.Images[0].Width, ShouldEqual, 795)
+			So(f.request.Method, ShouldEqual, methodPhotosSaveOwnerCoverPhoto)
+			So(f.request.Values.Get("hash"), ShouldEqual, "h")
+			So(f.request.Values.Get("photo"), ShouldEqual, "p")
+		})
+		Convey("Chat photo", func() {
+			server := newUploadServer("file", `{"response": "uploaded"}`)
+			defer server.Close()
+			mock := newApiMock(`{"response": {"upload_url": "`+server.URL+`", "message_id": 3}}`, nil)
+			f := rf()
+			resource := record(mock, &f)
+			resource.Uploader = Uploader{getDefaultHTTPClient()}
+			p := Photos{resource}
+			result, err := p.UploadChatPhoto(ChatPhotoUploadFields{ChatID: 1}, "cover.png", bytes.NewBufferString("image"))
+			So(err, ShouldBeNil)
+			So(result.MessageID, ShouldEqual, 3)
+			So(f.request.Method, ShouldEqual, methodMessagesSetChatPhoto)
+			So(f.request.Values.Get("file"), ShouldEqual, "uploaded")
+		})
+	})
+}
@@ -62,7 +62,7 @@ const (
 )
 
 type User struct {
-	ID        int     `json:"id"`
+	ID        ID      `json:"id"`
 	FirstName string  `json:"first_name"`
 	LastName  string  `json:"last_name"`
 	Sex       Sex     `json:"sex"`
@@ -71,14 +71,29 @@ type User struct {
 	Hidden    Bool    `json:"hidden"`
 	Birthday  string  `json:"bdate"`
 	PhotoMax  string  `json:"photo_max"`
+	PhotoID   string  `json:"photo_id,omitempty"`
 	Status    string  `json:"status"`
+	Online    Bool    `json:"online"`
+	HasPhoto  Bool    `json:"has_photo"`
 	LastSeen  struct {
-		Time     int64 `json:"time"`
-		Platform int   `json:"platform"`
+		Time     Time `json:"time"`
+		Platform int  `json:"platform"`
 	} `json:"last_seen"`
-	Books string `json:"books"`
-	About string `json:"about"`
+	Books    string        `json:"books"`
+	About    string        `json:"about"`
+	Counters *UserCounters `json:"counters,omitempty"`
 }
 
 // UserFields all fields that are in User struct
 const UserFields = "id,first_name,last_name,sex,country,city,photo_max,last_seen"
+
+type UsersGetFields struct {
+	UserIDs  []ID   `url:"user_ids,comma,omitempty"`
+	Fields   Fields `url:"fields,omitempty"`
+	NameCase string `url:"name_case,omitempty"`
+}
+
+// Get returns users by ids, current user if ids are empty
+func (u Users) Get(fields UsersGetFields) (result []User, err error) {
+	return result, u.Decode(u.Request(methodUsersGet, fields), &result)
+}
@@ -0,0 +1,144 @@
+package vk
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	methodUtilsGetServerTime = "utils.getServerTime"
+)
+
+// Utils resource
+type Utils struct {
+	Resource
+}
+
+// GetServerTime returns current vk server time
+func (u Utils) GetServerTime() (t time.Time, err error) {
+	var unix int64
+	err = u.Decode(u.Request(methodUtilsGetServerTime, nil), &unix)
+	return time.Unix(unix, 0), err
+}
+
+// Ping checks availability of vk api and measures offset of
+// server clock relative to local one, which is then used by Now
+func (c *Client) Ping(ctx context.Context) (offset time.Duration, err error) {
+	start := c.clock.Now()
+	res, err := c.DoContext(ctx, Request{Method: methodUtilsGetServerTime})
+	if err != nil {
+		return 0, err
+	}
+	rtt := c.clock.Now().Sub(start)
+	var unix int64
+	if err = res.To(&unix); err != nil {
+		return 0, err
+	}
+	// server time has second precision and is taken in the middle
+	// of round trip, so offsets less than that are indistinguishable
+	offset = time.Unix(unix, 0).Sub(start.Add(rtt / 2)).Truncate(time.Second)
+	atomic.StoreInt64(&c.clockOffset, int64(offset))
+	return offset, nil
+}
+
+// ClockOffset returns last offset measured by Ping
+func (c *Client) ClockOffset() time.Duration {
+	return time.Duration(atomic.LoadInt64(&c.clockOffset))
+}
+
+// Now returns local time corrected by clock offset
+func (c *Client) Now() time.Time {
+	return c.clock.Now().Add(c.ClockOffset())
+}
+
+const (
+	methodUtilsGetShortLink = "utils.getShortLink"
+	methodUtilsGetLinkStats = "utils.getLinkStats"
+)
+
+// ShortLink is vk.cc link
+type ShortLink struct {
+	ShortURL  string `json:"short_url"`
+	AccessKey string `json:"access_key"`
+	Key       string `json:"key"`
+	URL       string `json:"url"`
+}
+
+type ShortLinkFields struct {
+	URL     string `url:"url"`
+	Private Bool   `url:"private,omitempty"`
+}
+
+func (u Utils) GetShortLink(fields ShortLinkFields) (link ShortLink, err error) {
+	return link, u.Decode(u.Request(methodUtilsGetShortLink, fields), &link)
+}
+
+// LinkStatsInterval is time unit of link statistics
+type LinkStatsInterval string
+
+const (
+	LinkStatsHour    LinkStatsInterval = "hour"
+	LinkStatsDay     LinkStatsInterval = "day"
+	LinkStatsWeek    LinkStatsInterval = "week"
+	LinkStatsMonth   LinkStatsInterval = "month"
+	LinkStatsForever LinkStatsInterval = "forever"
+)
+
+type LinkStatsFields struct {
+	Key            string            `url:"key"`
+	AccessKey      string            `url:"access_key,omitempty"`
+	Interval       LinkStatsInterval `url:"interval,omitempty"`
+	IntervalsCount int               `url:"intervals_count,omitempty"`
+	Extended       Bool              `url:"extended,omitempty"`
+}
+
+// LinkStatsCount is views count of a group of visitors
+type LinkStatsCount struct {
+	Sex       string `json:"sex,omitempty"`
+	AgeRange  string `json:"age_range,omitempty"`
+	CountryID int    `json:"country_id,omitempty"`
+	CityID    int    `json:"city_id,omitempty"`
+	Views     int    `json:"views"`
+	Male      int    `json:"male,omitempty"`
+	Female    int    `json:"female,omitempty"`
+}
+
+// LinkStatsPoint is link statistics for one interval
+type LinkStatsPoint struct {
+	Timestamp Time             `json:"timestamp"`
+	Views     int              `json:"views"`
+	SexAge    []LinkStatsCount `json:"sex_age,omitempty"`
+	Countries []LinkStatsCount `json:"countries,omitempty"`
+	Cities    []LinkStatsCount `json:"cities,omitempty"`
+}
+
+type LinkStatsResult struct {
+	Key   string           `json:"key"`
+	Stats []LinkStatsPoint `json:"stats"`
+}
+
+func (u Utils) GetLinkStats(fields LinkStatsFields) (result LinkStatsResult, err error) {
+	return result, u.Decode(u.Request(methodUtilsGetLinkStats, fields), &result)
+}
+
+// LinkStats returns views of short link for last count intervals
+// as time series, ordered from oldest point
+func (u Utils) LinkStats(link ShortLink, interval LinkStatsInterval, count int) ([]LinkStatsPoint, error) {
+	result, err := u.GetLinkStats(LinkStatsFields{
+		Key:            link.Key,
+		AccessKey:      link.AccessKey,
+		Interval:       interval,
+		IntervalsCount: count,
+		Extended:       true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	points := result.Stats
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Timestamp.Before(points[j].Timestamp.Time)
+	})
+	return points, nil
+}
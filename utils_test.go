@@ -0,0 +1,99 @@
+package vk
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// httpClientFunc is HTTPClient mock from function
+type httpClientFunc func(req *http.Request) (*http.Response, error)
+
+func (f httpClientFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		Header:     http.Header{},
+	}
+}
+
+func TestPing(t *testing.T) {
+	Convey("Ping", t, func() {
+		client := New()
+		var shift time.Duration
+		client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			So(req.URL.Path, ShouldEqual, "/method/"+methodUtilsGetServerTime)
+			now := time.Now().Add(shift).Unix()
+			return jsonResponse(http.StatusOK, `{"response": `+strconv.FormatInt(now, 10)+`}`), nil
+		}))
+		Convey("Synchronized", func() {
+			offset, err := client.Ping(context.Background())
+			So(err, ShouldBeNil)
+			So(offset, ShouldBeBetweenOrEqual, -time.Second, time.Second)
+		})
+		Convey("Skew", func() {
+			shift = time.Hour
+			offset, err := client.Ping(context.Background())
+			So(err, ShouldBeNil)
+			So(offset, ShouldBeBetweenOrEqual, time.Hour-time.Second, time.Hour+time.Second)
+			So(client.ClockOffset(), ShouldEqual, offset)
+			So(client.Now().Sub(time.Now()), ShouldBeGreaterThan, time.Hour-2*time.Second)
+		})
+		Convey("Canceled", func() {
+			client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+				return nil, req.Context().Err()
+			}))
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_, err := client.Ping(ctx)
+			So(err, ShouldEqual, context.Canceled)
+		})
+	})
+	Convey("Server time", t, func() {
+		mock := newApiMock(`{"response": 1500000000}`, nil)
+		u := Utils{record(mock, DefaultFactory)}
+		t, err := u.GetServerTime()
+		So(err, ShouldBeNil)
+		So(t.Unix(), ShouldEqual, 1500000000)
+	})
+}
+
+func TestShortLinks(t *testing.T) {
+	Convey("Short links", t, func() {
+		mock := &apiMethodMock{responses: map[string][]string{
+			methodUtilsGetShortLink: {`{"response": {"short_url": "https://vk.cc/abc", "key": "abc",
+				"access_key": "secret", "url": "https://example.com"}}`},
+			methodUtilsGetLinkStats: {`{"response": {"key": "abc", "stats": [
+				{"timestamp": 1500086400, "views": 5, "countries": [{"country_id": 1, "views": 5}]},
+				{"timestamp": 1500000000, "views": 3}
+			]}}`},
+		}}
+		u := Utils{Resource{APIClient: mock, RequestFactory: DefaultFactory}}
+		link, err := u.GetShortLink(ShortLinkFields{URL: "https://example.com", Private: true})
+		So(err, ShouldBeNil)
+		So(link.ShortURL, ShouldEqual, "https://vk.cc/abc")
+		So(mock.requests[0].Values.Get("private"), ShouldEqual, "1")
+
+		points, err := u.LinkStats(link, LinkStatsDay, 2)
+		So(err, ShouldBeNil)
+		So(len(points), ShouldEqual, 2)
+		So(points[0].Timestamp.Unix(), ShouldEqual, 1500000000)
+		So(points[0].Views, ShouldEqual, 3)
+		So(points[1].Countries[0].CountryID, ShouldEqual, 1)
+		values := mock.requests[1].Values
+		So(values.Get("key"), ShouldEqual, "abc")
+		So(values.Get("access_key"), ShouldEqual, "secret")
+		So(values.Get("interval"), ShouldEqual, "day")
+		So(values.Get("intervals_count"), ShouldEqual, "2")
+	})
+}
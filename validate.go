@@ -0,0 +1,108 @@
+package vk
+
+import (
+	"context"
+	"net/url"
+)
+
+const (
+	methodSecureCheckToken         = "secure.checkToken"
+	methodUsersGet                 = "users.get"
+	methodAccountGetAppPermissions = "account.getAppPermissions"
+
+	paramClientSecret = "client_secret"
+	paramUserID       = "user_id"
+	// paramCheckedToken is token passed to secure.checkToken and vk id
+	paramCheckedToken = "token"
+)
+
+// TokenInfo is result of token validation
+type TokenInfo struct {
+	Valid  bool
+	UserID ID
+	Scope  Scope
+	// Date and Expire are only known for server flow
+	Date   Time
+	Expire Time
+}
+
+// WithClientSecret sets secret of application, that is used
+// with service token of client in server flows
+func WithClientSecret(secret string) Option {
+	return func(c *Client) {
+		c.clientSecret = secret
+	}
+}
+
+// ValidateToken reports whether token is valid, its owner and scope.
+// Token is checked with secure.checkToken if client has service token
+// and client secret, or with users.get otherwise. Errors of
+// secure.checkToken are returned, as they are caused by service
+// token or secret of client rather than by checked token.
+func (c *Client) ValidateToken(ctx context.Context, token string) (info TokenInfo, err error) {
+	if len(c.clientSecret) != 0 && len(c.Token().AccessToken) != 0 {
+		info, err = c.checkToken(ctx, token)
+	} else {
+		info, err = c.checkUserToken(ctx, token)
+	}
+	if err != nil || !info.Valid {
+		return info, err
+	}
+	values := url.Values{}
+	values.Set(paramUserID, info.UserID.String())
+	res, err := c.DoContext(ctx, Request{Method: methodAccountGetAppPermissions, Token: token, Values: values})
+	if err != nil {
+		return info, err
+	}
+	var mask int
+	if err = res.To(&mask); err != nil {
+		return info, err
+	}
+	info.Scope = ScopeFromMask(mask)
+	return info, nil
+}
+
+func (c *Client) checkToken(ctx context.Context, token string) (info TokenInfo, err error) {
+	values := url.Values{}
+	values.Set(paramCheckedToken, token)
+	values.Set(paramClientSecret, c.clientSecret)
+	res, err := c.DoContext(ctx, Request{Method: methodSecureCheckToken, Token: c.Token().AccessToken, Values: values})
+	if err != nil {
+		return info, err
+	}
+	result := struct {
+		Success Bool `json:"success"`
+		UserID  ID   `json:"user_id"`
+		Date    Time `json:"date"`
+		Expire  Time `json:"expire"`
+	}{}
+	if err = res.To(&result); err != nil {
+		return info, err
+	}
+	return TokenInfo{
+		Valid:  bool(result.Success),
+		UserID: result.UserID,
+		Date:   result.Date,
+		Expire: result.Expire,
+	}, nil
+}
+
+// checkUserToken calls users.get with token, so auth
+// errors mean that token is invalid
+func (c *Client) checkUserToken(ctx context.Context, token string) (info TokenInfo, err error) {
+	res, err := c.DoContext(ctx, Request{Method: methodUsersGet, Token: token})
+	if ErrAuthFailed.Is(err) || ErrNotAllowed.Is(err) {
+		return info, nil
+	}
+	if err != nil {
+		return info, err
+	}
+	var users []User
+	if err = res.To(&users); err != nil {
+		return info, err
+	}
+	if len(users) == 0 {
+		return info, nil
+	}
+	return TokenInfo{Valid: true, UserID: users[0].ID}, nil
+}
@@ -0,0 +1,81 @@
+package vk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestValidateToken(t *testing.T) {
+	Convey("Validate token", t, func() {
+		var requests []*http.Request
+		responses := map[string]string{
+			methodUsersGet:                 `{"response": [{"id": 1, "first_name": "Pavel"}]}`,
+			methodSecureCheckToken:         `{"response": {"success": 1, "user_id": 2, "date": 1500000000, "expire": 0}}`,
+			methodAccountGetAppPermissions: `{"response": 65538}`,
+		}
+		invalid := `{"error": {"error_code": 5, "error_msg": "User authorization failed"}}`
+		denied := `{"error": {"error_code": 15, "error_msg": "Access denied"}}`
+		mock := httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req)
+			switch {
+			case req.URL.Query().Get("access_token") == "bad":
+				return jsonResponse(http.StatusOK, invalid), nil
+			case req.URL.Query().Get("access_token") == "denied":
+				return jsonResponse(http.StatusOK, denied), nil
+			case req.URL.Query().Get("token") == "bad":
+				return jsonResponse(http.StatusOK, `{"response": {"success": 0}}`), nil
+			}
+			return jsonResponse(http.StatusOK, responses[req.URL.Path[len(defaultPath):]]), nil
+		})
+		ctx := context.Background()
+		Convey("User flow", func() {
+			client := New()
+			client.SetHTTPClient(mock)
+			info, err := client.ValidateToken(ctx, "user")
+			So(err, ShouldBeNil)
+			So(info.Valid, ShouldBeTrue)
+			So(info.UserID, ShouldEqual, 1)
+			So(info.Scope, ShouldResemble, NewScope(PermFriends, PermOffline))
+			So(requests[1].URL.Query().Get("user_id"), ShouldEqual, "1")
+			So(requests[1].URL.Query().Get("access_token"), ShouldEqual, "user")
+			Convey("Invalid", func() {
+				info, err := client.ValidateToken(ctx, "bad")
+				So(err, ShouldBeNil)
+				So(info.Valid, ShouldBeFalse)
+				info, err = client.ValidateToken(ctx, "denied")
+				So(err, ShouldBeNil)
+				So(info.Valid, ShouldBeFalse)
+			})
+		})
+		Convey("Server flow", func() {
+			client := NewWithToken("service", WithClientSecret("secret"))
+			client.SetHTTPClient(mock)
+			info, err := client.ValidateToken(ctx, "user")
+			So(err, ShouldBeNil)
+			So(info.Valid, ShouldBeTrue)
+			So(info.UserID, ShouldEqual, 2)
+			So(info.Date.Unix(), ShouldEqual, 1500000000)
+			So(info.Expire.IsZero(), ShouldBeTrue)
+			q := requests[0].URL.Query()
+			So(requests[0].URL.Path, ShouldEqual, defaultPath+methodSecureCheckToken)
+			So(q.Get("access_token"), ShouldEqual, "service")
+			So(q.Get("client_secret"), ShouldEqual, "secret")
+			So(q.Get("token"), ShouldEqual, "user")
+			Convey("Invalid", func() {
+				info, err := client.ValidateToken(ctx, "bad")
+				So(err, ShouldBeNil)
+				So(info.Valid, ShouldBeFalse)
+			})
+			Convey("Bad service token", func() {
+				for _, service := range []string{"bad", "denied"} {
+					client.SetToken(service)
+					_, err := client.ValidateToken(ctx, "user")
+					So(IsServerError(err), ShouldBeTrue)
+				}
+			})
+		})
+	})
+}
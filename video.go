@@ -6,7 +6,12 @@ import (
 )
 
 const (
-	methodVideoGet = "video.get"
+	methodVideoGet            = "video.get"
+	methodVideoSearch         = "video.search"
+	methodVideoGetAlbums      = "video.getAlbums"
+	methodVideoAddToAlbum     = "video.addToAlbum"
+	methodVideoStartStreaming = "video.startStreaming"
+	methodVideoStopStreaming  = "video.stopStreaming"
 )
 
 type Video struct {
@@ -14,6 +19,8 @@ type Video struct {
 }
 
 type VideoGetFields struct {
+	OwnerID  ID     `url:"owner_id,omitempty"`
+	AlbumID  int    `url:"album_id,omitempty"`
 	Offset   int    `url:"offset,omitempty"`
 	Count    int    `url:"count,omitempty"`
 	Extended Bool   `url:"extended,omitempty"`
@@ -46,10 +53,21 @@ func (v *VideoImage) UnmarshalJSON(b []byte) error {
 }
 
 type VideoItem struct {
-	Duration int          `json:"duration"`
-	Player   string       `json:"player"`
-	Files    VideoFiles   `json:"files"`
-	Images   []VideoImage `json:"image"`
+	ID          int          `json:"id"`
+	OwnerID     ID           `json:"owner_id"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Date        Time         `json:"date"`
+	Views       int          `json:"views"`
+	AccessKey   string       `json:"access_key,omitempty"`
+	Duration    int          `json:"duration"`
+	Player      string       `json:"player"`
+	Files       VideoFiles   `json:"files"`
+	Images      []VideoImage `json:"image"`
+	// Live is set for live streams, Upcoming for scheduled ones
+	Live       Bool   `json:"live,omitempty"`
+	Upcoming   Bool   `json:"upcoming,omitempty"`
+	LiveStatus string `json:"live_status,omitempty"`
 }
 
 type VideoFiles struct {
@@ -64,3 +82,106 @@ type VideoGetResult struct {
 func (v Video) Get(fields VideoGetFields) (result VideoGetResult, err error) {
 	return result, v.Decode(v.Request(methodVideoGet, fields), &result)
 }
+
+type VideoSearchFields struct {
+	Query     string `url:"q"`
+	Sort      int    `url:"sort,omitempty"`
+	HD        Bool   `url:"hd,omitempty"`
+	Adult     Bool   `url:"adult,omitempty"`
+	Filters   string `url:"filters,omitempty"`
+	SearchOwn Bool   `url:"search_own,omitempty"`
+	Offset    int    `url:"offset,omitempty"`
+	Longer    int    `url:"longer,omitempty"`
+	Shorter   int    `url:"shorter,omitempty"`
+	Count     int    `url:"count,omitempty"`
+	Extended  Bool   `url:"extended,omitempty"`
+}
+
+func (v Video) Search(fields VideoSearchFields) (result VideoGetResult, err error) {
+	return result, v.Decode(v.Request(methodVideoSearch, fields), &result)
+}
+
+// VideoAlbum is album of videos
+type VideoAlbum struct {
+	ID          int    `json:"id"`
+	OwnerID     ID     `json:"owner_id"`
+	Title       string `json:"title"`
+	Count       int    `json:"count"`
+	UpdatedTime Time   `json:"updated_time"`
+}
+
+type VideoGetAlbumsFields struct {
+	OwnerID    ID   `url:"owner_id,omitempty"`
+	Offset     int  `url:"offset,omitempty"`
+	Count      int  `url:"count,omitempty"`
+	Extended   Bool `url:"extended,omitempty"`
+	NeedSystem Bool `url:"need_system,omitempty"`
+}
+
+type VideoGetAlbumsResult struct {
+	Count int          `json:"count"`
+	Items []VideoAlbum `json:"items"`
+}
+
+func (v Video) GetAlbums(fields VideoGetAlbumsFields) (result VideoGetAlbumsResult, err error) {
+	return result, v.Decode(v.Request(methodVideoGetAlbums, fields), &result)
+}
+
+type VideoAddToAlbumFields struct {
+	TargetID ID    `url:"target_id,omitempty"`
+	AlbumID  int   `url:"album_id,omitempty"`
+	AlbumIDs []int `url:"album_ids,comma,omitempty"`
+	OwnerID  ID    `url:"owner_id"`
+	VideoID  int   `url:"video_id"`
+}
+
+func (v Video) AddToAlbum(fields VideoAddToAlbumFields) error {
+	var result int
+	return v.Decode(v.Request(methodVideoAddToAlbum, fields), &result)
+}
+
+// LiveStreamServer is rtmp server of live stream
+type LiveStreamServer struct {
+	URL     string `json:"url"`
+	Key     string `json:"key"`
+	OKMPURL string `json:"okmp_url,omitempty"`
+}
+
+// LiveStream is live video created by video.startStreaming
+type LiveStream struct {
+	VideoID     int              `json:"video_id"`
+	OwnerID     ID               `json:"owner_id"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	AccessKey   string           `json:"access_key"`
+	Stream      LiveStreamServer `json:"stream"`
+}
+
+type VideoStartStreamingFields struct {
+	VideoID     int    `url:"video_id,omitempty"`
+	Name        string `url:"name,omitempty"`
+	Description string `url:"description,omitempty"`
+	Wallpost    Bool   `url:"wallpost,omitempty"`
+	GroupID     ID     `url:"group_id,omitempty"`
+	AlbumID     int    `url:"album_id,omitempty"`
+	CategoryID  int    `url:"category_id,omitempty"`
+	Publish     Bool   `url:"publish,omitempty"`
+}
+
+// StartStreaming creates live stream or returns server of existing one
+func (v Video) StartStreaming(fields VideoStartStreamingFields) (stream LiveStream, err error) {
+	return stream, v.Decode(v.Request(methodVideoStartStreaming, fields), &stream)
+}
+
+type videoStopStreamingFields struct {
+	VideoID int `url:"video_id,omitempty"`
+	OwnerID ID  `url:"owner_id,omitempty"`
+}
+
+// StopStreaming finishes live stream and returns count of unique viewers
+func (v Video) StopStreaming(ownerID ID, videoID int) (viewers int, err error) {
+	result := struct {
+		UniqueViewers int `json:"unique_viewers"`
+	}{}
+	return result.UniqueViewers, v.Decode(v.Request(methodVideoStopStreaming, videoStopStreamingFields{videoID, ownerID}), &result)
+}
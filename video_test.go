@@ -0,0 +1,53 @@
+package vk
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestVideo(t *testing.T) {
+	Convey("Video", t, func() {
+		client := New()
+		var calls []string
+		client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			calls = append(calls, req.URL.Path[len("/method/"):])
+			switch req.URL.Path {
+			case "/method/video.get", "/method/video.search":
+				return jsonResponse(http.StatusOK, `{"response": {"count": 1, "items": [
+					{"id": 1, "owner_id": -2, "title": "`+q.Get("q")+`", "live": 1, "image": [{"url": "https://img", "width": 320, "height": 240}]}
+				]}}`), nil
+			case "/method/video.getAlbums":
+				return jsonResponse(http.StatusOK, `{"response": {"count": 1, "items": [{"id": 3, "title": "streams", "count": 5}]}}`), nil
+			case "/method/video.startStreaming":
+				So(q.Get("group_id"), ShouldEqual, "2")
+				return jsonResponse(http.StatusOK, `{"response": {"video_id": 4, "owner_id": -2, "name": "live", "stream": {"url": "rtmp://stream.vk.com/live", "key": "secret"}}}`), nil
+			case "/method/video.stopStreaming":
+				So(q.Get("video_id"), ShouldEqual, "4")
+				return jsonResponse(http.StatusOK, `{"response": {"unique_viewers": 42}}`), nil
+			}
+			So(q.Get("album_ids"), ShouldEqual, "3,5")
+			return jsonResponse(http.StatusOK, `{"response": 1}`), nil
+		}))
+		found, err := client.Video.Search(VideoSearchFields{Query: "cats"})
+		So(err, ShouldBeNil)
+		So(found.Items[0].Title, ShouldEqual, "cats")
+		So(bool(found.Items[0].Live), ShouldBeTrue)
+		So(found.Items[0].Images[0].Width, ShouldEqual, 320)
+
+		albums, err := client.Video.GetAlbums(VideoGetAlbumsFields{OwnerID: -2})
+		So(err, ShouldBeNil)
+		So(albums.Items[0].Count, ShouldEqual, 5)
+		So(client.Video.AddToAlbum(VideoAddToAlbumFields{AlbumIDs: []int{3, 5}, OwnerID: -2, VideoID: 1}), ShouldBeNil)
+
+		stream, err := client.Video.StartStreaming(VideoStartStreamingFields{Name: "live", GroupID: 2})
+		So(err, ShouldBeNil)
+		So(stream.Stream.Key, ShouldEqual, "secret")
+		viewers, err := client.Video.StopStreaming(stream.OwnerID, stream.VideoID)
+		So(err, ShouldBeNil)
+		So(viewers, ShouldEqual, 42)
+		So(calls, ShouldResemble, []string{"video.search", "video.getAlbums", "video.addToAlbum", "video.startStreaming", "video.stopStreaming"})
+	})
+}
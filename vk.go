@@ -3,6 +3,7 @@ package vk
 import (
 	"net/url"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/google/go-querystring/query"
@@ -22,6 +23,7 @@ const (
 	paramDisplay      = "display"
 	paramHTTPS        = "https"
 	paramResponseType = "response_type"
+	paramLang         = "lang"
 
 	oauthHost         = "oauth.vk.com"
 	oauthDisplay      = "page"
@@ -48,11 +50,60 @@ func int64s(v int64) string {
 	return strconv.FormatInt(v, 10)
 }
 
-// Client for vk api
+// Client for vk api.
+//
+// Client is safe for concurrent use: SetHTTPClient, SetLimiter,
+// SetToken, SetDebug and SetDebugWriter can be called while
+// requests are made. Options are applied only on creation.
 type Client struct {
+	// clockOffset is first to be 64-bit aligned for atomic operations
+	clockOffset int64
+
+	// mux guards httpClient and limiter
+	mux        sync.RWMutex
 	httpClient HTTPClient
-	Groups     Groups
-	Video      Video
+	limiter    Limiter
+
+	clock           Clock
+	debug           debugLog
+	audit           AuditFunc
+	onRequestDone   []RequestDoneFunc
+	validateMethods bool
+	quotas          *Quotas
+	retryBudget     *RetryBudget
+	hedging         *Hedging
+	signing         *Signing
+
+	tokenMux       sync.RWMutex
+	renewMux       sync.Mutex
+	renewal        *tokenRenewal
+	token          Token
+	onTokenExpired func() (Token, error)
+	clientSecret   string
+	official       *OfficialClient
+	lang           string
+
+	Groups   Groups
+	Video    Video
+	Messages Messages
+	Places   Places
+	Photos   Photos
+	Utils    Utils
+	Users    Users
+	Newsfeed Newsfeed
+	Search   Search
+	Widgets  Widgets
+	Ads      Ads
+	Audio    Audio
+	Wall     Wall
+	Friends  Friends
+	Board    Board
+	Account  Account
+	Market   Market
+	Stories  Stories
+	Polls    Polls
+	Database Database
+	Stats    Stats
 }
 
 // APIClient preforms request and fills
@@ -68,9 +119,33 @@ type Request struct {
 	Values url.Values `json:"values"`
 }
 
+// WithHTTPClient sets underlying http client
+func WithHTTPClient(httpClient HTTPClient) Option {
+	return func(c *Client) {
+		c.SetHTTPClient(httpClient)
+	}
+}
+
+// WithLang sets language of data returned by api, like "ru" or "en",
+// it is not set for requests that already have lang parameter
+func WithLang(lang string) Option {
+	return func(c *Client) {
+		c.lang = lang
+	}
+}
+
 // SetHTTPClient sets underlying http client
 func (c *Client) SetHTTPClient(httpClient HTTPClient) {
+	c.mux.Lock()
 	c.httpClient = httpClient
+	c.mux.Unlock()
+}
+
+// transport returns http client and limiter of client
+func (c *Client) transport() (HTTPClient, Limiter) {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	return c.httpClient, c.limiter
 }
 
 // Auth is helper struct for application authentication
@@ -132,26 +207,63 @@ func (a Auth) URL() string {
 	return u.String()
 }
 
+// Option configures Client
+type Option func(c *Client)
+
 // New creates and returns default vk api client
-func New() *Client {
-	c := new(Client)
-	c.SetHTTPClient(defaultHTTPClient)
-	resource := Resource{}
-	resource.APIClient = c
-	resource.RequestFactory = DefaultFactory
-	c.Groups = Groups{resource}
+func New(options ...Option) *Client {
+	return newClient(DefaultFactory, options)
+}
+
+// NewWithToken returns client which resources make requests with token
+func NewWithToken(token string, options ...Option) *Client {
+	c := newClient(nil, options)
+	c.SetToken(token)
 	return c
 }
 
-func NewWithToken(token string) *Client {
+func newClient(factory RequestFactory, options []Option) *Client {
 	c := new(Client)
 	c.SetHTTPClient(defaultHTTPClient)
+	c.clock = SystemClock
+	for _, option := range options {
+		option(c)
+	}
+	c.initResources(factory)
+	return c
+}
+
+// initResources sets up resources of client, that make
+// requests with factory or with client token if it is nil
+func (c *Client) initResources(factory RequestFactory) {
+	if factory == nil {
+		factory = clientFactory{c}
+	}
 	resource := Resource{}
 	resource.APIClient = c
-	resource.RequestFactory = Factory{token}
+	resource.RequestFactory = factory
+	resource.Uploader = Uploader{clientTransport{c}}
 	c.Video = Video{resource}
 	c.Groups = Groups{resource}
-	return c
+	c.Messages = Messages{resource}
+	c.Places = Places{resource}
+	c.Photos = Photos{resource}
+	c.Utils = Utils{resource}
+	c.Users = Users{resource}
+	c.Newsfeed = Newsfeed{resource}
+	c.Search = Search{resource}
+	c.Widgets = Widgets{resource}
+	c.Ads = Ads{resource}
+	c.Audio = Audio{resource, c.official != nil}
+	c.Wall = Wall{resource}
+	c.Friends = Friends{resource}
+	c.Board = Board{resource}
+	c.Account = Account{resource}
+	c.Market = Market{resource}
+	c.Stories = Stories{resource}
+	c.Polls = Polls{resource}
+	c.Database = Database{resource}
+	c.Stats = Stats{resource}
 }
 
 var (
@@ -0,0 +1,250 @@
+package vk
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	vkidHost          = "id.vk.com"
+	vkidAuthorizePath = "/authorize"
+	vkidTokenPath     = "/oauth2/auth"
+
+	paramState               = "state"
+	paramCodeChallenge       = "code_challenge"
+	paramCodeChallengeMethod = "code_challenge_method"
+	paramCodeVerifier        = "code_verifier"
+	paramGrantType           = "grant_type"
+	paramDeviceID            = "device_id"
+	paramRefreshToken        = "refresh_token"
+
+	grantAuthorizationCode = "authorization_code"
+	grantRefreshToken      = "refresh_token"
+
+	// PKCEMethod is code challenge method of PKCE
+	PKCEMethod = "S256"
+
+	methodAuthExchangeSilentAuthToken = "auth.exchangeSilentAuthToken"
+
+	defaultRefreshMargin = time.Minute
+)
+
+// PKCE is code verifier and challenge pair of
+// proof key for code exchange
+type PKCE struct {
+	Verifier  string
+	Challenge string
+}
+
+// NewPKCE generates random PKCE pair
+func NewPKCE() (PKCE, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return PKCE{}, err
+	}
+	return NewPKCEFromVerifier(base64.RawURLEncoding.EncodeToString(b)), nil
+}
+
+// NewPKCEFromVerifier returns PKCE pair for provided verifier
+func NewPKCEFromVerifier(verifier string) PKCE {
+	sum := sha256.Sum256([]byte(verifier))
+	return PKCE{
+		Verifier:  verifier,
+		Challenge: base64.RawURLEncoding.EncodeToString(sum[:]),
+	}
+}
+
+// Token is access token with its expiration and refresh info
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	IDToken      string `json:"id_token,omitempty"`
+	TokenType    string `json:"token_type,omitempty"`
+	ExpiresIn    int    `json:"expires_in,omitempty"`
+	UserID       ID     `json:"user_id,omitempty"`
+	State        string `json:"state,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+	// ExpiresAt is expiration time, zero if token does not expire
+	ExpiresAt Time `json:"expires_at,omitempty"`
+}
+
+// NewToken returns token that never expires
+func NewToken(accessToken string) Token {
+	return Token{AccessToken: accessToken}
+}
+
+// ExpiresWithin returns true if token expires in less than d
+func (t Token) ExpiresWithin(d time.Duration) bool {
+	return t.expiresWithin(time.Now(), d)
+}
+
+func (t Token) expiresWithin(now time.Time, d time.Duration) bool {
+	if t.ExpiresAt.IsZero() {
+		return false
+	}
+	return t.ExpiresAt.Sub(now) < d
+}
+
+// setExpiration sets ExpiresAt from ExpiresIn
+func (t *Token) setExpiration(now time.Time) {
+	if t.ExpiresIn != 0 && t.ExpiresAt.IsZero() {
+		t.ExpiresAt = Time{now.Add(time.Duration(t.ExpiresIn) * time.Second)}
+	}
+}
+
+// OAuthError is error returned by vk id
+type OAuthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (e OAuthError) Error() string {
+	return fmt.Sprintf("oauth: %s (%s)", e.Description, e.Code)
+}
+
+// VKID is client of vk id authorization flow
+type VKID struct {
+	ClientID    int64
+	RedirectURI string
+	HTTPClient  HTTPClient
+	// OnRefresh is called with every renewed token
+	OnRefresh func(token Token)
+}
+
+// AuthURL returns url to redirect user to for authorization
+func (v VKID) AuthURL(state string, pkce PKCE, scope Scope) string {
+	u := url.URL{Scheme: oauthScheme, Host: vkidHost, Path: vkidAuthorizePath}
+	values := url.Values{}
+	values.Add(paramResponseType, paramCode)
+	values.Add(paramAppID, int64s(v.ClientID))
+	values.Add(paramRedirectURI, v.RedirectURI)
+	values.Add(paramState, state)
+	values.Add(paramCodeChallenge, pkce.Challenge)
+	values.Add(paramCodeChallengeMethod, PKCEMethod)
+	if len(scope) != 0 {
+		values.Add(paramScope, strings.Replace(scope.String(), ",", " ", -1))
+	}
+	u.RawQuery = values.Encode()
+	return u.String()
+}
+
+// Exchange exchanges authorization code to token
+func (v VKID) Exchange(ctx context.Context, code, deviceID, state string, pkce PKCE) (Token, error) {
+	values := url.Values{}
+	values.Add(paramGrantType, grantAuthorizationCode)
+	values.Add(paramCode, code)
+	values.Add(paramCodeVerifier, pkce.Verifier)
+	values.Add(paramDeviceID, deviceID)
+	values.Add(paramState, state)
+	values.Add(paramRedirectURI, v.RedirectURI)
+	return v.token(ctx, values)
+}
+
+// Refresh exchanges refresh token to new token
+func (v VKID) Refresh(ctx context.Context, token Token, deviceID string) (Token, error) {
+	values := url.Values{}
+	values.Add(paramGrantType, grantRefreshToken)
+	values.Add(paramRefreshToken, token.RefreshToken)
+	values.Add(paramDeviceID, deviceID)
+	values.Add(paramState, token.State)
+	t, err := v.token(ctx, values)
+	if err == nil && v.OnRefresh != nil {
+		v.OnRefresh(t)
+	}
+	return t, err
+}
+
+func (v VKID) token(ctx context.Context, values url.Values) (token Token, err error) {
+	values.Add(paramAppID, int64s(v.ClientID))
+	u := url.URL{Scheme: oauthScheme, Host: vkidHost, Path: vkidTokenPath}
+	req, err := http.NewRequest(http.MethodPost, u.String(), strings.NewReader(values.Encode()))
+	if err != nil {
+		return token, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	client := v.HTTPClient
+	if client == nil {
+		client = defaultHTTPClient
+	}
+	res, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return token, err
+	}
+	defer res.Body.Close()
+	var body struct {
+		Token
+		OAuthError
+	}
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return token, err
+	}
+	if len(body.OAuthError.Code) != 0 {
+		return token, body.OAuthError
+	}
+	if res.StatusCode != http.StatusOK {
+		return token, HTTPError{Status: res.StatusCode}
+	}
+	body.Token.setExpiration(time.Now())
+	return body.Token, nil
+}
+
+type silentTokenFields struct {
+	Token string `url:"token"`
+	UUID  string `url:"uuid"`
+}
+
+// ExchangeSilentToken exchanges silent token to access token,
+// api must be authorized with service token of application
+func (v VKID) ExchangeSilentToken(api Resource, silentToken, uuid string) (token Token, err error) {
+	req := api.Request(methodAuthExchangeSilentAuthToken, silentTokenFields{silentToken, uuid})
+	err = api.Decode(req, &token)
+	token.setExpiration(time.Now())
+	return token, err
+}
+
+// TokenRefresher keeps token renewed by refreshing it
+// shortly before expiration
+type TokenRefresher struct {
+	VKID     VKID
+	DeviceID string
+	// Margin is time before expiration when token is refreshed
+	Margin time.Duration
+
+	mux   sync.Mutex
+	token Token
+}
+
+// NewTokenRefresher returns refresher for token, token
+// without expiration time is considered issued now
+func NewTokenRefresher(v VKID, deviceID string, token Token) *TokenRefresher {
+	token.setExpiration(time.Now())
+	return &TokenRefresher{VKID: v, DeviceID: deviceID, token: token}
+}
+
+// Token returns current token, refreshing it if needed
+func (r *TokenRefresher) Token(ctx context.Context) (Token, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	margin := r.Margin
+	if margin == 0 {
+		margin = defaultRefreshMargin
+	}
+	if !r.token.ExpiresWithin(margin) {
+		return r.token, nil
+	}
+	token, err := r.VKID.Refresh(ctx, r.token, r.DeviceID)
+	if err != nil {
+		return r.token, err
+	}
+	r.token = token
+	return token, nil
+}
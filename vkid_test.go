@@ -0,0 +1,93 @@
+package vk
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestVKID(t *testing.T) {
+	Convey("VK ID", t, func() {
+		Convey("PKCE", func() {
+			// example from RFC 7636
+			p := NewPKCEFromVerifier("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+			So(p.Challenge, ShouldEqual, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM")
+			random, err := NewPKCE()
+			So(err, ShouldBeNil)
+			So(len(random.Verifier), ShouldEqual, 43)
+			So(NewPKCEFromVerifier(random.Verifier), ShouldResemble, random)
+		})
+		var forms []url.Values
+		response := `{"access_token": "new", "refresh_token": "r2", "expires_in": 3600, "user_id": 1, "state": "s"}`
+		v := VKID{ClientID: 1, RedirectURI: "https://example.com/cb"}
+		v.HTTPClient = httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			So(req.URL.String(), ShouldEqual, "https://id.vk.com/oauth2/auth")
+			body, _ := ioutil.ReadAll(req.Body)
+			form, _ := url.ParseQuery(string(body))
+			forms = append(forms, form)
+			return jsonResponse(http.StatusOK, response), nil
+		})
+		pkce := NewPKCEFromVerifier("verifier")
+		Convey("Auth URL", func() {
+			u, err := url.Parse(v.AuthURL("s", pkce, NewScope(PermFriends, PermOffline)))
+			So(err, ShouldBeNil)
+			So(u.Host, ShouldEqual, "id.vk.com")
+			q := u.Query()
+			So(q.Get("code_challenge"), ShouldEqual, pkce.Challenge)
+			So(q.Get("code_challenge_method"), ShouldEqual, "S256")
+			So(q.Get("response_type"), ShouldEqual, "code")
+			So(q.Get("scope"), ShouldEqual, "friends offline")
+			So(q.Get("client_id"), ShouldEqual, "1")
+		})
+		Convey("Exchange", func() {
+			token, err := v.Exchange(context.Background(), "code", "device", "s", pkce)
+			So(err, ShouldBeNil)
+			So(token.AccessToken, ShouldEqual, "new")
+			So(token.UserID, ShouldEqual, 1)
+			So(forms[0].Get("grant_type"), ShouldEqual, "authorization_code")
+			So(forms[0].Get("code_verifier"), ShouldEqual, "verifier")
+			So(forms[0].Get("device_id"), ShouldEqual, "device")
+		})
+		Convey("OAuth error", func() {
+			response = `{"error": "invalid_grant", "error_description": "code expired"}`
+			_, err := v.Exchange(context.Background(), "code", "device", "s", pkce)
+			So(err, ShouldResemble, OAuthError{"invalid_grant", "code expired"})
+			So(err.Error(), ShouldEqual, "oauth: code expired (invalid_grant)")
+		})
+		Convey("Refresher", func() {
+			var refreshed []Token
+			v.OnRefresh = func(token Token) {
+				refreshed = append(refreshed, token)
+			}
+			r := NewTokenRefresher(v, "device", Token{AccessToken: "old", RefreshToken: "r1", ExpiresIn: 3600})
+			token, err := r.Token(context.Background())
+			So(err, ShouldBeNil)
+			So(token.AccessToken, ShouldEqual, "old")
+			So(forms, ShouldBeEmpty)
+
+			r.Margin = 2 * time.Hour
+			token, err = r.Token(context.Background())
+			So(err, ShouldBeNil)
+			So(token.AccessToken, ShouldEqual, "new")
+			So(forms[0].Get("grant_type"), ShouldEqual, "refresh_token")
+			So(forms[0].Get("refresh_token"), ShouldEqual, "r1")
+			So(len(refreshed), ShouldEqual, 1)
+		})
+		Convey("Silent token", func() {
+			mock := &apiMethodMock{responses: map[string][]string{
+				methodAuthExchangeSilentAuthToken: {`{"response": {"access_token": "user", "user_id": 5}}`},
+			}}
+			token, err := v.ExchangeSilentToken(Resource{APIClient: mock, RequestFactory: Factory{"service"}}, "silent", "uuid")
+			So(err, ShouldBeNil)
+			So(token.AccessToken, ShouldEqual, "user")
+			So(token.UserID, ShouldEqual, 5)
+			So(mock.requests[0].Token, ShouldEqual, "service")
+			So(mock.requests[0].Values.Get("token"), ShouldEqual, "silent")
+		})
+	})
+}
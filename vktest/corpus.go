@@ -0,0 +1,107 @@
+package vktest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Mutations returns variants of valid json payload where every value
+// in turn is replaced with null, replaced with value of wrong type or
+// removed, and payload truncated in half
+func Mutations(payload []byte) ([][]byte, error) {
+	d := json.NewDecoder(bytes.NewReader(payload))
+	d.UseNumber()
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+	result := [][]byte{payload[:len(payload)/2]}
+	for _, m := range mutate(v) {
+		data, err := json.Marshal(m)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+// wrongType returns value of other json type than v
+func wrongType(v interface{}) interface{} {
+	switch v.(type) {
+	case string:
+		return 1
+	case json.Number, bool:
+		return "x"
+	case map[string]interface{}:
+		return []interface{}{}
+	case []interface{}:
+		return map[string]interface{}{}
+	}
+	return false
+}
+
+// mutate returns mutated copies of v
+func mutate(v interface{}) []interface{} {
+	result := []interface{}{nil, wrongType(v)}
+	switch v := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			removed := make(map[string]interface{}, len(v))
+			for key, value := range v {
+				if key != k {
+					removed[key] = value
+				}
+			}
+			result = append(result, removed)
+			for _, m := range mutate(v[k]) {
+				changed := make(map[string]interface{}, len(v))
+				for key, value := range v {
+					changed[key] = value
+				}
+				changed[k] = m
+				result = append(result, changed)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			removed := append(append([]interface{}{}, v[:i]...), v[i+1:]...)
+			result = append(result, removed)
+			for _, m := range mutate(v[i]) {
+				changed := append([]interface{}{}, v...)
+				changed[i] = m
+				result = append(result, changed)
+			}
+		}
+	}
+	return result
+}
+
+// WriteCorpus writes payloads to dir in format of go fuzzing
+// corpus, like testdata/fuzz/FuzzDecode, naming files by hash
+func WriteCorpus(dir string, payloads [][]byte) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, p := range payloads {
+		sum := sha256.Sum256(p)
+		name := filepath.Join(dir, hex.EncodeToString(sum[:8]))
+		data := fmt.Sprintf("go test fuzz v1\n[]byte(%q)\n", p)
+		if err := ioutil.WriteFile(name, []byte(data), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
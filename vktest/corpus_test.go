@@ -0,0 +1,44 @@
+package vktest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMutations(t *testing.T) {
+	Convey("Mutations", t, func() {
+		mutations, err := Mutations([]byte(`{"a": 1, "b": ["x"]}`))
+		So(err, ShouldBeNil)
+		var got []string
+		for _, m := range mutations {
+			got = append(got, string(m))
+		}
+		So(got, ShouldResemble, []string{
+			`{"a": 1, "`,
+			`null`, `[]`,
+			`{"b":["x"]}`, `{"a":null,"b":["x"]}`, `{"a":"x","b":["x"]}`,
+			`{"a":1}`, `{"a":1,"b":null}`, `{"a":1,"b":{}}`,
+			`{"a":1,"b":[]}`, `{"a":1,"b":[null]}`, `{"a":1,"b":[1]}`,
+		})
+		_, err = Mutations([]byte(`{`))
+		So(err, ShouldNotBeNil)
+		Convey("Corpus", func() {
+			dir, err := ioutil.TempDir("", "vktest")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(dir)
+			dir = filepath.Join(dir, "FuzzDecode")
+			So(WriteCorpus(dir, mutations[:2]), ShouldBeNil)
+			files, err := ioutil.ReadDir(dir)
+			So(err, ShouldBeNil)
+			So(files, ShouldHaveLength, 2)
+			data, err := ioutil.ReadFile(filepath.Join(dir, files[0].Name()))
+			So(err, ShouldBeNil)
+			So(strings.HasPrefix(string(data), "go test fuzz v1\n[]byte("), ShouldBeTrue)
+		})
+	})
+}
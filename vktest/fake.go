@@ -0,0 +1,112 @@
+package vktest
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/ernado-legacy/vk"
+)
+
+// FakeHandler returns result of request, that is encoded to json
+// unless it is vk.Raw, or error, that is returned as vk.Error if
+// it is vk.ServerError
+type FakeHandler func(request vk.Request) (interface{}, error)
+
+// Fake is vk.Doer that records requests and responds with handlers
+// without http, safe for concurrent use
+type Fake struct {
+	// Default handles methods without handler,
+	// DefaultResponse is returned if nil
+	Default FakeHandler
+
+	mux      sync.Mutex
+	handlers map[string]FakeHandler
+	requests []vk.Request
+}
+
+var _ vk.Doer = (*Fake)(nil)
+
+// NewFake returns Fake and resource that makes requests to it
+func NewFake() (*Fake, vk.Resource) {
+	f := new(Fake)
+	return f, f.Resource()
+}
+
+// Resource returns resource that makes requests to f,
+// use it to create resources like vk.Users{Resource: r}
+func (f *Fake) Resource() vk.Resource {
+	return vk.Resource{APIClient: f, RequestFactory: vk.DefaultFactory}
+}
+
+// On sets handler of method
+func (f *Fake) On(method string, h FakeHandler) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	if f.handlers == nil {
+		f.handlers = make(map[string]FakeHandler)
+	}
+	f.handlers[method] = h
+}
+
+// Respond sets result of method to v
+func (f *Fake) Respond(method string, v interface{}) {
+	f.On(method, func(vk.Request) (interface{}, error) { return v, nil })
+}
+
+// Fail sets error of method to code
+func (f *Fake) Fail(method string, code vk.ServerError) {
+	f.On(method, func(vk.Request) (interface{}, error) { return nil, code })
+}
+
+func (f *Fake) Do(request vk.Request) (*vk.Response, error) {
+	return f.DoContext(context.Background(), request)
+}
+
+func (f *Fake) DoContext(ctx context.Context, request vk.Request) (*vk.Response, error) {
+	f.mux.Lock()
+	f.requests = append(f.requests, request)
+	h, ok := f.handlers[request.Method]
+	if !ok {
+		h = f.Default
+	}
+	f.mux.Unlock()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if h == nil {
+		return vk.Process(strings.NewReader(DefaultResponse))
+	}
+	v, err := h(request)
+	if code, ok := err.(vk.ServerError); ok {
+		err = vk.Error{Code: code, Message: code.String(), Request: request}
+	}
+	if e, ok := err.(vk.Error); ok {
+		return &vk.Response{Error: e}, e
+	}
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := v.(vk.Raw)
+	if !ok {
+		if raw, err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+	return &vk.Response{Response: raw}, nil
+}
+
+// Requests returns recorded requests to method, all if method is blank
+func (f *Fake) Requests(method string) []vk.Request {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	return filterRequests(f.requests, method)
+}
+
+// Reset removes recorded requests
+func (f *Fake) Reset() {
+	f.mux.Lock()
+	f.requests = nil
+	f.mux.Unlock()
+}
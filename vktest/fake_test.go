@@ -0,0 +1,51 @@
+package vktest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ernado-legacy/vk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFake(t *testing.T) {
+	Convey("Fake", t, func() {
+		f, r := NewFake()
+		users := vk.Users{Resource: r}
+		f.Respond("users.get", []vk.User{{ID: 1, FirstName: "Pavel"}})
+		result, err := users.Get(vk.UsersGetFields{UserIDs: []vk.ID{1}})
+		So(err, ShouldBeNil)
+		So(result[0].FirstName, ShouldEqual, "Pavel")
+		So(AssertCalls(t, f, "users.get", 1), ShouldBeTrue)
+		So(AssertLast(t, f, "users.get", map[string]interface{}{"user_ids": 1}), ShouldBeTrue)
+
+		Convey("Errors", func() {
+			f.Fail("users.get", vk.ErrAuthFailed)
+			_, err := users.Get(vk.UsersGetFields{})
+			So(vk.IsServerError(err), ShouldBeTrue)
+			So(vk.GetServerError(err).Code, ShouldEqual, vk.ErrAuthFailed)
+			f.On("users.get", func(vk.Request) (interface{}, error) { return nil, context.DeadlineExceeded })
+			_, err = users.Get(vk.UsersGetFields{})
+			So(errors.Is(err, context.DeadlineExceeded), ShouldBeTrue)
+		})
+		Convey("Default", func() {
+			var n int
+			So(r.Decode(r.Request("utils.getServerTime", nil), &n), ShouldBeNil)
+			So(n, ShouldEqual, 1)
+			f.Default = func(vk.Request) (interface{}, error) { return vk.Raw(`5`), nil }
+			So(r.Decode(r.Request("utils.getServerTime", nil), &n), ShouldBeNil)
+			So(n, ShouldEqual, 5)
+			So(f.Requests(""), ShouldHaveLength, 3)
+			f.Reset()
+			So(f.Requests(""), ShouldBeEmpty)
+		})
+		Convey("Doer", func() {
+			var d vk.Doer = f
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_, err := d.DoContext(ctx, vk.Request{Method: "users.get"})
+			So(err, ShouldEqual, context.Canceled)
+		})
+	})
+}
@@ -0,0 +1,51 @@
+//go:build go1.18
+// +build go1.18
+
+package vktest
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/ernado-legacy/vk"
+)
+
+func FuzzDecode(f *testing.F) {
+	for _, name := range []string{"users.get.json", "wall.get.json", "error.json"} {
+		data, err := ioutil.ReadFile(filepath.Join(FixtureDir, name))
+		if err != nil {
+			f.Fatal(err)
+		}
+		mutations, err := Mutations(data)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(data)
+		for _, m := range mutations {
+			f.Add(m)
+		}
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var decodeErr vk.DecodeError
+		res, err := vk.Process(bytes.NewReader(data))
+		if err != nil {
+			if !errors.As(err, &decodeErr) && res.ServerError() == nil {
+				t.Fatalf("untyped error %T: %v", err, err)
+			}
+			return
+		}
+		for _, v := range []interface{}{
+			new([]vk.User),
+			new(vk.WallGetResult),
+			new(vk.Message),
+			new(vk.VideoItem),
+		} {
+			if err := res.To(v); err != nil && !errors.As(err, &decodeErr) {
+				t.Fatalf("untyped error %T: %v", err, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package vktest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/ernado-legacy/vk"
+)
+
+// FixtureDir is directory of fixtures and golden files
+const FixtureDir = "testdata"
+
+const goldenExt = ".golden"
+
+var update = flag.Bool("vktest.update", false, "update golden files")
+
+// Fixture returns contents of file name from FixtureDir
+func Fixture(t TB, name string) []byte {
+	t.Helper()
+	data, err := ioutil.ReadFile(filepath.Join(FixtureDir, name))
+	if err != nil {
+		t.Errorf("vktest: fixture: %v", err)
+		return nil
+	}
+	return data
+}
+
+// DecodeFixture decodes response of api reply from fixture
+// name to v, failing on server errors in fixture
+func DecodeFixture(t TB, name string, v interface{}) bool {
+	t.Helper()
+	data := Fixture(t, name)
+	if data == nil {
+		return false
+	}
+	res, err := vk.Process(bytes.NewReader(data))
+	if err == nil {
+		err = res.To(v)
+	}
+	if err != nil {
+		t.Errorf("vktest: fixture %s: %v", name, err)
+		return false
+	}
+	return true
+}
+
+// HandleFixture sets fixture name as response to method
+func (r *Recorder) HandleFixture(t TB, method, name string) {
+	t.Helper()
+	if data := Fixture(t, name); data != nil {
+		r.Handle(method, string(data))
+	}
+}
+
+// AssertGolden checks that v encoded to indented json is equal
+// to golden file name, that is rewritten if -vktest.update is set
+func AssertGolden(t TB, name string, v interface{}) bool {
+	t.Helper()
+	got, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Errorf("vktest: golden %s: %v", name, err)
+		return false
+	}
+	got = append(got, '\n')
+	filename := filepath.Join(FixtureDir, name+goldenExt)
+	if *update {
+		if err = ioutil.WriteFile(filename, got, 0644); err != nil {
+			t.Errorf("vktest: golden: %v", err)
+			return false
+		}
+		return true
+	}
+	expected, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Errorf("vktest: golden: %v", err)
+		return false
+	}
+	if !bytes.Equal(got, expected) {
+		t.Errorf("vktest: golden %s mismatch:\n%s\nexpected:\n%s", name, got, expected)
+		return false
+	}
+	return true
+}
+
+// AssertRoundTrip decodes fixture name to v and compares
+// it to golden file of fixture
+func AssertRoundTrip(t TB, name string, v interface{}) bool {
+	t.Helper()
+	return DecodeFixture(t, name, v) && AssertGolden(t, name, v)
+}
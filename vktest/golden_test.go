@@ -0,0 +1,41 @@
+package vktest
+
+import (
+	"testing"
+
+	"github.com/ernado-legacy/vk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGolden(t *testing.T) {
+	Convey("Golden", t, func() {
+		Convey("Models", func() {
+			var users []vk.User
+			So(AssertRoundTrip(t, "users.get.json", &users), ShouldBeTrue)
+			So(users[0].Sex, ShouldEqual, vk.Male)
+			var posts vk.WallGetResult
+			So(AssertRoundTrip(t, "wall.get.json", &posts), ShouldBeTrue)
+			So(posts.Items[0].Text, ShouldEqual, "hello")
+		})
+		Convey("Fixture response", func() {
+			client, r := NewClient()
+			r.HandleFixture(t, "users.get", "users.get.json")
+			users, err := client.Users.Get(vk.UsersGetFields{})
+			So(err, ShouldBeNil)
+			So(users[0].LastName, ShouldEqual, "Durov")
+		})
+		Convey("Failures", func() {
+			if *update {
+				return
+			}
+			tb := new(fakeTB)
+			var users []vk.User
+			So(DecodeFixture(tb, "error.json", &users), ShouldBeFalse)
+			So(Fixture(tb, "missing.json"), ShouldBeNil)
+			So(AssertGolden(tb, "users.get.json", []vk.User{{ID: 2}}), ShouldBeFalse)
+			So(tb.errors, ShouldHaveLength, 3)
+			So(tb.errors[0], ShouldContainSubstring, "error.json")
+			So(tb.errors[2], ShouldContainSubstring, "golden users.get.json mismatch")
+		})
+	})
+}
@@ -0,0 +1,226 @@
+// Package vktest provides recording transport and assertions
+// for testing code that uses vk api client.
+package vktest
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/ernado-legacy/vk"
+)
+
+// DefaultResponse is returned for methods without registered response
+const DefaultResponse = `{"response": 1}`
+
+// transportParams are added to every request by client
+var transportParams = []string{"v", "https", "lang", "access_token"}
+
+// Recorder is vk.HTTPClient that records requests and
+// responds with registered responses, safe for concurrent use
+type Recorder struct {
+	mux       sync.Mutex
+	requests  []vk.Request
+	responses map[string]string
+}
+
+// NewClient returns client with token that makes requests to new recorder
+func NewClient(options ...vk.Option) (*vk.Client, *Recorder) {
+	r := new(Recorder)
+	options = append(options, vk.WithHTTPClient(r))
+	return vk.NewWithToken("token", options...), r
+}
+
+// Handle sets json body of responses to method
+func (r *Recorder) Handle(method, body string) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if r.responses == nil {
+		r.responses = make(map[string]string)
+	}
+	r.responses[method] = body
+}
+
+// Do records request and returns registered response
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	values := req.URL.Query()
+	request := vk.Request{
+		Method: path.Base(req.URL.Path),
+		Token:  values.Get("access_token"),
+	}
+	for _, k := range transportParams {
+		values.Del(k)
+	}
+	request.Values = values
+	r.mux.Lock()
+	r.requests = append(r.requests, request)
+	body, ok := r.responses[request.Method]
+	r.mux.Unlock()
+	if !ok {
+		body = DefaultResponse
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     http.StatusText(http.StatusOK),
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		Request:    req,
+	}, nil
+}
+
+// Requests returns recorded requests to method, all if method is blank
+func (r *Recorder) Requests(method string) []vk.Request {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	return filterRequests(r.requests, method)
+}
+
+func filterRequests(requests []vk.Request, method string) []vk.Request {
+	var result []vk.Request
+	for _, request := range requests {
+		if len(method) == 0 || request.Method == method {
+			result = append(result, request)
+		}
+	}
+	return result
+}
+
+// Last returns last recorded request to method and false if none
+func (r *Recorder) Last(method string) (vk.Request, bool) {
+	return last(r, method)
+}
+
+// Calls is recorder of requests, implemented by Recorder and Fake
+type Calls interface {
+	// Requests returns recorded requests to method, all if method is blank
+	Requests(method string) []vk.Request
+}
+
+func last(calls Calls, method string) (vk.Request, bool) {
+	requests := calls.Requests(method)
+	if len(requests) == 0 {
+		return vk.Request{}, false
+	}
+	return requests[len(requests)-1], true
+}
+
+// Reset removes recorded requests
+func (r *Recorder) Reset() {
+	r.mux.Lock()
+	r.requests = nil
+	r.mux.Unlock()
+}
+
+// TB is subset of testing.TB used by assertions
+type TB interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// Format returns v as it is encoded in request: bools as 1 or 0,
+// slices and arrays as comma separated values
+func Format(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case vk.Bool:
+		return Format(bool(v))
+	case fmt.Stringer:
+		return v.String()
+	}
+	rv := reflect.ValueOf(v)
+	if k := rv.Kind(); k == reflect.Slice || k == reflect.Array {
+		s := make([]string, rv.Len())
+		for i := range s {
+			s[i] = Format(rv.Index(i).Interface())
+		}
+		return strings.Join(s, ",")
+	}
+	return fmt.Sprint(v)
+}
+
+// AssertCalls checks that method was called n times
+func AssertCalls(t TB, r Calls, method string, n int) bool {
+	t.Helper()
+	if got := len(r.Requests(method)); got != n {
+		t.Errorf("vktest: %s called %d times, expected %d", method, got, n)
+		return false
+	}
+	return true
+}
+
+// AssertParam checks that request has parameter key equal to want
+func AssertParam(t TB, request vk.Request, key string, want interface{}) bool {
+	t.Helper()
+	if _, ok := request.Values[key]; !ok {
+		t.Errorf("vktest: %s has no %s, expected %q", request.Method, key, Format(want))
+		return false
+	}
+	if got, expected := request.Values.Get(key), Format(want); got != expected {
+		t.Errorf("vktest: %s %s is %q, expected %q", request.Method, key, got, expected)
+		return false
+	}
+	return true
+}
+
+// AssertNoParam checks that request has no parameter key
+func AssertNoParam(t TB, request vk.Request, key string) bool {
+	t.Helper()
+	if _, ok := request.Values[key]; ok {
+		t.Errorf("vktest: %s has unexpected %s %q", request.Method, key, request.Values.Get(key))
+		return false
+	}
+	return true
+}
+
+// AssertContains checks that comma separated parameter key
+// of request contains every of values, like user_ids contains 1,2,3
+func AssertContains(t TB, request vk.Request, key string, values ...interface{}) bool {
+	t.Helper()
+	items := make(map[string]bool)
+	for _, item := range strings.Split(request.Values.Get(key), ",") {
+		items[item] = true
+	}
+	var missing []string
+	for _, v := range values {
+		for _, item := range strings.Split(Format(v), ",") {
+			if !items[item] {
+				missing = append(missing, item)
+			}
+		}
+	}
+	if len(missing) != 0 {
+		t.Errorf("vktest: %s %s is %q, missing %s", request.Method, key,
+			request.Values.Get(key), strings.Join(missing, ","))
+		return false
+	}
+	return true
+}
+
+// AssertLast checks that method was called and last request
+// to it has every parameter from params
+func AssertLast(t TB, r Calls, method string, params map[string]interface{}) bool {
+	t.Helper()
+	request, ok := last(r, method)
+	if !ok {
+		t.Errorf("vktest: %s was not called", method)
+		return false
+	}
+	result := true
+	for k, v := range params {
+		if !AssertParam(t, request, k, v) {
+			result = false
+		}
+	}
+	return result
+}
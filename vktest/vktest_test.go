@@ -0,0 +1,76 @@
+package vktest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ernado-legacy/vk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type fakeTB struct {
+	errors []string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func TestRecorder(t *testing.T) {
+	Convey("Recorder", t, func() {
+		client, r := NewClient()
+		r.Handle("users.get", `{"response": [{"id": 1, "first_name": "Pavel"}]}`)
+		users, err := client.Users.Get(vk.UsersGetFields{UserIDs: []vk.ID{1, 2, 3}})
+		So(err, ShouldBeNil)
+		So(users[0].FirstName, ShouldEqual, "Pavel")
+		_, err = client.Messages.Send(vk.MessageSendFields{PeerID: 1, Message: "hi"})
+		So(err, ShouldBeNil)
+
+		So(r.Requests(""), ShouldHaveLength, 2)
+		request, ok := r.Last("users.get")
+		So(ok, ShouldBeTrue)
+		So(request.Token, ShouldEqual, "token")
+		So(request.Values.Get("v"), ShouldBeEmpty)
+
+		Convey("Passing", func() {
+			tb := new(fakeTB)
+			So(AssertCalls(tb, r, "users.get", 1), ShouldBeTrue)
+			So(AssertContains(tb, request, "user_ids", 3, []int{1, 2}), ShouldBeTrue)
+			So(AssertParam(tb, request, "user_ids", []vk.ID{1, 2, 3}), ShouldBeTrue)
+			So(AssertNoParam(tb, request, "fields"), ShouldBeTrue)
+			So(AssertLast(tb, r, "messages.send", map[string]interface{}{
+				"peer_id": 1,
+				"message": "hi",
+			}), ShouldBeTrue)
+			So(tb.errors, ShouldBeEmpty)
+		})
+		Convey("Failing", func() {
+			tb := new(fakeTB)
+			So(AssertCalls(tb, r, "users.get", 2), ShouldBeFalse)
+			So(AssertContains(tb, request, "user_ids", 1, 4), ShouldBeFalse)
+			So(AssertParam(tb, request, "fields", "sex"), ShouldBeFalse)
+			So(AssertNoParam(tb, request, "user_ids"), ShouldBeFalse)
+			So(AssertLast(tb, r, "wall.get", nil), ShouldBeFalse)
+			So(tb.errors, ShouldResemble, []string{
+				"vktest: users.get called 1 times, expected 2",
+				`vktest: users.get user_ids is "1,2,3", missing 4`,
+				`vktest: users.get has no fields, expected "sex"`,
+				`vktest: users.get has unexpected user_ids "1,2,3"`,
+				"vktest: wall.get was not called",
+			})
+		})
+		Convey("Format", func() {
+			So(Format(true), ShouldEqual, "1")
+			So(Format(vk.Bool(false)), ShouldEqual, "0")
+			So(Format(vk.ID(-1)), ShouldEqual, "-1")
+			So(Format([]string{"a", "b"}), ShouldEqual, "a,b")
+			So(Format(1.5), ShouldEqual, "1.5")
+		})
+		Convey("Reset", func() {
+			r.Reset()
+			So(r.Requests(""), ShouldBeEmpty)
+		})
+	})
+}
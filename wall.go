@@ -0,0 +1,321 @@
+package vk
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	methodWallGet  = "wall.get"
+	methodWallPost = "wall.post"
+	methodWallEdit = "wall.edit"
+
+	methodWallDeleteComment = "wall.deleteComment"
+	methodWallReportComment = "wall.reportComment"
+
+	defaultWallWatchCount = 20
+
+	eventWallPostNew = "wall_post_new"
+)
+
+// Counter is count object of post, like likes or comments
+type Counter struct {
+	Count int `json:"count"`
+}
+
+// Post is wall post
+type Post struct {
+	ID          int            `json:"id"`
+	OwnerID     ID             `json:"owner_id"`
+	FromID      ID             `json:"from_id"`
+	Date        Time           `json:"date"`
+	Edited      Time           `json:"edited,omitempty"`
+	IsPinned    Bool           `json:"is_pinned,omitempty"`
+	Text        string         `json:"text"`
+	PostType    string         `json:"post_type"`
+	Comments    Counter        `json:"comments"`
+	Likes       Counter        `json:"likes"`
+	Reposts     Counter        `json:"reposts"`
+	Views       Counter        `json:"views"`
+	Attachments []Raw          `json:"attachments"`
+	CopyHistory []Post         `json:"copy_history"`
+	Geo         *Geo           `json:"geo,omitempty"`
+	Reactions   *PostReactions `json:"reactions,omitempty"`
+}
+
+// Wall resource
+type Wall struct {
+	Resource
+}
+
+type WallGetFields struct {
+	OwnerID  ID     `url:"owner_id,omitempty"`
+	Domain   string `url:"domain,omitempty"`
+	Offset   int    `url:"offset,omitempty"`
+	Count    int    `url:"count,omitempty"`
+	Filter   string `url:"filter,omitempty"`
+	Extended Bool   `url:"extended,omitempty"`
+	Fields   Fields `url:"fields,omitempty"`
+}
+
+type WallGetResult struct {
+	Count int    `json:"count"`
+	Items []Post `json:"items"`
+}
+
+func (w Wall) Get(fields WallGetFields) (result WallGetResult, err error) {
+	return result, w.Decode(w.Request(methodWallGet, fields), &result)
+}
+
+// WallPostFields are arguments of wall.post, post is
+// deferred if PublishDate is set
+type WallPostFields struct {
+	OwnerID     ID       `url:"owner_id,omitempty"`
+	FriendsOnly Bool     `url:"friends_only,omitempty"`
+	FromGroup   Bool     `url:"from_group,omitempty"`
+	Message     string   `url:"message,omitempty"`
+	Attachments []string `url:"attachments,comma,omitempty"`
+	Signed      Bool     `url:"signed,omitempty"`
+	PublishDate Time     `url:"publish_date,omitempty"`
+	GUID        string   `url:"guid,omitempty"`
+}
+
+type wallPostResult struct {
+	PostID int `json:"post_id"`
+}
+
+// Post publishes post on wall and returns its id
+func (w Wall) Post(fields WallPostFields) (int, error) {
+	result := wallPostResult{}
+	return result.PostID, w.Decode(w.Request(methodWallPost, fields), &result)
+}
+
+// WallEditFields are arguments of wall.edit
+type WallEditFields struct {
+	OwnerID     ID       `url:"owner_id,omitempty"`
+	PostID      int      `url:"post_id"`
+	FriendsOnly Bool     `url:"friends_only,omitempty"`
+	Message     string   `url:"message,omitempty"`
+	Attachments []string `url:"attachments,comma,omitempty"`
+	Signed      Bool     `url:"signed,omitempty"`
+	PublishDate Time     `url:"publish_date,omitempty"`
+}
+
+// Edit edits post, PublishDate reschedules deferred post
+func (w Wall) Edit(fields WallEditFields) error {
+	result := wallPostResult{}
+	return w.Decode(w.Request(methodWallEdit, fields), &result)
+}
+
+// WallCommentFields identify comment on wall
+type WallCommentFields struct {
+	OwnerID   ID  `url:"owner_id"`
+	CommentID int `url:"comment_id"`
+}
+
+// DeleteComment deletes comment on wall
+func (w Wall) DeleteComment(fields WallCommentFields) error {
+	var result int
+	return w.Decode(w.Request(methodWallDeleteComment, fields), &result)
+}
+
+// ReportReason is reason of complaint
+type ReportReason int
+
+const (
+	ReportSpam ReportReason = iota
+	ReportChildPornography
+	ReportExtremism
+	ReportViolence
+	ReportDrugs
+	ReportAdult
+	ReportInsult
+	ReportSuicide
+)
+
+type wallReportCommentFields struct {
+	WallCommentFields
+	Reason ReportReason `url:"reason"`
+}
+
+// ReportComment complains about comment on wall
+func (w Wall) ReportComment(fields WallCommentFields, reason ReportReason) error {
+	var result int
+	return w.Decode(w.Request(methodWallReportComment, wallReportCommentFields{fields, reason}), &result)
+}
+
+// WallChange is type of change of wall post
+type WallChange int
+
+const (
+	WallPostNew WallChange = iota
+	WallPostEdited
+	WallPostDeleted
+)
+
+// WallEvent is change of wall post
+type WallEvent struct {
+	Change WallChange
+	Post   Post
+}
+
+// WallWatcher is Component that tracks new, edited and deleted
+// posts among Count latest posts of wall by periodic wall.get.
+// First poll only remembers current posts. Only posts newer than
+// all seen before are new, so older post that gets into window
+// after deletion is not reported.
+type WallWatcher struct {
+	Wall    Wall
+	OwnerID ID
+	// OnChange is called for every change, change is
+	// delivered again on next poll if error is returned
+	OnChange func(event WallEvent) error
+	// Count of latest posts that are tracked, 20 if zero
+	Count int
+	// Interval between polls, one minute if zero
+	Interval time.Duration
+	// Clock is SystemClock if nil
+	Clock Clock
+
+	mux    sync.Mutex
+	posts  map[int]Post
+	maxID  int
+	polled bool
+}
+
+// Run polls wall until ctx is done or OnChange fails
+func (w *WallWatcher) Run(ctx context.Context) error {
+	clock := clockOrSystem(w.Clock)
+	interval := w.Interval
+	if interval == 0 {
+		interval = defaultWatchInterval
+	}
+	for {
+		if err := w.Poll(); err != nil {
+			return err
+		}
+		if err := clock.Sleep(ctx, interval); err != nil {
+			return nil
+		}
+	}
+}
+
+// Poll fetches latest posts and emits changes since previous poll
+func (w *WallWatcher) Poll() error {
+	count := w.Count
+	if count == 0 {
+		count = defaultWallWatchCount
+	}
+	result, err := w.Wall.Get(WallGetFields{OwnerID: w.OwnerID, Count: count})
+	if err != nil {
+		return err
+	}
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	current := make(map[int]Post, len(result.Items))
+	// posts older than oldest unpinned one are out of window
+	oldest := 0
+	for _, p := range result.Items {
+		current[p.ID] = p
+		if !p.IsPinned && (oldest == 0 || p.ID < oldest) {
+			oldest = p.ID
+		}
+	}
+	if !w.polled {
+		// first poll is baseline even if callback events came before
+		w.polled = true
+		if w.posts == nil {
+			w.posts = make(map[int]Post, len(current))
+		}
+		for id, p := range current {
+			w.posts[id] = p
+			if id > w.maxID {
+				w.maxID = id
+			}
+		}
+		return nil
+	}
+	var events []WallEvent
+	for _, p := range result.Items {
+		previous, ok := w.posts[p.ID]
+		switch {
+		case !ok && p.ID > w.maxID:
+			events = append(events, WallEvent{WallPostNew, p})
+		case !ok:
+			// older post moved into window, it is remembered below
+		case postEdited(previous, p):
+			events = append(events, WallEvent{WallPostEdited, p})
+		}
+	}
+	for id, p := range w.posts {
+		if _, ok := current[id]; !ok && id >= oldest {
+			events = append(events, WallEvent{WallPostDeleted, p})
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Post.ID < events[j].Post.ID
+	})
+	for _, e := range events {
+		if err := w.OnChange(e); err != nil {
+			return err
+		}
+		w.apply(e)
+	}
+	for id := range w.posts {
+		if _, ok := current[id]; !ok {
+			delete(w.posts, id)
+		}
+	}
+	for id, p := range current {
+		w.posts[id] = p
+	}
+	return nil
+}
+
+// apply remembers delivered change
+func (w *WallWatcher) apply(e WallEvent) {
+	if e.Change == WallPostDeleted {
+		delete(w.posts, e.Post.ID)
+		return
+	}
+	w.posts[e.Post.ID] = e.Post
+	if e.Change == WallPostNew && e.Post.ID > w.maxID {
+		w.maxID = e.Post.ID
+	}
+}
+
+// HandleEvent accepts wall_post_new callback event of watched wall,
+// so new post is delivered without waiting for poll
+func (w *WallWatcher) HandleEvent(event Event) error {
+	if event.Type != eventWallPostNew {
+		return nil
+	}
+	var p Post
+	if err := json.Unmarshal(event.Object, &p); err != nil {
+		return err
+	}
+	if p.OwnerID != w.OwnerID {
+		return nil
+	}
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	if w.posts == nil {
+		w.posts = make(map[int]Post)
+	}
+	if _, ok := w.posts[p.ID]; ok || (w.polled && p.ID <= w.maxID) {
+		return nil
+	}
+	e := WallEvent{WallPostNew, p}
+	if err := w.OnChange(e); err != nil {
+		return err
+	}
+	w.apply(e)
+	return nil
+}
+
+func postEdited(previous, current Post) bool {
+	return !previous.Edited.Equal(current.Edited.Time) || previous.Text != current.Text
+}
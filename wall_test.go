@@ -0,0 +1,106 @@
+package vk
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWallWatcher(t *testing.T) {
+	Convey("Wall watcher", t, func() {
+		response := `{"response": {"count": 3, "items": [
+			{"id": 1, "owner_id": -1, "is_pinned": 1, "text": "pinned"},
+			{"id": 12, "owner_id": -1, "text": "c"},
+			{"id": 11, "owner_id": -1, "text": "b"},
+			{"id": 10, "owner_id": -1, "text": "a"}]}}`
+		var query string
+		client := New(WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			query = req.URL.RawQuery
+			return jsonResponse(http.StatusOK, response), nil
+		})))
+		var events []WallEvent
+		w := &WallWatcher{Wall: client.Wall, OwnerID: -1, Count: 3, OnChange: func(e WallEvent) error {
+			events = append(events, e)
+			return nil
+		}}
+		So(w.Poll(), ShouldBeNil)
+		So(events, ShouldBeEmpty)
+		So(query, ShouldContainSubstring, "count=3")
+		So(query, ShouldContainSubstring, "owner_id=-1")
+
+		Convey("Changes", func() {
+			// 13 is new, 12 is edited, 11 is deleted
+			response = `{"response": {"count": 3, "items": [
+				{"id": 1, "owner_id": -1, "is_pinned": 1, "text": "pinned"},
+				{"id": 13, "owner_id": -1, "text": "d"},
+				{"id": 12, "owner_id": -1, "text": "c2", "edited": 100},
+				{"id": 10, "owner_id": -1, "text": "a"}]}}`
+			So(w.Poll(), ShouldBeNil)
+			So(events, ShouldHaveLength, 3)
+			So(events[0].Change, ShouldEqual, WallPostDeleted)
+			So(events[0].Post.ID, ShouldEqual, 11)
+			So(events[1].Change, ShouldEqual, WallPostEdited)
+			So(events[1].Post.Text, ShouldEqual, "c2")
+			So(events[2].Change, ShouldEqual, WallPostNew)
+			So(events[2].Post.ID, ShouldEqual, 13)
+			So(w.Poll(), ShouldBeNil)
+			So(events, ShouldHaveLength, 3)
+		})
+		Convey("Out of window", func() {
+			response = `{"response": {"count": 3, "items": [
+				{"id": 13, "owner_id": -1, "text": "d"},
+				{"id": 12, "owner_id": -1, "text": "c"},
+				{"id": 11, "owner_id": -1, "text": "b"}]}}`
+			So(w.Poll(), ShouldBeNil)
+			So(events, ShouldHaveLength, 1)
+			So(events[0].Change, ShouldEqual, WallPostNew)
+		})
+		Convey("Handler error", func() {
+			response = `{"response": {"count": 1, "items": [
+				{"id": 13, "owner_id": -1, "text": "d"},
+				{"id": 12, "owner_id": -1, "text": "c"},
+				{"id": 11, "owner_id": -1, "text": "b"}]}}`
+			failure := errors.New("failure")
+			onChange := w.OnChange
+			w.OnChange = func(e WallEvent) error { return failure }
+			So(w.Poll(), ShouldEqual, failure)
+			w.OnChange = onChange
+			So(w.Poll(), ShouldBeNil)
+			So(events, ShouldHaveLength, 1)
+			So(events[0].Post.ID, ShouldEqual, 13)
+		})
+		Convey("Older post moves into window", func() {
+			// 12 is deleted, so 9 gets into window
+			response = `{"response": {"count": 3, "items": [
+				{"id": 1, "owner_id": -1, "is_pinned": 1, "text": "pinned"},
+				{"id": 11, "owner_id": -1, "text": "b"},
+				{"id": 10, "owner_id": -1, "text": "a"},
+				{"id": 9, "owner_id": -1, "text": "z"}]}}`
+			So(w.Poll(), ShouldBeNil)
+			So(events, ShouldHaveLength, 1)
+			So(events[0].Change, ShouldEqual, WallPostDeleted)
+			So(events[0].Post.ID, ShouldEqual, 12)
+		})
+		Convey("Callback event before first poll", func() {
+			w := &WallWatcher{Wall: client.Wall, OwnerID: -1, Count: 3, OnChange: w.OnChange}
+			So(w.HandleEvent(Event{Type: "wall_post_new", Object: Raw(`{"id": 12, "owner_id": -1, "text": "c"}`)}), ShouldBeNil)
+			So(events, ShouldHaveLength, 1)
+			So(w.Poll(), ShouldBeNil)
+			So(events, ShouldHaveLength, 1)
+		})
+		Convey("Callback event", func() {
+			So(w.HandleEvent(Event{Type: "wall_post_new", Object: Raw(`{"id": 13, "owner_id": -1, "text": "d"}`)}), ShouldBeNil)
+			So(w.HandleEvent(Event{Type: "wall_post_new", Object: Raw(`{"id": 5, "owner_id": -2}`)}), ShouldBeNil)
+			So(w.HandleEvent(Event{Type: "message_new", Object: Raw(`{}`)}), ShouldBeNil)
+			So(events, ShouldHaveLength, 1)
+			response = `{"response": {"count": 1, "items": [
+				{"id": 13, "owner_id": -1, "text": "d"},
+				{"id": 12, "owner_id": -1, "text": "c"},
+				{"id": 11, "owner_id": -1, "text": "b"}]}}`
+			So(w.Poll(), ShouldBeNil)
+			So(events, ShouldHaveLength, 1)
+		})
+	})
+}
@@ -0,0 +1,44 @@
+package vk
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+// Warmup resolves api host and establishes connection to it, that
+// is kept alive for next requests, so first call after start is not
+// slowed by dns and tls handshake. Head request to utils.getServerTime
+// is made, as it needs no token.
+func (c *Client) Warmup(ctx context.Context) error {
+	u := url.URL{
+		Scheme: defaultScheme,
+		Host:   defaultHost,
+		Path:   path.Join(defaultPath, methodUtilsGetServerTime),
+	}
+	req, err := http.NewRequest(http.MethodHead, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	httpClient, _ := c.transport()
+	res, err := httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	// body is drained to reuse connection
+	io.Copy(ioutil.Discard, res.Body)
+	return res.Body.Close()
+}
+
+// WarmupLimiter waits for rate limiter once, initializing its state
+// and connections of shared limiters like RedisLimiter
+func (c *Client) WarmupLimiter(ctx context.Context) error {
+	_, limiter := c.transport()
+	if limiter == nil {
+		return nil
+	}
+	return limiter.Wait(ctx)
+}
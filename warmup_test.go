@@ -0,0 +1,37 @@
+package vk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWarmup(t *testing.T) {
+	Convey("Warmup", t, func() {
+		var requests []string
+		client := New(WithHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Method+" "+req.URL.String())
+			return jsonResponse(http.StatusOK, ""), nil
+		})))
+		ctx := context.Background()
+		So(client.Warmup(ctx), ShouldBeNil)
+		So(requests, ShouldResemble, []string{"HEAD https://api.vk.com/method/utils.getServerTime"})
+		So(client.WarmupLimiter(ctx), ShouldBeNil)
+
+		clock := NewFakeClock(time.Unix(1000, 0))
+		limiter := NewLimiter(1)
+		limiter.Clock = clock
+		client.SetLimiter(limiter)
+		So(client.WarmupLimiter(ctx), ShouldBeNil)
+		So(limiter.next, ShouldResemble, time.Unix(1001, 0))
+
+		client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("dial failed")
+		}))
+		So(client.Warmup(ctx), ShouldNotBeNil)
+	})
+}
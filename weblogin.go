@@ -0,0 +1,200 @@
+package vk
+
+import (
+	"context"
+	"errors"
+	"html"
+	"io/ioutil"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultWebLoginSteps = 10
+
+	formEmail      = "email"
+	formPassword   = "pass"
+	formCode       = "code"
+	formCaptchaSID = "captcha_sid"
+	formCaptchaKey = "captcha_key"
+)
+
+var (
+	// ErrWebLoginFailed is returned when login page flow did not
+	// result in token, e.g. because of wrong credentials
+	ErrWebLoginFailed = errors.New("web login failed")
+	// ErrTwoFactorRequired is returned when code is requested
+	// and no TwoFactor provider is set
+	ErrTwoFactorRequired = errors.New("two-factor code required")
+
+	reForm       = regexp.MustCompile(`(?is)<form[^>]*action="([^"]*)"[^>]*>(.*?)</form>`)
+	reInput      = regexp.MustCompile(`(?is)<input[^>]*>`)
+	reAttrName   = regexp.MustCompile(`(?is)\sname="([^"]*)"`)
+	reAttrValue  = regexp.MustCompile(`(?is)\svalue="([^"]*)"`)
+	reCaptchaImg = regexp.MustCompile(`(?is)<img[^>]*src="([^"]*captcha[^"]*)"`)
+)
+
+// WebLogin obtains user token by driving oauth.vk.com pages as
+// browser does, for tools that can not open a browser
+type WebLogin struct {
+	Auth     Auth
+	Login    string
+	Password string
+	// TwoFactor returns two-factor authentication code
+	TwoFactor func() (string, error)
+	// Captcha returns text of captcha image from url
+	Captcha func(imageURL string) (string, error)
+	// Transport of underlying http client, default if nil
+	Transport http.RoundTripper
+	// URL of authorization page, Auth.URL() if empty
+	URL string
+}
+
+type webForm struct {
+	action string
+	values url.Values
+	body   string
+}
+
+func parseWebForm(page string) (form webForm, ok bool) {
+	m := reForm.FindStringSubmatch(page)
+	if m == nil {
+		return form, false
+	}
+	form.action = html.UnescapeString(m[1])
+	form.body = m[2]
+	form.values = url.Values{}
+	for _, input := range reInput.FindAllString(m[2], -1) {
+		name := reAttrName.FindStringSubmatch(input)
+		if name == nil {
+			continue
+		}
+		value := ""
+		if v := reAttrValue.FindStringSubmatch(input); v != nil {
+			value = html.UnescapeString(v[1])
+		}
+		form.values.Set(name[1], value)
+	}
+	return form, true
+}
+
+// tokenFromFragment parses token from redirect uri fragment
+func tokenFromFragment(location string) (token Token, ok bool) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return token, false
+	}
+	values, err := url.ParseQuery(u.Fragment)
+	if err != nil || len(values.Get(paramToken)) == 0 {
+		return token, false
+	}
+	token.AccessToken = values.Get(paramToken)
+	token.ExpiresIn, _ = strconv.Atoi(values.Get("expires_in"))
+	id, _ := strconv.ParseInt(values.Get(paramUserID), 10, 64)
+	token.UserID = ID(id)
+	token.setExpiration(time.Now())
+	return token, true
+}
+
+// Token performs login and returns access token
+func (w WebLogin) Token(ctx context.Context) (token Token, err error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return token, err
+	}
+	client := &http.Client{
+		Jar:       jar,
+		Transport: w.Transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if strings.Contains(req.URL.Fragment, paramToken) {
+				return http.ErrUseLastResponse
+			}
+			return nil
+		},
+	}
+	start := w.URL
+	if len(start) == 0 {
+		start = w.Auth.URL()
+	}
+	req, err := http.NewRequest(http.MethodGet, start, nil)
+	if err != nil {
+		return token, err
+	}
+	sentPassword := false
+	for step := 0; step < defaultWebLoginSteps; step++ {
+		res, err := client.Do(req.WithContext(ctx))
+		if err != nil {
+			return token, err
+		}
+		page, err := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return token, err
+		}
+		if token, ok := tokenFromFragment(res.Header.Get("Location")); ok {
+			return token, nil
+		}
+		form, ok := parseWebForm(string(page))
+		if !ok {
+			return token, ErrWebLoginFailed
+		}
+		if err = w.fill(form, string(page), sentPassword); err != nil {
+			return token, err
+		}
+		if _, ok := form.values[formPassword]; ok {
+			sentPassword = true
+		}
+		action, err := res.Request.URL.Parse(form.action)
+		if err != nil {
+			return token, err
+		}
+		req, err = http.NewRequest(http.MethodPost, action.String(), strings.NewReader(form.values.Encode()))
+		if err != nil {
+			return token, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	return token, ErrWebLoginFailed
+}
+
+// fill sets credentials, two-factor code or captcha in form
+func (w WebLogin) fill(form webForm, page string, sentPassword bool) error {
+	if _, ok := form.values[formPassword]; ok {
+		if sentPassword && len(form.values.Get(formCaptchaSID)) == 0 {
+			// login form is shown again on wrong credentials
+			return ErrWebLoginFailed
+		}
+		form.values.Set(formEmail, w.Login)
+		form.values.Set(formPassword, w.Password)
+	}
+	if _, ok := form.values[formCode]; ok {
+		if w.TwoFactor == nil {
+			return ErrTwoFactorRequired
+		}
+		code, err := w.TwoFactor()
+		if err != nil {
+			return err
+		}
+		form.values.Set(formCode, code)
+	}
+	if len(form.values.Get(formCaptchaSID)) != 0 {
+		if w.Captcha == nil {
+			return ErrCaptchaNeeded
+		}
+		img := reCaptchaImg.FindStringSubmatch(page)
+		if img == nil {
+			return ErrWebLoginFailed
+		}
+		key, err := w.Captcha(html.UnescapeString(img[1]))
+		if err != nil {
+			return err
+		}
+		form.values.Set(formCaptchaKey, key)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package vk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func newWebLoginServer(captcha bool) *httptest.Server {
+	mux := http.NewServeMux()
+	loginForm := `<html><form method="post" action="/login?act=login&amp;a=1">
+		<input type="hidden" name="ip_h" value="h1" />
+		<input type="text" name="email">
+		<input type="password" name="pass">
+		%s
+	</form></html>`
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "s", Value: "1"})
+		fmt.Fprintf(w, loginForm, "")
+	})
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if c, err := r.Cookie("s"); err != nil || c.Value != "1" || r.Form.Get("ip_h") != "h1" || r.Form.Get("a") != "1" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if captcha && r.Form.Get("captcha_key") != "42" {
+			fmt.Fprintf(w, loginForm, `<img src="/captcha.php?sid=7"><input name="captcha_sid" value="7"><input name="captcha_key">`)
+			return
+		}
+		if r.Form.Get("email") != "user" || r.Form.Get("pass") != "secret" {
+			fmt.Fprintf(w, loginForm, "")
+			return
+		}
+		fmt.Fprint(w, `<form action="/check"><input name="code"><input name="remember" value="1"></form>`)
+	})
+	mux.HandleFunc("/check", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("code") != "123456" || r.Form.Get("remember") != "1" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		http.Redirect(w, r, "/blank.html#access_token=abc&expires_in=0&user_id=5", http.StatusFound)
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestWebLogin(t *testing.T) {
+	Convey("Web login", t, func() {
+		server := newWebLoginServer(false)
+		defer server.Close()
+		l := WebLogin{
+			URL:       server.URL + "/authorize",
+			Login:     "user",
+			Password:  "secret",
+			TwoFactor: func() (string, error) { return "123456", nil },
+		}
+		ctx := context.Background()
+		Convey("Ok", func() {
+			token, err := l.Token(ctx)
+			So(err, ShouldBeNil)
+			So(token.AccessToken, ShouldEqual, "abc")
+			So(token.UserID, ShouldEqual, 5)
+			So(token.ExpiresAt.IsZero(), ShouldBeTrue)
+		})
+		Convey("Wrong password", func() {
+			l.Password = "bad"
+			_, err := l.Token(ctx)
+			So(err, ShouldEqual, ErrWebLoginFailed)
+		})
+		Convey("No two-factor provider", func() {
+			l.TwoFactor = nil
+			_, err := l.Token(ctx)
+			So(err, ShouldEqual, ErrTwoFactorRequired)
+		})
+		Convey("Captcha", func() {
+			server := newWebLoginServer(true)
+			defer server.Close()
+			l.URL = server.URL + "/authorize"
+			_, err := l.Token(ctx)
+			So(err, ShouldEqual, ErrCaptchaNeeded)
+			var image string
+			l.Captcha = func(imageURL string) (string, error) {
+				image = imageURL
+				return "42", nil
+			}
+			token, err := l.Token(ctx)
+			So(err, ShouldBeNil)
+			So(token.AccessToken, ShouldEqual, "abc")
+			So(image, ShouldEqual, "/captcha.php?sid=7")
+		})
+		Convey("Form parsing", func() {
+			_, ok := parseWebForm("<html></html>")
+			So(ok, ShouldBeFalse)
+			_, ok = tokenFromFragment("https://oauth.vk.com/blank.html#error=access_denied")
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
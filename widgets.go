@@ -0,0 +1,99 @@
+package vk
+
+const (
+	methodWidgetsGetComments = "widgets.getComments"
+	methodWidgetsGetPages    = "widgets.getPages"
+)
+
+// Widgets resource
+type Widgets struct {
+	Resource
+}
+
+// WidgetCommentsOrder is sort order of widget comments
+type WidgetCommentsOrder string
+
+const (
+	WidgetCommentsByDate        WidgetCommentsOrder = "date"
+	WidgetCommentsByLikes       WidgetCommentsOrder = "likes"
+	WidgetCommentsByLastComment WidgetCommentsOrder = "last_comment"
+)
+
+type WidgetCommentsFields struct {
+	WidgetAPIID int                 `url:"widget_api_id,omitempty"`
+	URL         string              `url:"url,omitempty"`
+	PageID      string              `url:"page_id,omitempty"`
+	Order       WidgetCommentsOrder `url:"order,omitempty"`
+	Fields      Fields              `url:"fields,omitempty"`
+	Offset      int                 `url:"offset,omitempty"`
+	Count       int                 `url:"count,omitempty"`
+}
+
+// WidgetComment is comment posted through comments widget
+type WidgetComment struct {
+	Post
+	User     *User `json:"user,omitempty"`
+	Comments struct {
+		Count   int             `json:"count"`
+		Replies []WidgetComment `json:"replies"`
+	} `json:"comments"`
+}
+
+type WidgetCommentsResult struct {
+	Count int             `json:"count"`
+	Posts []WidgetComment `json:"posts"`
+}
+
+func (w Widgets) GetComments(fields WidgetCommentsFields) (result WidgetCommentsResult, err error) {
+	return result, w.Decode(w.Request(methodWidgetsGetComments, fields), &result)
+}
+
+// WidgetPagesOrder is sort order of widget pages
+type WidgetPagesOrder string
+
+const (
+	WidgetPagesByDate        WidgetPagesOrder = "date"
+	WidgetPagesByComments    WidgetPagesOrder = "comments"
+	WidgetPagesByLikes       WidgetPagesOrder = "likes"
+	WidgetPagesByFriendLikes WidgetPagesOrder = "friend_likes"
+)
+
+// WidgetPeriod is period of widget pages statistics
+type WidgetPeriod string
+
+const (
+	WidgetPeriodDay     WidgetPeriod = "day"
+	WidgetPeriodWeek    WidgetPeriod = "week"
+	WidgetPeriodMonth   WidgetPeriod = "month"
+	WidgetPeriodAllTime WidgetPeriod = "alltime"
+)
+
+type WidgetPagesFields struct {
+	WidgetAPIID int              `url:"widget_api_id,omitempty"`
+	Order       WidgetPagesOrder `url:"order,omitempty"`
+	Period      WidgetPeriod     `url:"period,omitempty"`
+	Offset      int              `url:"offset,omitempty"`
+	Count       int              `url:"count,omitempty"`
+}
+
+// WidgetPage is site page with comments or like widget
+type WidgetPage struct {
+	ID          int     `json:"id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Photo       string  `json:"photo"`
+	URL         string  `json:"url"`
+	PageID      string  `json:"page_id"`
+	Date        Time    `json:"date"`
+	Likes       Counter `json:"likes"`
+	Comments    Counter `json:"comments"`
+}
+
+type WidgetPagesResult struct {
+	Count int          `json:"count"`
+	Pages []WidgetPage `json:"pages"`
+}
+
+func (w Widgets) GetPages(fields WidgetPagesFields) (result WidgetPagesResult, err error) {
+	return result, w.Decode(w.Request(methodWidgetsGetPages, fields), &result)
+}
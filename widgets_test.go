@@ -0,0 +1,45 @@
+package vk
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWidgets(t *testing.T) {
+	Convey("Widgets", t, func() {
+		mock := &apiMethodMock{responses: map[string][]string{
+			methodWidgetsGetComments: {`{"response": {"count": 1, "posts": [
+				{"id": 10, "from_id": 1, "date": 1500000000, "text": "nice",
+				"user": {"id": 1, "first_name": "Pavel"},
+				"comments": {"count": 1, "replies": [{"id": 11, "text": "thanks"}]}}
+			]}}`},
+			methodWidgetsGetPages: {`{"response": {"count": 1, "pages": [
+				{"id": 3, "title": "Page", "url": "https://example.com/a", "page_id": "a",
+				"likes": {"count": 4}, "comments": {"count": 2}}
+			]}}`},
+		}}
+		w := Widgets{Resource{APIClient: mock, RequestFactory: DefaultFactory}}
+		Convey(methodWidgetsGetComments, func() {
+			result, err := w.GetComments(WidgetCommentsFields{
+				WidgetAPIID: 1,
+				URL:         "https://example.com/a",
+				Order:       WidgetCommentsByLikes,
+				Fields:      NewFields(FieldPhoto50),
+			})
+			So(err, ShouldBeNil)
+			So(result.Posts[0].Text, ShouldEqual, "nice")
+			So(result.Posts[0].User.FirstName, ShouldEqual, "Pavel")
+			So(result.Posts[0].Comments.Replies[0].Text, ShouldEqual, "thanks")
+			So(mock.requests[0].Values.Get("order"), ShouldEqual, "likes")
+			So(mock.requests[0].Values.Get("fields"), ShouldEqual, "photo_50")
+		})
+		Convey(methodWidgetsGetPages, func() {
+			result, err := w.GetPages(WidgetPagesFields{WidgetAPIID: 1, Period: WidgetPeriodWeek})
+			So(err, ShouldBeNil)
+			So(result.Pages[0].Comments.Count, ShouldEqual, 2)
+			So(result.Pages[0].PageID, ShouldEqual, "a")
+			So(mock.requests[0].Values.Get("period"), ShouldEqual, "week")
+		})
+	})
+}
@@ -0,0 +1,50 @@
+package vk
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"net/url"
+)
+
+// requestWireVersion is version of Request binary format,
+// it is increased on incompatible changes of wireRequest
+const requestWireVersion byte = 1
+
+// ErrWireVersion is returned on decoding of Request encoded
+// in unsupported binary format version
+var ErrWireVersion = errors.New("unsupported request wire format version")
+
+// wireRequest is Request in binary format of version 1
+type wireRequest struct {
+	Method string
+	Token  string
+	Values url.Values
+}
+
+// MarshalBinary encodes request to compact binary format for queues,
+// first byte of result is format version
+func (r Request) MarshalBinary() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	buf.WriteByte(requestWireVersion)
+	w := wireRequest{Method: r.Method, Token: r.Token, Values: r.Values}
+	if err := gob.NewEncoder(buf).Encode(w); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes request encoded by MarshalBinary
+func (r *Request) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != requestWireVersion {
+		return ErrWireVersion
+	}
+	w := wireRequest{}
+	if err := gob.NewDecoder(bytes.NewReader(data[1:])).Decode(&w); err != nil {
+		return err
+	}
+	r.Method = w.Method
+	r.Token = w.Token
+	r.Values = w.Values
+	return nil
+}
@@ -0,0 +1,47 @@
+package vk
+
+import (
+	"bytes"
+	"encoding/gob"
+	"net/url"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRequestWire(t *testing.T) {
+	Convey("Request binary format", t, func() {
+		values := url.Values{}
+		values.Add("user_ids", "1")
+		values.Add("user_ids", "2")
+		r := Request{Method: "users.get", Token: "token", Values: values}
+		data, err := r.MarshalBinary()
+		So(err, ShouldBeNil)
+		So(data[0], ShouldEqual, requestWireVersion)
+
+		decoded := Request{}
+		So(decoded.UnmarshalBinary(data), ShouldBeNil)
+		So(decoded, ShouldResemble, r)
+
+		Convey("Without values", func() {
+			data, err := Request{Method: "utils.getServerTime"}.MarshalBinary()
+			So(err, ShouldBeNil)
+			decoded := Request{}
+			So(decoded.UnmarshalBinary(data), ShouldBeNil)
+			So(decoded.Method, ShouldEqual, "utils.getServerTime")
+			So(decoded.Values, ShouldBeNil)
+		})
+		Convey("Unknown version", func() {
+			data[0] = requestWireVersion + 1
+			So(decoded.UnmarshalBinary(data), ShouldEqual, ErrWireVersion)
+			So(decoded.UnmarshalBinary(nil), ShouldEqual, ErrWireVersion)
+		})
+		Convey("Gob", func() {
+			buf := new(bytes.Buffer)
+			So(gob.NewEncoder(buf).Encode(r), ShouldBeNil)
+			decoded := Request{}
+			So(gob.NewDecoder(buf).Decode(&decoded), ShouldBeNil)
+			So(decoded, ShouldResemble, r)
+		})
+	})
+}
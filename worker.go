@@ -0,0 +1,112 @@
+package vk
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+const defaultWorkerConcurrency = 1
+
+// Job is request envelope consumed by Worker, ID is used
+// to correlate Result with Job
+type Job struct {
+	ID      string  `json:"id"`
+	Request Request `json:"request"`
+}
+
+// Result of Job execution published by Worker
+type Result struct {
+	ID       string      `json:"id"`
+	Response Raw         `json:"response,omitempty"`
+	Code     ServerError `json:"code,omitempty"`
+	Error    string      `json:"error,omitempty"`
+}
+
+// Source of jobs, like queue consumer. Receive blocks until
+// job is available and returns io.EOF when source is drained.
+type Source interface {
+	Receive(ctx context.Context) (Job, error)
+}
+
+// Sink of results, like queue producer
+type Sink interface {
+	Send(ctx context.Context, result Result) error
+}
+
+// Worker executes jobs from Source with Client, so requests are
+// rate limited by client limiter, and publishes results to Sink
+type Worker struct {
+	Client *Client
+	Source Source
+	Sink   Sink
+	// Concurrency is count of jobs executed in parallel, 1 if zero
+	Concurrency int
+}
+
+// Run consumes jobs until ctx is done or Source is drained,
+// returning first Source or Sink error. Worker is Component.
+func (w Worker) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	n := w.Concurrency
+	if n <= 0 {
+		n = defaultWorkerConcurrency
+	}
+	var (
+		wg    sync.WaitGroup
+		once  sync.Once
+		first error
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := w.consume(ctx); err != nil {
+				once.Do(func() {
+					first = err
+					cancel()
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	return first
+}
+
+// consume executes jobs until Source is drained, io.EOF stops only
+// this consumer, so jobs taken by others are finished and published
+func (w Worker) consume(ctx context.Context) error {
+	for {
+		job, err := w.Source.Receive(ctx)
+		if err == io.EOF || (err != nil && ctx.Err() != nil) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		result := executeJob(ctx, w.Client, job)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err = w.Sink.Send(ctx, result); err != nil {
+			return err
+		}
+	}
+}
+
+// executeJob performs job request with client
+func executeJob(ctx context.Context, client *Client, job Job) Result {
+	result := Result{ID: job.ID}
+	res, err := client.DoContext(ctx, job.Request)
+	if res != nil {
+		result.Response = res.Response
+	}
+	if err != nil {
+		result.Error = err.Error()
+		if IsServerError(err) {
+			result.Code = GetServerError(err).Code
+		}
+	}
+	return result
+}
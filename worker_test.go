@@ -0,0 +1,93 @@
+package vk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type sliceSource struct {
+	mux  sync.Mutex
+	jobs []Job
+}
+
+func (s *sliceSource) Receive(ctx context.Context) (Job, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if len(s.jobs) == 0 {
+		return Job{}, io.EOF
+	}
+	job := s.jobs[0]
+	s.jobs = s.jobs[1:]
+	return job, nil
+}
+
+type sliceSink struct {
+	mux     sync.Mutex
+	results []Result
+	err     error
+}
+
+func (s *sliceSink) Send(ctx context.Context, result Result) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.results = append(s.results, result)
+	return s.err
+}
+
+func TestWorker(t *testing.T) {
+	Convey("Worker", t, func() {
+		client := New()
+		client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/method/users.get" {
+				return jsonResponse(http.StatusOK, `{"response": [{"id": 1}]}`), nil
+			}
+			return jsonResponse(http.StatusOK, `{"error": {"error_code": 5, "error_msg": "auth"}}`), nil
+		}))
+		source := &sliceSource{jobs: []Job{
+			{ID: "1", Request: Request{Method: "users.get"}},
+			{ID: "2", Request: Request{Method: "groups.get"}},
+			{ID: "3", Request: Request{Method: "users.get"}},
+		}}
+		sink := &sliceSink{}
+		w := Worker{Client: client, Source: source, Sink: sink, Concurrency: 2}
+		Convey("Drain", func() {
+			So(w.Run(context.Background()), ShouldBeNil)
+			So(sink.results, ShouldHaveLength, 3)
+			sort.Slice(sink.results, func(i, j int) bool {
+				return sink.results[i].ID < sink.results[j].ID
+			})
+			So(sink.results[0].Response.String(), ShouldEqual, `[{"id": 1}]`)
+			So(sink.results[0].Error, ShouldBeEmpty)
+			So(sink.results[1].Code, ShouldEqual, ErrAuthFailed)
+			So(sink.results[1].Error, ShouldNotBeEmpty)
+		})
+		Convey("Drain with jobs in flight", func() {
+			client.SetHTTPClient(httpClientFunc(func(req *http.Request) (*http.Response, error) {
+				if req.URL.Path == "/method/groups.get" {
+					time.Sleep(20 * time.Millisecond)
+				}
+				return jsonResponse(http.StatusOK, `{"response": 1}`), nil
+			}))
+			source.jobs = source.jobs[1:]
+			So(w.Run(context.Background()), ShouldBeNil)
+			So(sink.results, ShouldHaveLength, 2)
+			for _, result := range sink.results {
+				So(result.Error, ShouldBeEmpty)
+			}
+		})
+		Convey("Sink error", func() {
+			sink.err = errors.New("closed")
+			w.Concurrency = 0
+			So(w.Run(context.Background()), ShouldEqual, sink.err)
+			So(sink.results, ShouldHaveLength, 1)
+		})
+	})
+}